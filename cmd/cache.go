@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// CacheOption holds the flags of the cache of page headers, dictionaries and
+// decoded pages
+type CacheOption struct {
+	CacheSize  int64         `name:"cache-size" group:"Cache" help:"MiB of memory to cache page headers, dictionaries and decoded pages in, 0 disables the cache (default 64)." default:"64"`
+	CacheCheck time.Duration `name:"cache-check" group:"Cache" help:"how often to check whether the file changed and empty the cache if so, 0 never checks (default 5s)." default:"5s"`
+}
+
+// cacheOptions returns the page cache options of the flags
+func (c CacheOption) cacheOptions() *model.CacheOptions {
+	return &model.CacheOptions{MaxBytes: c.CacheSize << 20, CheckInterval: c.CacheCheck}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+	"github.com/hangxie/parquet-browser/service"
+)
+
+func Test_CacheOption_cacheOptions(t *testing.T) {
+	opts := CacheOption{CacheSize: 16, CacheCheck: time.Minute}.cacheOptions()
+	require.Equal(t, &model.CacheOptions{MaxBytes: 16 << 20, CheckInterval: time.Minute}, opts)
+
+	require.Zero(t, CacheOption{}.cacheOptions().MaxBytes)
+}
+
+func Test_viewSettings_apply_Cache(t *testing.T) {
+	svc, err := service.NewParquetService("../build/testdata/all-types.parquet", pio.ReadOption{})
+	require.NoError(t, err)
+
+	require.NoError(t, viewSettings{}.apply(svc))
+	stats := svc.CacheStats()
+	require.Equal(t, int64(model.DefaultCacheSize), stats.MaxBytes)
+
+	require.NoError(t, viewSettings{cache: CacheOption{CacheSize: 0}.cacheOptions()}.apply(svc))
+	stats = svc.CacheStats()
+	require.False(t, stats.Enabled)
+}
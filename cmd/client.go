@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/hangxie/parquet-browser/model"
 )
@@ -24,90 +29,347 @@ func newParquetClient(baseURL string) *parquetClient {
 }
 
 // getFileInfo retrieves file-level metadata
-func (c *parquetClient) getFileInfo() (model.FileInfo, error) {
+func (c *parquetClient) getFileInfo(ctx context.Context) (model.FileInfo, error) {
 	var info model.FileInfo
-	err := c.get("/info", &info)
+	err := c.get(ctx, "/info", &info)
 	return info, err
 }
 
-// getAllRowGroupsInfo retrieves all row groups
-func (c *parquetClient) getAllRowGroupsInfo() ([]model.RowGroupInfo, error) {
-	var rowGroups []model.RowGroupInfo
-	err := c.get("/rowgroups", &rowGroups)
-	return rowGroups, err
+// getIOStats retrieves the reads of the file with their bytes and latency,
+// by operation
+func (c *parquetClient) getIOStats(ctx context.Context) (model.IOStats, error) {
+	var stats model.IOStats
+	err := c.get(ctx, "/debug/io", &stats)
+	return stats, err
+}
+
+// getFileLayout retrieves the byte-level layout of the file
+func (c *parquetClient) getFileLayout(ctx context.Context) (model.FileLayout, error) {
+	var layout model.FileLayout
+	err := c.get(ctx, "/layout", &layout)
+	return layout, err
+}
+
+// getHeatmap retrieves a metric of every column chunk as a row group × column matrix
+func (c *parquetClient) getHeatmap(ctx context.Context, metric model.HeatmapMetric) (model.Heatmap, error) {
+	var heatmap model.Heatmap
+	err := c.get(ctx, "/heatmap?metric="+url.QueryEscape(string(metric)), &heatmap)
+	return heatmap, err
+}
+
+// getRowPages retrieves the pages holding a row or first-last row range in
+// the given columns, all columns when none are given
+func (c *parquetClient) getRowPages(ctx context.Context, rows string, columns []string) (model.RowPageMap, error) {
+	query := url.Values{"column": columns}
+	path := "/rows/" + url.PathEscape(rows) + "/pages"
+	if len(columns) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var rowPages model.RowPageMap
+	err := c.get(ctx, path, &rowPages)
+	return rowPages, err
+}
+
+// getRowLocation retrieves the row group of a row and the page and values
+// holding it in every column, with the assembled row when withRecord is set
+func (c *parquetClient) getRowLocation(ctx context.Context, row int64, withRecord bool) (model.RowLocation, error) {
+	path := fmt.Sprintf("/rows/%d/location", row)
+	if withRecord {
+		path += "?record=true"
+	}
+
+	var location model.RowLocation
+	err := c.get(ctx, path, &location)
+	return location, err
+}
+
+// getSample draws n rows of the file, or n rows of every row group with the
+// stratified mode, in the given columns or all columns when none are given.
+// An empty seed lets the server pick one, which the sample reports.
+func (c *parquetClient) getSample(ctx context.Context, n, mode, seed string, columns []string) (model.Sample, error) {
+	query := url.Values{"column": columns}
+	for name, value := range map[string]string{"n": n, "mode": mode, "seed": seed} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+
+	var sample model.Sample
+	err := c.get(ctx, "/sample?"+query.Encode(), &sample)
+	return sample, err
+}
+
+// getRowGroupList retrieves at most limit row groups from offset, with the
+// number of row groups in the file
+func (c *parquetClient) getRowGroupList(ctx context.Context, offset, limit int) (model.RowGroupList, error) {
+	var list model.RowGroupList
+	err := c.get(ctx, fmt.Sprintf("/rowgroups?offset=%d&limit=%d", offset, limit), &list)
+	return list, err
 }
 
 // getRowGroupInfo retrieves info for a specific row group
-func (c *parquetClient) getRowGroupInfo(rgIndex int) (model.RowGroupInfo, error) {
+func (c *parquetClient) getRowGroupInfo(ctx context.Context, rgIndex int) (model.RowGroupInfo, error) {
 	var info model.RowGroupInfo
-	err := c.get(fmt.Sprintf("/rowgroups/%d", rgIndex), &info)
+	err := c.get(ctx, fmt.Sprintf("/rowgroups/%d", rgIndex), &info)
 	return info, err
 }
 
-// getAllColumnChunksInfo retrieves all column chunks for a row group
-func (c *parquetClient) getAllColumnChunksInfo(rgIndex int) ([]model.ColumnChunkInfo, error) {
-	var columns []model.ColumnChunkInfo
-	err := c.get(fmt.Sprintf("/rowgroups/%d/columnchunks", rgIndex), &columns)
-	return columns, err
+// getColumnChunkList retrieves at most limit column chunks of a row group
+// from offset, with the number of chunks and their totals
+func (c *parquetClient) getColumnChunkList(ctx context.Context, rgIndex, offset, limit int) (model.ColumnChunkList, error) {
+	var list model.ColumnChunkList
+	err := c.get(ctx, fmt.Sprintf("/rowgroups/%d/columnchunks?offset=%d&limit=%d", rgIndex, offset, limit), &list)
+	return list, err
 }
 
 // getColumnChunkInfo retrieves info for a specific column chunk
-func (c *parquetClient) getColumnChunkInfo(rgIndex, colIndex int) (model.ColumnChunkInfo, error) {
+func (c *parquetClient) getColumnChunkInfo(ctx context.Context, rgIndex, colIndex int) (model.ColumnChunkInfo, error) {
 	var info model.ColumnChunkInfo
-	err := c.get(fmt.Sprintf("/rowgroups/%d/columnchunks/%d", rgIndex, colIndex), &info)
+	err := c.get(ctx, fmt.Sprintf("/rowgroups/%d/columnchunks/%d", rgIndex, colIndex), &info)
 	return info, err
 }
 
 // getAllPagesInfo retrieves all page metadata for a column chunk
-func (c *parquetClient) getAllPagesInfo(rgIndex, colIndex int) ([]model.PageMetadata, error) {
+func (c *parquetClient) getAllPagesInfo(ctx context.Context, rgIndex, colIndex int) ([]model.PageMetadata, error) {
 	var pages []model.PageMetadata
-	err := c.get(fmt.Sprintf("/rowgroups/%d/columnchunks/%d/pages", rgIndex, colIndex), &pages)
+	err := c.get(ctx, fmt.Sprintf("/rowgroups/%d/columnchunks/%d/pages", rgIndex, colIndex), &pages)
 	return pages, err
 }
 
 // getPageInfo retrieves info for a specific page
-func (c *parquetClient) getPageInfo(rgIndex, colIndex, pageIndex int) (model.PageMetadata, error) {
+func (c *parquetClient) getPageInfo(ctx context.Context, rgIndex, colIndex, pageIndex int) (model.PageMetadata, error) {
 	var info model.PageMetadata
-	err := c.get(fmt.Sprintf("/rowgroups/%d/columnchunks/%d/pages/%d", rgIndex, colIndex, pageIndex), &info)
+	err := c.get(ctx, fmt.Sprintf("/rowgroups/%d/columnchunks/%d/pages/%d", rgIndex, colIndex, pageIndex), &info)
 	return info, err
 }
 
 // getPageContent retrieves the pre-formatted values/content of a specific page
 // Values are returned as strings, already formatted for display
-func (c *parquetClient) getPageContent(rgIndex, colIndex, pageIndex int) ([]string, error) {
+func (c *parquetClient) getPageContent(ctx context.Context, rgIndex, colIndex, pageIndex int) ([]string, error) {
+	return c.getPageContentGeo(ctx, rgIndex, colIndex, pageIndex, "")
+}
+
+// getPageContentGeo retrieves the content of a specific page with GEOMETRY
+// and GEOGRAPHY values in the given format, empty for the server default
+func (c *parquetClient) getPageContentGeo(ctx context.Context, rgIndex, colIndex, pageIndex int, geoFormat model.GeoFormat) ([]string, error) {
 	var response struct {
 		Values []string `json:"values"`
 		Count  int      `json:"count"`
 	}
-	err := c.get(fmt.Sprintf("/rowgroups/%d/columnchunks/%d/pages/%d/content", rgIndex, colIndex, pageIndex), &response)
+	path := fmt.Sprintf("/rowgroups/%d/columnchunks/%d/pages/%d/content", rgIndex, colIndex, pageIndex)
+	if geoFormat != "" {
+		path += "?geo=" + url.QueryEscape(string(geoFormat))
+	}
+	err := c.get(ctx, path, &response)
 	return response.Values, err
 }
 
+// pageContentPart is a range of the pre-formatted values of a page
+type pageContentPart struct {
+	Values []string
+	Total  int    // values in the whole page
+	Next   string // cursor of the following values, empty at the end of the page
+}
+
+// getPageContentRange retrieves at most limit pre-formatted values of a page
+// starting at offset, with GEOMETRY and GEOGRAPHY values in the given format,
+// empty for the server default
+func (c *parquetClient) getPageContentRange(ctx context.Context, rgIndex, colIndex, pageIndex int, geoFormat model.GeoFormat, offset, limit int) (pageContentPart, error) {
+	var response struct {
+		Values []string `json:"values"`
+		Offset *int     `json:"offset"`
+		Total  int      `json:"total"`
+		Next   string   `json:"next"`
+	}
+	path := fmt.Sprintf("/rowgroups/%d/columnchunks/%d/pages/%d/content?offset=%d&limit=%d", rgIndex, colIndex, pageIndex, offset, limit)
+	if geoFormat != "" {
+		path += "&geo=" + url.QueryEscape(string(geoFormat))
+	}
+	if err := c.get(ctx, path, &response); err != nil {
+		return pageContentPart{}, err
+	}
+	part := pageContentPart{Values: response.Values, Total: response.Total, Next: response.Next}
+	if response.Offset == nil {
+		// A server without paging returns the whole page
+		part.Total = len(response.Values)
+	}
+	return part, nil
+}
+
+// getPageContentTyped retrieves the values of a specific page as JSON-typed
+// data, with their physical values and levels
+func (c *parquetClient) getPageContentTyped(ctx context.Context, rgIndex, colIndex, pageIndex int) ([]model.TypedValue, error) {
+	return c.getPageContentTypedGeo(ctx, rgIndex, colIndex, pageIndex, "")
+}
+
+// getPageContentTypedGeo is getPageContentTyped with GEOMETRY and GEOGRAPHY
+// values in the given format, empty for the server default. Numbers are
+// decoded as json.Number so 64-bit integers and decimals keep every digit.
+func (c *parquetClient) getPageContentTypedGeo(ctx context.Context, rgIndex, colIndex, pageIndex int, geoFormat model.GeoFormat) ([]model.TypedValue, error) {
+	var response struct {
+		Values []json.RawMessage `json:"values"`
+		Count  int               `json:"count"`
+	}
+	path := fmt.Sprintf("/rowgroups/%d/columnchunks/%d/pages/%d/content?typed=true", rgIndex, colIndex, pageIndex)
+	if geoFormat != "" {
+		path += "&geo=" + url.QueryEscape(string(geoFormat))
+	}
+	if err := c.get(ctx, path, &response); err != nil {
+		return nil, err
+	}
+
+	values := make([]model.TypedValue, len(response.Values))
+	for i, raw := range response.Values {
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&values[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode value %d: %w", i, err)
+		}
+	}
+	return values, nil
+}
+
+// getPageStructure retrieves the encoding structure of a specific page
+func (c *parquetClient) getPageStructure(ctx context.Context, rgIndex, colIndex, pageIndex int) (model.PageStructure, error) {
+	var structure model.PageStructure
+	err := c.get(ctx, fmt.Sprintf("/rowgroups/%d/columnchunks/%d/pages/%d/structure", rgIndex, colIndex, pageIndex), &structure)
+	return structure, err
+}
+
+// getPageValue retrieves one value of a page in full
+func (c *parquetClient) getPageValue(ctx context.Context, rgIndex, colIndex, pageIndex, valueIndex int) (model.ValueDetail, error) {
+	var detail model.ValueDetail
+	err := c.get(ctx, fmt.Sprintf("/rowgroups/%d/columnchunks/%d/pages/%d/content/%d", rgIndex, colIndex, pageIndex, valueIndex), &detail)
+	return detail, err
+}
+
+// getColumnChunkStatistic retrieves the min or max statistic of a column chunk in full
+func (c *parquetClient) getColumnChunkStatistic(ctx context.Context, rgIndex, colIndex int, stat string) (model.ValueDetail, error) {
+	var detail model.ValueDetail
+	err := c.get(ctx, fmt.Sprintf("/rowgroups/%d/columnchunks/%d/statistics/%s", rgIndex, colIndex, stat), &detail)
+	return detail, err
+}
+
+// getPageStatistic retrieves the min or max statistic of a page in full
+func (c *parquetClient) getPageStatistic(ctx context.Context, rgIndex, colIndex, pageIndex int, stat string) (model.ValueDetail, error) {
+	var detail model.ValueDetail
+	err := c.get(ctx, fmt.Sprintf("/rowgroups/%d/columnchunks/%d/pages/%d/statistics/%s", rgIndex, colIndex, pageIndex, stat), &detail)
+	return detail, err
+}
+
+// getColumnProfile computes the data profile of a column, streaming progress
+// updates to the progress callback until the final profile arrives
+func (c *parquetClient) getColumnProfile(ctx context.Context, path string, progress func(model.ProfileProgress)) (model.ColumnProfile, error) {
+	var profile model.ColumnProfile
+
+	reqURL := c.baseURL + "/columns/" + url.PathEscape(path) + "/profile?stream=true"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return profile, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return profile, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return profile, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	// Each line is one event: progress updates followed by the profile or an error
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var event struct {
+			Progress *model.ProfileProgress `json:"progress"`
+			Profile  *model.ColumnProfile   `json:"profile"`
+			Error    string                 `json:"error"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return profile, fmt.Errorf("failed to decode response: %w", err)
+		}
+		switch {
+		case event.Error != "":
+			return profile, errors.New(event.Error)
+		case event.Profile != nil:
+			return *event.Profile, nil
+		case event.Progress != nil && progress != nil:
+			progress(*event.Progress)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return profile, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return profile, errors.New("profile stream ended unexpectedly")
+}
+
+// getLeafColumns retrieves the leaf columns of the schema
+func (c *parquetClient) getLeafColumns(ctx context.Context) ([]model.LeafColumn, error) {
+	var columns []model.LeafColumn
+	err := c.get(ctx, "/columns", &columns)
+	return columns, err
+}
+
+// getColumnOverview retrieves the chunks of a column in every row group
+func (c *parquetClient) getColumnOverview(ctx context.Context, path string) (model.ColumnOverview, error) {
+	var overview model.ColumnOverview
+	err := c.get(ctx, "/columns/"+url.PathEscape(path), &overview)
+	return overview, err
+}
+
+// getColumnDictionary retrieves the dictionary analysis of a column across all row groups
+func (c *parquetClient) getColumnDictionary(ctx context.Context, path string) (model.DictionaryAnalysis, error) {
+	var analysis model.DictionaryAnalysis
+	err := c.get(ctx, "/columns/"+url.PathEscape(path)+"/dictionary", &analysis)
+	return analysis, err
+}
+
+// getColumnGeo retrieves the bounding box of a geospatial column per row group
+func (c *parquetClient) getColumnGeo(ctx context.Context, path string) (model.GeospatialBounds, error) {
+	var bounds model.GeospatialBounds
+	err := c.get(ctx, "/columns/"+url.PathEscape(path)+"/geo", &bounds)
+	return bounds, err
+}
+
 // getSchemaGo retrieves the schema in Go struct format
-func (c *parquetClient) getSchemaGo() (string, error) {
-	return c.getText("/schema/go")
+func (c *parquetClient) getSchemaGo(ctx context.Context) (string, error) {
+	return c.getText(ctx, "/schema/go")
 }
 
 // getSchemaJSON retrieves the schema in JSON format (compact)
-func (c *parquetClient) getSchemaJSON() (string, error) {
-	return c.getText("/schema/json")
+func (c *parquetClient) getSchemaJSON(ctx context.Context) (string, error) {
+	return c.getText(ctx, "/schema/json")
 }
 
 // getSchemaRaw retrieves the raw schema tree structure (compact JSON)
-func (c *parquetClient) getSchemaRaw() (string, error) {
-	return c.getText("/schema/raw")
+func (c *parquetClient) getSchemaRaw(ctx context.Context) (string, error) {
+	return c.getText(ctx, "/schema/raw")
 }
 
 // getSchemaCSV retrieves the schema in CSV format
-func (c *parquetClient) getSchemaCSV() (string, error) {
-	return c.getText("/schema/csv")
+func (c *parquetClient) getSchemaCSV(ctx context.Context) (string, error) {
+	return c.getText(ctx, "/schema/csv")
 }
 
-// Helper method to make GET requests and decode JSON
-func (c *parquetClient) get(path string, result interface{}) error {
-	url := c.baseURL + path
+// getSchemaVariants retrieves the VARIANT columns and the role of their leaf columns
+func (c *parquetClient) getSchemaVariants(ctx context.Context) ([]model.VariantColumn, error) {
+	var variants []model.VariantColumn
+	err := c.get(ctx, "/schema/variants", &variants)
+	return variants, err
+}
 
-	resp, err := c.client.Get(url)
+// Helper method to make GET requests and decode JSON, aborted when ctx is
+// cancelled
+func (c *parquetClient) get(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -128,10 +390,12 @@ func (c *parquetClient) get(path string, result interface{}) error {
 }
 
 // Helper method to make GET requests and return text
-func (c *parquetClient) getText(path string) (string, error) {
-	url := c.baseURL + path
-
-	resp, err := c.client.Get(url)
+func (c *parquetClient) getText(ctx context.Context, path string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.client.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -42,7 +44,7 @@ func Test_getFileInfo(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	info, err := client.getFileInfo()
+	info, err := client.getFileInfo(context.Background())
 
 	require.NoError(t, err)
 
@@ -50,28 +52,49 @@ func Test_getFileInfo(t *testing.T) {
 	require.Equal(t, expectedInfo.NumRows, info.NumRows)
 }
 
-func Test_getAllRowGroupsInfo(t *testing.T) {
-	expectedGroups := []model.RowGroupInfo{
-		{NumRows: 100, CompressedSize: 1024, NumColumns: 5},
-		{NumRows: 200, CompressedSize: 2048, NumColumns: 5},
+func Test_getIOStats(t *testing.T) {
+	expected := model.IOStats{
+		Operations: []model.OperationIOStats{{Operation: model.IOPageHeaders, Reads: 3, Bytes: 12288, Latency: time.Millisecond}},
+		Total:      model.OperationIOStats{Reads: 3, Bytes: 12288, Latency: time.Millisecond},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/debug/io", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer server.Close()
+
+	stats, err := newParquetClient(server.URL).getIOStats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expected, stats)
+}
+
+func Test_getRowGroupList(t *testing.T) {
+	expected := model.RowGroupList{
+		RowGroups: []model.RowGroupInfo{
+			{Index: 100, NumRows: 100, CompressedSize: 1024, NumColumns: 5},
+			{Index: 101, NumRows: 200, CompressedSize: 2048, NumColumns: 5},
+		},
+		Offset: 100,
+		Total:  500,
 	}
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		require.Equal(t, "/rowgroups", r.URL.Path)
+		require.Equal(t, "100", r.URL.Query().Get("offset"))
+		require.Equal(t, "2", r.URL.Query().Get("limit"))
 
 		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(expectedGroups)
+		_ = json.NewEncoder(w).Encode(expected)
 	}))
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	groups, err := client.getAllRowGroupsInfo()
+	list, err := client.getRowGroupList(context.Background(), 100, 2)
 
 	require.NoError(t, err)
-
-	require.Len(t, groups, 2)
-
-	require.Equal(t, int64(100), groups[0].NumRows)
+	require.Equal(t, expected, list)
 }
 
 func Test_getRowGroupInfo(t *testing.T) {
@@ -90,33 +113,38 @@ func Test_getRowGroupInfo(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	info, err := client.getRowGroupInfo(0)
+	info, err := client.getRowGroupInfo(context.Background(), 0)
 
 	require.NoError(t, err)
 
 	require.Equal(t, expectedInfo.NumRows, info.NumRows)
 }
 
-func Test_getAllColumnChunksInfo(t *testing.T) {
-	expectedColumns := []model.ColumnChunkInfo{
-		{Name: "col1", PathInSchema: []string{"col1"}},
-		{Name: "col2", PathInSchema: []string{"col2"}},
+func Test_getColumnChunkList(t *testing.T) {
+	expected := model.ColumnChunkList{
+		ColumnChunks: []model.ColumnChunkInfo{
+			{Name: "col1", PathInSchema: []string{"col1"}},
+			{Name: "col2", PathInSchema: []string{"col2"}},
+		},
+		Total:     2,
+		NumValues: 10,
 	}
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		require.Equal(t, "/rowgroups/0/columnchunks", r.URL.Path)
+		require.Equal(t, "0", r.URL.Query().Get("offset"))
+		require.Equal(t, "10", r.URL.Query().Get("limit"))
 
 		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(expectedColumns)
+		_ = json.NewEncoder(w).Encode(expected)
 	}))
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	columns, err := client.getAllColumnChunksInfo(0)
+	list, err := client.getColumnChunkList(context.Background(), 0, 0, 10)
 
 	require.NoError(t, err)
-
-	require.Len(t, columns, 2)
+	require.Equal(t, expected, list)
 }
 
 func Test_getColumnChunkInfo(t *testing.T) {
@@ -134,7 +162,7 @@ func Test_getColumnChunkInfo(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	info, err := client.getColumnChunkInfo(0, 0)
+	info, err := client.getColumnChunkInfo(context.Background(), 0, 0)
 
 	require.NoError(t, err)
 
@@ -156,7 +184,7 @@ func Test_getAllPagesInfo(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	pages, err := client.getAllPagesInfo(0, 0)
+	pages, err := client.getAllPagesInfo(context.Background(), 0, 0)
 
 	require.NoError(t, err)
 
@@ -179,7 +207,7 @@ func Test_getPageInfo(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	info, err := client.getPageInfo(0, 0, 0)
+	info, err := client.getPageInfo(context.Background(), 0, 0, 0)
 
 	require.NoError(t, err)
 
@@ -205,7 +233,7 @@ func Test_getPageContent(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	values, err := client.getPageContent(0, 0, 0)
+	values, err := client.getPageContent(context.Background(), 0, 0, 0)
 
 	require.NoError(t, err)
 
@@ -226,7 +254,7 @@ func Test_getSchemaGo(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	schema, err := client.getSchemaGo()
+	schema, err := client.getSchemaGo(context.Background())
 
 	require.NoError(t, err)
 
@@ -245,7 +273,7 @@ func Test_getSchemaJSON(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	schema, err := client.getSchemaJSON()
+	schema, err := client.getSchemaJSON(context.Background())
 
 	require.NoError(t, err)
 	require.Equal(t, expectedSchema, schema)
@@ -263,7 +291,7 @@ func Test_getSchemaRaw(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	schema, err := client.getSchemaRaw()
+	schema, err := client.getSchemaRaw(context.Background())
 
 	require.NoError(t, err)
 	require.Equal(t, expectedSchema, schema)
@@ -281,7 +309,7 @@ func Test_getSchemaCSV(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	schema, err := client.getSchemaCSV()
+	schema, err := client.getSchemaCSV(context.Background())
 
 	require.NoError(t, err)
 
@@ -295,7 +323,7 @@ func Test_get_HTTPError(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	_, err := client.getFileInfo()
+	_, err := client.getFileInfo(context.Background())
 
 	require.Error(t, err)
 
@@ -311,7 +339,7 @@ func Test_get_InvalidJSON(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	_, err := client.getFileInfo()
+	_, err := client.getFileInfo(context.Background())
 
 	require.Error(t, err)
 
@@ -325,7 +353,7 @@ func Test_getText_HTTPError(t *testing.T) {
 	defer server.Close()
 
 	client := newParquetClient(server.URL)
-	_, err := client.getSchemaGo()
+	_, err := client.getSchemaGo(context.Background())
 
 	require.Error(t, err)
 
@@ -336,7 +364,7 @@ func Test_client_InvalidURL(t *testing.T) {
 	// Create client with invalid URL that can't be reached
 	client := newParquetClient("http://invalid-host-that-does-not-exist:99999")
 
-	_, err := client.getFileInfo()
+	_, err := client.getFileInfo(context.Background())
 	require.Error(t, err)
 
 	require.Contains(t, err.Error(), "HTTP request failed")
@@ -345,7 +373,7 @@ func Test_client_InvalidURL(t *testing.T) {
 func Test_getText_InvalidURL(t *testing.T) {
 	client := newParquetClient("http://invalid-host-that-does-not-exist:99999")
 
-	_, err := client.getSchemaGo()
+	_, err := client.getSchemaGo(context.Background())
 	require.Error(t, err)
 
 	require.Contains(t, err.Error(), "HTTP request failed")
@@ -367,9 +395,420 @@ func Test_Multiple_Requests(t *testing.T) {
 
 	// Make multiple requests
 	for i := 0; i < 3; i++ {
-		_, err := client.getFileInfo()
+		_, err := client.getFileInfo(context.Background())
 		require.NoError(t, err)
 	}
 
 	require.Equal(t, 3, callCount)
 }
+
+func Test_getColumnProfile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/columns/a.b/profile", r.URL.Path)
+		require.Equal(t, "true", r.URL.Query().Get("stream"))
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = w.Write([]byte(`{"progress":{"RowGroup":0,"NumRowGroups":2,"RowsRead":5,"TotalRows":10}}` + "\n"))
+		_, _ = w.Write([]byte(`{"progress":{"RowGroup":1,"NumRowGroups":2,"RowsRead":10,"TotalRows":10}}` + "\n"))
+		_, _ = w.Write([]byte(`{"profile":{"Path":"a.b","Kind":"NUMERIC","TotalValues":10,"DistinctCount":7}}` + "\n"))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	var updates []model.ProfileProgress
+	profile, err := client.getColumnProfile(context.Background(), "a.b", func(p model.ProfileProgress) {
+		updates = append(updates, p)
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "a.b", profile.Path)
+	require.Equal(t, uint64(7), profile.DistinctCount)
+	require.Len(t, updates, 2)
+	require.Equal(t, int64(10), updates[1].RowsRead)
+}
+
+func Test_getColumnProfile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		errMsg  string
+	}{
+		{
+			name: "HTTP error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "column not found", http.StatusNotFound)
+			},
+			errMsg: "HTTP 404",
+		},
+		{
+			name: "error event",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"error":"read failed"}` + "\n"))
+			},
+			errMsg: "read failed",
+		},
+		{
+			name: "invalid event",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("not json\n"))
+			},
+			errMsg: "failed to decode response",
+		},
+		{
+			name: "stream ends early",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"progress":{"RowGroup":0,"NumRowGroups":1}}` + "\n"))
+			},
+			errMsg: "ended unexpectedly",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			client := newParquetClient(server.URL)
+			_, err := client.getColumnProfile(context.Background(), "a", nil)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func Test_getColumnProfile_Cancelled(t *testing.T) {
+	client := newParquetClient("http://127.0.0.1:0")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.getColumnProfile(ctx, "a", nil)
+	require.Error(t, err)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_parquetClient_Cancelled(t *testing.T) {
+	aborted := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(aborted)
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	_, err := client.getAllPagesInfo(ctx, 0, 1)
+	require.ErrorIs(t, err, context.Canceled)
+	<-aborted
+
+	_, err = client.getSchemaGo(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_getColumnDictionary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/columns/a b/dictionary", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Path":"a b","DataPages":3,"DictionaryEncodedPages":2,"DistinctValues":1,` +
+			`"Values":[{"Value":"x","RowGroups":1,"References":5}]}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	analysis, err := client.getColumnDictionary(context.Background(), "a b")
+	require.NoError(t, err)
+	require.Equal(t, "a b", analysis.Path)
+	require.Equal(t, 2, analysis.DictionaryEncodedPages)
+	require.Len(t, analysis.Values, 1)
+	require.Equal(t, int64(5), analysis.Values[0].References)
+}
+
+func Test_getColumnDictionary_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "column not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	_, err := client.getColumnDictionary(context.Background(), "missing")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "HTTP 404")
+}
+
+func Test_getPageStructure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rowgroups/1/columnchunks/2/pages/3/structure", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"PageType":"DATA_PAGE","Values":{"Encoding":"DELTA_BINARY_PACKED","DeltaBinary":{"FirstValue":7}}}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	structure, err := client.getPageStructure(context.Background(), 1, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, "DATA_PAGE", structure.PageType)
+	require.NotNil(t, structure.Values.DeltaBinary)
+	require.Equal(t, int64(7), structure.Values.DeltaBinary.FirstValue)
+}
+
+func Test_getFileLayout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/layout", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"FileSize":12,"Regions":[{"Kind":"MAGIC","Offset":0,"Length":4,"RowGroup":-1,"Column":-1,"Page":-1}]}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	layout, err := client.getFileLayout(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(12), layout.FileSize)
+	require.Len(t, layout.Regions, 1)
+	require.Equal(t, model.LayoutMagic, layout.Regions[0].Kind)
+}
+
+func Test_getPageContentGeo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rowgroups/0/columnchunks/1/pages/2/content", r.URL.Path)
+		require.Equal(t, "wkt", r.URL.Query().Get("geo"))
+		_, _ = w.Write([]byte(`{"values": ["POINT (1 2)"], "count": 1}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	values, err := client.getPageContentGeo(context.Background(), 0, 1, 2, model.GeoFormatWKT)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"POINT (1 2)"}, values)
+}
+
+func Test_getPageContentRange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rowgroups/0/columnchunks/1/pages/2/content", r.URL.Path)
+		query := r.URL.Query()
+		require.Equal(t, "10", query.Get("limit"))
+		if query.Get("offset") == "0" {
+			_, _ = w.Write([]byte(`{"values": ["a", "b"], "count": 2}`))
+			return
+		}
+		require.Equal(t, "wkt", query.Get("geo"))
+		_, _ = w.Write([]byte(`{"values": ["POINT (1 2)"], "count": 1, "offset": 20, "total": 40, "next": "abc"}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	part, err := client.getPageContentRange(context.Background(), 0, 1, 2, model.GeoFormatWKT, 20, 10)
+	require.NoError(t, err)
+	require.Equal(t, pageContentPart{Values: []string{"POINT (1 2)"}, Total: 40, Next: "abc"}, part)
+
+	part, err = client.getPageContentRange(context.Background(), 0, 1, 2, "", 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, pageContentPart{Values: []string{"a", "b"}, Total: 2}, part)
+}
+
+func Test_getColumnGeo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/columns/a.geom/geo", r.URL.Path)
+		_, _ = w.Write([]byte(`{"Path":"a.geom","LogicalType":"GEOMETRY","RowGroups":[{"RowGroup":0,"Source":"statistics"}]}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	bounds, err := client.getColumnGeo(context.Background(), "a.geom")
+
+	require.NoError(t, err)
+	require.Equal(t, "GEOMETRY", bounds.LogicalType)
+	require.Len(t, bounds.RowGroups, 1)
+}
+
+func Test_getSchemaVariants(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/schema/variants", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"Path":"v","Shredded":true,"Columns":[{"ColumnIndex":3,"Path":"v.typed_value","Role":"shredded"}]}]`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	variants, err := client.getSchemaVariants(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, variants, 1)
+	require.True(t, variants[0].Shredded)
+	require.Equal(t, model.VariantRoleShredded, variants[0].Columns[0].Role)
+}
+
+func Test_getPageValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rowgroups/0/columnchunks/1/pages/2/content/3", r.URL.Path)
+		_, _ = w.Write([]byte(`{"Kind":"json","Formatted":"{}","Size":2,"Hex":"7b7d","Base64":"e30="}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	detail, err := client.getPageValue(context.Background(), 0, 1, 2, 3)
+
+	require.NoError(t, err)
+	require.Equal(t, model.ValueDetail{Kind: model.ValueKindJSON, Formatted: "{}", Size: 2, Hex: "7b7d", Base64: "e30="}, detail)
+}
+
+func Test_getStatistics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"Kind":"scalar","Formatted":"` + r.URL.Path + `"}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	detail, err := client.getColumnChunkStatistic(context.Background(), 0, 1, model.StatisticMin)
+	require.NoError(t, err)
+	require.Equal(t, "/rowgroups/0/columnchunks/1/statistics/min", detail.Formatted)
+
+	detail, err = client.getPageStatistic(context.Background(), 0, 1, 2, model.StatisticMax)
+	require.NoError(t, err)
+	require.Equal(t, "/rowgroups/0/columnchunks/1/pages/2/statistics/max", detail.Formatted)
+}
+
+func Test_getPageContentTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rowgroups/0/columnchunks/1/pages/2/content", r.URL.Path)
+		require.Equal(t, "true", r.URL.Query().Get("typed"))
+		_, _ = w.Write([]byte(`{"values":[{"Value":9007199254740993,"Raw":9007199254740993,"DefinitionLevel":1,"RepetitionLevel":0},` +
+			`{"Value":null,"Raw":null,"DefinitionLevel":0,"RepetitionLevel":0},{"Value":{"a":[true]},"Raw":"e30="}],"count":3}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	values, err := client.getPageContentTyped(context.Background(), 0, 1, 2)
+
+	require.NoError(t, err)
+	require.Equal(t, []model.TypedValue{
+		{Value: json.Number("9007199254740993"), Raw: json.Number("9007199254740993"), DefinitionLevel: 1},
+		{},
+		{Value: map[string]any{"a": []any{true}}, Raw: "e30="},
+	}, values)
+}
+
+func Test_getPageContentTypedGeo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "true", r.URL.Query().Get("typed"))
+		require.Equal(t, "wkt", r.URL.Query().Get("geo"))
+		_, _ = w.Write([]byte(`{"values":[{"Value":"POINT (1 2)"},"bad"],"count":2}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	_, err := client.getPageContentTypedGeo(context.Background(), 0, 1, 2, model.GeoFormatWKT)
+
+	require.ErrorContains(t, err, "failed to decode value 1")
+}
+
+func Test_getLeafColumns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/columns", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"Index":0,"Path":"a.b","PhysicalType":"INT32","LogicalType":"-","ConvertedType":"-"}]`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	columns, err := client.getLeafColumns(context.Background())
+	require.NoError(t, err)
+	require.Len(t, columns, 1)
+	require.Equal(t, "a.b", columns[0].Path)
+}
+
+func Test_getColumnOverview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/columns/a b", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Index":2,"Path":"a b","Chunks":[{"RowGroup":0,"Encodings":["PLAIN"]}],` +
+			`"NumValues":5,"TotalCompressedSize":10,"Codecs":["SNAPPY"]}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	overview, err := client.getColumnOverview(context.Background(), "a b")
+	require.NoError(t, err)
+	require.Equal(t, "a b", overview.Path)
+	require.Equal(t, int64(5), overview.NumValues)
+	require.Len(t, overview.Chunks, 1)
+	require.Equal(t, []string{"PLAIN"}, overview.Chunks[0].Encodings)
+}
+
+func Test_getHeatmap(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/heatmap", r.URL.Path)
+		require.Equal(t, "nulls", r.URL.Query().Get("metric"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Metric":"nulls","Columns":["a"],"Cells":[[{"Value":0.5,"Valid":true,"Level":2,"Label":"50.0%"}]]}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	heatmap, err := client.getHeatmap(context.Background(), model.HeatmapNullFraction)
+	require.NoError(t, err)
+	require.Equal(t, model.HeatmapNullFraction, heatmap.Metric)
+	require.Len(t, heatmap.Cells, 1)
+	require.Equal(t, 2, heatmap.Cells[0][0].Level)
+}
+
+func Test_getRowPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rows/10-20/pages", r.URL.Path)
+		require.Equal(t, []string{"a.b", "c"}, r.URL.Query()["column"])
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"FirstRow":10,"LastRow":20,"Columns":[{"ColumnIndex":0,"Path":"a.b","Source":"OFFSET_INDEX","Pages":[{"RowGroup":0,"PageIndex":1,"FirstRowIndex":0,"FirstRow":0,"NumRows":100,"Offset":4,"Size":200}],"Bytes":200}],"TotalBytes":200}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	rowPages, err := client.getRowPages(context.Background(), "10-20", []string{"a.b", "c"})
+	require.NoError(t, err)
+	require.Equal(t, int64(20), rowPages.LastRow)
+	require.Len(t, rowPages.Columns, 1)
+	require.Equal(t, 1, rowPages.Columns[0].Pages[0].PageIndex)
+	require.Equal(t, int64(200), rowPages.TotalBytes)
+}
+
+func Test_getRowLocation(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rows/42/location", r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Row":42,"RowGroup":1,"RowIndex":2,"Columns":[{"ColumnIndex":0,"Path":"id","PageIndex":1,"ValueIndex":2,"NumValues":1}],"Record":"{}"}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	location, err := client.getRowLocation(context.Background(), 42, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, location.RowGroup)
+	require.Equal(t, int64(2), location.RowIndex)
+	require.Equal(t, 2, location.Columns[0].ValueIndex)
+
+	_, err = client.getRowLocation(context.Background(), 42, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"", "record=true"}, queries)
+}
+
+func Test_getSample(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/sample", r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Mode":"random","N":1,"Seed":7,"Columns":["id"],"Rows":[{"Row":3,"RowGroup":0,"RowIndex":3,"Values":["3"]}]}`))
+	}))
+	defer server.Close()
+
+	client := newParquetClient(server.URL)
+	sample, err := client.getSample(context.Background(), "1", "", "", nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), sample.Seed)
+	require.Equal(t, []string{"3"}, sample.Rows[0].Values)
+
+	_, err = client.getSample(context.Background(), "5", "stratified", "7", []string{"id", "name"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"n=1", "column=id&column=name&mode=stratified&n=5&seed=7"}, queries)
+}
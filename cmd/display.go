@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hangxie/parquet-browser/model"
+	"github.com/hangxie/parquet-browser/service"
+)
+
+// DisplayOption holds the flags that control how values are rendered as text
+type DisplayOption struct {
+	DisplayConfig  string `name:"display-config" group:"Display" help:"path to a JSON file with {timezone, temporal, binary, decimal, float_precision}. CLI flags override file values." default:""`
+	TimeZone       string `name:"timezone" group:"Display" help:"IANA time zone to show UTC-adjusted timestamps in, e.g. America/New_York (default UTC)." default:""`
+	Temporal       string `name:"temporal" group:"Display" help:"render dates, times and timestamps as iso or epoch (the stored integer)." default:""`
+	Binary         string `name:"binary" group:"Display" help:"render binary values as base64, hex or utf8." default:""`
+	Decimal        string `name:"decimal" group:"Display" help:"render decimals as scaled or unscaled (the stored integer)." default:""`
+	FloatPrecision string `name:"float-precision" group:"Display" help:"digits after the decimal point for floats, 1 to 17, or shortest (also 0) for the shortest form even over the display config." default:""`
+	DecoderConfig  string `name:"decoder-config" group:"Display" help:"path to a JSON file mapping binary columns to protobuf, avro, msgpack, cbor or gzip-json decoders." default:""`
+}
+
+// viewSettings are the display, cache, timeout, read-ahead and reader pool
+// flags resolved and ready to apply to a service
+type viewSettings struct {
+	display        model.DisplayOptions
+	decoders       *model.DecoderRegistry
+	cache          *model.CacheOptions // nil keeps the default cache of the service
+	requestTimeout time.Duration       // how long a request may read the file, 0 for no limit
+	prefetch       model.PrefetchOptions
+	readers        int // readers of the file serving requests at most, 0 keeps the default
+}
+
+// settings resolves the display flags along with the config files they name
+func (d DisplayOption) settings() (viewSettings, error) {
+	display, err := d.displayOptions()
+	if err != nil {
+		return viewSettings{}, err
+	}
+	settings := viewSettings{display: display}
+	if d.DecoderConfig != "" {
+		if settings.decoders, err = model.LoadDecoderRegistry(d.DecoderConfig); err != nil {
+			return viewSettings{}, err
+		}
+	}
+	return settings, nil
+}
+
+// apply configures a service to render values with the settings
+func (v viewSettings) apply(svc *service.ParquetService) error {
+	if err := svc.SetDisplayOptions(v.display); err != nil {
+		return err
+	}
+	if v.cache != nil {
+		svc.SetCacheOptions(*v.cache)
+	}
+	svc.SetRequestTimeout(v.requestTimeout)
+	svc.SetPrefetchOptions(v.prefetch)
+	if v.readers > 0 {
+		svc.SetReaderPoolSize(v.readers)
+	}
+	return svc.SetDecoders(v.decoders)
+}
+
+// displayOptions returns the validated display options of the flags, with
+// the display config file filling in anything the flags leave unset
+func (d DisplayOption) displayOptions() (model.DisplayOptions, error) {
+	precision, err := d.floatPrecision()
+	if err != nil {
+		return model.DisplayOptions{}, err
+	}
+	opts := model.DisplayOptions{
+		TimeZone:       d.TimeZone,
+		Temporal:       d.Temporal,
+		Binary:         d.Binary,
+		Decimal:        d.Decimal,
+		FloatPrecision: precision,
+	}
+	if err := loadDisplayConfig(d.DisplayConfig, &opts); err != nil {
+		return opts, err
+	}
+	if err := opts.Validate(); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// floatPrecision parses the float precision flag. An empty flag leaves the
+// precision unset, shortest or 0 asks for the shortest form over any precision
+// in the display config.
+func (d DisplayOption) floatPrecision() (int, error) {
+	switch d.FloatPrecision {
+	case "":
+		return 0, nil
+	case "shortest", "0":
+		return model.FloatShortest, nil
+	}
+	n, err := strconv.Atoi(d.FloatPrecision)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: float precision %q, expected shortest or a number of digits", model.ErrInvalidDisplayOption, d.FloatPrecision)
+	}
+	return n, nil
+}
+
+// loadDisplayConfig reads a JSON display config file and merges its contents
+// into opt. CLI flag values take precedence: a field already set on opt is
+// never replaced.
+func loadDisplayConfig(path string, opt *model.DisplayOptions) error {
+	if path == "" {
+		return nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read display config: %w", err)
+	}
+
+	var file model.DisplayOptions
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return fmt.Errorf("parse display config: %w", err)
+	}
+	if file.FloatPrecision < 0 {
+		return fmt.Errorf("%w: float precision %d in display config", model.ErrInvalidDisplayOption, file.FloatPrecision)
+	}
+
+	*opt = file.Merge(*opt)
+	return nil
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+	"github.com/hangxie/parquet-browser/service"
+)
+
+func writeDisplayConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "display.json")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func Test_loadDisplayConfig(t *testing.T) {
+	t.Run("empty path is a no-op", func(t *testing.T) {
+		opt := model.DisplayOptions{Binary: model.BinaryHex}
+		require.NoError(t, loadDisplayConfig("", &opt))
+		require.Equal(t, model.DisplayOptions{Binary: model.BinaryHex}, opt)
+	})
+
+	t.Run("missing file returns error", func(t *testing.T) {
+		opt := model.DisplayOptions{}
+		err := loadDisplayConfig(filepath.Join(t.TempDir(), "nope.json"), &opt)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read display config")
+	})
+
+	t.Run("invalid JSON returns error", func(t *testing.T) {
+		opt := model.DisplayOptions{}
+		err := loadDisplayConfig(writeDisplayConfig(t, "{not json"), &opt)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse display config")
+	})
+
+	t.Run("unknown field returns error", func(t *testing.T) {
+		opt := model.DisplayOptions{}
+		err := loadDisplayConfig(writeDisplayConfig(t, `{"timezones": "UTC"}`), &opt)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse display config")
+	})
+
+	t.Run("populates empty fields from file", func(t *testing.T) {
+		path := writeDisplayConfig(t, `{
+			"timezone": "Asia/Tokyo",
+			"temporal": "epoch",
+			"binary": "utf8",
+			"decimal": "unscaled",
+			"float_precision": 3
+		}`)
+		opt := model.DisplayOptions{}
+		require.NoError(t, loadDisplayConfig(path, &opt))
+		require.Equal(t, model.DisplayOptions{
+			TimeZone: "Asia/Tokyo", Temporal: "epoch", Binary: "utf8", Decimal: "unscaled", FloatPrecision: 3,
+		}, opt)
+	})
+
+	t.Run("flags take precedence", func(t *testing.T) {
+		path := writeDisplayConfig(t, `{"timezone": "Asia/Tokyo", "binary": "utf8", "float_precision": 3}`)
+		opt := model.DisplayOptions{TimeZone: "UTC", FloatPrecision: 1}
+		require.NoError(t, loadDisplayConfig(path, &opt))
+		require.Equal(t, model.DisplayOptions{TimeZone: "UTC", Binary: "utf8", FloatPrecision: 1}, opt)
+	})
+}
+
+func Test_DisplayOption_displayOptions(t *testing.T) {
+	t.Run("flags only", func(t *testing.T) {
+		opts, err := DisplayOption{Temporal: "epoch", FloatPrecision: "2"}.displayOptions()
+		require.NoError(t, err)
+		require.Equal(t, model.DisplayOptions{Temporal: "epoch", FloatPrecision: 2}, opts)
+	})
+
+	t.Run("config file under flags", func(t *testing.T) {
+		path := writeDisplayConfig(t, `{"decimal": "unscaled", "binary": "utf8"}`)
+		opts, err := DisplayOption{DisplayConfig: path, Binary: "hex"}.displayOptions()
+		require.NoError(t, err)
+		require.Equal(t, model.DisplayOptions{Decimal: "unscaled", Binary: "hex"}, opts)
+	})
+
+	t.Run("invalid flag", func(t *testing.T) {
+		_, err := DisplayOption{TimeZone: "Nowhere/Nothing"}.displayOptions()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, model.ErrInvalidDisplayOption))
+	})
+
+	t.Run("shortest over config file", func(t *testing.T) {
+		path := writeDisplayConfig(t, `{"float_precision": 3}`)
+		for _, precision := range []string{"shortest", "0"} {
+			opts, err := DisplayOption{DisplayConfig: path, FloatPrecision: precision}.displayOptions()
+			require.NoError(t, err)
+			require.Equal(t, model.DisplayOptions{FloatPrecision: model.FloatShortest}, opts)
+		}
+	})
+
+	t.Run("invalid float precision", func(t *testing.T) {
+		for _, precision := range []string{"-1", "abc", "18"} {
+			_, err := DisplayOption{FloatPrecision: precision}.displayOptions()
+			require.Error(t, err, precision)
+			require.True(t, errors.Is(err, model.ErrInvalidDisplayOption), precision)
+		}
+	})
+
+	t.Run("negative config file precision", func(t *testing.T) {
+		path := writeDisplayConfig(t, `{"float_precision": -1}`)
+		_, err := DisplayOption{DisplayConfig: path}.displayOptions()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, model.ErrInvalidDisplayOption))
+	})
+
+	t.Run("invalid config file value", func(t *testing.T) {
+		path := writeDisplayConfig(t, `{"float_precision": 42}`)
+		_, err := DisplayOption{DisplayConfig: path}.displayOptions()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, model.ErrInvalidDisplayOption))
+	})
+
+	t.Run("bad config file", func(t *testing.T) {
+		_, err := DisplayOption{DisplayConfig: filepath.Join(t.TempDir(), "nope.json")}.displayOptions()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read display config")
+	})
+}
+
+func Test_Commands_InvalidDisplayOption(t *testing.T) {
+	display := DisplayOption{Temporal: "never"}
+
+	err := ServeCmd{URI: "nonexistent.parquet", Addr: ":0", DisplayOption: display}.Run()
+	require.True(t, errors.Is(err, model.ErrInvalidDisplayOption))
+
+	err = WebUICmd{URI: "nonexistent.parquet", DisplayOption: display}.Run()
+	require.True(t, errors.Is(err, model.ErrInvalidDisplayOption))
+
+	err = TUICmd{URI: "nonexistent.parquet", DisplayOption: display}.Run()
+	require.True(t, errors.Is(err, model.ErrInvalidDisplayOption))
+}
+
+func Test_DisplayOption_settings(t *testing.T) {
+	t.Run("no decoder config", func(t *testing.T) {
+		settings, err := DisplayOption{Binary: "hex"}.settings()
+		require.NoError(t, err)
+		require.Equal(t, model.DisplayOptions{Binary: "hex"}, settings.display)
+		require.Nil(t, settings.decoders)
+	})
+
+	t.Run("decoder config", func(t *testing.T) {
+		path := writeDisplayConfig(t, `{"columns": {"ByteArray": {"type": "msgpack"}}}`)
+		settings, err := DisplayOption{DecoderConfig: path}.settings()
+		require.NoError(t, err)
+		require.Equal(t, []string{"ByteArray"}, settings.decoders.Columns())
+	})
+
+	t.Run("bad decoder config", func(t *testing.T) {
+		path := writeDisplayConfig(t, `{"columns": {"ByteArray": {"type": "xml"}}}`)
+		_, err := DisplayOption{DecoderConfig: path}.settings()
+		require.ErrorIs(t, err, model.ErrInvalidDecoderConfig)
+	})
+
+	t.Run("invalid display option", func(t *testing.T) {
+		_, err := DisplayOption{Decimal: "rounded"}.settings()
+		require.ErrorIs(t, err, model.ErrInvalidDisplayOption)
+	})
+}
+
+func Test_viewSettings_apply(t *testing.T) {
+	svc, err := service.NewParquetService("../build/testdata/all-types.parquet", pio.ReadOption{})
+	require.NoError(t, err)
+
+	registry := model.NewDecoderRegistry()
+	registry.Register("Int32", nil)
+	require.ErrorIs(t, viewSettings{decoders: registry}.apply(svc), model.ErrInvalidDecoderConfig)
+	require.ErrorIs(t, viewSettings{display: model.DisplayOptions{Temporal: "never"}}.apply(svc), model.ErrInvalidDisplayOption)
+	require.NoError(t, viewSettings{display: model.DisplayOptions{Temporal: "epoch"}}.apply(svc))
+}
@@ -0,0 +1,6 @@
+package cmd
+
+// PoolOption holds the flag sizing the pool of readers of the file
+type PoolOption struct {
+	Readers int `name:"readers" group:"I/O" help:"readers of the file serving requests in parallel at most, each with a file handle per column (default 4)." default:"4"`
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"testing"
+
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+	"github.com/hangxie/parquet-browser/service"
+)
+
+func Test_viewSettings_apply_Readers(t *testing.T) {
+	svc, err := service.NewParquetService("../build/testdata/all-types.parquet", pio.ReadOption{})
+	require.NoError(t, err)
+	defer func() { _ = svc.Close() }()
+
+	require.NoError(t, viewSettings{}.apply(svc))
+	require.Equal(t, model.DefaultReaderPoolSize, svc.HandleStats().PoolSize)
+
+	require.NoError(t, viewSettings{readers: 2}.apply(svc))
+	require.Equal(t, 2, svc.HandleStats().PoolSize)
+}
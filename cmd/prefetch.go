@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// PrefetchOption holds the flag reading ahead of small reads of the file
+type PrefetchOption struct {
+	Prefetch int `name:"prefetch" group:"I/O" help:"KiB to read ahead at most after a small read, so that page header scans of remote files take fewer range requests, 0 disables read-ahead (default 0)." default:"0"`
+}
+
+// prefetchOptions returns the read-ahead options of the flag, starting from
+// model.DefaultPrefetchMinBlock or the whole read-ahead when it is smaller
+func (p PrefetchOption) prefetchOptions() model.PrefetchOptions {
+	maxBlock := max(p.Prefetch, 0) << 10
+	return model.PrefetchOptions{MinBlock: min(model.DefaultPrefetchMinBlock, maxBlock), MaxBlock: maxBlock}
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+	"github.com/hangxie/parquet-browser/service"
+)
+
+func Test_PrefetchOption_prefetchOptions(t *testing.T) {
+	require.Equal(t, model.PrefetchOptions{}, PrefetchOption{}.prefetchOptions())
+	require.Equal(t, model.PrefetchOptions{}, PrefetchOption{Prefetch: -1}.prefetchOptions())
+	require.Equal(t, model.PrefetchOptions{MinBlock: 16 << 10, MaxBlock: 16 << 10}, PrefetchOption{Prefetch: 16}.prefetchOptions())
+	require.Equal(t, model.PrefetchOptions{MinBlock: model.DefaultPrefetchMinBlock, MaxBlock: 4 << 20}, PrefetchOption{Prefetch: 4096}.prefetchOptions())
+}
+
+func Test_viewSettings_apply_Prefetch(t *testing.T) {
+	svc, err := service.NewParquetService("../build/testdata/all-types.parquet", pio.ReadOption{})
+	require.NoError(t, err)
+	defer func() { _ = svc.Close() }()
+
+	require.NoError(t, viewSettings{}.apply(svc))
+	require.Zero(t, svc.IOStats().Prefetch.MaxBlock)
+
+	opts := PrefetchOption{Prefetch: 1024}.prefetchOptions()
+	require.NoError(t, viewSettings{prefetch: opts}.apply(svc))
+	require.Equal(t, opts, svc.IOStats().Prefetch)
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	pio "github.com/hangxie/parquet-tools/io"
+
+	"github.com/hangxie/parquet-browser/model"
+	"github.com/hangxie/parquet-browser/service"
+)
+
+// SampleCmd is a kong command for sampling rows
+type SampleCmd struct {
+	URI     string   `arg:"" predictor:"file" help:"URI of Parquet file."`
+	N       int      `short:"n" default:"10" help:"Rows to sample, per row group when stratified (default 10)."`
+	Mode    string   `short:"m" enum:"random,stratified" default:"random" help:"random rows of the file or stratified rows of every row group (default random)."`
+	Seed    string   `short:"s" default:"" help:"seed of the draw, the same seed draws the same rows. Empty for a new draw, the seed used is reported."`
+	Columns []string `name:"column" short:"c" help:"dotted path of a column to show, can be repeated. All columns when not given."`
+	JSON    bool     `short:"j" help:"Output in JSON format." default:"false"`
+	KeyFile string   `name:"key-file" group:"Encryption" help:"path to a JSON file with {footer_key, aad_prefix, column_keys}. CLI flags override file values." default:""`
+	pio.ReadOption
+	DisplayOption
+}
+
+// Run draws the sample and prints it as a table, or as JSON
+func (c SampleCmd) Run() error {
+	if err := loadKeyFile(c.KeyFile, &c.ReadOption); err != nil {
+		return err
+	}
+	settings, err := c.settings()
+	if err != nil {
+		return err
+	}
+	svc, err := service.NewParquetService(c.URI, c.ReadOption)
+	if err != nil {
+		return fmt.Errorf("failed to create service: %w", err)
+	}
+	defer func() { _ = svc.Close() }()
+	if err := settings.apply(svc); err != nil {
+		return err
+	}
+
+	return c.run(svc, os.Stdout, os.Stderr)
+}
+
+// run draws the sample from a service, the rows go to out and the seed and
+// pages read to info so that the table stays clean
+func (c SampleCmd) run(svc *service.ParquetService, out, info io.Writer) error {
+	mode, err := model.ParseSampleMode(c.Mode)
+	if err != nil {
+		return err
+	}
+	seed, err := model.ParseSampleSeed(c.Seed)
+	if err != nil {
+		return err
+	}
+
+	sample, err := svc.Sample(context.Background(), model.SampleOptions{Mode: mode, N: c.N, Seed: seed}, c.Columns)
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		buf, err := json.Marshal(sample)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(buf))
+		return err
+	}
+
+	_, _ = fmt.Fprintf(info, "Mode: %s  Seed: %d  Rows: %d  Pages read: %d  Bytes read: %s\n",
+		sample.Mode, sample.Seed, len(sample.Rows), sample.PagesRead, model.FormatBytes(sample.BytesRead))
+	return writeSampleTable(out, sample)
+}
+
+// writeSampleTable prints the sampled rows as aligned columns, tabs and line
+// breaks within values are shown as spaces
+func writeSampleTable(out io.Writer, sample model.Sample) error {
+	flatten := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Row\tRow Group\t%s\n", strings.Join(sample.Columns, "\t"))
+	for _, row := range sample.Rows {
+		values := make([]string, len(row.Values))
+		for i, value := range row.Values {
+			values[i] = flatten.Replace(value)
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%d\t%s\n", row.Row, row.RowGroup, strings.Join(values, "\t"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+	"github.com/hangxie/parquet-browser/service"
+)
+
+func Test_SampleCmd_Run_InvalidFile(t *testing.T) {
+	cmd := SampleCmd{URI: "nonexistent.parquet", N: 1}
+
+	err := cmd.Run()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to create service")
+}
+
+func Test_SampleCmd_run(t *testing.T) {
+	svc, err := service.NewParquetService("../build/testdata/all-types.parquet", pio.ReadOption{})
+	require.NoError(t, err)
+	defer func() { _ = svc.Close() }()
+
+	t.Run("Table", func(t *testing.T) {
+		var out, info bytes.Buffer
+		cmd := SampleCmd{N: 2, Mode: "random", Seed: "42", Columns: []string{"Int32", "Map.Key_value.Key"}}
+		require.NoError(t, cmd.run(svc, &out, &info))
+
+		require.Contains(t, info.String(), "Mode: random  Seed: 42  Rows: 2")
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 3)
+		require.Equal(t, []string{"Row", "Row", "Group", "Int32", "Map.Key_value.Key"}, strings.Fields(lines[0]))
+
+		var again bytes.Buffer
+		require.NoError(t, cmd.run(svc, &again, &info))
+		require.Equal(t, out.String(), again.String())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var out, info bytes.Buffer
+		cmd := SampleCmd{N: 10, Mode: "stratified", Seed: "1", Columns: []string{"Int32"}, JSON: true}
+		require.NoError(t, cmd.run(svc, &out, &info))
+
+		var sample model.Sample
+		require.NoError(t, json.Unmarshal(out.Bytes(), &sample))
+		require.Equal(t, model.SampleStratified, sample.Mode)
+		require.Len(t, sample.Rows, 5)
+		require.Empty(t, info.String())
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		var out bytes.Buffer
+		require.ErrorIs(t, SampleCmd{N: 0}.run(svc, &out, &out), model.ErrInvalidSampleSize)
+		require.ErrorIs(t, SampleCmd{N: 1, Mode: "bogus"}.run(svc, &out, &out), model.ErrInvalidSampleMode)
+		require.Error(t, SampleCmd{N: 1, Seed: "x"}.run(svc, &out, &out))
+		require.ErrorIs(t, SampleCmd{N: 1, Columns: []string{"NoSuchColumn"}}.run(svc, &out, &out), model.ErrInvalidColumnPath)
+	})
+}
+
+func Test_writeSampleTable(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeSampleTable(&out, model.Sample{
+		Columns: []string{"id", "note"},
+		Rows:    []model.SampleRow{{Row: 3, RowGroup: 1, Values: []string{"3", "two\nlines"}}},
+	}))
+	require.Equal(t, "Row  Row Group  id  note\n3    1          3   two lines\n", out.String())
+}
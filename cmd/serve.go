@@ -14,6 +14,11 @@ type ServeCmd struct {
 	Addr    string `short:"a" default:":8080" help:"Address to listen on (default :8080)."`
 	KeyFile string `name:"key-file" group:"Encryption" help:"path to a JSON file with {footer_key, aad_prefix, column_keys}. CLI flags override file values." default:""`
 	pio.ReadOption
+	DisplayOption
+	CacheOption
+	TimeoutOption
+	PrefetchOption
+	PoolOption
 }
 
 // Run starts the HTTP API server
@@ -21,12 +26,23 @@ func (s ServeCmd) Run() error {
 	if err := loadKeyFile(s.KeyFile, &s.ReadOption); err != nil {
 		return err
 	}
+	settings, err := s.settings()
+	if err != nil {
+		return err
+	}
+	settings.cache = s.cacheOptions()
+	settings.requestTimeout = s.RequestTimeout
+	settings.prefetch = s.prefetchOptions()
+	settings.readers = s.Readers
 	// Create the service
 	svc, err := service.NewParquetService(s.URI, s.ReadOption)
 	if err != nil {
 		return fmt.Errorf("failed to create service: %w", err)
 	}
 	defer func() { _ = svc.Close() }()
+	if err := settings.apply(svc); err != nil {
+		return err
+	}
 
 	// Start the server
 	return service.StartServer(svc, s.Addr)
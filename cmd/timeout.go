@@ -0,0 +1,8 @@
+package cmd
+
+import "time"
+
+// TimeoutOption holds the flag limiting how long a request may read the file
+type TimeoutOption struct {
+	RequestTimeout time.Duration `name:"request-timeout" help:"longest a request may read the file before failing with 504, 0 for no limit (default 0)." default:"0s"`
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/service"
+)
+
+func Test_viewSettings_apply_RequestTimeout(t *testing.T) {
+	svc, err := service.NewParquetService("../build/testdata/all-types.parquet", pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = svc.Close() })
+
+	require.NoError(t, viewSettings{requestTimeout: time.Minute}.apply(svc))
+	require.Equal(t, time.Minute, svc.RequestTimeout())
+
+	require.NoError(t, viewSettings{}.apply(svc))
+	require.Zero(t, svc.RequestTimeout())
+}
@@ -20,6 +20,11 @@ type TUICmd struct {
 	URI     string `arg:"" predictor:"file" help:"URI of Parquet file."`
 	KeyFile string `name:"key-file" group:"Encryption" help:"path to a JSON file with {footer_key, aad_prefix, column_keys}. CLI flags override file values." default:""`
 	pio.ReadOption
+	DisplayOption
+	CacheOption
+	TimeoutOption
+	PrefetchOption
+	PoolOption
 }
 
 // serverResult contains the result of HTTP server startup
@@ -36,9 +41,14 @@ var (
 
 // startHTTPServer starts an embedded HTTP server for serving Parquet file data
 // It runs in a goroutine and sends the result (server URL and instance, or error) to resultChan
-func startHTTPServer(ctx context.Context, uri string, readOpt pio.ReadOption, resultChan chan<- serverResult) {
+func startHTTPServer(ctx context.Context, uri string, readOpt pio.ReadOption, settings viewSettings, resultChan chan<- serverResult) {
 	// Create the service
 	svc, err := service.NewParquetService(uri, readOpt)
+	if err == nil {
+		if err = settings.apply(svc); err != nil {
+			_ = svc.Close()
+		}
+	}
 	if err != nil {
 		select {
 		case <-ctx.Done():
@@ -51,6 +61,7 @@ func startHTTPServer(ctx context.Context, uri string, readOpt pio.ReadOption, re
 	// Find an available port
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
+		_ = svc.Close()
 		select {
 		case <-ctx.Done():
 			return
@@ -67,6 +78,8 @@ func startHTTPServer(ctx context.Context, uri string, readOpt pio.ReadOption, re
 		Addr:    addr,
 		Handler: router,
 	}
+	// The file handles go with the server, after the requests in progress
+	server.RegisterOnShutdown(func() { _ = svc.Close() })
 
 	// Start server in background
 	go func() {
@@ -104,6 +117,14 @@ func (b TUICmd) Run() error {
 	if err := loadKeyFile(b.KeyFile, &b.ReadOption); err != nil {
 		return err
 	}
+	settings, err := b.settings()
+	if err != nil {
+		return err
+	}
+	settings.cache = b.cacheOptions()
+	settings.requestTimeout = b.RequestTimeout
+	settings.prefetch = b.prefetchOptions()
+	settings.readers = b.Readers
 	app := newTUIAppForRun()
 
 	// Create a loading modal with cancellation instructions
@@ -136,7 +157,7 @@ func (b TUICmd) Run() error {
 	resultChan := make(chan serverResult, 1)
 
 	// Start embedded HTTP server in background
-	go startHTTPServerForRun(ctx, b.URI, b.ReadOption, resultChan)
+	go startHTTPServerForRun(ctx, b.URI, b.ReadOption, settings, resultChan)
 
 	// Start the app and wait for server startup
 	var httpServer *http.Server
@@ -173,12 +194,13 @@ func (b TUICmd) Run() error {
 				app.showMainView()
 				app.pages.AddPage("main", app.mainLayout, true, true)
 				app.pages.SwitchToPage("main")
+				go app.watchIO(ctx)
 			})
 		}
 	}()
 
 	// Run the app
-	err := app.tviewApp.Run()
+	err = app.tviewApp.Run()
 
 	// Clean up - shutdown HTTP server
 	if httpServer != nil {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/hangxie/parquet-go/v3/parquet"
@@ -13,6 +14,10 @@ import (
 	"github.com/hangxie/parquet-browser/model"
 )
 
+// ioRefreshInterval is how often the status line of the main view reloads
+// the reads of the file
+const ioRefreshInterval = 2 * time.Second
+
 // TUIApp represents the TUI application for browsing Parquet files
 type TUIApp struct {
 	tviewApp       *tview.Application
@@ -21,6 +26,7 @@ type TUIApp struct {
 	headerView     *tview.TextView
 	rowGroupList   *tview.Table
 	statusLine     *tview.TextView
+	ioStatus       string // reads of the file shown under the keys of the status line, "" until loaded
 	currentFile    string
 	httpClient     *parquetClient // HTTP client for data access
 	lastRGIndex    int            // Track which row group we're positioned at (unused, kept for compatibility)
@@ -38,15 +44,16 @@ func NewTUIApp() *TUIApp {
 }
 
 // readPageHeaders reads all page headers from a column chunk via HTTP API
-func (app *TUIApp) readPageHeaders(rgIndex, colIndex int) ([]model.PageMetadata, error) {
+func (app *TUIApp) readPageHeaders(ctx context.Context, rgIndex, colIndex int) ([]model.PageMetadata, error) {
 	// Use HTTP client to get page metadata
-	return app.httpClient.getAllPagesInfo(rgIndex, colIndex)
+	return app.httpClient.getAllPagesInfo(ctx, rgIndex, colIndex)
 }
 
-// readPageContent reads and decodes the content of a specific page via HTTP API
-func (app *TUIApp) readPageContent(rgIndex, colIndex, pageIndex int) ([]string, error) {
+// readPageContent reads at most limit values of a page from offset via HTTP
+// API, geospatial values in the given format
+func (app *TUIApp) readPageContent(ctx context.Context, rgIndex, colIndex, pageIndex int, geoFormat model.GeoFormat, offset, limit int) (pageContentPart, error) {
 	// Use HTTP client to get pre-formatted page content
-	return app.httpClient.getPageContent(rgIndex, colIndex, pageIndex)
+	return app.httpClient.getPageContentRange(ctx, rgIndex, colIndex, pageIndex, geoFormat, offset, limit)
 }
 
 // buildColumnChunkInfoViewFromHTTP creates the info view for a column chunk using HTTP API data
@@ -105,6 +112,21 @@ func (app *TUIApp) buildColumnChunkInfoViewFromHTTP(colInfo model.ColumnChunkInf
 		}
 	}
 
+	// Line 5: Geospatial statistics (if available)
+	if geo := formatGeospatialStatistics(colInfo.GeospatialStatistics); geo != "" {
+		info.WriteString("\n" + geo)
+	}
+
+	// Line 6: Enclosing VARIANT (if any)
+	if variant := formatVariantMember(colInfo.Variant); variant != "" {
+		info.WriteString("\n" + variant)
+	}
+
+	// Line 7: Size statistics (if available)
+	if sizeStats := formatSizeStatistics(colInfo.SizeStatistics); sizeStats != "" {
+		info.WriteString("\n" + sizeStats)
+	}
+
 	infoView.SetText(info.String())
 	infoView.SetBorder(true).SetTitle(" Column Chunk Info ")
 
@@ -138,7 +160,7 @@ func (app *TUIApp) showMainView() {
 	app.mainLayout.
 		AddItem(app.headerView, headerHeight, 0, false).
 		AddItem(app.rowGroupList, 0, 1, true).
-		AddItem(app.statusLine, 1, 0, false)
+		AddItem(app.statusLine, 2, 0, false)
 
 	// Add key bindings
 	app.mainLayout.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
@@ -155,9 +177,28 @@ func (app *TUIApp) showMainView() {
 			}
 			return nil
 		case tcell.KeyRune:
-			if event.Rune() == 's' {
+			switch event.Rune() {
+			case 's':
 				app.showSchema()
 				return nil
+			case 'l':
+				app.showFileLayout()
+				return nil
+			case 'c':
+				app.showLeafColumns()
+				return nil
+			case 'h':
+				app.showHeatmap(model.HeatmapCompressedSize)
+				return nil
+			case 'r':
+				app.showRowPagesPrompt()
+				return nil
+			case 'S':
+				app.showSamplePrompt()
+				return nil
+			case ':':
+				app.showCommandPrompt()
+				return nil
 			}
 		}
 		return event
@@ -168,7 +209,7 @@ func (app *TUIApp) showSchema() {
 	// Inline schema viewer creation
 	viewer := &schemaViewer{
 		app:           app,
-		schemaFormats: []string{"json", "raw", "go", "csv"},
+		schemaFormats: []string{"json", "raw", "go", "csv", "variants"},
 		currentFormat: 0,
 		isPretty:      true,
 		textView: tview.NewTextView().
@@ -257,10 +298,20 @@ func (app *TUIApp) showPageView(rgIndex, colIndex int) {
 
 	// Build the page view in background
 	go func() {
-		defer cancel() // Ensure context is cancelled when done
+		// Once shown, the view reads with ctx until ESC closes it
+		shown := false
+		defer func() {
+			if !shown {
+				cancel()
+			}
+		}()
 
 		// Fetch column chunk info from HTTP API
-		colInfo, err := app.httpClient.getColumnChunkInfo(rgIndex, colIndex)
+		colInfo, err := app.httpClient.getColumnChunkInfo(ctx, rgIndex, colIndex)
+		if ctx.Err() != nil {
+			// Cancelled by the user, the loading modal is already gone
+			return
+		}
 		if err != nil {
 			app.tviewApp.QueueUpdateDraw(func() {
 				app.pages.RemovePage("page-loading")
@@ -277,7 +328,10 @@ func (app *TUIApp) showPageView(rgIndex, colIndex int) {
 		}
 
 		// Fetch page metadata from HTTP API
-		pages, err := app.httpClient.getAllPagesInfo(rgIndex, colIndex)
+		pages, err := app.httpClient.getAllPagesInfo(ctx, rgIndex, colIndex)
+		if ctx.Err() != nil {
+			return
+		}
 		if err != nil {
 			app.tviewApp.QueueUpdateDraw(func() {
 				app.pages.RemovePage("page-loading")
@@ -314,24 +368,14 @@ func (app *TUIApp) showPageView(rgIndex, colIndex int) {
 				UncompressedSizeFormatted: p.UncompressedSizeFormatted,
 				MinValueFormatted:         p.MinValueFormatted,
 				MaxValueFormatted:         p.MaxValueFormatted,
+				SizeStatistics:            p.SizeStatistics,
 			}
 		}
 
 		// Create column chunk info view with HTTP data
 		infoView := app.buildColumnChunkInfoViewFromHTTP(colInfo, len(pageInfos))
 
-		// Create a minimal ColumnMetaData from HTTP data for compatibility
-		// This allows existing code to work without major refactoring
-		physicalType := parsePhysicalType(colInfo.PhysicalType)
-		codec := parseCompressionCodec(colInfo.Codec)
-		meta := &parquet.ColumnMetaData{
-			Type:                  physicalType,
-			Codec:                 codec,
-			NumValues:             colInfo.NumValues,
-			TotalCompressedSize:   colInfo.CompressedSize,
-			TotalUncompressedSize: colInfo.UncompressedSize,
-			PathInSchema:          colInfo.PathInSchema,
-		}
+		meta := columnMetaFromInfo(colInfo)
 
 		// Create pages table with lazy loading
 		pageTable := tview.NewTable().
@@ -360,12 +404,13 @@ func (app *TUIApp) showPageView(rgIndex, colIndex int) {
 			loadedPages:    0,
 			isLoading:      false,
 			statusTextView: statusText,
+			ctx:            ctx,
 		}
 
 		builder.build()
 
 		// Set status line text (keys only)
-		status := " [yellow]Keys:[-] ESC=back, s=schema, ↑↓=scroll, Enter=see item details"
+		status := " [yellow]Keys:[-] ESC=back, s=schema, ↑↓=scroll, Enter=see item details, e=encoding structure, m/M=full min/max"
 		if v := GetVersion(); v != "" {
 			status += fmt.Sprintf("  [gray]%s[-]", v)
 		}
@@ -379,6 +424,7 @@ func (app *TUIApp) showPageView(rgIndex, colIndex int) {
 		}
 
 		// Update UI on main thread
+		shown = true
 		app.tviewApp.QueueUpdateDraw(func() {
 			// Check one more time if cancelled
 			select {
@@ -400,12 +446,33 @@ func (app *TUIApp) showPageView(rgIndex, colIndex int) {
 			flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 				switch event.Key() {
 				case tcell.KeyEscape:
+					cancel()
 					app.pages.RemovePage("pageview")
 					return nil
 				case tcell.KeyRune:
-					if event.Rune() == 's' {
+					switch event.Rune() {
+					case 's':
 						app.showSchema()
 						return nil
+					case 'e':
+						// Show the encoding structure of the selected page
+						row, _ := pageTable.GetSelection()
+						if pageIndex := row - 1; pageIndex >= 0 && pageIndex < len(pageInfos) {
+							app.showPageStructure(rgIndex, colIndex, pageIndex)
+						}
+						return nil
+					case 'm', 'M':
+						// Show the min or max statistic of the selected page in full
+						row, _ := pageTable.GetSelection()
+						if pageIndex := row - 1; pageIndex >= 0 && pageIndex < len(pageInfos) {
+							stat := statisticForKey(event.Rune())
+							app.showValueDetail(
+								fmt.Sprintf("Page %s - RG %d, Column %d, Page %d", statisticLabel(stat), rgIndex, colIndex, pageIndex),
+								func(ctx context.Context) (model.ValueDetail, error) {
+									return app.httpClient.getPageStatistic(ctx, rgIndex, colIndex, pageIndex, stat)
+								})
+						}
+						return nil
 					}
 				}
 				return event
@@ -416,24 +483,52 @@ func (app *TUIApp) showPageView(rgIndex, colIndex int) {
 	}()
 }
 
+// columnMetaFromInfo creates a minimal ColumnMetaData from HTTP data for
+// compatibility. This allows existing code to work without major refactoring.
+func columnMetaFromInfo(colInfo model.ColumnChunkInfo) *parquet.ColumnMetaData {
+	return &parquet.ColumnMetaData{
+		Type:                  parsePhysicalType(colInfo.PhysicalType),
+		Codec:                 parseCompressionCodec(colInfo.Codec),
+		NumValues:             colInfo.NumValues,
+		TotalCompressedSize:   colInfo.CompressedSize,
+		TotalUncompressedSize: colInfo.UncompressedSize,
+		PathInSchema:          colInfo.PathInSchema,
+	}
+}
+
 func (app *TUIApp) showPageContent(rgIndex, colIndex, pageIndex int, allPages []model.PageMetadata, meta *parquet.ColumnMetaData) {
+	app.showHighlightedPageContent(rgIndex, colIndex, pageIndex, allPages, meta, -1, 0)
+}
+
+// showHighlightedPageContent shows the page content with highlightCount
+// values from highlightStart highlighted and the first of them selected. A
+// negative highlightStart highlights nothing.
+func (app *TUIApp) showHighlightedPageContent(rgIndex, colIndex, pageIndex int, allPages []model.PageMetadata, meta *parquet.ColumnMetaData, highlightStart, highlightCount int) {
 	pageInfo := allPages[pageIndex]
 	// Show loading message
 	loadingModal := tview.NewModal().
-		SetText(fmt.Sprintf("Loading page content...\n\nPage Type: %s\nValues: %d\n\nPlease wait...",
+		SetText(fmt.Sprintf("Loading page content...\n\nPage Type: %s\nValues: %d\n\nPlease wait...\n\nPress ESC to cancel",
 			pageInfo.PageType,
 			pageInfo.NumValues)).
 		SetTextColor(tcell.ColorYellow)
 
-	app.pages.AddPage("page-content-loading", loadingModal, true, true)
-
 	// Create context for cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 
-	// Load content in background
-	go func() {
-		defer cancel()
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("page-content-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("page-content-loading", loadingModal, true, true)
 
+	// Load content in background, more values are loaded with ctx as the
+	// user scrolls until ESC cancels it
+	go func() {
 		// Create header info view
 		headerView := tview.NewTextView().
 			SetDynamicColors(true).
@@ -457,6 +552,12 @@ func (app *TUIApp) showPageContent(rgIndex, colIndex, pageIndex int, allPages []
 			SetDynamicColors(true).
 			SetTextAlign(tview.AlignLeft)
 
+		// GEOMETRY and GEOGRAPHY values can be switched between GeoJSON and WKT
+		isGeospatial := false
+		if colInfo, err := app.httpClient.getColumnChunkInfo(ctx, rgIndex, colIndex); err == nil {
+			isGeospatial = colInfo.LogicalType == "GEOMETRY" || colInfo.LogicalType == "GEOGRAPHY"
+		}
+
 		// Build table with lazy loading
 		builder := &pageContentBuilder{
 			app:            app,
@@ -468,18 +569,28 @@ func (app *TUIApp) showPageContent(rgIndex, colIndex, pageIndex int, allPages []
 			meta:           meta,
 			table:          contentTable,
 			batchSize:      100, // Load 100 values at a time
-			loadedValues:   0,
 			isLoading:      false,
 			statusTextView: statusText,
 			headerView:     headerView,
 			ctx:            ctx,
 			cancel:         cancel,
+			isGeospatial:   isGeospatial,
+			geoFormat:      model.GeoFormatGeoJSON,
+			highlightStart: highlightStart,
+			highlightCount: highlightCount,
 		}
 
 		table, err := builder.build()
+		if ctx.Err() != nil {
+			// Cancelled by the user, the loading modal is already gone
+			return
+		}
 
 		// Set status line text (keys only)
-		status := " [yellow]Keys:[-] ESC=back, s=schema, ↑↓=scroll"
+		status := " [yellow]Keys:[-] ESC=back, s=schema, ↑↓=scroll, Enter=full value, e=encoding structure"
+		if isGeospatial {
+			status += ", w=WKT/GeoJSON"
+		}
 		if v := GetVersion(); v != "" {
 			status += fmt.Sprintf("  [gray]%s[-]", v)
 		}
@@ -546,7 +657,7 @@ func (app *TUIApp) createHeaderView() {
 	// Line 2: Basic file info from HTTP API
 	header.WriteString("\n")
 
-	fileInfo, err := app.httpClient.getFileInfo()
+	fileInfo, err := app.httpClient.getFileInfo(context.Background())
 	if err != nil {
 		app.headerView.SetText(fmt.Sprintf("[red]Error loading file info: %v[-]", err))
 		return
@@ -597,8 +708,25 @@ func (app *TUIApp) createRowGroupList() {
 		SetTitle(" Row Groups (↑↓ to navigate) ").
 		SetTitleAlign(tview.AlignLeft)
 
-	// Get row groups from HTTP client
-	rowGroups, err := app.httpClient.getAllRowGroupsInfo()
+	// Set header row (removed "Columns" as it's in the file header - all row groups have same columns)
+	headers := []string{"#", "Rows", "Size"}
+	headerCells := make([]*tview.TableCell, len(headers))
+	for colIdx, header := range headers {
+		headerCells[colIdx] = tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignCenter).
+			SetSelectable(false).
+			SetExpansion(0)
+	}
+
+	// Row groups are fetched from the HTTP client a window at a time, as
+	// they are scrolled into view
+	list, err := newWindowedList(app, headerCells,
+		func(ctx context.Context, offset, limit int) ([]model.RowGroupInfo, int, error) {
+			list, err := app.httpClient.getRowGroupList(ctx, offset, limit)
+			return list.RowGroups, list.Total, err
+		},
+		rowGroupCells)
 	if err != nil {
 		// Show error in table
 		cell := tview.NewTableCell(fmt.Sprintf("[red]Error loading row groups: %v[-]", err)).
@@ -608,43 +736,30 @@ func (app *TUIApp) createRowGroupList() {
 		app.rowGroupList.SetCell(1, 0, cell)
 		return
 	}
+	app.rowGroupList.SetContent(list)
 
-	// Set header row (removed "Columns" as it's in the file header - all row groups have same columns)
-	headers := []string{"#", "Rows", "Size"}
-	for colIdx, header := range headers {
-		cell := tview.NewTableCell(header).
-			SetTextColor(tcell.ColorYellow).
-			SetAlign(tview.AlignCenter).
-			SetSelectable(false).
-			SetExpansion(0)
-		app.rowGroupList.SetCell(0, colIdx, cell)
-	}
+	// Selection handler removed - use keyboard shortcuts instead (d=data, c=columns)
+}
 
-	// Populate rows
-	for rowIdx, rg := range rowGroups {
+// rowGroupCells returns the cells of the row of a row group
+func rowGroupCells(rg model.RowGroupInfo) []*tview.TableCell {
+	// Size - show compressed → uncompressed (ratio)
+	sizeStr := fmt.Sprintf("%s → %s",
+		model.FormatBytes(rg.CompressedSize),
+		model.FormatBytes(rg.UncompressedSize))
+	return []*tview.TableCell{
 		// Row group index
-		cell := tview.NewTableCell(fmt.Sprintf("%d", rg.Index)).
+		tview.NewTableCell(fmt.Sprintf("%d", rg.Index)).
 			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignRight)
-		app.rowGroupList.SetCell(rowIdx+1, 0, cell)
-
+			SetAlign(tview.AlignRight),
 		// Rows
-		cell = tview.NewTableCell(fmt.Sprintf("%d", rg.NumRows)).
+		tview.NewTableCell(fmt.Sprintf("%d", rg.NumRows)).
 			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignRight)
-		app.rowGroupList.SetCell(rowIdx+1, 1, cell)
-
-		// Size - show compressed → uncompressed (ratio)
-		sizeStr := fmt.Sprintf("%s → %s",
-			model.FormatBytes(rg.CompressedSize),
-			model.FormatBytes(rg.UncompressedSize))
-		cell = tview.NewTableCell(sizeStr).
+			SetAlign(tview.AlignRight),
+		tview.NewTableCell(sizeStr).
 			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignRight)
-		app.rowGroupList.SetCell(rowIdx+1, 2, cell)
+			SetAlign(tview.AlignRight),
 	}
-
-	// Selection handler removed - use keyboard shortcuts instead (d=data, c=columns)
 }
 
 func (app *TUIApp) createStatusLine() {
@@ -652,16 +767,59 @@ func (app *TUIApp) createStatusLine() {
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignLeft)
 
-	status := " [yellow]Keys:[-] ESC=quit, s=schema, ↑↓=scroll, Enter=see item details"
+	app.updateStatusLine()
+}
+
+// updateStatusLine shows the keys of the main view and, once loaded, the
+// reads of the file on the status line
+func (app *TUIApp) updateStatusLine() {
+	status := " [yellow]Keys:[-] ESC=quit, s=schema, l=file layout, c=columns, h=heatmap, r=find rows, S=sample rows, :row N=go to row, ↑↓=scroll, Enter=see item details"
 	if v := GetVersion(); v != "" {
 		status += fmt.Sprintf("  [gray]%s[-]", v)
 	}
+	if app.ioStatus != "" {
+		status += "\n " + app.ioStatus
+	}
 	app.statusLine.SetText(status)
 }
 
+// watchIO reloads the reads of the file on the status line of the main view
+// every ioRefreshInterval until ctx is done
+func (app *TUIApp) watchIO(ctx context.Context) {
+	ticker := time.NewTicker(ioRefreshInterval)
+	defer ticker.Stop()
+	for {
+		app.refreshIOStatus(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshIOStatus fetches the reads of the file and shows them on the status
+// line, which keeps the last ones when the fetch fails
+func (app *TUIApp) refreshIOStatus(ctx context.Context) {
+	stats, err := app.httpClient.getIOStats(ctx)
+	if err != nil {
+		return
+	}
+	status := "[yellow]File I/O:[-] " + tview.Escape(stats.Total.Summary())
+	if stats.Prefetch.MaxBlock > 0 {
+		status += fmt.Sprintf(", read-ahead %s", model.FormatBytes(int64(stats.Prefetch.MaxBlock)))
+	}
+	app.tviewApp.QueueUpdateDraw(func() {
+		app.ioStatus = status
+		if app.statusLine != nil {
+			app.updateStatusLine()
+		}
+	})
+}
+
 func (app *TUIApp) showColumnChunksView(rgIndex int) {
 	// Get row group info from HTTP client
-	rowGroup, err := app.httpClient.getRowGroupInfo(rgIndex)
+	rowGroup, err := app.httpClient.getRowGroupInfo(context.Background(), rgIndex)
 	if err != nil {
 		// Show error modal
 		errorModal := tview.NewModal().
@@ -675,8 +833,8 @@ func (app *TUIApp) showColumnChunksView(rgIndex int) {
 		return
 	}
 
-	// Get column chunks info to calculate totals
-	columns, err := app.httpClient.getAllColumnChunksInfo(rgIndex)
+	// The column chunk listing carries the totals of all the chunks
+	columns, err := app.httpClient.getColumnChunkList(context.Background(), rgIndex, 0, 1)
 	if err != nil {
 		// Show error modal
 		errorModal := tview.NewModal().
@@ -690,16 +848,6 @@ func (app *TUIApp) showColumnChunksView(rgIndex int) {
 		return
 	}
 
-	// Calculate total values and nulls
-	var totalValues int64
-	var totalNulls int64
-	for _, col := range columns {
-		totalValues += col.NumValues
-		if col.NullCount != nil {
-			totalNulls += *col.NullCount
-		}
-	}
-
 	// Create row group info header
 	headerView := tview.NewTextView().
 		SetDynamicColors(true).
@@ -715,8 +863,8 @@ func (app *TUIApp) showColumnChunksView(rgIndex int) {
 
 	// Line 2: Total Values and Total Nulls
 	info.WriteString("\n")
-	_, _ = fmt.Fprintf(&info, "[yellow]Total Values:[-] %d  ", totalValues)
-	_, _ = fmt.Fprintf(&info, "[yellow]Total Nulls:[-] %d", totalNulls)
+	_, _ = fmt.Fprintf(&info, "[yellow]Total Values:[-] %d  ", columns.NumValues)
+	_, _ = fmt.Fprintf(&info, "[yellow]Total Nulls:[-] %d", columns.NullCount)
 
 	// Line 3: Size info
 	info.WriteString("\n")
@@ -732,7 +880,7 @@ func (app *TUIApp) showColumnChunksView(rgIndex int) {
 	columnList := app.createColumnChunksList(rgIndex)
 
 	columnList.SetBorder(true).
-		SetTitle(" Column Chunks (↑↓ to navigate, Enter=view pages) ").
+		SetTitle(" Column Chunks (↑↓ to navigate, Enter=view pages, p=profile, d=dictionary) ").
 		SetTitleAlign(tview.AlignLeft)
 
 	// Create status line (keys only)
@@ -740,7 +888,7 @@ func (app *TUIApp) showColumnChunksView(rgIndex int) {
 		SetDynamicColors(true).
 		SetTextAlign(tview.AlignLeft)
 
-	status := " [yellow]Keys:[-] ESC=back, s=schema, ↑↓=scroll, Enter=view pages"
+	status := " [yellow]Keys:[-] ESC=back, s=schema, ↑↓=scroll, Enter=view pages, p=profile column, d=dictionary, g=geo map, m/M=full min/max"
 	if v := GetVersion(); v != "" {
 		status += fmt.Sprintf("  [gray]%s[-]", v)
 	}
@@ -763,9 +911,49 @@ func (app *TUIApp) showColumnChunksView(rgIndex int) {
 			app.pages.RemovePage("columnsview")
 			return nil
 		case tcell.KeyRune:
-			if event.Rune() == 's' {
+			switch event.Rune() {
+			case 's':
 				app.showSchema()
 				return nil
+			case 'p':
+				// Profile the selected column across all row groups
+				row, _ := columnList.GetSelection()
+				if row > 0 {
+					if path := columnList.GetCell(row, 1).Text; path != "" {
+						app.showColumnProfile(path)
+					}
+				}
+				return nil
+			case 'd':
+				// Analyze the selected column's dictionary across all row groups
+				row, _ := columnList.GetSelection()
+				if row > 0 {
+					if path := columnList.GetCell(row, 1).Text; path != "" {
+						app.showDictionaryAnalysis(path)
+					}
+				}
+				return nil
+			case 'g':
+				// Plot the selected geospatial column's bounding box per row group
+				row, _ := columnList.GetSelection()
+				if row > 0 {
+					if path := columnList.GetCell(row, 1).Text; path != "" {
+						app.showGeoMap(path)
+					}
+				}
+				return nil
+			case 'm', 'M':
+				// Show the min or max statistic of the selected column chunk in full
+				row, _ := columnList.GetSelection()
+				if colIndex := row - 1; colIndex >= 0 {
+					stat := statisticForKey(event.Rune())
+					app.showValueDetail(
+						fmt.Sprintf("Column Chunk %s - RG %d, Column %d", statisticLabel(stat), rgIndex, colIndex),
+						func(ctx context.Context) (model.ValueDetail, error) {
+							return app.httpClient.getColumnChunkStatistic(ctx, rgIndex, colIndex, stat)
+						})
+				}
+				return nil
 			}
 		}
 		return event
@@ -783,20 +971,9 @@ func (app *TUIApp) createColumnChunksList(rgIndex int) *tview.Table {
 
 	table.SetBorder(false)
 
-	// Get column chunks from HTTP client
-	columns, err := app.httpClient.getAllColumnChunksInfo(rgIndex)
-	if err != nil {
-		// Show error in table
-		cell := tview.NewTableCell(fmt.Sprintf("[red]Error loading columns: %v[-]", err)).
-			SetTextColor(tcell.ColorRed).
-			SetAlign(tview.AlignLeft).
-			SetExpansion(1)
-		table.SetCell(1, 0, cell)
-		return table
-	}
-
 	// Set header row
 	headers := []string{"#", "Name", "Type", "Codec", "Size", "Min", "Max"}
+	headerCells := make([]*tview.TableCell, len(headers))
 	for colIdx, header := range headers {
 		cell := tview.NewTableCell(header).
 			SetTextColor(tcell.ColorYellow).
@@ -806,77 +983,90 @@ func (app *TUIApp) createColumnChunksList(rgIndex int) *tview.Table {
 		if colIdx == 1 { // Name column - limit width
 			cell.SetMaxWidth(30)
 		}
-		table.SetCell(0, colIdx, cell)
+		headerCells[colIdx] = cell
 	}
 
-	// Populate rows
-	for rowIdx, col := range columns {
+	// Column chunks are fetched from the HTTP client a window at a time, as
+	// they are scrolled into view
+	list, err := newWindowedList(app, headerCells,
+		func(ctx context.Context, offset, limit int) ([]model.ColumnChunkInfo, int, error) {
+			list, err := app.httpClient.getColumnChunkList(ctx, rgIndex, offset, limit)
+			return list.ColumnChunks, list.Total, err
+		},
+		columnChunkCells)
+	if err != nil {
+		// Show error in table
+		cell := tview.NewTableCell(fmt.Sprintf("[red]Error loading columns: %v[-]", err)).
+			SetTextColor(tcell.ColorRed).
+			SetAlign(tview.AlignLeft).
+			SetExpansion(1)
+		table.SetCell(1, 0, cell)
+		return table
+	}
+	table.SetContent(list)
+
+	// Add selection handler
+	table.SetSelectedFunc(func(row, column int) {
+		if row == 0 {
+			return // Skip header
+		}
+		colIndex := row - 1
+		app.showPageView(rgIndex, colIndex)
+	})
+
+	return table
+}
+
+// columnChunkCells returns the cells of the row of a column chunk
+func columnChunkCells(col model.ColumnChunkInfo) []*tview.TableCell {
+	// Min value - base64 encoded
+	minStr := "-"
+	if len(col.MinValue) > 0 {
+		minStr = string(col.MinValue)
+		if len(minStr) > 20 {
+			minStr = minStr[:20] + "..."
+		}
+	}
+	// Max value - base64 encoded
+	maxStr := "-"
+	if len(col.MaxValue) > 0 {
+		maxStr = string(col.MaxValue)
+		if len(maxStr) > 20 {
+			maxStr = maxStr[:20] + "..."
+		}
+	}
+	// Geospatial columns show the bounding box corners instead
+	if geoMin, geoMax := geoBoundingBoxCorners(col.GeospatialStatistics); geoMin != "" {
+		minStr, maxStr = geoMin, geoMax
+	}
+
+	return []*tview.TableCell{
 		// Column index
-		cell := tview.NewTableCell(fmt.Sprintf("%d", col.Index)).
+		tview.NewTableCell(fmt.Sprintf("%d", col.Index)).
 			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignRight)
-		table.SetCell(rowIdx+1, 0, cell)
-
+			SetAlign(tview.AlignRight),
 		// Column name
-		cell = tview.NewTableCell(col.Name).
+		tview.NewTableCell(col.Name).
 			SetTextColor(tcell.ColorWhite).
 			SetAlign(tview.AlignLeft).
-			SetMaxWidth(30)
-		table.SetCell(rowIdx+1, 1, cell)
-
+			SetMaxWidth(30),
 		// Type
-		cell = tview.NewTableCell(col.PhysicalType).
+		tview.NewTableCell(col.PhysicalType).
 			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignLeft)
-		table.SetCell(rowIdx+1, 2, cell)
-
+			SetAlign(tview.AlignLeft),
 		// Codec
-		cell = tview.NewTableCell(col.Codec).
+		tview.NewTableCell(col.Codec).
 			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignLeft)
-		table.SetCell(rowIdx+1, 3, cell)
-
+			SetAlign(tview.AlignLeft),
 		// Size
-		cell = tview.NewTableCell(model.FormatBytes(col.CompressedSize)).
+		tview.NewTableCell(model.FormatBytes(col.CompressedSize)).
 			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignRight)
-		table.SetCell(rowIdx+1, 4, cell)
-
-		// Min value - base64 encoded
-		minStr := "-"
-		if len(col.MinValue) > 0 {
-			minStr = string(col.MinValue)
-			if len(minStr) > 20 {
-				minStr = minStr[:20] + "..."
-			}
-		}
-		cell = tview.NewTableCell(minStr).
+			SetAlign(tview.AlignRight),
+		tview.NewTableCell(minStr).
 			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignLeft)
-		table.SetCell(rowIdx+1, 5, cell)
-
-		// Max value - base64 encoded
-		maxStr := "-"
-		if len(col.MaxValue) > 0 {
-			maxStr = string(col.MaxValue)
-			if len(maxStr) > 20 {
-				maxStr = maxStr[:20] + "..."
-			}
-		}
-		cell = tview.NewTableCell(maxStr).
+			SetAlign(tview.AlignLeft),
+		tview.NewTableCell(maxStr).
 			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignLeft)
-		table.SetCell(rowIdx+1, 6, cell)
+			SetAlign(tview.AlignLeft),
 	}
-
-	// Add selection handler
-	table.SetSelectedFunc(func(row, column int) {
-		if row == 0 {
-			return // Skip header
-		}
-		colIndex := row - 1
-		app.showPageView(rgIndex, colIndex)
-	})
-
-	return table
 }
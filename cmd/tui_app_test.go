@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/hangxie/parquet-go/v3/parquet"
@@ -282,7 +284,7 @@ func Test_TUIApp_readPageHeaders(t *testing.T) {
 	app := NewTUIApp()
 	app.httpClient = newParquetClient(server.URL)
 
-	pages, err := app.readPageHeaders(0, 0)
+	pages, err := app.readPageHeaders(context.Background(), 0, 0)
 	require.NoError(t, err)
 	require.Len(t, pages, 1)
 	assert.Equal(t, 0, pages[0].Index)
@@ -301,7 +303,7 @@ func Test_TUIApp_readPageHeaders_Error(t *testing.T) {
 	app := NewTUIApp()
 	app.httpClient = newParquetClient(server.URL)
 
-	_, err := app.readPageHeaders(0, 0)
+	_, err := app.readPageHeaders(context.Background(), 0, 0)
 	require.Error(t, err)
 }
 
@@ -319,9 +321,11 @@ func Test_TUIApp_readPageContent(t *testing.T) {
 	app := NewTUIApp()
 	app.httpClient = newParquetClient(server.URL)
 
-	values, err := app.readPageContent(0, 0, 0)
+	part, err := app.readPageContent(context.Background(), 0, 0, 0, "", 0, 100)
 	require.NoError(t, err)
+	values := part.Values
 	require.Len(t, values, 4)
+	assert.Equal(t, 4, part.Total)
 	assert.Equal(t, "value1", values[0])
 	assert.Equal(t, "value2", values[1])
 	assert.Equal(t, "NULL", values[2])
@@ -382,16 +386,19 @@ func Test_TUIApp_createRowGroupList(t *testing.T) {
 		if strings.HasSuffix(r.URL.Path, "/rowgroups") {
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write([]byte(`[
-				{
-					"Index": 0,
-					"NumRows": 100,
-					"NumColumns": 5,
-					"CompressedSize": 1000,
-					"UncompressedSize": 2000,
-					"FileOffset": 0
-				}
-			]`))
+			_, _ = w.Write([]byte(`{
+				"RowGroups": [
+					{
+						"Index": 0,
+						"NumRows": 100,
+						"NumColumns": 5,
+						"CompressedSize": 1000,
+						"UncompressedSize": 2000,
+						"FileOffset": 0
+					}
+				],
+				"Total": 1
+			}`))
 		}
 	}))
 	defer server.Close()
@@ -402,8 +409,9 @@ func Test_TUIApp_createRowGroupList(t *testing.T) {
 
 	require.NotNil(t, app.rowGroupList)
 
-	// Verify table has been populated (at least header row)
-	assert.Greater(t, app.rowGroupList.GetRowCount(), 0)
+	assert.Equal(t, 2, app.rowGroupList.GetRowCount())
+	assert.Equal(t, "0", app.rowGroupList.GetCell(1, 0).Text)
+	assert.Equal(t, "100", app.rowGroupList.GetCell(1, 1).Text)
 }
 
 func Test_TUIApp_showSchema(t *testing.T) {
@@ -450,16 +458,19 @@ func Test_TUIApp_showMainView(t *testing.T) {
 				"CreatedBy": "test-writer"
 			}`))
 		case "/rowgroups":
-			_, _ = w.Write([]byte(`[
-				{
-					"Index": 0,
-					"NumRows": 1000,
-					"NumColumns": 2,
-					"CompressedSize": 512,
-					"UncompressedSize": 1024,
-					"CompressionRatio": 2.0
-				}
-			]`))
+			_, _ = w.Write([]byte(`{
+				"RowGroups": [
+					{
+						"Index": 0,
+						"NumRows": 1000,
+						"NumColumns": 2,
+						"CompressedSize": 512,
+						"UncompressedSize": 1024,
+						"CompressionRatio": 2.0
+					}
+				],
+				"Total": 1
+			}`))
 		default:
 			http.NotFound(w, r)
 		}
@@ -482,6 +493,63 @@ func Test_TUIApp_showMainView(t *testing.T) {
 	assert.Contains(t, app.statusLine.GetText(false), "Enter=see item details")
 }
 
+func Test_TUIApp_watchIO(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/debug/io" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"Total": {"Reads": 3, "Bytes": 2048, "Latency": 5000000, "Prefetched": 7},
+			"Prefetch": {"MinBlock": 65536, "MaxBlock": 1048576}
+		}`))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+	queueTUIUpdate(t, app, app.createStatusLine)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		app.watchIO(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	require.Eventually(t, func() bool {
+		var text string
+		queueTUIUpdate(t, app, func() { text = app.statusLine.GetText(true) })
+		return strings.Contains(text, "File I/O: 3 reads, 2.0 KB in 5ms, 7 prefetched, read-ahead 1.0 MB")
+	}, 2*time.Second, 10*time.Millisecond)
+	queueTUIUpdate(t, app, func() {
+		assert.Contains(t, app.statusLine.GetText(true), "Enter=see item details")
+	})
+}
+
+func Test_TUIApp_refreshIOStatus_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "broken", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	app.createStatusLine()
+
+	app.refreshIOStatus(context.Background())
+
+	assert.Empty(t, app.ioStatus)
+	assert.NotContains(t, app.statusLine.GetText(true), "File reads")
+}
+
 func Test_TUIApp_createHeaderView_Error(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "broken", http.StatusInternalServerError)
@@ -521,17 +589,20 @@ func Test_TUIApp_createColumnChunksList(t *testing.T) {
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`[
-			{
-				"Index": 0,
-				"Name": "user.email",
-				"PhysicalType": "BYTE_ARRAY",
-				"Codec": "SNAPPY",
-				"CompressedSize": 2048,
-				"MinValue": "abcdefghijklmnopqrstuvwxyz",
-				"MaxValue": "zyxwvutsrqponmlkjihgfedcba"
-			}
-		]`))
+		_, _ = w.Write([]byte(`{
+			"ColumnChunks": [
+				{
+					"Index": 0,
+					"Name": "user.email",
+					"PhysicalType": "BYTE_ARRAY",
+					"Codec": "SNAPPY",
+					"CompressedSize": 2048,
+					"MinValue": "abcdefghijklmnopqrstuvwxyz",
+					"MaxValue": "zyxwvutsrqponmlkjihgfedcba"
+				}
+			],
+			"Total": 1
+		}`))
 	}))
 	defer server.Close()
 
@@ -583,17 +654,22 @@ func Test_TUIApp_showColumnChunksView(t *testing.T) {
 				"CompressionRatio": 2.0
 			}`))
 		case "/rowgroups/0/columnchunks":
-			_, _ = w.Write([]byte(`[
-				{
-					"Index": 0,
-					"Name": "id",
-					"PhysicalType": "INT32",
-					"Codec": "SNAPPY",
-					"NumValues": 1000,
-					"NullCount": 1,
-					"CompressedSize": 1024
-				}
-			]`))
+			_, _ = w.Write([]byte(`{
+				"ColumnChunks": [
+					{
+						"Index": 0,
+						"Name": "id",
+						"PhysicalType": "INT32",
+						"Codec": "SNAPPY",
+						"NumValues": 1000,
+						"NullCount": 1,
+						"CompressedSize": 1024
+					}
+				],
+				"Total": 1,
+				"NumValues": 1000,
+				"NullCount": 1
+			}`))
 		default:
 			http.NotFound(w, r)
 		}
@@ -890,4 +966,9 @@ func Test_TUIApp_buildColumnChunkInfoViewFromHTTP(t *testing.T) {
 	assert.Contains(t, text, "INT32")
 	assert.Contains(t, text, "Pages:")
 	assert.Contains(t, text, "5")
+	assert.NotContains(t, text, "Variant:")
+
+	colInfo.Variant = &model.VariantMember{Path: "v", Role: model.VariantRoleValue, Shredded: true}
+	text = app.buildColumnChunkInfoViewFromHTTP(colInfo, 5).GetText(false)
+	assert.Contains(t, text, "[yellow]Variant:[-] v  [yellow]Role:[-] value  (shredded)")
 }
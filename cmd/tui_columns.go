@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// showLeafColumns lists the leaf columns of the schema. Selecting one shows
+// its chunk in every row group side by side.
+func (app *TUIApp) showLeafColumns() {
+	columns, err := app.httpClient.getLeafColumns(context.Background())
+	if err != nil {
+		errorModal := tview.NewModal().
+			SetText(fmt.Sprintf("Error loading columns:\n%v\n\nPress ESC to go back", err)).
+			SetTextColor(tcell.ColorRed).
+			AddButtons([]string{"OK"}).
+			SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+				app.pages.RemovePage("error")
+			})
+		app.pages.AddPage("error", errorModal, true, true)
+		return
+	}
+
+	columnList := tview.NewTable().
+		SetBorders(false).
+		SetSeparator(tview.Borders.Vertical).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	headers := []string{"#", "Path", "Physical Type", "Logical Type", "Converted Type"}
+	for colIdx, header := range headers {
+		cell := tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignCenter).
+			SetSelectable(false).
+			SetExpansion(1)
+		columnList.SetCell(0, colIdx, cell)
+	}
+
+	for i, col := range columns {
+		for colIdx, text := range []string{fmt.Sprintf("%d", col.Index), col.Path, col.PhysicalType, col.LogicalType, col.ConvertedType} {
+			cell := tview.NewTableCell(text).
+				SetTextColor(tcell.ColorWhite).
+				SetAlign(tview.AlignLeft).
+				SetExpansion(1)
+			columnList.SetCell(i+1, colIdx, cell)
+		}
+	}
+	columnList.Select(1, 0)
+
+	columnList.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Columns (%d) (↑↓ to navigate, Enter=across row groups) ", len(columns))).
+		SetTitleAlign(tview.AlignLeft)
+
+	statusLine := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+
+	status := " [yellow]Keys:[-] ESC=back, ↑↓=scroll, Enter=column across row groups, p=profile column, d=dictionary"
+	if v := GetVersion(); v != "" {
+		status += fmt.Sprintf("  [gray]%s[-]", v)
+	}
+	statusLine.SetText(status)
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(columnList, 0, 1, true).
+		AddItem(statusLine, 1, 0, false)
+
+	selectedPath := func() string {
+		row, _ := columnList.GetSelection()
+		if row > 0 {
+			return columnList.GetCell(row, 1).Text
+		}
+		return ""
+	}
+
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			app.pages.RemovePage("leafcolumns")
+			return nil
+		case tcell.KeyEnter:
+			if path := selectedPath(); path != "" {
+				app.showColumnOverview(path)
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'p':
+				if path := selectedPath(); path != "" {
+					app.showColumnProfile(path)
+				}
+				return nil
+			case 'd':
+				if path := selectedPath(); path != "" {
+					app.showDictionaryAnalysis(path)
+				}
+				return nil
+			}
+		}
+		return event
+	})
+
+	app.pages.AddPage("leafcolumns", flex, true, true)
+}
+
+// showColumnOverview shows the chunks of a column in every row group in a popup
+func (app *TUIApp) showColumnOverview(path string) {
+	loadingModal := tview.NewModal().
+		SetText(fmt.Sprintf("Loading %s across row groups...\n\nPlease wait...\n\nPress ESC to cancel", path)).
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("column-overview-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("column-overview-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		overview, err := app.httpClient.getColumnOverview(ctx, path)
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage("column-overview-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error loading column:\n%v\n\nPress ESC to go back", err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage("column-overview-error")
+					})
+				app.pages.AddPage("column-overview-error", errorModal, true, true)
+				return
+			}
+
+			overviewView := tview.NewTextView().
+				SetDynamicColors(true).
+				SetScrollable(true).
+				SetWrap(false).
+				SetText(buildColumnOverviewText(overview))
+
+			overviewView.SetBorder(true).
+				SetTitle(fmt.Sprintf(" Column - %s (↑↓ to scroll, ESC to close) ", overview.Path)).
+				SetTitleAlign(tview.AlignLeft)
+
+			overviewView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+				if event.Key() == tcell.KeyEscape {
+					app.pages.RemovePage("column-overview")
+					return nil
+				}
+				return event
+			})
+
+			app.pages.AddPage("column-overview", overviewView, true, true)
+			app.tviewApp.SetFocus(overviewView)
+		})
+	}()
+}
+
+// buildColumnOverviewText renders a column's chunks across all row groups as
+// tview-colored text, file-wide totals first
+func buildColumnOverviewText(overview model.ColumnOverview) string {
+	var text strings.Builder
+
+	_, _ = fmt.Fprintf(&text, "[yellow]Column:[-] %d %s  [yellow]Type:[-] %s / %s / %s\n",
+		overview.Index, overview.Path, overview.PhysicalType, overview.LogicalType, overview.ConvertedType)
+	_, _ = fmt.Fprintf(&text, "[yellow]Row Groups:[-] %d  [yellow]Total Values:[-] %d  [yellow]Total Nulls:[-] %s\n",
+		len(overview.Chunks), overview.NumValues, formatNullCount(overview.NullCount))
+	_, _ = fmt.Fprintf(&text, "[yellow]Size:[-] %s → %s (%.2fx)  [yellow]Codecs:[-] %s  [yellow]Encodings:[-] %s\n",
+		model.FormatBytes(overview.TotalCompressedSize), model.FormatBytes(overview.TotalUncompressedSize),
+		overview.CompressionRatio, strings.Join(overview.Codecs, ", "), strings.Join(overview.Encodings, ", "))
+
+	_, _ = fmt.Fprintf(&text, "\n[yellow]%-5s %-12s %10s %8s %10s %10s %7s  %-20s %-20s %s[-]\n",
+		"RG", "Codec", "Values", "Nulls", "Size", "Raw Size", "Ratio", "Min", "Max", "Encodings")
+	for _, chunk := range overview.Chunks {
+		_, _ = fmt.Fprintf(&text, "%-5d %-12s %10d %8s %10s %10s %6.2fx  %-20s %-20s %s\n",
+			chunk.RowGroup, chunk.Codec, chunk.NumValues, formatNullCount(chunk.NullCount),
+			model.FormatBytes(chunk.CompressedSize), model.FormatBytes(chunk.UncompressedSize), chunk.CompressionRatio,
+			tview.Escape(truncateOverviewValue(chunk.MinValue)), tview.Escape(truncateOverviewValue(chunk.MaxValue)),
+			strings.Join(chunk.Encodings, ", "))
+	}
+
+	return text.String()
+}
+
+// formatNullCount renders an optional null count, "-" when it is unknown
+func formatNullCount(nullCount *int64) string {
+	if nullCount == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *nullCount)
+}
+
+// truncateOverviewValue fits a min/max value into its overview column
+func truncateOverviewValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	if runes := []rune(value); len(runes) > 20 {
+		return string(runes[:17]) + "..."
+	}
+	return value
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+func Test_TUIApp_showLeafColumns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/columns", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"Index":0,"Path":"id","PhysicalType":"INT32","LogicalType":"-","ConvertedType":"-"},` +
+			`{"Index":1,"Path":"name","PhysicalType":"BYTE_ARRAY","LogicalType":"STRING","ConvertedType":"UTF8"}]`))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showLeafColumns()
+	})
+
+	primitive := waitForTUIPage(t, app, "leafcolumns")
+	require.IsType(t, &tview.Flex{}, primitive)
+	var rows int
+	var path string
+	queueTUIUpdate(t, app, func() {
+		table := primitive.(*tview.Flex).GetItem(0).(*tview.Table)
+		rows = table.GetRowCount()
+		path = table.GetCell(2, 1).Text
+	})
+	assert.Equal(t, 3, rows)
+	assert.Equal(t, "name", path)
+}
+
+func Test_TUIApp_showLeafColumns_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "broken", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showLeafColumns()
+	})
+
+	primitive := waitForTUIPage(t, app, "error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_TUIApp_showColumnOverview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/columns/id", r.URL.Path)
+		_, _ = w.Write([]byte(`{"Index":0,"Path":"id","PhysicalType":"INT32","Chunks":[` +
+			`{"RowGroup":0,"Codec":"SNAPPY","NumValues":3,"MinValue":"1","MaxValue":"3","Encodings":["PLAIN"]}],` +
+			`"NumValues":3,"Codecs":["SNAPPY"],"Encodings":["PLAIN"]}`))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showColumnOverview("id")
+	})
+
+	primitive := waitForTUIPage(t, app, "column-overview")
+	require.IsType(t, &tview.TextView{}, primitive)
+	var text string
+	var hasLoading bool
+	queueTUIUpdate(t, app, func() {
+		text = primitive.(*tview.TextView).GetText(true)
+		hasLoading = app.pages.HasPage("column-overview-loading")
+	})
+	assert.Contains(t, text, "Codecs: SNAPPY")
+	assert.False(t, hasLoading)
+}
+
+func Test_TUIApp_showColumnOverview_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "broken", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showColumnOverview("id")
+	})
+
+	primitive := waitForTUIPage(t, app, "column-overview-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_buildColumnOverviewText(t *testing.T) {
+	nulls := int64(1)
+	overview := model.ColumnOverview{
+		LeafColumn: model.LeafColumn{Index: 3, Path: "name", PhysicalType: "BYTE_ARRAY", LogicalType: "STRING", ConvertedType: "UTF8"},
+		Chunks: []model.ColumnChunkInfo{
+			{RowGroup: 0, Codec: "ZSTD", NumValues: 4, NullCount: &nulls, MinValue: "a", MaxValue: "abcdefghijklmnopqrstuvwxyz", Encodings: []string{"PLAIN", "RLE"}},
+			{RowGroup: 1, Codec: "ZSTD", NumValues: 2},
+		},
+		NumValues: 6,
+		Codecs:    []string{"ZSTD"},
+		Encodings: []string{"PLAIN", "RLE"},
+	}
+
+	text := buildColumnOverviewText(overview)
+	for _, s := range []string{
+		"Column:[-] 3 name", "Total Values:[-] 6", "Total Nulls:[-] -", "abcdefghijklmnopq...", "PLAIN, RLE",
+	} {
+		assert.Contains(t, text, s)
+	}
+}
+
+func Test_formatNullCount(t *testing.T) {
+	n := int64(7)
+	assert.Equal(t, "-", formatNullCount(nil))
+	assert.Equal(t, "7", formatNullCount(&n))
+}
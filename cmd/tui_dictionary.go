@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// maxDictionaryValuesShown caps the dictionary values chart in the TUI
+const maxDictionaryValuesShown = 50
+
+// showDictionaryAnalysis analyzes the dictionary of a column across all row
+// groups and shows the result in a popup
+func (app *TUIApp) showDictionaryAnalysis(path string) {
+	loadingModal := tview.NewModal().
+		SetText(fmt.Sprintf("Analyzing dictionary of %s...\n\nPlease wait...\n\nPress ESC to cancel", path)).
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("dictionary-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("dictionary-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		analysis, err := app.httpClient.getColumnDictionary(ctx, path)
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage("dictionary-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error analyzing dictionary:\n%v\n\nPress ESC to go back", err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage("dictionary-error")
+					})
+				app.pages.AddPage("dictionary-error", errorModal, true, true)
+				return
+			}
+
+			dictionaryView := tview.NewTextView().
+				SetDynamicColors(true).
+				SetScrollable(true).
+				SetWrap(false).
+				SetText(buildDictionaryText(analysis))
+
+			dictionaryView.SetBorder(true).
+				SetTitle(fmt.Sprintf(" Dictionary - %s (↑↓ to scroll, ESC to close) ", analysis.Path)).
+				SetTitleAlign(tview.AlignLeft)
+
+			dictionaryView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+				if event.Key() == tcell.KeyEscape {
+					app.pages.RemovePage("dictionary")
+					return nil
+				}
+				return event
+			})
+
+			app.pages.AddPage("dictionary", dictionaryView, true, true)
+			app.tviewApp.SetFocus(dictionaryView)
+		})
+	}()
+}
+
+// buildDictionaryText renders a dictionary analysis as tview-colored text
+func buildDictionaryText(analysis model.DictionaryAnalysis) string {
+	var text strings.Builder
+
+	_, _ = fmt.Fprintf(&text, "[yellow]Column:[-] %s  [yellow]Type:[-] %s\n", analysis.Path, analysis.PhysicalType)
+	_, _ = fmt.Fprintf(&text, "[yellow]Dictionary-Encoded Pages:[-] %d of %d (%.0f%%)  [yellow]Fallback Pages:[-] %d\n",
+		analysis.DictionaryEncodedPages, analysis.DataPages, analysis.DictionaryEncodedRatio*100, analysis.FallbackPages)
+	_, _ = fmt.Fprintf(&text, "[yellow]Dictionary Size:[-] %s → %s  [yellow]Entries:[-] %d  [yellow]Distinct Values:[-] %d\n",
+		model.FormatBytes(analysis.TotalCompressedSize), model.FormatBytes(analysis.TotalUncompressedSize),
+		analysis.TotalEntries, analysis.DistinctValues)
+
+	text.WriteString("\n[yellow]Row Groups[-]\n")
+	_, _ = fmt.Fprintf(&text, "  %-5s %8s %8s %10s %6s  %-*s  %s\n",
+		"RG", "Entries", "Unused", "Size", "Pages", profileBarWidth/2, "Dictionary-encoded", "Fallback")
+	for _, rg := range analysis.RowGroups {
+		entries, unused, size := "-", "-", "-"
+		if rg.HasDictionary {
+			entries = fmt.Sprintf("%d", rg.NumEntries)
+			unused = fmt.Sprintf("%d", rg.UnusedEntries)
+			size = model.FormatBytes(int64(rg.CompressedSize))
+		}
+		bar := renderProfileBar(int64(rg.DictionaryEncodedPages), int64(rg.DataPages), profileBarWidth/2)
+		padding := strings.Repeat(" ", max(0, profileBarWidth/2-len([]rune(bar))))
+		fallback := fmt.Sprintf("%d", rg.FallbackPages)
+		if len(rg.FallbackEncodings) > 0 {
+			fallback += " (" + strings.Join(rg.FallbackEncodings, ", ") + ")"
+		}
+		_, _ = fmt.Fprintf(&text, "  %-5d %8s %8s %10s %6d  [green]%s[-]%s  %s\n",
+			rg.RowGroup, entries, unused, size, rg.DataPages, bar, padding, fallback)
+	}
+
+	if len(analysis.Values) > 0 {
+		shown := analysis.Values[:min(len(analysis.Values), maxDictionaryValuesShown)]
+		rows := make([]profileBarRow, len(shown))
+		for i, v := range shown {
+			rows[i] = profileBarRow{label: v.Value, count: v.References}
+		}
+		text.WriteString("\n[yellow]Dictionary Values[-] (references")
+		if len(shown) < analysis.DistinctValues {
+			_, _ = fmt.Fprintf(&text, ", top %d of %d", len(shown), analysis.DistinctValues)
+		}
+		text.WriteString(")\n")
+		writeProfileBars(&text, rows)
+	}
+
+	return text.String()
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+func Test_TUIApp_showDictionaryAnalysis(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/columns/id/dictionary", r.URL.Path)
+		_, _ = w.Write([]byte(`{"Path":"id","PhysicalType":"INT32","DataPages":1,"DictionaryEncodedPages":1,` +
+			`"RowGroups":[{"RowGroup":0,"HasDictionary":true,"NumEntries":1,"DataPages":1,"DictionaryEncodedPages":1}],` +
+			`"DistinctValues":1,"Values":[{"Value":"7","RowGroups":1,"References":3}]}`))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showDictionaryAnalysis("id")
+	})
+
+	primitive := waitForTUIPage(t, app, "dictionary")
+	require.IsType(t, &tview.TextView{}, primitive)
+	var text string
+	var hasLoading bool
+	queueTUIUpdate(t, app, func() {
+		text = primitive.(*tview.TextView).GetText(true)
+		hasLoading = app.pages.HasPage("dictionary-loading")
+	})
+	assert.Contains(t, text, "Dictionary Values")
+	assert.False(t, hasLoading)
+}
+
+func Test_TUIApp_showDictionaryAnalysis_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "broken", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showDictionaryAnalysis("id")
+	})
+
+	primitive := waitForTUIPage(t, app, "dictionary-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_buildDictionaryText(t *testing.T) {
+	values := make([]model.DictionaryValue, maxDictionaryValuesShown+5)
+	for i := range values {
+		values[i] = model.DictionaryValue{Value: "v", RowGroups: 1, References: int64(len(values) - i)}
+	}
+	analysis := model.DictionaryAnalysis{
+		Path:                   "name",
+		PhysicalType:           "BYTE_ARRAY",
+		DataPages:              4,
+		DictionaryEncodedPages: 3,
+		FallbackPages:          1,
+		DictionaryEncodedRatio: 0.75,
+		TotalEntries:           len(values),
+		DistinctValues:         len(values),
+		RowGroups: []model.RowGroupDictionary{
+			{RowGroup: 0, HasDictionary: true, NumEntries: len(values), UnusedEntries: 2, DataPages: 2, DictionaryEncodedPages: 2},
+			{RowGroup: 1, DataPages: 2, DictionaryEncodedPages: 1, FallbackPages: 1, FallbackEncodings: []string{"PLAIN"}},
+		},
+		Values: values,
+	}
+
+	text := buildDictionaryText(analysis)
+	for _, s := range []string{
+		"Column:[-] name", "3 of 4 (75%)", "Fallback Pages:[-] 1", "1 (PLAIN)",
+		"Dictionary Values[-] (references, top 50 of 55)",
+	} {
+		assert.Contains(t, text, s)
+	}
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// Size of the bounding box plot of the geo map popup, in cells
+const (
+	geoPlotWidth  = 72
+	geoPlotHeight = 24
+)
+
+// geoPlotColors are cycled through to tell row groups apart on the plot
+var geoPlotColors = []string{"blue", "orange", "green", "red", "purple", "yellow", "aqua", "fuchsia", "lime", "white"}
+
+// geoPlotCell is one character of the bounding box plot
+type geoPlotCell struct {
+	char  rune
+	color string
+}
+
+// showGeoMap plots the bounding box of a geospatial column per row group in a popup
+func (app *TUIApp) showGeoMap(path string) {
+	loadingModal := tview.NewModal().
+		SetText(fmt.Sprintf("Reading geospatial bounds...\n\nColumn: %s\n\nPress ESC to cancel", path)).
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("geo-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("geo-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		bounds, err := app.httpClient.getColumnGeo(ctx, path)
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage("geo-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error reading geospatial bounds:\n%v\n\nPress ESC to go back", err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage("geo-error")
+					})
+				app.pages.AddPage("geo-error", errorModal, true, true)
+				return
+			}
+
+			geoView := tview.NewTextView().
+				SetDynamicColors(true).
+				SetScrollable(true).
+				SetWrap(false).
+				SetText(buildGeoMapText(bounds))
+
+			geoView.SetBorder(true).
+				SetTitle(fmt.Sprintf(" Geo Map: %s (↑↓ to scroll, ESC to close) ", path)).
+				SetTitleAlign(tview.AlignLeft)
+
+			geoView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+				if event.Key() == tcell.KeyEscape {
+					app.pages.RemovePage("geo")
+					return nil
+				}
+				return event
+			})
+
+			app.pages.AddPage("geo", geoView, true, true)
+			app.tviewApp.SetFocus(geoView)
+		})
+	}()
+}
+
+// buildGeoMapText renders the geospatial bounds as a summary, the plot and
+// one line per row group
+func buildGeoMapText(bounds model.GeospatialBounds) string {
+	var text strings.Builder
+
+	_, _ = fmt.Fprintf(&text, "[yellow]Type:[-] %s  [yellow]CRS:[-] %s  [yellow]Row Groups:[-] %d\n",
+		bounds.LogicalType, tview.Escape(bounds.CRS), len(bounds.RowGroups))
+	_, _ = fmt.Fprintf(&text, "[yellow]Extent:[-] %s\n\n", formatGeoBoundingBox(bounds.BoundingBox))
+
+	if plot := renderGeoPlot(bounds, geoPlotWidth, geoPlotHeight); plot != "" {
+		text.WriteString(plot)
+		text.WriteString("\n")
+	} else {
+		text.WriteString("[gray]No row group holds coordinates[-]\n\n")
+	}
+
+	_, _ = fmt.Fprintf(&text, "[yellow]%-4s %-10s %-12s %-40s %s[-]\n", "RG", "Rows", "Source", "Bounding Box", "Geometry Types")
+	for _, rg := range bounds.RowGroups {
+		types := strings.Join(rg.GeometryTypes, ", ")
+		if types == "" {
+			types = "-"
+		}
+		_, _ = fmt.Fprintf(&text, "[%s]%-4s[-] %-10d %-12s %-40s %s\n",
+			geoPlotColors[rg.RowGroup%len(geoPlotColors)], geoPlotLabel(rg.RowGroup), rg.NumRows, rg.Source,
+			formatGeoBoundingBox(rg.BoundingBox), types)
+	}
+
+	return text.String()
+}
+
+// renderGeoPlot draws each row group bounding box as a rectangle outline on
+// a width x height grid, labelled at its top-left corner. Boxes too small for
+// an outline are drawn as their label only, like a scatter plot.
+func renderGeoPlot(bounds model.GeospatialBounds, width, height int) string {
+	extent := bounds.BoundingBox
+	if extent == nil || width < 2 || height < 2 {
+		return ""
+	}
+
+	// Pad degenerate extents, such as a single point, so they can be scaled
+	xmin, xmax, ymin, ymax := extent.Xmin, extent.Xmax, extent.Ymin, extent.Ymax
+	if xmax-xmin == 0 {
+		xmin, xmax = xmin-0.5, xmax+0.5
+	}
+	if ymax-ymin == 0 {
+		ymin, ymax = ymin-0.5, ymax+0.5
+	}
+	col := func(x float64) int {
+		return min(max(int(math.Round((x-xmin)/(xmax-xmin)*float64(width-1))), 0), width-1)
+	}
+	row := func(y float64) int {
+		return min(max(int(math.Round((ymax-y)/(ymax-ymin)*float64(height-1))), 0), height-1)
+	}
+
+	grid := make([][]geoPlotCell, height)
+	for r := range grid {
+		grid[r] = make([]geoPlotCell, width)
+	}
+
+	for _, rg := range bounds.RowGroups {
+		if rg.BoundingBox == nil {
+			continue
+		}
+		color := geoPlotColors[rg.RowGroup%len(geoPlotColors)]
+		c0, c1 := col(rg.BoundingBox.Xmin), col(rg.BoundingBox.Xmax)
+		r0, r1 := row(rg.BoundingBox.Ymax), row(rg.BoundingBox.Ymin)
+		if c1 > c0 && r1 > r0 {
+			for c := c0; c <= c1; c++ {
+				grid[r0][c] = geoPlotCell{'─', color}
+				grid[r1][c] = geoPlotCell{'─', color}
+			}
+			for r := r0; r <= r1; r++ {
+				grid[r][c0] = geoPlotCell{'│', color}
+				grid[r][c1] = geoPlotCell{'│', color}
+			}
+			grid[r0][c0] = geoPlotCell{'┌', color}
+			grid[r0][c1] = geoPlotCell{'┐', color}
+			grid[r1][c0] = geoPlotCell{'└', color}
+			grid[r1][c1] = geoPlotCell{'┘', color}
+			c0++ // label inside the corner
+		}
+		for i, ch := range geoPlotLabel(rg.RowGroup) {
+			if c0+i < width {
+				grid[r0][c0+i] = geoPlotCell{ch, color}
+			}
+		}
+	}
+
+	yMaxLabel, yMinLabel := formatGeoCoord(extent.Ymax), formatGeoCoord(extent.Ymin)
+	labelWidth := max(len(yMaxLabel), len(yMinLabel))
+
+	var plot strings.Builder
+	_, _ = fmt.Fprintf(&plot, "%*s ┌%s┐\n", labelWidth, "", strings.Repeat("─", width))
+	for r, cells := range grid {
+		label := ""
+		switch r {
+		case 0:
+			label = yMaxLabel
+		case height - 1:
+			label = yMinLabel
+		}
+		_, _ = fmt.Fprintf(&plot, "%*s │", labelWidth, label)
+		for _, cell := range cells {
+			if cell.char == 0 {
+				plot.WriteString(" ")
+				continue
+			}
+			_, _ = fmt.Fprintf(&plot, "[%s]%c[-]", cell.color, cell.char)
+		}
+		plot.WriteString("│\n")
+	}
+	_, _ = fmt.Fprintf(&plot, "%*s └%s┘\n", labelWidth, "", strings.Repeat("─", width))
+
+	xMinLabel, xMaxLabel := formatGeoCoord(extent.Xmin), formatGeoCoord(extent.Xmax)
+	padding := max(width-len(xMinLabel)-len(xMaxLabel), 1)
+	_, _ = fmt.Fprintf(&plot, "%*s  %s%s%s\n", labelWidth, "", xMinLabel, strings.Repeat(" ", padding), xMaxLabel)
+
+	return plot.String()
+}
+
+// geoPlotLabel is the label of a row group on the plot
+func geoPlotLabel(rowGroup int) string {
+	return strconv.Itoa(rowGroup)
+}
+
+// formatGeospatialStatistics renders geospatial statistics on one line, or
+// an empty string when there are none
+func formatGeospatialStatistics(stats *model.GeospatialStatistics) string {
+	if stats == nil {
+		return ""
+	}
+	text := fmt.Sprintf("[yellow]BBox:[-] %s", formatGeoBoundingBox(stats.BoundingBox))
+	if len(stats.GeometryTypes) > 0 {
+		text += fmt.Sprintf("  [yellow]Geometry Types:[-] %s", strings.Join(stats.GeometryTypes, ", "))
+	}
+	return text
+}
+
+// geoBoundingBoxCorners formats the lower-left and upper-right corners of
+// the bounding box, empty without one
+func geoBoundingBoxCorners(stats *model.GeospatialStatistics) (string, string) {
+	if stats == nil || stats.BoundingBox == nil {
+		return "", ""
+	}
+	bbox := stats.BoundingBox
+	return fmt.Sprintf("(%s, %s)", formatGeoCoord(bbox.Xmin), formatGeoCoord(bbox.Ymin)),
+		fmt.Sprintf("(%s, %s)", formatGeoCoord(bbox.Xmax), formatGeoCoord(bbox.Ymax))
+}
+
+// formatGeoBoundingBox formats a bounding box as coordinate ranges
+func formatGeoBoundingBox(bbox *model.BoundingBox) string {
+	if bbox == nil {
+		return "-"
+	}
+	text := fmt.Sprintf("X [%s, %s] Y [%s, %s]",
+		formatGeoCoord(bbox.Xmin), formatGeoCoord(bbox.Xmax), formatGeoCoord(bbox.Ymin), formatGeoCoord(bbox.Ymax))
+	if bbox.Zmin != nil && bbox.Zmax != nil {
+		text += fmt.Sprintf(" Z [%s, %s]", formatGeoCoord(*bbox.Zmin), formatGeoCoord(*bbox.Zmax))
+	}
+	if bbox.Mmin != nil && bbox.Mmax != nil {
+		text += fmt.Sprintf(" M [%s, %s]", formatGeoCoord(*bbox.Mmin), formatGeoCoord(*bbox.Mmax))
+	}
+	return text
+}
+
+// formatGeoCoord formats a coordinate with at most 6 decimals
+func formatGeoCoord(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1e6)/1e6, 'f', -1, 64)
+}
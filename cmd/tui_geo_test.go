@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+func Test_TUIApp_showGeoMap(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/columns/geom/geo", r.URL.Path)
+		_, _ = w.Write([]byte(`{"Path":"geom","LogicalType":"GEOMETRY","CRS":"OGC:CRS84",` +
+			`"RowGroups":[{"RowGroup":0,"NumRows":10,"BoundingBox":{"Xmin":0,"Xmax":1,"Ymin":0,"Ymax":1},` +
+			`"GeometryTypes":["Point"],"Source":"statistics"}],` +
+			`"BoundingBox":{"Xmin":0,"Xmax":1,"Ymin":0,"Ymax":1}}`))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showGeoMap("geom")
+	})
+
+	primitive := waitForTUIPage(t, app, "geo")
+	require.IsType(t, &tview.TextView{}, primitive)
+	var text string
+	var hasLoading bool
+	queueTUIUpdate(t, app, func() {
+		text = primitive.(*tview.TextView).GetText(true)
+		hasLoading = app.pages.HasPage("geo-loading")
+	})
+	assert.Contains(t, text, "CRS: OGC:CRS84")
+	assert.Contains(t, text, "X [0, 1] Y [0, 1]")
+	assert.False(t, hasLoading)
+}
+
+func Test_TUIApp_showGeoMap_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"not a GEOMETRY or GEOGRAPHY column"}`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showGeoMap("Int32")
+	})
+
+	primitive := waitForTUIPage(t, app, "geo-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_buildGeoMapText(t *testing.T) {
+	text := buildGeoMapText(model.GeospatialBounds{
+		LogicalType: "GEOGRAPHY",
+		CRS:         "OGC:CRS84",
+		RowGroups: []model.RowGroupBounds{
+			{RowGroup: 0, NumRows: 5, Source: model.BoundsFromValues},
+		},
+	})
+	assert.Contains(t, text, "Type:[-] GEOGRAPHY")
+	assert.Contains(t, text, "No row group holds coordinates")
+	assert.Contains(t, text, "values")
+}
+
+func Test_renderGeoPlot(t *testing.T) {
+	require.Empty(t, renderGeoPlot(model.GeospatialBounds{}, 10, 5))
+
+	plot := renderGeoPlot(model.GeospatialBounds{
+		BoundingBox: &model.BoundingBox{Xmin: 0, Xmax: 9, Ymin: 0, Ymax: 4},
+		RowGroups: []model.RowGroupBounds{
+			{RowGroup: 0, BoundingBox: &model.BoundingBox{Xmin: 0, Xmax: 3, Ymin: 2, Ymax: 4}},
+			{RowGroup: 1},
+			{RowGroup: 2, BoundingBox: &model.BoundingBox{Xmin: 9, Xmax: 9, Ymin: 0, Ymax: 0}},
+		},
+	}, 10, 5)
+
+	lines := strings.Split(stripTviewColors(plot), "\n")
+	require.Equal(t, []string{
+		"  ┌──────────┐",
+		"4 │┌0─┐      │",
+		"  ││  │      │",
+		"  │└──┘      │",
+		"  │          │",
+		"0 │         2│",
+		"  └──────────┘",
+		"   0        9",
+		"",
+	}, lines)
+
+	// A single point extent is padded rather than divided by zero
+	plot = renderGeoPlot(model.GeospatialBounds{
+		BoundingBox: &model.BoundingBox{Xmin: 1, Xmax: 1, Ymin: 1, Ymax: 1},
+		RowGroups:   []model.RowGroupBounds{{RowGroup: 0, BoundingBox: &model.BoundingBox{Xmin: 1, Xmax: 1, Ymin: 1, Ymax: 1}}},
+	}, 10, 5)
+	require.Contains(t, plot, "[blue]0[-]")
+}
+
+// stripTviewColors removes the [color] and [-] tags of plot output
+func stripTviewColors(text string) string {
+	for _, color := range append(geoPlotColors, "-") {
+		text = strings.ReplaceAll(text, "["+color+"]", "")
+	}
+	return text
+}
+
+func Test_formatGeospatialStatistics(t *testing.T) {
+	require.Empty(t, formatGeospatialStatistics(nil))
+	require.Equal(t, "[yellow]BBox:[-] -  [yellow]Geometry Types:[-] Point, Polygon",
+		formatGeospatialStatistics(&model.GeospatialStatistics{GeometryTypes: []string{"Point", "Polygon"}}))
+
+	zmin, zmax := 1.0, 2.5
+	require.Equal(t, "[yellow]BBox:[-] X [-1, 1] Y [0.123457, 2] Z [1, 2.5]",
+		formatGeospatialStatistics(&model.GeospatialStatistics{
+			BoundingBox: &model.BoundingBox{Xmin: -1, Xmax: 1, Ymin: 0.1234567, Ymax: 2, Zmin: &zmin, Zmax: &zmax},
+		}))
+}
+
+func Test_geoBoundingBoxCorners(t *testing.T) {
+	lower, upper := geoBoundingBoxCorners(nil)
+	require.Empty(t, lower)
+	require.Empty(t, upper)
+
+	lower, upper = geoBoundingBoxCorners(&model.GeospatialStatistics{
+		BoundingBox: &model.BoundingBox{Xmin: -3, Xmax: 16, Ymin: -8, Ymax: 11},
+	})
+	require.Equal(t, "(-3, -8)", lower)
+	require.Equal(t, "(16, 11)", upper)
+}
+
+func Test_pageContentBuilder_toggleGeoFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("geo") == "wkt" {
+			_, _ = w.Write([]byte(`{"values": ["POINT (1 2)"], "count": 1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"values": ["{\"type\":\"Point\"}"], "count": 1}`))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	builder := &pageContentBuilder{
+		app:            app,
+		table:          tview.NewTable(),
+		headerView:     tview.NewTextView().SetDynamicColors(true),
+		statusTextView: tview.NewTextView(),
+		pageInfo:       model.PageMetadata{PageType: "DATA_PAGE", CompressedSize: 1, UncompressedSize: 1},
+		ctx:            ctx,
+		cancel:         cancel,
+		isGeospatial:   true,
+		geoFormat:      model.GeoFormatGeoJSON,
+	}
+
+	var buildErr error
+	queueTUIUpdate(t, app, func() {
+		_, buildErr = builder.build()
+	})
+	require.NoError(t, buildErr)
+
+	var value, header string
+	queueTUIUpdate(t, app, func() {
+		value = builder.table.GetCell(1, 1).Text
+		header = builder.headerView.GetText(true)
+	})
+	require.Equal(t, `{"type":"Point"}`, value)
+	require.Contains(t, header, "Format: GeoJSON")
+
+	queueTUIUpdate(t, app, func() {
+		builder.table.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'w', tcell.ModNone), nil)
+	})
+	require.Eventually(t, func() bool {
+		queueTUIUpdate(t, app, func() {
+			value = builder.table.GetCell(1, 1).Text
+			header = builder.headerView.GetText(true)
+		})
+		return value == "POINT (1 2)"
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Contains(t, header, "Format: WKT")
+}
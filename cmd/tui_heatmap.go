@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// heatmapBlocks are the block characters of the heatmap levels, lowest first
+var heatmapBlocks = []string{"░", "▒", "▓", "█"}
+
+// heatmapColors are the colors of the heatmap levels, lowest first
+var heatmapColors = []tcell.Color{tcell.ColorLightSkyBlue, tcell.ColorSteelBlue, tcell.ColorOrange, tcell.ColorRed}
+
+// showHeatmap shows a metric of every column chunk as a row group × column
+// matrix of block characters. Enter opens the pages of the selected chunk.
+func (app *TUIApp) showHeatmap(metric model.HeatmapMetric) {
+	loadingModal := tview.NewModal().
+		SetText(fmt.Sprintf("Building %s heatmap...\n\nPlease wait...\n\nPress ESC to cancel", metric.Title())).
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("heatmap-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("heatmap-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		heatmap, err := app.httpClient.getHeatmap(ctx, metric)
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage("heatmap-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error building heatmap:\n%v\n\nPress ESC to go back", err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage("heatmap-error")
+					})
+				app.pages.AddPage("heatmap-error", errorModal, true, true)
+				return
+			}
+
+			app.pages.RemovePage("heatmap")
+			app.pages.AddPage("heatmap", app.buildHeatmapView(heatmap), true, true)
+		})
+	}()
+}
+
+// buildHeatmapView lays out the heatmap summary, the matrix, the details of
+// the selected chunk and a status line
+func (app *TUIApp) buildHeatmapView(heatmap model.Heatmap) *tview.Flex {
+	summary := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(buildHeatmapSummaryText(heatmap))
+
+	details := tview.NewTextView().
+		SetDynamicColors(true)
+
+	table := buildHeatmapTable(heatmap)
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Heatmap - %s (↑↓←→ to navigate, Enter=pages, m=next metric) ", heatmap.Metric.Title())).
+		SetTitleAlign(tview.AlignLeft)
+	table.SetSelectionChangedFunc(func(row, column int) {
+		details.SetText(heatmapCellDetails(heatmap, row-1, column-1))
+	})
+	if len(heatmap.Cells) > 0 && len(heatmap.Columns) > 0 {
+		table.Select(1, 1)
+		details.SetText(heatmapCellDetails(heatmap, 0, 0))
+	}
+
+	statusLine := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+
+	status := " [yellow]Keys:[-] ESC=back, ↑↓←→=move, Enter=column chunk pages, m=next metric"
+	if v := GetVersion(); v != "" {
+		status += fmt.Sprintf("  [gray]%s[-]", v)
+	}
+	statusLine.SetText(status)
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, 2, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(details, 1, 0, false).
+		AddItem(statusLine, 1, 0, false)
+
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			app.pages.RemovePage("heatmap")
+			return nil
+		case tcell.KeyEnter:
+			row, column := table.GetSelection()
+			if row > 0 && column > 0 {
+				app.showPageView(row-1, column-1)
+			}
+			return nil
+		case tcell.KeyRune:
+			if event.Rune() == 'm' {
+				app.showHeatmap(nextHeatmapMetric(heatmap.Metric))
+				return nil
+			}
+		}
+		return event
+	})
+
+	return flex
+}
+
+// buildHeatmapTable draws one block character per column chunk, row groups
+// down and columns across. The header marks every tenth column with its
+// tens digit.
+func buildHeatmapTable(heatmap model.Heatmap) *tview.Table {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSeparator(' ').
+		SetSelectable(true, true).
+		SetFixed(1, 1)
+
+	table.SetCell(0, 0, tview.NewTableCell("RG").
+		SetTextColor(tcell.ColorYellow).
+		SetSelectable(false))
+	for colIndex := range heatmap.Columns {
+		label := ""
+		if colIndex%10 == 0 {
+			label = fmt.Sprintf("%d", colIndex/10%10)
+		}
+		table.SetCell(0, colIndex+1, tview.NewTableCell(label).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+
+	for rgIndex, cells := range heatmap.Cells {
+		table.SetCell(rgIndex+1, 0, tview.NewTableCell(fmt.Sprintf("%d", rgIndex)).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignRight).
+			SetSelectable(false))
+		for colIndex, cell := range cells {
+			block, color := heatmapBlock(cell)
+			table.SetCell(rgIndex+1, colIndex+1, tview.NewTableCell(block).SetTextColor(color))
+		}
+	}
+
+	return table
+}
+
+// heatmapBlock is the block character and color of a heatmap cell, a gray
+// dot when the chunk does not record the metric
+func heatmapBlock(cell model.HeatmapCell) (string, tcell.Color) {
+	if !cell.Valid {
+		return "·", tcell.ColorGray
+	}
+	level := min(max(cell.Level, 0), len(heatmapBlocks)-1)
+	return heatmapBlocks[level], heatmapColors[level]
+}
+
+// buildHeatmapSummaryText renders the metric, its range and the legend
+func buildHeatmapSummaryText(heatmap model.Heatmap) string {
+	var text strings.Builder
+
+	_, _ = fmt.Fprintf(&text, "[yellow]Metric:[-] %s  [yellow]Row Groups:[-] %d  [yellow]Columns:[-] %d  [yellow]Chunks:[-] %d",
+		heatmap.Metric.Title(), len(heatmap.Cells), len(heatmap.Columns), heatmap.NumChunks)
+	if heatmap.NumValid > 0 {
+		_, _ = fmt.Fprintf(&text, "  [yellow]Range:[-] %s - %s", tview.Escape(heatmap.MinLabel), tview.Escape(heatmap.MaxLabel))
+		if heatmap.LogScale {
+			text.WriteString(" (log scale)")
+		}
+	}
+
+	text.WriteString("\n[yellow]Legend:[-] low ")
+	for level, block := range heatmapBlocks {
+		_, _ = fmt.Fprintf(&text, "[%s]%s[-]", heatmapColors[level].String(), block)
+	}
+	text.WriteString(" high  [gray]·[-] not recorded")
+	return text.String()
+}
+
+// heatmapCellDetails describes the chunk at rgIndex, colIndex of the heatmap
+func heatmapCellDetails(heatmap model.Heatmap, rgIndex, colIndex int) string {
+	if rgIndex < 0 || rgIndex >= len(heatmap.Cells) || colIndex < 0 || colIndex >= len(heatmap.Cells[rgIndex]) {
+		return ""
+	}
+	path := ""
+	if colIndex < len(heatmap.Columns) {
+		path = heatmap.Columns[colIndex]
+	}
+	return fmt.Sprintf(" [yellow]RG %d / Column %d %s:[-] %s", rgIndex, colIndex, tview.Escape(path),
+		tview.Escape(heatmap.Cells[rgIndex][colIndex].Label))
+}
+
+// nextHeatmapMetric is the metric after metric, wrapping around
+func nextHeatmapMetric(metric model.HeatmapMetric) model.HeatmapMetric {
+	i := slices.Index(model.HeatmapMetrics, metric)
+	return model.HeatmapMetrics[(i+1)%len(model.HeatmapMetrics)]
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// testHeatmap is a 2 row group × 12 column heatmap with one unrecorded chunk
+func testHeatmap() model.Heatmap {
+	heatmap := model.Heatmap{
+		Metric:    model.HeatmapNullFraction,
+		NumChunks: 24,
+		NumValid:  23,
+		MinLabel:  "0.0%",
+		MaxLabel:  "100.0%",
+	}
+	for i := 0; i < 12; i++ {
+		heatmap.Columns = append(heatmap.Columns, "col"+string(rune('a'+i)))
+	}
+	for rg := 0; rg < 2; rg++ {
+		row := make([]model.HeatmapCell, 12)
+		for i := range row {
+			row[i] = model.HeatmapCell{Valid: true, Level: i % model.HeatmapLevels, Label: "x"}
+		}
+		heatmap.Cells = append(heatmap.Cells, row)
+	}
+	heatmap.Cells[1][11] = model.HeatmapCell{Label: "-"}
+	return heatmap
+}
+
+func Test_TUIApp_showHeatmap(t *testing.T) {
+	var metrics []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/heatmap", r.URL.Path)
+		metrics = append(metrics, r.URL.Query().Get("metric"))
+		_, _ = w.Write([]byte(`{"Metric":"` + r.URL.Query().Get("metric") + `","Columns":["id","name"],` +
+			`"Cells":[[{"Value":1,"Valid":true,"Level":0,"Label":"1 B"},{"Value":9,"Valid":true,"Level":3,"Label":"9 B"}]],` +
+			`"NumValid":2,"NumChunks":2,"MinLabel":"1 B","MaxLabel":"9 B"}`))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showHeatmap(model.HeatmapCompressedSize)
+	})
+
+	primitive := waitForTUIPage(t, app, "heatmap")
+	require.IsType(t, &tview.Flex{}, primitive)
+	var details string
+	var hasLoading bool
+	queueTUIUpdate(t, app, func() {
+		details = primitive.(*tview.Flex).GetItem(2).(*tview.TextView).GetText(true)
+		hasLoading = app.pages.HasPage("heatmap-loading")
+	})
+	assert.Contains(t, details, "RG 0 / Column 0 id: 1 B")
+	assert.False(t, hasLoading)
+	assert.Equal(t, []string{"size"}, metrics)
+}
+
+func Test_TUIApp_showHeatmap_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "broken", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showHeatmap(model.HeatmapPageCount)
+	})
+
+	primitive := waitForTUIPage(t, app, "heatmap-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_buildHeatmapTable(t *testing.T) {
+	table := buildHeatmapTable(testHeatmap())
+
+	require.Equal(t, 3, table.GetRowCount())
+	require.Equal(t, 13, table.GetColumnCount())
+	assert.Equal(t, "0", table.GetCell(0, 1).Text)
+	assert.Equal(t, "", table.GetCell(0, 2).Text)
+	assert.Equal(t, "1", table.GetCell(0, 11).Text)
+	assert.Equal(t, "1", table.GetCell(2, 0).Text)
+	assert.Equal(t, heatmapBlocks[0], table.GetCell(1, 1).Text)
+	assert.Equal(t, heatmapBlocks[3], table.GetCell(1, 4).Text)
+	assert.Equal(t, "·", table.GetCell(2, 12).Text)
+}
+
+func Test_heatmapBlock(t *testing.T) {
+	block, color := heatmapBlock(model.HeatmapCell{})
+	assert.Equal(t, "·", block)
+	assert.Equal(t, tcell.ColorGray, color)
+
+	block, color = heatmapBlock(model.HeatmapCell{Valid: true, Level: 2})
+	assert.Equal(t, "▓", block)
+	assert.Equal(t, heatmapColors[2], color)
+
+	block, _ = heatmapBlock(model.HeatmapCell{Valid: true, Level: 99})
+	assert.Equal(t, "█", block)
+}
+
+func Test_buildHeatmapSummaryText(t *testing.T) {
+	heatmap := testHeatmap()
+	heatmap.LogScale = true
+
+	text := buildHeatmapSummaryText(heatmap)
+	for _, s := range []string{
+		"Metric:[-] Null Fraction", "Row Groups:[-] 2", "Columns:[-] 12", "Range:[-] 0.0% - 100.0% (log scale)", "not recorded",
+	} {
+		assert.Contains(t, text, s)
+	}
+
+	heatmap.NumValid = 0
+	assert.NotContains(t, buildHeatmapSummaryText(heatmap), "Range")
+}
+
+func Test_heatmapCellDetails(t *testing.T) {
+	heatmap := testHeatmap()
+	assert.Equal(t, " [yellow]RG 1 / Column 11 coll:[-] -", heatmapCellDetails(heatmap, 1, 11))
+	assert.Empty(t, heatmapCellDetails(heatmap, -1, 0))
+	assert.Empty(t, heatmapCellDetails(heatmap, 2, 0))
+	assert.Empty(t, heatmapCellDetails(heatmap, 0, 12))
+}
+
+func Test_nextHeatmapMetric(t *testing.T) {
+	assert.Equal(t, model.HeatmapCompressionRatio, nextHeatmapMetric(model.HeatmapCompressedSize))
+	assert.Equal(t, model.HeatmapCompressedSize, nextHeatmapMetric(model.HeatmapHasDictionary))
+}
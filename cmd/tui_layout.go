@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// layoutStripWidth is the number of cells in the whole-file overview strip
+const layoutStripWidth = 100
+
+// maxLayoutRegionsShown caps the region list of the file layout popup
+const maxLayoutRegionsShown = 5000
+
+// showFileLayout shows the byte-level layout of the file in a popup
+func (app *TUIApp) showFileLayout() {
+	loadingModal := tview.NewModal().
+		SetText("Mapping file layout...\n\nPlease wait...\n\nPress ESC to cancel").
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("layout-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("layout-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		layout, err := app.httpClient.getFileLayout(ctx)
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage("layout-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error reading file layout:\n%v\n\nPress ESC to go back", err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage("layout-error")
+					})
+				app.pages.AddPage("layout-error", errorModal, true, true)
+				return
+			}
+
+			layoutView := tview.NewTextView().
+				SetDynamicColors(true).
+				SetScrollable(true).
+				SetWrap(false).
+				SetText(buildFileLayoutText(layout))
+
+			layoutView.SetBorder(true).
+				SetTitle(" File Layout (↑↓ to scroll, ESC to close) ").
+				SetTitleAlign(tview.AlignLeft)
+
+			layoutView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+				if event.Key() == tcell.KeyEscape {
+					app.pages.RemovePage("layout")
+					return nil
+				}
+				return event
+			})
+
+			app.pages.AddPage("layout", layoutView, true, true)
+			app.tviewApp.SetFocus(layoutView)
+		})
+	}()
+}
+
+// buildFileLayoutText renders the file layout as an overview strip followed
+// by one line per region, in offset order
+func buildFileLayoutText(layout model.FileLayout) string {
+	var text strings.Builder
+
+	_, _ = fmt.Fprintf(&text, "[yellow]File Size:[-] %s (%d bytes)  [yellow]Regions:[-] %d  [yellow]Gaps:[-] %s  [yellow]Overlaps:[-] %s\n",
+		model.FormatBytes(layout.FileSize), layout.FileSize, len(layout.Regions),
+		model.FormatBytes(layout.GapBytes), model.FormatBytes(layout.OverlapBytes))
+
+	text.WriteString("\n")
+	text.WriteString(buildLayoutStrip(layout, layoutStripWidth))
+	text.WriteString("\n")
+	for _, legend := range [][2]string{
+		{model.LayoutFooter, "Magic/Footer"}, {model.LayoutPageHeader, "Page Header"}, {"DICTIONARY_PAGE", "Dictionary Page"},
+		{"DATA_PAGE", "Data Page"}, {model.LayoutColumnIndex, "Column/Offset Index"}, {model.LayoutBloomFilter, "Bloom Filter"}, {model.LayoutGap, "Gap"},
+	} {
+		_, _ = fmt.Fprintf(&text, "[%s]█[-] %s  ", layoutKindColor(legend[0]), legend[1])
+	}
+	text.WriteString("\n")
+
+	if len(layout.Issues) > 0 {
+		_, _ = fmt.Fprintf(&text, "\n[red]Issues (%d)[-]\n", len(layout.Issues))
+		for _, issue := range layout.Issues {
+			_, _ = fmt.Fprintf(&text, "  [red]%-13s[-] @%-10d %s\n", issue.Kind, issue.Offset, tview.Escape(issue.Message))
+		}
+	}
+
+	_, _ = fmt.Fprintf(&text, "\n[yellow]%-12s %-12s %10s  %-16s %s[-]\n", "Offset", "End", "Size", "Kind", "Location")
+	var cursor int64
+	for i, region := range layout.Regions {
+		if i == maxLayoutRegionsShown {
+			_, _ = fmt.Fprintf(&text, "[gray]... %d more[-]\n", len(layout.Regions)-maxLayoutRegionsShown)
+			break
+		}
+		end := region.Offset + region.Length
+		location := ""
+		switch {
+		case region.Page >= 0:
+			location = fmt.Sprintf("RG %d / %s / page %d", region.RowGroup, region.Path, region.Page)
+		case region.RowGroup >= 0:
+			location = fmt.Sprintf("RG %d / %s", region.RowGroup, region.Path)
+		}
+		if region.Offset < cursor {
+			location += "  [red]overlaps previous[-]"
+		}
+		_, _ = fmt.Fprintf(&text, "%-12d %-12d %10s  [%s]█[-] %-14s %s\n",
+			region.Offset, end, model.FormatBytes(region.Length), layoutKindColor(region.Kind), region.Kind, location)
+		cursor = max(cursor, end)
+	}
+
+	return text.String()
+}
+
+// buildLayoutStrip draws the whole file as width cells, each colored by the
+// kind covering most of its bytes. Any gap in a cell wins so that small
+// gaps stay visible.
+func buildLayoutStrip(layout model.FileLayout, width int) string {
+	if layout.FileSize <= 0 || width <= 0 {
+		return ""
+	}
+
+	cells := make([]map[string]int64, width)
+	for i := range cells {
+		cells[i] = map[string]int64{}
+	}
+	for _, region := range layout.Regions {
+		start, end := max(region.Offset, 0), min(region.Offset+region.Length, layout.FileSize)
+		for cell := int(start * int64(width) / layout.FileSize); cell < width && start < end; cell++ {
+			cellEnd := (int64(cell) + 1) * layout.FileSize / int64(width)
+			covered := min(end, cellEnd) - start
+			if covered > 0 {
+				cells[cell][region.Kind] += covered
+				start += covered
+			}
+		}
+	}
+
+	var strip strings.Builder
+	for _, kinds := range cells {
+		best, bestBytes := "", int64(0)
+		for kind, n := range kinds {
+			if kind == model.LayoutGap {
+				best = kind
+				break
+			}
+			if n > bestBytes || (n == bestBytes && kind < best) {
+				best, bestBytes = kind, n
+			}
+		}
+		if best == "" {
+			strip.WriteString(" ")
+			continue
+		}
+		_, _ = fmt.Fprintf(&strip, "[%s]█[-]", layoutKindColor(best))
+	}
+	return strip.String()
+}
+
+// layoutKindColor maps a layout region kind to its tview color
+func layoutKindColor(kind string) string {
+	switch kind {
+	case model.LayoutMagic, model.LayoutFooter, model.LayoutFooterLength:
+		return "white"
+	case model.LayoutPageHeader:
+		return "gray"
+	case "DICTIONARY_PAGE":
+		return "orange"
+	case "DATA_PAGE", "DATA_PAGE_V2", model.LayoutColumnChunk:
+		return "blue"
+	case model.LayoutColumnIndex, model.LayoutOffsetIndex:
+		return "green"
+	case model.LayoutBloomFilter:
+		return "purple"
+	case model.LayoutGap:
+		return "red"
+	default:
+		return "yellow"
+	}
+}
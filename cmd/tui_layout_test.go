@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+func Test_TUIApp_showFileLayout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/layout", r.URL.Path)
+		_, _ = w.Write([]byte(`{"FileSize":20,"Regions":[` +
+			`{"Kind":"MAGIC","Offset":0,"Length":4,"RowGroup":-1,"Column":-1,"Page":-1},` +
+			`{"Kind":"GAP","Offset":4,"Length":8,"RowGroup":-1,"Column":-1,"Page":-1},` +
+			`{"Kind":"FOOTER","Offset":12,"Length":4,"RowGroup":-1,"Column":-1,"Page":-1},` +
+			`{"Kind":"MAGIC","Offset":16,"Length":4,"RowGroup":-1,"Column":-1,"Page":-1}],` +
+			`"Issues":[{"Kind":"GAP","Offset":4,"Length":8,"Message":"8 unreferenced bytes before FOOTER"}]}`))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showFileLayout()
+	})
+
+	primitive := waitForTUIPage(t, app, "layout")
+	require.IsType(t, &tview.TextView{}, primitive)
+	var text string
+	var hasLoading bool
+	queueTUIUpdate(t, app, func() {
+		text = primitive.(*tview.TextView).GetText(true)
+		hasLoading = app.pages.HasPage("layout-loading")
+	})
+	assert.Contains(t, text, "8 unreferenced bytes before FOOTER")
+	assert.False(t, hasLoading)
+}
+
+func Test_TUIApp_showFileLayout_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "broken", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showFileLayout()
+	})
+
+	primitive := waitForTUIPage(t, app, "layout-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_buildFileLayoutText(t *testing.T) {
+	layout := model.FileLayout{
+		FileSize: 100,
+		Regions: []model.LayoutRegion{
+			{Kind: model.LayoutMagic, Offset: 0, Length: 4, RowGroup: -1, Column: -1, Page: -1},
+			{Kind: model.LayoutPageHeader, Offset: 4, Length: 10, RowGroup: 0, Column: 1, Page: 0, Path: "a.b"},
+			{Kind: "DATA_PAGE", Offset: 14, Length: 60, RowGroup: 0, Column: 1, Page: 0, Path: "a.b"},
+			{Kind: model.LayoutColumnIndex, Offset: 70, Length: 10, RowGroup: 0, Column: 1, Page: -1, Path: "a.b"},
+			{Kind: model.LayoutFooter, Offset: 80, Length: 12, RowGroup: -1, Column: -1, Page: -1},
+		},
+		Issues: []model.LayoutIssue{{Kind: model.LayoutIssueOverlap, Offset: 70, Length: 4, Message: "index overlaps page"}},
+	}
+
+	text := buildFileLayoutText(layout)
+	for _, s := range []string{
+		"Regions:[-] 5", "Issues (1)", "index overlaps page", "RG 0 / a.b / page 0", "RG 0 / a.b  [red]overlaps previous",
+	} {
+		assert.Contains(t, text, s)
+	}
+}
+
+func Test_buildLayoutStrip(t *testing.T) {
+	layout := model.FileLayout{
+		FileSize: 40,
+		Regions: []model.LayoutRegion{
+			{Kind: model.LayoutMagic, Offset: 0, Length: 10},
+			{Kind: model.LayoutGap, Offset: 10, Length: 1},
+			{Kind: "DATA_PAGE", Offset: 11, Length: 29},
+		},
+	}
+
+	strip := buildLayoutStrip(layout, 4)
+	require.Equal(t, "[white]█[-][red]█[-][blue]█[-][blue]█[-]", strip)
+	require.Empty(t, buildLayoutStrip(model.FileLayout{}, 4))
+}
+
+func Test_layoutKindColor(t *testing.T) {
+	require.Equal(t, "orange", layoutKindColor("DICTIONARY_PAGE"))
+	require.Equal(t, "green", layoutKindColor(model.LayoutOffsetIndex))
+	require.Equal(t, "purple", layoutKindColor(model.LayoutBloomFilter))
+	require.Equal(t, "yellow", layoutKindColor("INDEX_PAGE"))
+}
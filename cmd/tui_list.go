@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// listWindowSize is the number of row groups or column chunks fetched at once
+const listWindowSize = 100
+
+// listCachedWindows caps the windows a list keeps in memory, the ones
+// farthest from the last fetched are dropped first
+const listCachedWindows = 10
+
+// windowedList is the content of a table listing more items than can be
+// fetched at once, such as the row groups of a file with a huge footer. Rows
+// are fetched a window at a time as the table draws them, with a placeholder
+// until their window arrives. It is only used from the UI goroutine.
+type windowedList[T any] struct {
+	tview.TableContentReadOnly
+	app     *TUIApp
+	headers []*tview.TableCell
+	total   int
+	fetch   func(ctx context.Context, offset, limit int) ([]T, int, error)
+	cells   func(item T) []*tview.TableCell
+	windows map[int][]T
+	loading map[int]bool
+	failed  map[int]error
+}
+
+// newWindowedList fetches the first window of a list, which also tells how
+// many items there are, and returns the content of a table showing them.
+// fetch returns the items from offset and the number of items, cells the
+// cells of the row of an item.
+func newWindowedList[T any](app *TUIApp, headers []*tview.TableCell,
+	fetch func(ctx context.Context, offset, limit int) ([]T, int, error),
+	cells func(item T) []*tview.TableCell,
+) (*windowedList[T], error) {
+	items, total, err := fetch(context.Background(), 0, listWindowSize)
+	if err != nil {
+		return nil, err
+	}
+	return &windowedList[T]{
+		app:     app,
+		headers: headers,
+		total:   total,
+		fetch:   fetch,
+		cells:   cells,
+		windows: map[int][]T{0: items},
+		loading: map[int]bool{},
+		failed:  map[int]error{},
+	}, nil
+}
+
+// GetRowCount returns the header row and one row per item
+func (l *windowedList[T]) GetRowCount() int {
+	return l.total + 1
+}
+
+// GetColumnCount returns the number of columns
+func (l *windowedList[T]) GetColumnCount() int {
+	return len(l.headers)
+}
+
+// GetCell returns the cell of an item, starting to fetch its window when it
+// is not there yet
+func (l *windowedList[T]) GetCell(row, column int) *tview.TableCell {
+	if column < 0 || column >= len(l.headers) || row < 0 || row > l.total {
+		return nil
+	}
+	if row == 0 {
+		return l.headers[column]
+	}
+
+	index := row - 1
+	window := index / listWindowSize
+	items, ok := l.windows[window]
+	if !ok || index-window*listWindowSize >= len(items) {
+		if !ok {
+			l.load(window)
+		}
+		return l.placeholder(window, column)
+	}
+	return l.cells(items[index-window*listWindowSize])[column]
+}
+
+// placeholder returns the cell shown while the window of a row is fetched,
+// or the error fetching it
+func (l *windowedList[T]) placeholder(window, column int) *tview.TableCell {
+	err := l.failed[window]
+	switch {
+	case column == 0 && err != nil:
+		return tview.NewTableCell("!").SetTextColor(tcell.ColorRed).SetAlign(tview.AlignRight)
+	case column == 0:
+		return tview.NewTableCell("…").SetTextColor(tcell.ColorGray).SetAlign(tview.AlignRight)
+	case column == len(l.headers)-1 && err != nil:
+		return tview.NewTableCell(fmt.Sprintf("Error loading: %v", err)).SetTextColor(tcell.ColorRed)
+	}
+	return tview.NewTableCell("")
+}
+
+// load fetches a window in the background unless it is already being
+// fetched or failed, and redraws the table when it arrives
+func (l *windowedList[T]) load(window int) {
+	if l.loading[window] || l.failed[window] != nil {
+		return
+	}
+	l.loading[window] = true
+
+	go func() {
+		items, _, err := l.fetch(context.Background(), window*listWindowSize, listWindowSize)
+		l.app.tviewApp.QueueUpdateDraw(func() {
+			delete(l.loading, window)
+			if err != nil {
+				l.failed[window] = err
+				return
+			}
+			l.windows[window] = items
+			l.evict(window)
+		})
+	}()
+}
+
+// evict drops the windows farthest from the last fetched one while there are
+// more than listCachedWindows
+func (l *windowedList[T]) evict(last int) {
+	for len(l.windows) > listCachedWindows {
+		farthest := last
+		for window := range l.windows {
+			if abs(window-last) > abs(farthest-last) {
+				farthest = window
+			}
+		}
+		delete(l.windows, farthest)
+	}
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
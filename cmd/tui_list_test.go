@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWindowedList(t *testing.T, app *TUIApp, total int, failAt int) (*windowedList[int], func() []int) {
+	t.Helper()
+
+	var (
+		mu      sync.Mutex
+		offsets []int
+	)
+	fetch := func(ctx context.Context, offset, limit int) ([]int, int, error) {
+		mu.Lock()
+		offsets = append(offsets, offset)
+		mu.Unlock()
+		if offset == failAt {
+			return nil, 0, errors.New("broken")
+		}
+		var items []int
+		for i := offset; i < min(total, offset+limit); i++ {
+			items = append(items, i)
+		}
+		return items, total, nil
+	}
+	cells := func(item int) []*tview.TableCell {
+		return []*tview.TableCell{
+			tview.NewTableCell(strconv.Itoa(item)),
+			tview.NewTableCell("item " + strconv.Itoa(item)),
+		}
+	}
+	headers := []*tview.TableCell{tview.NewTableCell("#"), tview.NewTableCell("Name")}
+
+	list, err := newWindowedList(app, headers, fetch, cells)
+	require.NoError(t, err)
+	return list, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), offsets...)
+	}
+}
+
+func Test_windowedList_FirstWindow(t *testing.T) {
+	list, fetched := newTestWindowedList(t, NewTUIApp(), 250, -1)
+
+	assert.Equal(t, 251, list.GetRowCount())
+	assert.Equal(t, 2, list.GetColumnCount())
+	assert.Equal(t, "#", list.GetCell(0, 0).Text)
+	assert.Equal(t, "0", list.GetCell(1, 0).Text)
+	assert.Equal(t, "item 99", list.GetCell(100, 1).Text)
+	assert.Nil(t, list.GetCell(252, 0))
+	assert.Nil(t, list.GetCell(1, 2))
+	assert.Equal(t, []int{0}, fetched())
+}
+
+func Test_windowedList_LoadsWindows(t *testing.T) {
+	app := NewTUIApp()
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	list, fetched := newTestWindowedList(t, app, 2500, 3*listWindowSize)
+
+	// Rows of a window not fetched yet show a placeholder until it arrives
+	queueTUIUpdate(t, app, func() {
+		assert.Equal(t, "…", list.GetCell(1001, 0).Text)
+		assert.Equal(t, "…", list.GetCell(1002, 0).Text)
+		assert.Equal(t, "…", list.GetCell(301, 0).Text)
+	})
+	require.Eventually(t, func() bool {
+		var text string
+		queueTUIUpdate(t, app, func() { text = list.GetCell(1001, 1).Text })
+		return text == "item 1000"
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// A failed window shows the error and is not fetched again
+	require.Eventually(t, func() bool {
+		var text string
+		queueTUIUpdate(t, app, func() { text = list.GetCell(301, 0).Text })
+		return text == "!"
+	}, 2*time.Second, 10*time.Millisecond)
+	queueTUIUpdate(t, app, func() {
+		assert.Contains(t, list.GetCell(301, 1).Text, "broken")
+	})
+	assert.ElementsMatch(t, []int{0, 1000, 300}, fetched())
+}
+
+func Test_windowedList_evict(t *testing.T) {
+	list, _ := newTestWindowedList(t, NewTUIApp(), 2500, -1)
+	for window := range listCachedWindows + 2 {
+		list.windows[window] = []int{window}
+	}
+
+	list.evict(3)
+
+	require.Len(t, list.windows, listCachedWindows)
+	assert.NotContains(t, list.windows, 11)
+	assert.NotContains(t, list.windows, 10)
+	assert.Contains(t, list.windows, 0)
+}
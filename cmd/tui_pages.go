@@ -30,14 +30,18 @@ type pageContentBuilder struct {
 	allPages       []model.PageMetadata
 	meta           *parquet.ColumnMetaData
 	table          *tview.Table
-	allValues      []string // Pre-formatted values from API/model layer
-	loadedValues   int
+	allValues      []string // Pre-formatted values loaded so far from API/model layer
+	totalValues    int      // values in the page
 	isLoading      bool
 	batchSize      int
 	statusTextView *tview.TextView
 	headerView     *tview.TextView
 	ctx            context.Context
 	cancel         context.CancelFunc
+	isGeospatial   bool            // GEOMETRY or GEOGRAPHY column
+	geoFormat      model.GeoFormat // how geospatial values are shown
+	highlightStart int             // first highlighted value, negative for none
+	highlightCount int
 }
 
 // pageTableBuilder handles building page tables with lazy loading
@@ -53,6 +57,7 @@ type pageTableBuilder struct {
 	batchSize      int
 	statusTextView *tview.TextView
 	loadError      error
+	ctx            context.Context // cancelled when the page view is closed
 }
 
 // readPageHeadersBatch reads page headers in batches for lazy loading
@@ -64,7 +69,7 @@ func (b *pageTableBuilder) readPageHeadersBatch() error {
 	// If we haven't started reading yet, read all headers at once
 	// (page headers are lightweight, we just lazy-load the table display)
 	if len(b.pages) == 0 {
-		pages, err := b.app.readPageHeaders(b.rgIndex, b.colIndex)
+		pages, err := b.app.readPageHeaders(b.ctx, b.rgIndex, b.colIndex)
 		if err != nil {
 			b.loadError = err
 			return err
@@ -161,12 +166,18 @@ func (b *pageTableBuilder) build() *tview.Table {
 			SetTextColor(tcell.ColorWhite).
 			SetAlign(tview.AlignLeft)
 		b.table.SetCell(tableRowIdx, 8, cell)
+
+		// Level histograms
+		cell = tview.NewTableCell(formatSizeStatisticsCell(page.SizeStatistics)).
+			SetTextColor(tcell.ColorGreen).
+			SetAlign(tview.AlignLeft)
+		b.table.SetCell(tableRowIdx, 9, cell)
 	}
 
 	b.loadedPages = totalPages
 
 	// Set simple title
-	b.table.SetTitle(" Pages (↑↓ to navigate, Enter=view values) ")
+	b.table.SetTitle(" Pages (↑↓ to navigate, Enter=view values, e=encoding structure) ")
 
 	// Setup selection handler for viewing page content
 	b.table.SetSelectedFunc(func(row, col int) {
@@ -183,7 +194,7 @@ func (b *pageTableBuilder) build() *tview.Table {
 }
 
 func (b *pageTableBuilder) setupHeader() {
-	headers := []string{"#", "Page Type", "Offset", "Comp Size", "Uncomp Size", "Values", "Encoding", "Min", "Max"}
+	headers := []string{"#", "Page Type", "Offset", "Comp Size", "Uncomp Size", "Values", "Encoding", "Min", "Max", "Levels"}
 	for colIdx, header := range headers {
 		cell := tview.NewTableCell(header).
 			SetTextColor(tcell.ColorYellow).
@@ -197,41 +208,34 @@ func (b *pageTableBuilder) setupHeader() {
 }
 
 func (b *pageContentBuilder) build() (*tview.Table, error) {
-	// Read all values first
-	values, err := b.app.readPageContent(b.rgIndex, b.colIndex, b.pageIndex)
+	// Read the first batch, the highlighted values included
+	part, err := b.readValues(0, b.firstBatchSize())
 	if err != nil {
 		return nil, err
 	}
 
-	b.allValues = values
-
-	// Setup header
-	b.setupHeader()
-
-	// Load ALL values at once - they're already in memory
-	totalValues := len(b.allValues)
-	for i := 0; i < totalValues; i++ {
-		tableRowIdx := i + 1 // +1 because row 0 is the header
-
-		// Index column
-		cell := tview.NewTableCell(fmt.Sprintf("%d", i+1)).
-			SetTextColor(tcell.ColorDarkCyan).
-			SetAlign(tview.AlignRight)
-		b.table.SetCell(tableRowIdx, 0, cell)
-
-		// Value column - values are already formatted strings from the API/model layer
-		cell = tview.NewTableCell(b.allValues[i]).
-			SetTextColor(tcell.ColorWhite).
-			SetAlign(tview.AlignLeft).
-			SetExpansion(1)
-		b.table.SetCell(tableRowIdx, 1, cell)
-	}
-
-	b.loadedValues = totalValues
+	b.setValues(part)
 
 	// Update header info
 	b.updateHeaderInfo()
 
+	b.table.SetSelectedFunc(func(row, col int) {
+		if valueIndex := row - 1; valueIndex >= 0 && valueIndex < len(b.allValues) {
+			b.app.showValueDetail(
+				fmt.Sprintf("Value %d - RG %d, Column %d, Page %d", valueIndex, b.rgIndex, b.colIndex, b.pageIndex),
+				func(ctx context.Context) (model.ValueDetail, error) {
+					return b.app.httpClient.getPageValue(ctx, b.rgIndex, b.colIndex, b.pageIndex, valueIndex)
+				})
+		}
+	})
+
+	// Load more values when the selection gets close to the last loaded one
+	b.table.SetSelectionChangedFunc(func(row, col int) {
+		if row >= len(b.allValues)-b.batchSize/4 {
+			b.loadMore()
+		}
+	})
+
 	// Setup key handlers
 	b.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		switch event.Key() {
@@ -240,9 +244,18 @@ func (b *pageContentBuilder) build() (*tview.Table, error) {
 			b.app.pages.RemovePage("page-content")
 			return nil
 		case tcell.KeyRune:
-			if event.Rune() == 's' {
+			switch event.Rune() {
+			case 's':
 				b.app.showSchema()
 				return nil
+			case 'e':
+				b.app.showPageStructure(b.rgIndex, b.colIndex, b.pageIndex)
+				return nil
+			case 'w':
+				if b.isGeospatial {
+					b.toggleGeoFormat()
+					return nil
+				}
 			}
 		}
 		return event
@@ -251,6 +264,140 @@ func (b *pageContentBuilder) build() (*tview.Table, error) {
 	return b.table, nil
 }
 
+// firstBatchSize returns how many values to load first, enough to show the
+// highlighted ones
+func (b *pageContentBuilder) firstBatchSize() int {
+	size := max(b.batchSize, 1)
+	if b.highlightStart >= 0 {
+		size = max(size, b.highlightStart+b.highlightCount)
+	}
+	return min(size, model.MaxPageContentLimit)
+}
+
+// readValues reads at most limit page values from offset, geospatial ones in
+// the chosen format
+func (b *pageContentBuilder) readValues(offset, limit int) (pageContentPart, error) {
+	var geoFormat model.GeoFormat
+	if b.isGeospatial {
+		geoFormat = b.geoFormat
+	}
+	return b.app.readPageContent(b.ctx, b.rgIndex, b.colIndex, b.pageIndex, geoFormat, offset, limit)
+}
+
+// loadMore reads the next batch of values in the background and appends them
+// to the table, unless a batch is already loading or all values are loaded
+func (b *pageContentBuilder) loadMore() {
+	offset := len(b.allValues)
+	if b.isLoading || offset >= b.totalValues {
+		return
+	}
+	b.isLoading = true
+	geoFormat := b.geoFormat
+	b.setTitle()
+
+	go func() {
+		part, err := b.readValues(offset, max(b.batchSize, 1))
+		b.app.tviewApp.QueueUpdateDraw(func() {
+			b.isLoading = false
+			if b.ctx.Err() != nil || b.geoFormat != geoFormat || len(b.allValues) != offset {
+				// Closed, or reloaded in another format meanwhile
+				return
+			}
+			if err != nil {
+				b.statusTextView.SetText(fmt.Sprintf(" [red]Error reading page content: %v[-]", err))
+				b.setTitle()
+				return
+			}
+			b.appendValues(part.Values)
+		})
+	}()
+}
+
+// toggleGeoFormat switches geospatial values between GeoJSON and WKT and
+// reloads the values loaded so far in the background
+func (b *pageContentBuilder) toggleGeoFormat() {
+	geoFormat := model.GeoFormatWKT
+	if b.geoFormat == model.GeoFormatWKT {
+		geoFormat = model.GeoFormatGeoJSON
+	}
+	limit := min(max(len(b.allValues), b.firstBatchSize()), model.MaxPageContentLimit)
+
+	go func() {
+		part, err := b.app.readPageContent(b.ctx, b.rgIndex, b.colIndex, b.pageIndex, geoFormat, 0, limit)
+		b.app.tviewApp.QueueUpdateDraw(func() {
+			// The view was closed while the values were read
+			if b.ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				b.statusTextView.SetText(fmt.Sprintf(" [red]Error reading page content: %v[-]", err))
+				return
+			}
+			b.geoFormat = geoFormat
+			b.setValues(part)
+			b.updateHeaderInfo()
+		})
+	}()
+}
+
+// setValues fills the table with the first values of the page
+func (b *pageContentBuilder) setValues(part pageContentPart) {
+	b.allValues = nil
+	b.totalValues = part.Total
+
+	b.table.Clear()
+	b.setupHeader()
+	b.appendValues(part.Values)
+
+	if b.isHighlighted(b.highlightStart) {
+		b.table.Select(b.highlightStart+1, 0)
+	}
+}
+
+// appendValues adds one row per value after the loaded ones
+func (b *pageContentBuilder) appendValues(values []string) {
+	for _, value := range values {
+		i := len(b.allValues)
+		b.allValues = append(b.allValues, value)
+		tableRowIdx := i + 1 // +1 because row 0 is the header
+
+		// Index column
+		cell := tview.NewTableCell(fmt.Sprintf("%d", i+1)).
+			SetTextColor(tcell.ColorDarkCyan).
+			SetAlign(tview.AlignRight)
+		b.table.SetCell(tableRowIdx, 0, cell)
+
+		// Value column - values are already formatted strings from the API/model layer
+		cell = tview.NewTableCell(value).
+			SetTextColor(tcell.ColorWhite).
+			SetAlign(tview.AlignLeft).
+			SetExpansion(1)
+		if b.isHighlighted(i) {
+			cell.SetTextColor(tcell.ColorBlack).SetBackgroundColor(tcell.ColorYellow)
+		}
+		b.table.SetCell(tableRowIdx, 1, cell)
+	}
+	b.setTitle()
+}
+
+// setTitle shows how many values are loaded while some are not
+func (b *pageContentBuilder) setTitle() {
+	switch {
+	case b.isLoading:
+		b.table.SetTitle(fmt.Sprintf(" Page Content (%d of %d values, loading...) ", len(b.allValues), b.totalValues))
+	case len(b.allValues) < b.totalValues:
+		b.table.SetTitle(fmt.Sprintf(" Page Content (%d of %d values, ↑↓ to load more) ", len(b.allValues), b.totalValues))
+	default:
+		b.table.SetTitle(" Page Content (↑↓ to navigate) ")
+	}
+}
+
+// isHighlighted reports whether the value at index belongs to the located row
+func (b *pageContentBuilder) isHighlighted(index int) bool {
+	return b.highlightStart >= 0 && index >= b.highlightStart && index < b.highlightStart+b.highlightCount &&
+		index < len(b.allValues)
+}
+
 func (b *pageContentBuilder) setupHeader() {
 	// Add header row with two columns: Index and Value
 	headers := []string{"#", "Value"}
@@ -291,6 +438,14 @@ func (b *pageContentBuilder) updateHeaderInfo() {
 		_, _ = fmt.Fprintf(&info, "  [yellow]Encoding:[-] %s", b.pageInfo.Encoding)
 	}
 
+	if b.isGeospatial {
+		format := "GeoJSON"
+		if b.geoFormat == model.GeoFormatWKT {
+			format = "WKT"
+		}
+		_, _ = fmt.Fprintf(&info, "  [yellow]Format:[-] %s", format)
+	}
+
 	// Line 3: Min/Max (if available)
 	if b.pageInfo.MinValue != "" || b.pageInfo.MaxValue != "" {
 		info.WriteString("\n")
@@ -305,6 +460,11 @@ func (b *pageContentBuilder) updateHeaderInfo() {
 		}
 	}
 
+	// Line 4: Size statistics (if available)
+	if sizeStats := formatSizeStatistics(b.pageInfo.SizeStatistics); sizeStats != "" {
+		info.WriteString("\n" + sizeStats)
+	}
+
 	b.headerView.SetText(info.String())
 }
 
@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/hangxie/parquet-go/v3/parquet"
 	"github.com/rivo/tview"
@@ -38,6 +42,7 @@ func Test_pageTableBuilder_readPageHeadersBatch(t *testing.T) {
 				app.httpClient = newParquetClient(server.URL)
 
 				return &pageTableBuilder{
+					ctx:      context.Background(),
 					app:      app,
 					rgIndex:  0,
 					colIndex: 0,
@@ -60,6 +65,7 @@ func Test_pageTableBuilder_readPageHeadersBatch(t *testing.T) {
 				app.httpClient = newParquetClient(server.URL)
 
 				return &pageTableBuilder{
+					ctx:      context.Background(),
 					app:      app,
 					rgIndex:  0,
 					colIndex: 0,
@@ -91,6 +97,7 @@ func Test_pageTableBuilder_readPageHeadersBatch(t *testing.T) {
 				app := NewTUIApp()
 
 				return &pageTableBuilder{
+					ctx:      context.Background(),
 					app:      app,
 					rgIndex:  0,
 					colIndex: 0,
@@ -178,6 +185,7 @@ func Test_pageTableBuilder_build(t *testing.T) {
 				app.httpClient = newParquetClient(server.URL)
 
 				return &pageTableBuilder{
+					ctx:      context.Background(),
 					app:      app,
 					rgIndex:  0,
 					colIndex: 0,
@@ -200,6 +208,7 @@ func Test_pageTableBuilder_build(t *testing.T) {
 				app.httpClient = newParquetClient(server.URL)
 
 				return &pageTableBuilder{
+					ctx:      context.Background(),
 					app:      app,
 					rgIndex:  0,
 					colIndex: 0,
@@ -447,3 +456,67 @@ func Test_pageContentBuilder_build(t *testing.T) {
 		})
 	}
 }
+
+func Test_pageContentBuilder_loadMore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		values := []string{}
+		for i := offset; i < min(offset+limit, 10); i++ {
+			values = append(values, fmt.Sprintf("value%d", i))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"values": values, "count": len(values), "offset": offset, "total": 10,
+		})
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	builder := &pageContentBuilder{
+		app:            app,
+		table:          tview.NewTable().SetSelectable(true, false),
+		headerView:     tview.NewTextView().SetDynamicColors(true),
+		statusTextView: tview.NewTextView(),
+		pageInfo:       model.PageMetadata{PageType: "DATA_PAGE", CompressedSize: 1, UncompressedSize: 1},
+		batchSize:      4,
+		ctx:            ctx,
+		cancel:         cancel,
+		highlightStart: 5,
+		highlightCount: 1,
+	}
+
+	var buildErr error
+	queueTUIUpdate(t, app, func() {
+		_, buildErr = builder.build()
+	})
+	require.NoError(t, buildErr)
+
+	loaded := func() int {
+		var n int
+		queueTUIUpdate(t, app, func() { n = len(builder.allValues) })
+		return n
+	}
+	// Enough to show the highlighted value
+	require.Equal(t, 6, loaded())
+	queueTUIUpdate(t, app, func() {
+		require.Contains(t, builder.table.GetTitle(), "6 of 10")
+		require.Equal(t, "value5", builder.table.GetCell(6, 1).Text)
+	})
+
+	// Selecting the last loaded value loads the rest
+	queueTUIUpdate(t, app, func() {
+		builder.table.Select(len(builder.allValues), 0)
+	})
+	require.Eventually(t, func() bool { return loaded() == 10 }, 2*time.Second, 10*time.Millisecond)
+	queueTUIUpdate(t, app, func() {
+		require.Equal(t, 11, builder.table.GetRowCount())
+		require.Equal(t, "value9", builder.table.GetCell(10, 1).Text)
+		require.Equal(t, " Page Content (↑↓ to navigate) ", builder.table.GetTitle())
+	})
+}
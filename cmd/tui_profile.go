@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// profileBarWidth is the width in cells of the longest bar in profile charts
+const profileBarWidth = 40
+
+// profileBarBlocks are the partial block characters used for the fractional
+// end of a bar, in eighths
+var profileBarBlocks = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// profileBarRow is one labelled bar in a profile chart
+type profileBarRow struct {
+	label string
+	count int64
+}
+
+// showColumnProfile profiles a column across all row groups and shows the
+// result in a popup. Progress is shown while the profile runs, ESC cancels it.
+func (app *TUIApp) showColumnProfile(path string) {
+	loadingModal := tview.NewModal().
+		SetText(formatProfileLoadingText(path, nil)).
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("profile-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("profile-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		profile, err := app.httpClient.getColumnProfile(ctx, path, func(progress model.ProfileProgress) {
+			app.tviewApp.QueueUpdateDraw(func() {
+				loadingModal.SetText(formatProfileLoadingText(path, &progress))
+			})
+		})
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage("profile-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error profiling column:\n%v\n\nPress ESC to go back", err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage("profile-error")
+					})
+				app.pages.AddPage("profile-error", errorModal, true, true)
+				return
+			}
+
+			profileView := tview.NewTextView().
+				SetDynamicColors(true).
+				SetScrollable(true).
+				SetWrap(false).
+				SetText(buildProfileText(profile))
+
+			profileView.SetBorder(true).
+				SetTitle(fmt.Sprintf(" Profile - %s (↑↓ to scroll, ESC to close) ", profile.Path)).
+				SetTitleAlign(tview.AlignLeft)
+
+			profileView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+				if event.Key() == tcell.KeyEscape {
+					app.pages.RemovePage("profile")
+					return nil
+				}
+				return event
+			})
+
+			app.pages.AddPage("profile", profileView, true, true)
+			app.tviewApp.SetFocus(profileView)
+		})
+	}()
+}
+
+// formatProfileLoadingText builds the loading modal text for a running profile
+func formatProfileLoadingText(path string, progress *model.ProfileProgress) string {
+	var text strings.Builder
+	_, _ = fmt.Fprintf(&text, "Profiling %s...\n\n", path)
+	if progress != nil && progress.NumRowGroups > 0 {
+		percent := 0.0
+		if progress.TotalRows > 0 {
+			percent = float64(progress.RowsRead) * 100 / float64(progress.TotalRows)
+		}
+		_, _ = fmt.Fprintf(&text, "Row group %d/%d\n%d/%d rows (%.0f%%)\n\n",
+			progress.RowGroup+1, progress.NumRowGroups, progress.RowsRead, progress.TotalRows, percent)
+	} else {
+		text.WriteString("Please wait...\n\n")
+	}
+	text.WriteString("Press ESC to cancel")
+	return text.String()
+}
+
+// buildProfileText renders a column profile as tview-colored text with bar charts
+func buildProfileText(profile model.ColumnProfile) string {
+	var text strings.Builder
+
+	_, _ = fmt.Fprintf(&text, "[yellow]Column:[-] %s  [yellow]Type:[-] %s", profile.Path, profile.PhysicalType)
+	if profile.LogicalType != "-" {
+		_, _ = fmt.Fprintf(&text, "  [yellow]Logical:[-] %s", profile.LogicalType)
+	}
+	_, _ = fmt.Fprintf(&text, "  [yellow]Kind:[-] %s\n", profile.Kind)
+	_, _ = fmt.Fprintf(&text, "[yellow]Values:[-] %d  [yellow]Nulls:[-] %d (%.1f%%)  [yellow]Distinct (approx.):[-] %d\n",
+		profile.TotalValues, profile.NullCount, profile.NullRatio*100, profile.DistinctCount)
+	_, _ = fmt.Fprintf(&text, "[yellow]Min:[-] %s  [yellow]Max:[-] %s\n",
+		tview.Escape(profile.MinValue), tview.Escape(profile.MaxValue))
+
+	if len(profile.TopValues) > 0 {
+		rows := make([]profileBarRow, len(profile.TopValues))
+		for i, v := range profile.TopValues {
+			rows[i] = profileBarRow{label: v.Value, count: v.Count}
+		}
+		text.WriteString("\n[yellow]Top Values[-]\n")
+		writeProfileBars(&text, rows)
+	}
+
+	if len(profile.Histogram) > 0 {
+		rows := make([]profileBarRow, len(profile.Histogram))
+		for i, b := range profile.Histogram {
+			rows[i] = profileBarRow{label: fmt.Sprintf("%g – %g", b.Lower, b.Upper), count: b.Count}
+		}
+		_, _ = fmt.Fprintf(&text, "\n[yellow]Histogram[-] (sample of %d)\n", profile.SampleSize)
+		writeProfileBars(&text, rows)
+	}
+
+	if len(profile.Quantiles) > 0 {
+		text.WriteString("\n[yellow]Quantiles[-]\n")
+		for _, q := range profile.Quantiles {
+			_, _ = fmt.Fprintf(&text, "  p%-4g %g\n", q.Quantile*100, q.Value)
+		}
+	}
+
+	if profile.StringLengths != nil {
+		rows := make([]profileBarRow, len(profile.StringLengths.Buckets))
+		for i, b := range profile.StringLengths.Buckets {
+			rows[i] = profileBarRow{label: model.FormatLengthBucket(b), count: b.Count}
+		}
+		_, _ = fmt.Fprintf(&text, "\n[yellow]Length Distribution[-] (min %d, max %d, mean %.1f bytes)\n",
+			profile.StringLengths.Min, profile.StringLengths.Max, profile.StringLengths.Mean)
+		writeProfileBars(&text, rows)
+	}
+
+	if profile.Timestamps != nil {
+		_, _ = fmt.Fprintf(&text, "\n[yellow]Timestamp Range:[-] %s → %s (%s)\n",
+			profile.Timestamps.Min, profile.Timestamps.Max, profile.Timestamps.Span)
+		rows := make([]profileBarRow, len(profile.Timestamps.Gaps))
+		for i, g := range profile.Timestamps.Gaps {
+			rows[i] = profileBarRow{label: g.Label, count: g.Count}
+		}
+		text.WriteString("\n[yellow]Gaps Between Consecutive Values[-]\n")
+		writeProfileBars(&text, rows)
+	}
+
+	return text.String()
+}
+
+// writeProfileBars writes one horizontal bar per row, scaled to the largest count
+func writeProfileBars(text *strings.Builder, rows []profileBarRow) {
+	var maxCount int64
+	labelWidth := 0
+	for _, row := range rows {
+		maxCount = max(maxCount, row.count)
+		labelWidth = max(labelWidth, len([]rune(truncateProfileLabel(row.label))))
+	}
+
+	for _, row := range rows {
+		label := truncateProfileLabel(row.label)
+		padding := strings.Repeat(" ", labelWidth-len([]rune(label)))
+		_, _ = fmt.Fprintf(text, "  %s%s [green]%s[-] %d\n",
+			tview.Escape(label), padding, renderProfileBar(row.count, maxCount, profileBarWidth), row.count)
+	}
+}
+
+// truncateProfileLabel keeps chart labels short enough to leave room for bars
+func truncateProfileLabel(label string) string {
+	runes := []rune(label)
+	if len(runes) > 30 {
+		return string(runes[:27]) + "..."
+	}
+	return label
+}
+
+// renderProfileBar draws a bar of count relative to maxCount using block
+// characters, with eighth-block precision at the end
+func renderProfileBar(count, maxCount int64, width int) string {
+	if maxCount <= 0 || count <= 0 {
+		return ""
+	}
+	eighths := int(count * int64(width) * 8 / maxCount)
+	if eighths == 0 {
+		eighths = 1 // keep non-zero counts visible
+	}
+	return strings.Repeat("█", eighths/8) + profileBarBlocks[eighths%8]
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+func Test_TUIApp_showColumnProfile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/columns/id/profile", r.URL.Path)
+		_, _ = w.Write([]byte(`{"progress":{"RowGroup":0,"NumRowGroups":1,"RowsRead":3,"TotalRows":3}}` + "\n"))
+		_, _ = w.Write([]byte(`{"profile":{"Path":"id","PhysicalType":"INT32","LogicalType":"-","Kind":"NUMERIC",` +
+			`"TotalValues":3,"MinValue":"1","MaxValue":"3","TopValues":[{"Value":"1","Count":1}]}}` + "\n"))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showColumnProfile("id")
+	})
+
+	primitive := waitForTUIPage(t, app, "profile")
+	require.IsType(t, &tview.TextView{}, primitive)
+	var text string
+	var hasLoading bool
+	queueTUIUpdate(t, app, func() {
+		text = primitive.(*tview.TextView).GetText(true)
+		hasLoading = app.pages.HasPage("profile-loading")
+	})
+	assert.Contains(t, text, "Top Values")
+	assert.False(t, hasLoading)
+}
+
+func Test_TUIApp_showColumnProfile_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "broken", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showColumnProfile("id")
+	})
+
+	primitive := waitForTUIPage(t, app, "profile-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_formatProfileLoadingText(t *testing.T) {
+	text := formatProfileLoadingText("id", nil)
+	assert.Contains(t, text, "Profiling id")
+	assert.Contains(t, text, "Please wait")
+
+	text = formatProfileLoadingText("id", &model.ProfileProgress{RowGroup: 1, NumRowGroups: 4, RowsRead: 50, TotalRows: 200})
+	assert.Contains(t, text, "Row group 2/4")
+	assert.Contains(t, text, "50/200 rows (25%)")
+	assert.Contains(t, text, "ESC to cancel")
+}
+
+func Test_buildProfileText(t *testing.T) {
+	profile := model.ColumnProfile{
+		Path:          "ts",
+		PhysicalType:  "INT64",
+		LogicalType:   "TIMESTAMP",
+		Kind:          model.ProfileKindTimestamp,
+		TotalValues:   4,
+		NullCount:     1,
+		NullRatio:     0.25,
+		DistinctCount: 3,
+		MinValue:      "[a]",
+		MaxValue:      "b",
+		TopValues:     []model.ValueCount{{Value: "x", Count: 2}},
+		Histogram:     []model.HistogramBucket{{Lower: 1, Upper: 2, Count: 3}},
+		Quantiles:     []model.QuantileValue{{Quantile: 0.5, Value: 1.5}},
+		StringLengths: &model.LengthProfile{Min: 1, Max: 3, Mean: 2, Buckets: []model.HistogramBucket{{Lower: 2, Upper: 4, Count: 3}}},
+		Timestamps: &model.TimestampProfile{
+			Min:  "2022-01-01T00:00:00Z",
+			Max:  "2022-01-02T00:00:00Z",
+			Span: "24h0m0s",
+			Gaps: []model.DurationBucket{{Label: "< 1d", Count: 2}},
+		},
+	}
+
+	text := buildProfileText(profile)
+	for _, s := range []string{
+		"Logical:[-] TIMESTAMP", "Nulls:[-] 1 (25.0%)", "[a[]", "Top Values", "Histogram[-] (sample of 0)",
+		"p50", "Length Distribution", "2 – 3", "Timestamp Range:[-] 2022-01-01T00:00:00Z", "< 1d",
+	} {
+		assert.Contains(t, text, s)
+	}
+}
+
+func Test_renderProfileBar(t *testing.T) {
+	assert.Equal(t, "", renderProfileBar(0, 10, 8))
+	assert.Equal(t, "", renderProfileBar(5, 0, 8))
+	assert.Equal(t, "████████", renderProfileBar(10, 10, 8))
+	assert.Equal(t, "████", renderProfileBar(5, 10, 8))
+	assert.Equal(t, "█▌", renderProfileBar(3, 16, 8))
+	assert.Equal(t, "▏", renderProfileBar(1, 1000, 8))
+}
+
+func Test_truncateProfileLabel(t *testing.T) {
+	assert.Equal(t, "short", truncateProfileLabel("short"))
+	long := "abcdefghijklmnopqrstuvwxyz0123456789"
+	assert.Equal(t, "abcdefghijklmnopqrstuvwxyz0...", truncateProfileLabel(long))
+}
@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// showCommandPrompt opens a vi-style command line at the bottom of the
+// screen. The only command is "row N", which goes to row N of the file.
+func (app *TUIApp) showCommandPrompt() {
+	input := tview.NewInputField().
+		SetLabel(":").
+		SetFieldBackgroundColor(tcell.ColorDefault)
+	input.SetDoneFunc(func(key tcell.Key) {
+		app.pages.RemovePage("command")
+		if key == tcell.KeyEnter {
+			app.runCommand(input.GetText())
+		}
+	})
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(input, 1, 0, true)
+
+	app.pages.AddPage("command", flex, true, true)
+}
+
+// runCommand runs a command typed at the command prompt
+func (app *TUIApp) runCommand(command string) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return
+	}
+
+	var err error
+	switch fields[0] {
+	case "row":
+		var row int64
+		if len(fields) != 2 {
+			err = fmt.Errorf("usage: row N")
+		} else if row, err = model.ParseRowNumber(fields[1]); err == nil {
+			app.showRowLocation(row)
+			return
+		}
+	default:
+		err = fmt.Errorf("unknown command %q, try: row N", fields[0])
+	}
+
+	errorModal := tview.NewModal().
+		SetText(fmt.Sprintf("%v\n\nPress ESC to go back", err)).
+		SetTextColor(tcell.ColorRed).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			app.pages.RemovePage("command-error")
+		})
+	app.pages.AddPage("command-error", errorModal, true, true)
+}
+
+// showRowLocation shows the row group of a row and, in every column, the
+// page and values holding it. Enter opens the page with the row's values
+// highlighted.
+func (app *TUIApp) showRowLocation(row int64) {
+	app.loadRowLocation(row, false, "row-location", func(location model.RowLocation) tview.Primitive {
+		return app.buildRowLocationView(location)
+	})
+}
+
+// showRowRecord shows a row assembled from all its columns as JSON
+func (app *TUIApp) showRowRecord(row int64) {
+	app.loadRowLocation(row, true, "row-record", func(location model.RowLocation) tview.Primitive {
+		return app.buildRowRecordView(location)
+	})
+}
+
+// loadRowLocation fetches the location of a row in the background behind a
+// cancellable loading modal, then shows the view built from it as page name
+func (app *TUIApp) loadRowLocation(row int64, withRecord bool, name string, build func(model.RowLocation) tview.Primitive) {
+	loadingModal := tview.NewModal().
+		SetText(fmt.Sprintf("Locating row %d...\n\nPlease wait...\n\nPress ESC to cancel", row)).
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage(name + "-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage(name+"-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		location, err := app.httpClient.getRowLocation(ctx, row, withRecord)
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage(name + "-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error locating row %d:\n%v\n\nPress ESC to go back", row, err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage(name + "-error")
+					})
+				app.pages.AddPage(name+"-error", errorModal, true, true)
+				return
+			}
+
+			app.pages.RemovePage(name)
+			app.pages.AddPage(name, build(location), true, true)
+		})
+	}()
+}
+
+// buildRowLocationView lays out where the row is, one table row per column
+// and a status line
+func (app *TUIApp) buildRowLocationView(location model.RowLocation) *tview.Flex {
+	summary := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(fmt.Sprintf("[yellow]Row:[-] %d  [yellow]Row Group:[-] %d  [yellow]Row in Row Group:[-] %d  [yellow]Columns:[-] %d",
+			location.Row, location.RowGroup, location.RowIndex, len(location.Columns)))
+
+	table := buildRowLocationTable(location)
+	table.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Row %d (↑↓ to scroll, Enter=page with the row highlighted) ", location.Row)).
+		SetTitleAlign(tview.AlignLeft)
+
+	statusLine := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+
+	status := " [yellow]Keys:[-] ESC=back, ↑↓=scroll, Enter=page values, a=assembled record"
+	if v := GetVersion(); v != "" {
+		status += fmt.Sprintf("  [gray]%s[-]", v)
+	}
+	statusLine.SetText(status)
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, 1, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(statusLine, 1, 0, false)
+
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			app.pages.RemovePage("row-location")
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			if i := row - 1; i >= 0 && i < len(location.Columns) {
+				column := location.Columns[i]
+				app.showRowValues(location.RowGroup, column)
+			}
+			return nil
+		case tcell.KeyRune:
+			if event.Rune() == 'a' {
+				app.showRowRecord(location.Row)
+				return nil
+			}
+		}
+		return event
+	})
+
+	return flex
+}
+
+// buildRowLocationTable lists the page and values holding the row in each
+// column
+func buildRowLocationTable(location model.RowLocation) *tview.Table {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	headers := []string{"#", "Column Path", "Page", "Page First Row (RG)", "Page Offset", "Value Index", "Values"}
+	for i, header := range headers {
+		table.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+
+	for i, column := range location.Columns {
+		cells := []string{
+			fmt.Sprintf("%d", column.ColumnIndex),
+			tview.Escape(column.Path),
+			fmt.Sprintf("%d", column.PageIndex),
+			fmt.Sprintf("%d", column.PageFirstRowIndex),
+			fmt.Sprintf("%d", column.PageOffset),
+			fmt.Sprintf("%d", column.ValueIndex),
+			fmt.Sprintf("%d", column.NumValues),
+		}
+		for col, text := range cells {
+			table.SetCell(i+1, col, tview.NewTableCell(text))
+		}
+	}
+
+	if len(location.Columns) > 0 {
+		table.Select(1, 0)
+	}
+	return table
+}
+
+// buildRowRecordView shows the assembled row in a scrollable text view
+func (app *TUIApp) buildRowRecordView(location model.RowLocation) *tview.TextView {
+	textView := tview.NewTextView().
+		SetDynamicColors(false).
+		SetScrollable(true).
+		SetText(location.Record)
+	textView.SetBorder(true).
+		SetTitle(fmt.Sprintf(" Row %d - Assembled Record, values as stored (ESC to close) ", location.Row)).
+		SetTitleAlign(tview.AlignLeft)
+
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			app.pages.RemovePage("row-record")
+			return nil
+		}
+		return event
+	})
+	return textView
+}
+
+// showRowValues opens the page of a column holding a located row with the
+// row's values highlighted, behind a loading modal that cancels the lookup
+func (app *TUIApp) showRowValues(rgIndex int, column model.RowColumnLocation) {
+	loadingModal := tview.NewModal().
+		SetText(fmt.Sprintf("Loading page %d of %s...\n\nPlease wait...\n\nPress ESC to cancel", column.PageIndex, column.Path)).
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("row-values-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("row-values-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		colInfo, err := app.httpClient.getColumnChunkInfo(ctx, rgIndex, column.ColumnIndex)
+		var pages []model.PageMetadata
+		if err == nil {
+			pages, err = app.httpClient.getAllPagesInfo(ctx, rgIndex, column.ColumnIndex)
+		}
+		if err == nil && (column.PageIndex < 0 || column.PageIndex >= len(pages)) {
+			err = fmt.Errorf("page %d not found in column %s", column.PageIndex, column.Path)
+		}
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage("row-values-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error loading page:\n%v\n\nPress ESC to go back", err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage("row-location-error")
+					})
+				app.pages.AddPage("row-location-error", errorModal, true, true)
+				return
+			}
+			app.showHighlightedPageContent(rgIndex, column.ColumnIndex, column.PageIndex, pages, columnMetaFromInfo(colInfo),
+				column.ValueIndex, column.NumValues)
+		})
+	}()
+}
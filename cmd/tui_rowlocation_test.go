@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// testRowLocation is row 7 in a flat column and a repeated one
+func testRowLocation() model.RowLocation {
+	return model.RowLocation{
+		Row:      7,
+		RowGroup: 1,
+		RowIndex: 2,
+		Columns: []model.RowColumnLocation{
+			{ColumnIndex: 0, Path: "id", PageIndex: 1, PageFirstRowIndex: 0, PageOffset: 4, ValueIndex: 2, NumValues: 1},
+			{ColumnIndex: 1, Path: "tags.list.element", PageIndex: 0, PageFirstRowIndex: 0, PageOffset: 100, ValueIndex: 5, NumValues: 3},
+		},
+	}
+}
+
+// rowLocationServer serves the location of row 7, with a record when asked
+func rowLocationServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rows/7/location", r.URL.Path)
+		record := ""
+		if r.URL.Query().Get("record") == "true" {
+			record = `,"Record":"{\n  \"id\": 7\n}"`
+		}
+		_, _ = w.Write([]byte(`{"Row":7,"RowGroup":1,"RowIndex":2,"Columns":[{"ColumnIndex":0,"Path":"id","PageIndex":1,"ValueIndex":2,"NumValues":1}]` + record + `}`))
+	}))
+}
+
+func Test_TUIApp_runCommand(t *testing.T) {
+	server := rowLocationServer(t)
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.runCommand("row 7")
+	})
+
+	primitive := waitForTUIPage(t, app, "row-location")
+	require.IsType(t, &tview.Flex{}, primitive)
+	var summary string
+	queueTUIUpdate(t, app, func() {
+		summary = primitive.(*tview.Flex).GetItem(0).(*tview.TextView).GetText(true)
+	})
+	assert.Contains(t, summary, "Row: 7  Row Group: 1  Row in Row Group: 2")
+}
+
+func Test_TUIApp_runCommand_Invalid(t *testing.T) {
+	for _, command := range []string{"row", "row x", "bogus 1"} {
+		app := NewTUIApp()
+		app.runCommand(command)
+		assert.True(t, app.pages.HasPage("command-error"), command)
+	}
+
+	app := NewTUIApp()
+	app.runCommand("   ")
+	assert.False(t, app.pages.HasPage("command-error"))
+}
+
+func Test_TUIApp_showCommandPrompt(t *testing.T) {
+	app := NewTUIApp()
+	app.showCommandPrompt()
+	require.True(t, app.pages.HasPage("command"))
+
+	_, primitive := app.pages.GetFrontPage()
+	input := primitive.(*tview.Flex).GetItem(1).(*tview.InputField)
+	assert.Equal(t, ":", input.GetLabel())
+
+	input.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), nil)
+	assert.False(t, app.pages.HasPage("command"))
+}
+
+func Test_TUIApp_showRowRecord(t *testing.T) {
+	server := rowLocationServer(t)
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showRowRecord(7)
+	})
+
+	primitive := waitForTUIPage(t, app, "row-record")
+	require.IsType(t, &tview.TextView{}, primitive)
+	var text string
+	queueTUIUpdate(t, app, func() {
+		text = primitive.(*tview.TextView).GetText(true)
+	})
+	assert.Contains(t, text, `"id": 7`)
+}
+
+func Test_TUIApp_showRowLocation_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "out of range", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showRowLocation(99)
+	})
+
+	primitive := waitForTUIPage(t, app, "row-location-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_TUIApp_showRowValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rowgroups/1/columnchunks/1":
+			_, _ = w.Write([]byte(`{"PhysicalType":"INT32","PathInSchema":["tags","list","element"]}`))
+		case "/rowgroups/1/columnchunks/1/pages":
+			_, _ = w.Write([]byte(`[{"PageType":"DATA_PAGE","NumValues":10,"CompressedSize":10,"UncompressedSize":10}]`))
+		case "/rowgroups/1/columnchunks/1/pages/0/content":
+			_, _ = w.Write([]byte(`{"values":["0","1","2","3","4","5","6","7","8","9"],"count":10}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showRowValues(1, testRowLocation().Columns[1])
+	})
+
+	primitive := waitForTUIPage(t, app, "page-content")
+	require.IsType(t, &tview.Flex{}, primitive)
+	var (
+		selected   int
+		background tcell.Color
+		plain      tcell.Color
+	)
+	queueTUIUpdate(t, app, func() {
+		table := primitive.(*tview.Flex).GetItem(1).(*tview.Table)
+		selected, _ = table.GetSelection()
+		_, background, _ = table.GetCell(8, 1).Style.Decompose()
+		_, plain, _ = table.GetCell(9, 1).Style.Decompose()
+	})
+	assert.Equal(t, 6, selected)
+	assert.Equal(t, tcell.ColorYellow, background)
+	assert.NotEqual(t, tcell.ColorYellow, plain)
+}
+
+func Test_TUIApp_showRowValues_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "broken", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showRowValues(1, testRowLocation().Columns[0])
+	})
+
+	primitive := waitForTUIPage(t, app, "row-location-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_TUIApp_showRowValues_CancelOnEscape(t *testing.T) {
+	cancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(cancelled)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showRowValues(1, testRowLocation().Columns[1])
+	})
+
+	modal := waitForTUIPage(t, app, "row-values-loading")
+	queueTUIUpdate(t, app, func() {
+		modal.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), nil)
+	})
+
+	select {
+	case <-cancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the request to be cancelled")
+	}
+	queueTUIUpdate(t, app, func() {
+		assert.False(t, app.pages.HasPage("row-values-loading"))
+		assert.False(t, app.pages.HasPage("page-content"))
+	})
+}
+
+func Test_buildRowLocationTable(t *testing.T) {
+	table := buildRowLocationTable(testRowLocation())
+
+	require.Equal(t, 3, table.GetRowCount())
+	assert.Equal(t, "id", table.GetCell(1, 1).Text)
+	assert.Equal(t, "tags.list.element", table.GetCell(2, 1).Text)
+	assert.Equal(t, "5", table.GetCell(2, 5).Text)
+	assert.Equal(t, "3", table.GetCell(2, 6).Text)
+	row, _ := table.GetSelection()
+	assert.Equal(t, 1, row)
+}
+
+func Test_pageContentBuilder_isHighlighted(t *testing.T) {
+	b := &pageContentBuilder{allValues: make([]string, 10), highlightStart: 5, highlightCount: 3}
+	assert.False(t, b.isHighlighted(4))
+	assert.True(t, b.isHighlighted(5))
+	assert.True(t, b.isHighlighted(7))
+	assert.False(t, b.isHighlighted(8))
+
+	b.highlightStart = -1
+	assert.False(t, b.isHighlighted(0))
+
+	b.highlightStart, b.highlightCount = 9, 5
+	assert.True(t, b.isHighlighted(9))
+	assert.False(t, b.isHighlighted(10))
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// showRowPagesPrompt asks for a row or row range and the columns to look it
+// up in, then shows the pages holding it
+func (app *TUIApp) showRowPagesPrompt() {
+	form := tview.NewForm().
+		AddInputField("Rows", "", 30, nil, nil).
+		AddInputField("Columns", "", 30, nil, nil)
+	find := func() {
+		rows := form.GetFormItemByLabel("Rows").(*tview.InputField).GetText()
+		columns := form.GetFormItemByLabel("Columns").(*tview.InputField).GetText()
+		app.pages.RemovePage("rows-prompt")
+		app.showRowPages(rows, parseColumnList(columns))
+	}
+	form.AddButton("Find", find).
+		AddButton("Cancel", func() {
+			app.pages.RemovePage("rows-prompt")
+		})
+	form.SetCancelFunc(func() {
+		app.pages.RemovePage("rows-prompt")
+	})
+	form.SetBorder(true).
+		SetTitle(" Find Rows (e.g. 42 or 100-199, columns as comma-separated paths, empty for all) ").
+		SetTitleAlign(tview.AlignLeft)
+
+	// Enter in the last input field submits the form instead of moving on
+	form.GetFormItemByLabel("Columns").(*tview.InputField).SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			find()
+		}
+	})
+
+	centered := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 9, 0, true).
+			AddItem(nil, 0, 1, false), 90, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("rows-prompt", centered, true, true)
+}
+
+// parseColumnList splits a comma-separated list of column paths
+func parseColumnList(s string) []string {
+	var columns []string
+	for _, column := range strings.Split(s, ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// showRowPages shows, for each of the given columns or all columns, the
+// pages holding a row or row range. Enter opens the pages of the selected
+// column chunk.
+func (app *TUIApp) showRowPages(rows string, columns []string) {
+	loadingModal := tview.NewModal().
+		SetText(fmt.Sprintf("Finding rows %s...\n\nPlease wait...\n\nPress ESC to cancel", rows)).
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("rows-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("rows-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		rowPages, err := app.httpClient.getRowPages(ctx, rows, columns)
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage("rows-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error finding rows:\n%v\n\nPress ESC to go back", err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage("rows-error")
+					})
+				app.pages.AddPage("rows-error", errorModal, true, true)
+				return
+			}
+
+			app.pages.RemovePage("rows")
+			app.pages.AddPage("rows", app.buildRowPagesView(rowPages), true, true)
+		})
+	}()
+}
+
+// rowPageRef is the column chunk a row of the row pages table belongs to
+type rowPageRef struct {
+	rgIndex  int
+	colIndex int
+}
+
+// buildRowPagesView lays out the row range summary, one table row per page
+// and a status line
+func (app *TUIApp) buildRowPagesView(rowPages model.RowPageMap) *tview.Flex {
+	summary := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(buildRowPagesSummaryText(rowPages))
+
+	table, refs := buildRowPagesTable(rowPages)
+	table.SetBorder(true).
+		SetTitle(" Pages by Column (↑↓ to scroll, Enter=column chunk pages) ").
+		SetTitleAlign(tview.AlignLeft)
+
+	statusLine := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+
+	status := " [yellow]Keys:[-] ESC=back, ↑↓=scroll, Enter=column chunk pages, r=find other rows"
+	if v := GetVersion(); v != "" {
+		status += fmt.Sprintf("  [gray]%s[-]", v)
+	}
+	statusLine.SetText(status)
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, 2, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(statusLine, 1, 0, false)
+
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			app.pages.RemovePage("rows")
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			if ref, ok := refs[row]; ok {
+				app.showPageView(ref.rgIndex, ref.colIndex)
+			}
+			return nil
+		case tcell.KeyRune:
+			if event.Rune() == 'r' {
+				app.showRowPagesPrompt()
+				return nil
+			}
+		}
+		return event
+	})
+
+	return flex
+}
+
+// buildRowPagesSummaryText renders the row range and the bytes to read
+func buildRowPagesSummaryText(rowPages model.RowPageMap) string {
+	rows := fmt.Sprintf("%d", rowPages.FirstRow)
+	if rowPages.LastRow != rowPages.FirstRow {
+		rows = fmt.Sprintf("%d - %d", rowPages.FirstRow, rowPages.LastRow)
+	}
+	return fmt.Sprintf("[yellow]Rows:[-] %s  [yellow]Columns:[-] %d  [yellow]Bytes To Read:[-] %s\n"+
+		"[gray]Bytes include the dictionary page needed to decode dictionary-encoded pages[-]",
+		rows, len(rowPages.Columns), model.FormatBytes(rowPages.TotalBytes))
+}
+
+// buildRowPagesTable lists the pages of every column, the column path on
+// its first page, followed by a total line per column. It also returns the
+// column chunk of each page row.
+func buildRowPagesTable(rowPages model.RowPageMap) (*tview.Table, map[int]rowPageRef) {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 0)
+
+	headers := []string{"Column Path", "RG", "Page", "First Row (RG)", "First Row (file)", "Rows", "Offset", "Size", "Source"}
+	for i, header := range headers {
+		table.SetCell(0, i, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+
+	refs := make(map[int]rowPageRef)
+	row := 1
+	for _, column := range rowPages.Columns {
+		for i, page := range column.Pages {
+			path, source := "", ""
+			if i == 0 {
+				path, source = column.Path, column.Source
+			}
+			cells := []string{
+				tview.Escape(path),
+				fmt.Sprintf("%d", page.RowGroup),
+				fmt.Sprintf("%d", page.PageIndex),
+				fmt.Sprintf("%d", page.FirstRowIndex),
+				fmt.Sprintf("%d", page.FirstRow),
+				fmt.Sprintf("%d", page.NumRows),
+				fmt.Sprintf("%d", page.Offset),
+				model.FormatBytes(page.Size),
+				source,
+			}
+			for col, text := range cells {
+				table.SetCell(row, col, tview.NewTableCell(text))
+			}
+			refs[row] = rowPageRef{rgIndex: page.RowGroup, colIndex: column.ColumnIndex}
+			row++
+		}
+
+		table.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("  total (dictionary %s)", model.FormatBytes(column.DictionaryBytes))).
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+		table.SetCell(row, 7, tview.NewTableCell(model.FormatBytes(column.Bytes)).
+			SetTextColor(tcell.ColorGray).
+			SetSelectable(false))
+		row++
+	}
+
+	if row > 1 {
+		table.Select(1, 0)
+	}
+	return table, refs
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// testRowPages is rows 3-7 of two columns, the first split over two pages
+func testRowPages() model.RowPageMap {
+	return model.RowPageMap{
+		FirstRow: 3,
+		LastRow:  7,
+		Columns: []model.RowColumnPages{
+			{
+				ColumnIndex: 0,
+				Path:        "id",
+				Source:      model.RowPageSourceOffsetIndex,
+				Pages: []model.RowPage{
+					{RowGroup: 0, PageIndex: 1, FirstRowIndex: 0, FirstRow: 0, NumRows: 5, Offset: 4, Size: 100},
+					{RowGroup: 0, PageIndex: 2, FirstRowIndex: 5, FirstRow: 5, NumRows: 5, Offset: 104, Size: 100},
+				},
+				DictionaryBytes: 20,
+				Bytes:           220,
+			},
+			{
+				ColumnIndex: 3,
+				Path:        "tags.list.element",
+				Source:      model.RowPageSourcePageHeaders,
+				Pages: []model.RowPage{
+					{RowGroup: 0, PageIndex: 0, FirstRowIndex: 0, FirstRow: 0, NumRows: 10, Offset: 500, Size: 300},
+				},
+				Bytes: 300,
+			},
+		},
+		TotalBytes: 520,
+	}
+}
+
+func Test_TUIApp_showRowPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rows/3-7/pages", r.URL.Path)
+		require.Equal(t, []string{"id"}, r.URL.Query()["column"])
+		_, _ = w.Write([]byte(`{"FirstRow":3,"LastRow":7,"Columns":[{"ColumnIndex":0,"Path":"id","Source":"OFFSET_INDEX",` +
+			`"Pages":[{"RowGroup":0,"PageIndex":1,"FirstRowIndex":0,"FirstRow":0,"NumRows":10,"Offset":4,"Size":100}],"Bytes":100}],"TotalBytes":100}`))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showRowPages("3-7", []string{"id"})
+	})
+
+	primitive := waitForTUIPage(t, app, "rows")
+	require.IsType(t, &tview.Flex{}, primitive)
+	var summary string
+	var hasLoading bool
+	queueTUIUpdate(t, app, func() {
+		summary = primitive.(*tview.Flex).GetItem(0).(*tview.TextView).GetText(true)
+		hasLoading = app.pages.HasPage("rows-loading")
+	})
+	assert.Contains(t, summary, "Rows: 3 - 7")
+	assert.Contains(t, summary, "Bytes To Read: 100 B")
+	assert.False(t, hasLoading)
+}
+
+func Test_TUIApp_showRowPages_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "out of range", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showRowPages("99", nil)
+	})
+
+	primitive := waitForTUIPage(t, app, "rows-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_TUIApp_showRowPagesPrompt(t *testing.T) {
+	app := NewTUIApp()
+	app.showRowPagesPrompt()
+
+	require.True(t, app.pages.HasPage("rows-prompt"))
+	_, primitive := app.pages.GetFrontPage()
+	form := primitive.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1).(*tview.Form)
+	require.NotNil(t, form.GetFormItemByLabel("Rows"))
+	require.NotNil(t, form.GetFormItemByLabel("Columns"))
+	require.Equal(t, 2, form.GetButtonCount())
+
+	// Cancel closes the prompt
+	form.GetButton(1).InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+	require.False(t, app.pages.HasPage("rows-prompt"))
+}
+
+func Test_buildRowPagesTable(t *testing.T) {
+	table, refs := buildRowPagesTable(testRowPages())
+
+	// Header, two pages and a total for the first column, one page and a
+	// total for the second
+	require.Equal(t, 6, table.GetRowCount())
+	assert.Equal(t, "id", table.GetCell(1, 0).Text)
+	assert.Equal(t, "", table.GetCell(2, 0).Text)
+	assert.Equal(t, "5", table.GetCell(2, 3).Text)
+	assert.Equal(t, model.RowPageSourceOffsetIndex, table.GetCell(1, 8).Text)
+	assert.Contains(t, table.GetCell(3, 0).Text, "dictionary 20 B")
+	assert.Equal(t, "220 B", table.GetCell(3, 7).Text)
+	assert.Equal(t, "tags.list.element", table.GetCell(4, 0).Text)
+	assert.Equal(t, model.RowPageSourcePageHeaders, table.GetCell(4, 8).Text)
+
+	assert.Equal(t, rowPageRef{rgIndex: 0, colIndex: 0}, refs[2])
+	assert.Equal(t, rowPageRef{rgIndex: 0, colIndex: 3}, refs[4])
+	_, ok := refs[3]
+	assert.False(t, ok)
+}
+
+func Test_buildRowPagesSummaryText(t *testing.T) {
+	text := buildRowPagesSummaryText(testRowPages())
+	assert.Contains(t, text, "Rows:[-] 3 - 7")
+	assert.Contains(t, text, "Columns:[-] 2")
+	assert.Contains(t, text, "Bytes To Read:[-] 520 B")
+
+	rowPages := testRowPages()
+	rowPages.LastRow = 3
+	assert.Contains(t, buildRowPagesSummaryText(rowPages), "Rows:[-] 3 ")
+}
+
+func Test_parseColumnList(t *testing.T) {
+	assert.Nil(t, parseColumnList(""))
+	assert.Nil(t, parseColumnList(" , "))
+	assert.Equal(t, []string{"a.b", "c"}, parseColumnList(" a.b, c ,"))
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// showSamplePrompt asks for the size, mode, seed and columns of a sample,
+// then shows the sampled rows
+func (app *TUIApp) showSamplePrompt() {
+	modes := make([]string, len(model.SampleModes))
+	for i, mode := range model.SampleModes {
+		modes[i] = string(mode)
+	}
+
+	form := tview.NewForm().
+		AddInputField("Rows", strconv.Itoa(model.DefaultSampleSize), 30, nil, nil).
+		AddDropDown("Mode", modes, 0, nil).
+		AddInputField("Seed", "", 30, nil, nil).
+		AddInputField("Columns", "", 30, nil, nil)
+	draw := func() {
+		n := form.GetFormItemByLabel("Rows").(*tview.InputField).GetText()
+		_, mode := form.GetFormItemByLabel("Mode").(*tview.DropDown).GetCurrentOption()
+		seed := form.GetFormItemByLabel("Seed").(*tview.InputField).GetText()
+		columns := form.GetFormItemByLabel("Columns").(*tview.InputField).GetText()
+		app.pages.RemovePage("sample-prompt")
+		app.showSample(n, mode, seed, parseColumnList(columns))
+	}
+	form.AddButton("Sample", draw).
+		AddButton("Cancel", func() {
+			app.pages.RemovePage("sample-prompt")
+		})
+	form.SetCancelFunc(func() {
+		app.pages.RemovePage("sample-prompt")
+	})
+	form.SetBorder(true).
+		SetTitle(" Sample Rows (rows per row group when stratified, empty seed for a new draw) ").
+		SetTitleAlign(tview.AlignLeft)
+
+	// Enter in the last input field submits the form instead of moving on
+	form.GetFormItemByLabel("Columns").(*tview.InputField).SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			draw()
+		}
+	})
+
+	centered := tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(form, 13, 0, true).
+			AddItem(nil, 0, 1, false), 90, 0, true).
+		AddItem(nil, 0, 1, false)
+
+	app.pages.AddPage("sample-prompt", centered, true, true)
+}
+
+// showSample draws a sample of rows in the background behind a cancellable
+// loading modal and shows it as a grid of rows × columns
+func (app *TUIApp) showSample(n, mode, seed string, columns []string) {
+	loadingModal := tview.NewModal().
+		SetText("Sampling rows...\n\nPlease wait...\n\nPress ESC to cancel").
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("sample-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("sample-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		sample, err := app.httpClient.getSample(ctx, n, mode, seed, columns)
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage("sample-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error sampling rows:\n%v\n\nPress ESC to go back", err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage("sample-error")
+					})
+				app.pages.AddPage("sample-error", errorModal, true, true)
+				return
+			}
+
+			app.pages.RemovePage("sample")
+			app.pages.AddPage("sample", app.buildSampleView(sample, columns), true, true)
+		})
+	}()
+}
+
+// buildSampleView lays out the sample summary, the grid of sampled rows and
+// a status line. The columns are those the sample was asked for, so that a
+// new draw keeps them.
+func (app *TUIApp) buildSampleView(sample model.Sample, columns []string) *tview.Flex {
+	summary := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(fmt.Sprintf("[yellow]Mode:[-] %s  [yellow]Seed:[-] %d  [yellow]Rows:[-] %d  [yellow]Columns:[-] %d  [yellow]Pages Read:[-] %d  [yellow]Bytes Read:[-] %s",
+			sample.Mode, sample.Seed, len(sample.Rows), len(sample.Columns), sample.PagesRead, model.FormatBytes(sample.BytesRead)))
+
+	table := buildSampleTable(sample)
+	table.SetBorder(true).
+		SetTitle(" Sampled Rows (↑↓←→ to scroll, Enter=go to row) ").
+		SetTitleAlign(tview.AlignLeft)
+
+	statusLine := tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft)
+
+	status := " [yellow]Keys:[-] ESC=back, ↑↓←→=scroll, Enter=go to row, n=new draw, S=sample options"
+	if v := GetVersion(); v != "" {
+		status += fmt.Sprintf("  [gray]%s[-]", v)
+	}
+	statusLine.SetText(status)
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(summary, 1, 0, false).
+		AddItem(table, 0, 1, true).
+		AddItem(statusLine, 1, 0, false)
+
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			app.pages.RemovePage("sample")
+			return nil
+		case tcell.KeyEnter:
+			row, _ := table.GetSelection()
+			if i := row - 1; i >= 0 && i < len(sample.Rows) {
+				app.showRowLocation(sample.Rows[i].Row)
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'n':
+				app.showSample(strconv.Itoa(sample.N), string(sample.Mode), "", columns)
+				return nil
+			case 'S':
+				app.showSamplePrompt()
+				return nil
+			}
+		}
+		return event
+	})
+
+	return flex
+}
+
+// buildSampleTable lays out the sampled rows, one table row each, with the
+// row number and row group fixed on the left
+func buildSampleTable(sample model.Sample) *tview.Table {
+	table := tview.NewTable().
+		SetBorders(false).
+		SetSelectable(true, false).
+		SetFixed(1, 2)
+
+	headers := append([]string{"Row", "RG"}, sample.Columns...)
+	for i, header := range headers {
+		table.SetCell(0, i, tview.NewTableCell(tview.Escape(header)).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false))
+	}
+
+	for i, row := range sample.Rows {
+		table.SetCell(i+1, 0, tview.NewTableCell(fmt.Sprintf("%d", row.Row)))
+		table.SetCell(i+1, 1, tview.NewTableCell(fmt.Sprintf("%d", row.RowGroup)))
+		for col, value := range row.Values {
+			table.SetCell(i+1, col+2, tview.NewTableCell(tview.Escape(value)).SetMaxWidth(30))
+		}
+	}
+
+	if len(sample.Rows) > 0 {
+		table.Select(1, 0)
+	}
+	return table
+}
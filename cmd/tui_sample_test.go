@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// testSample is two rows of a flat column and a repeated one
+func testSample() model.Sample {
+	return model.Sample{
+		Mode:      model.SampleStratified,
+		N:         1,
+		Seed:      42,
+		Columns:   []string{"id", "tags.list.element"},
+		PagesRead: 4,
+		BytesRead: 2048,
+		Rows: []model.SampleRow{
+			{Row: 3, RowGroup: 0, RowIndex: 3, Values: []string{"3", "[a, b]"}},
+			{Row: 17, RowGroup: 1, RowIndex: 7, Values: []string{"17", "[]"}},
+		},
+	}
+}
+
+func Test_TUIApp_showSample(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/sample", r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+		_, _ = w.Write([]byte(`{"Mode":"stratified","N":1,"Seed":42,"Columns":["id"],"Rows":[{"Row":3,"RowGroup":0,"RowIndex":3,"Values":["3"]}],"PagesRead":1,"BytesRead":10}`))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showSample("1", "stratified", "42", []string{"id"})
+	})
+
+	primitive := waitForTUIPage(t, app, "sample")
+	require.IsType(t, &tview.Flex{}, primitive)
+	var summary string
+	queueTUIUpdate(t, app, func() {
+		summary = primitive.(*tview.Flex).GetItem(0).(*tview.TextView).GetText(true)
+	})
+	assert.Contains(t, summary, "Mode: stratified  Seed: 42  Rows: 1")
+	assert.Equal(t, []string{"column=id&mode=stratified&n=1&seed=42"}, queries)
+}
+
+func Test_TUIApp_showSample_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid sample size", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showSample("0", "random", "", nil)
+	})
+
+	primitive := waitForTUIPage(t, app, "sample-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_TUIApp_showSamplePrompt(t *testing.T) {
+	app := NewTUIApp()
+	app.showSamplePrompt()
+	require.True(t, app.pages.HasPage("sample-prompt"))
+
+	_, primitive := app.pages.GetFrontPage()
+	form := primitive.(*tview.Flex).GetItem(1).(*tview.Flex).GetItem(1).(*tview.Form)
+	assert.Equal(t, "10", form.GetFormItemByLabel("Rows").(*tview.InputField).GetText())
+	_, mode := form.GetFormItemByLabel("Mode").(*tview.DropDown).GetCurrentOption()
+	assert.Equal(t, "random", mode)
+}
+
+func Test_buildSampleTable(t *testing.T) {
+	table := buildSampleTable(testSample())
+
+	require.Equal(t, 3, table.GetRowCount())
+	require.Equal(t, 4, table.GetColumnCount())
+	assert.Equal(t, "tags.list.element", table.GetCell(0, 3).Text)
+	assert.Equal(t, "17", table.GetCell(2, 0).Text)
+	assert.Equal(t, "1", table.GetCell(2, 1).Text)
+	assert.Equal(t, "[a, b[]", table.GetCell(1, 3).Text)
+	row, _ := table.GetSelection()
+	assert.Equal(t, 1, row)
+}
+
+func Test_TUIApp_buildSampleView_Keys(t *testing.T) {
+	app := NewTUIApp()
+	flex := app.buildSampleView(testSample(), nil)
+	app.pages.AddPage("sample", flex, true, true)
+
+	flex.InputHandler()(tcell.NewEventKey(tcell.KeyRune, 'S', tcell.ModNone), nil)
+	assert.True(t, app.pages.HasPage("sample-prompt"))
+
+	flex.InputHandler()(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone), nil)
+	assert.False(t, app.pages.HasPage("sample"))
+}
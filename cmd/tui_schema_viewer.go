@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -8,6 +9,8 @@ import (
 	"github.com/atotto/clipboard"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
 )
 
 // schemaViewer encapsulates schema viewing functionality
@@ -56,6 +59,9 @@ func (sv *schemaViewer) handleInput(event *tcell.EventKey) *tcell.EventKey {
 		case 'c', 'C':
 			sv.switchToFormat("csv")
 			return nil
+		case 'v', 'V':
+			sv.switchToFormat("variants")
+			return nil
 		case 'p', 'P':
 			sv.togglePretty()
 			return nil
@@ -104,25 +110,30 @@ func (sv *schemaViewer) updateDisplay() {
 	// Fetch schema from HTTP API
 	var schemaText string
 	var err error
+	ctx := context.Background()
 
 	format := sv.schemaFormats[sv.currentFormat]
 	switch format {
 	case "go":
-		schemaText, err = sv.app.httpClient.getSchemaGo()
+		schemaText, err = sv.app.httpClient.getSchemaGo(ctx)
 	case "csv":
-		schemaText, err = sv.app.httpClient.getSchemaCSV()
+		schemaText, err = sv.app.httpClient.getSchemaCSV(ctx)
 	case "json":
-		schemaText, err = sv.app.httpClient.getSchemaJSON()
+		schemaText, err = sv.app.httpClient.getSchemaJSON(ctx)
 		if err == nil && sv.isPretty {
 			schemaText = sv.formatJSON(schemaText)
 		}
 	case "raw":
-		schemaText, err = sv.app.httpClient.getSchemaRaw()
+		schemaText, err = sv.app.httpClient.getSchemaRaw(ctx)
 		if err == nil && sv.isPretty {
 			schemaText = sv.formatJSON(schemaText)
 		}
+	case "variants":
+		var variants []model.VariantColumn
+		variants, err = sv.app.httpClient.getSchemaVariants(ctx)
+		schemaText = formatVariantColumns(variants)
 	default:
-		schemaText, err = sv.app.httpClient.getSchemaGo()
+		schemaText, err = sv.app.httpClient.getSchemaGo(ctx)
 	}
 
 	if err != nil {
@@ -158,13 +169,15 @@ func (sv *schemaViewer) updateTitle() {
 		if !sv.isPretty {
 			mode = "Compact"
 		}
-		titleText = fmt.Sprintf("[yellow]Schema [%s - %s] | ESC=close, g=go, j=json, r=raw, c=csv, p=pretty/compact, y=copy[-]", strings.ToUpper(format), mode)
+		titleText = fmt.Sprintf("[yellow]Schema [%s - %s] | ESC=close, g=go, j=json, r=raw, c=csv, v=variants, p=pretty/compact, y=copy[-]", strings.ToUpper(format), mode)
 	case "go":
-		titleText = "[yellow]Schema [Go Struct] | ESC=close, g=go, j=json, r=raw, c=csv, y=copy[-]"
+		titleText = "[yellow]Schema [Go Struct] | ESC=close, g=go, j=json, r=raw, c=csv, v=variants, y=copy[-]"
 	case "csv":
-		titleText = "[yellow]Schema [CSV] | ESC=close, g=go, j=json, r=raw, c=csv, y=copy[-]"
+		titleText = "[yellow]Schema [CSV] | ESC=close, g=go, j=json, r=raw, c=csv, v=variants, y=copy[-]"
+	case "variants":
+		titleText = "[yellow]Schema [Variants] | ESC=close, g=go, j=json, r=raw, c=csv, v=variants, y=copy[-]"
 	default:
-		titleText = fmt.Sprintf("[yellow]Schema [%s] | ESC=close, g=go, j=json, r=raw, c=csv, y=copy[-]", strings.ToUpper(format))
+		titleText = fmt.Sprintf("[yellow]Schema [%s] | ESC=close, g=go, j=json, r=raw, c=csv, v=variants, y=copy[-]", strings.ToUpper(format))
 	}
 
 	sv.titleBar.SetText(titleText)
@@ -28,6 +28,9 @@ func newTestSchemaViewer(t *testing.T) (*schemaViewer, *httptest.Server) {
 		case "/schema/csv":
 			w.Header().Set("Content-Type", "text/csv")
 			_, _ = w.Write([]byte("name,type\nid,INT32\n"))
+		case "/schema/variants":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"Path":"v","Shredded":true,"Columns":[{"ColumnIndex":1,"Path":"v.typed_value","Role":"shredded"}]}]`))
 		default:
 			http.NotFound(w, r)
 		}
@@ -38,7 +41,7 @@ func newTestSchemaViewer(t *testing.T) (*schemaViewer, *httptest.Server) {
 
 	return &schemaViewer{
 		app:           app,
-		schemaFormats: []string{"json", "raw", "go", "csv"},
+		schemaFormats: []string{"json", "raw", "go", "csv", "variants"},
 		currentFormat: 0,
 		isPretty:      true,
 		textView:      tview.NewTextView().SetDynamicColors(true),
@@ -109,6 +112,13 @@ func Test_schemaViewer_handleInput(t *testing.T) {
 	require.Nil(t, result)
 	assert.NotEqual(t, wasPretty, viewer.isPretty)
 
+	result = viewer.handleInput(tcell.NewEventKey(tcell.KeyRune, 'v', tcell.ModNone))
+	require.Nil(t, result)
+	assert.Equal(t, 4, viewer.currentFormat)
+	assert.Contains(t, viewer.textView.GetText(true), "v (VARIANT, shredded)")
+	assert.Contains(t, viewer.textView.GetText(true), "[shredded] #1    v.typed_value")
+	assert.Contains(t, viewer.titleBar.GetText(false), "Schema [Variants]")
+
 	unknown := tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone)
 	assert.Same(t, unknown, viewer.handleInput(unknown))
 
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// sparklineBlocks are the block characters of a level histogram sparkline,
+// from lowest to highest
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// levelSparkline draws a level histogram as one block per level, scaled to
+// the largest bucket. Empty buckets are drawn as a space.
+func levelSparkline(histogram []int64) string {
+	var maxCount int64
+	for _, count := range histogram {
+		maxCount = max(maxCount, count)
+	}
+	if maxCount <= 0 {
+		return ""
+	}
+
+	var spark strings.Builder
+	for _, count := range histogram {
+		if count <= 0 {
+			spark.WriteRune(' ')
+			continue
+		}
+		idx := int((count*int64(len(sparklineBlocks)) - 1) / maxCount)
+		spark.WriteRune(sparklineBlocks[idx])
+	}
+	return spark.String()
+}
+
+// formatSizeStatistics renders size statistics on one line: unencoded byte
+// array size and the level histograms as sparklines with their counts
+func formatSizeStatistics(stats *model.SizeStatistics) string {
+	if stats == nil {
+		return ""
+	}
+
+	var parts []string
+	if stats.UnencodedByteArrayDataBytes != nil {
+		parts = append(parts, fmt.Sprintf("[yellow]Unencoded:[-] %s", model.FormatBytes(*stats.UnencodedByteArrayDataBytes)))
+	}
+	if len(stats.RepetitionLevelHistogram) > 0 {
+		parts = append(parts, fmt.Sprintf("[yellow]Rep Levels:[-] [green]%s[-] %v",
+			levelSparkline(stats.RepetitionLevelHistogram), stats.RepetitionLevelHistogram))
+	}
+	if len(stats.DefinitionLevelHistogram) > 0 {
+		parts = append(parts, fmt.Sprintf("[yellow]Def Levels:[-] [green]%s[-] %v",
+			levelSparkline(stats.DefinitionLevelHistogram), stats.DefinitionLevelHistogram))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// formatSizeStatisticsCell renders size statistics compactly for a table
+// cell, as R and D sparklines
+func formatSizeStatisticsCell(stats *model.SizeStatistics) string {
+	if stats == nil {
+		return "-"
+	}
+
+	var parts []string
+	if spark := levelSparkline(stats.RepetitionLevelHistogram); spark != "" {
+		parts = append(parts, "R "+spark)
+	}
+	if spark := levelSparkline(stats.DefinitionLevelHistogram); spark != "" {
+		parts = append(parts, "D "+spark)
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+func Test_levelSparkline(t *testing.T) {
+	tests := []struct {
+		name      string
+		histogram []int64
+		expected  string
+	}{
+		{"Nil", nil, ""},
+		{"All zero", []int64{0, 0}, ""},
+		{"Scaled", []int64{1, 4, 8}, "▁▄█"},
+		{"Empty bucket", []int64{0, 5}, " █"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, levelSparkline(tt.histogram))
+		})
+	}
+}
+
+func Test_formatSizeStatistics(t *testing.T) {
+	require.Empty(t, formatSizeStatistics(nil))
+
+	bytes := int64(2048)
+	text := formatSizeStatistics(&model.SizeStatistics{
+		UnencodedByteArrayDataBytes: &bytes,
+		RepetitionLevelHistogram:    []int64{3, 1},
+		DefinitionLevelHistogram:    []int64{1, 3},
+	})
+	require.Contains(t, text, "Unencoded:[-] 2.0 KB")
+	require.Contains(t, text, "Rep Levels:[-] [green]█▃[-] [3 1]")
+	require.Contains(t, text, "Def Levels:[-] [green]▃█[-] [1 3]")
+}
+
+func Test_formatSizeStatisticsCell(t *testing.T) {
+	bytes := int64(10)
+	require.Equal(t, "-", formatSizeStatisticsCell(nil))
+	require.Equal(t, "-", formatSizeStatisticsCell(&model.SizeStatistics{UnencodedByteArrayDataBytes: &bytes}))
+	require.Equal(t, "D ▃█", formatSizeStatisticsCell(&model.SizeStatistics{DefinitionLevelHistogram: []int64{1, 3}}))
+	require.Equal(t, "R █▃ D ▃█", formatSizeStatisticsCell(&model.SizeStatistics{
+		RepetitionLevelHistogram: []int64{3, 1},
+		DefinitionLevelHistogram: []int64{1, 3},
+	}))
+}
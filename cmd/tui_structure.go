@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// maxStructureRowsShown caps each list of runs, miniblocks or lengths in the
+// page structure popup
+const maxStructureRowsShown = 100
+
+// showPageStructure shows the encoding structure of a page in a popup
+func (app *TUIApp) showPageStructure(rgIndex, colIndex, pageIndex int) {
+	loadingModal := tview.NewModal().
+		SetText("Decoding page structure...\n\nPlease wait...\n\nPress ESC to cancel").
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("structure-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("structure-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		structure, err := app.httpClient.getPageStructure(ctx, rgIndex, colIndex, pageIndex)
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage("structure-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error reading page structure:\n%v\n\nPress ESC to go back", err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage("structure-error")
+					})
+				app.pages.AddPage("structure-error", errorModal, true, true)
+				return
+			}
+
+			structureView := tview.NewTextView().
+				SetDynamicColors(true).
+				SetScrollable(true).
+				SetWrap(false).
+				SetText(buildPageStructureText(structure))
+
+			structureView.SetBorder(true).
+				SetTitle(fmt.Sprintf(" Page Structure - RG %d, Column %d, Page %d (↑↓ to scroll, ESC to close) ",
+					rgIndex, colIndex, pageIndex)).
+				SetTitleAlign(tview.AlignLeft)
+
+			structureView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+				if event.Key() == tcell.KeyEscape {
+					app.pages.RemovePage("structure")
+					return nil
+				}
+				return event
+			})
+
+			app.pages.AddPage("structure", structureView, true, true)
+			app.tviewApp.SetFocus(structureView)
+		})
+	}()
+}
+
+// buildPageStructureText renders a page structure as tview-colored text
+func buildPageStructureText(structure model.PageStructure) string {
+	var text strings.Builder
+
+	_, _ = fmt.Fprintf(&text, "[yellow]Column:[-] %s  [yellow]Type:[-] %s  [yellow]Page Type:[-] %s\n",
+		structure.Path, structure.PhysicalType, structure.PageType)
+	_, _ = fmt.Fprintf(&text, "[yellow]Uncompressed Size:[-] %d bytes  [yellow]Values:[-] %d (%d non-null)\n",
+		structure.UncompressedSize, structure.NumValues, structure.NumNonNull)
+
+	if structure.RepetitionLevels != nil {
+		writeEncodedSection(&text, "Repetition Levels", *structure.RepetitionLevels)
+	}
+	if structure.DefinitionLevels != nil {
+		writeEncodedSection(&text, "Definition Levels", *structure.DefinitionLevels)
+	}
+	writeEncodedSection(&text, "Values", structure.Values)
+
+	return text.String()
+}
+
+// writeEncodedSection writes one encoded section and its structure
+func writeEncodedSection(text *strings.Builder, title string, section model.EncodedSection) {
+	_, _ = fmt.Fprintf(text, "\n[yellow]%s[-] %s, %d bytes at offset %d, %d values",
+		title, section.Encoding, section.ByteLength, section.Offset, section.NumValues)
+	if section.MaxLevel > 0 {
+		_, _ = fmt.Fprintf(text, ", max level %d", section.MaxLevel)
+	}
+	if section.BitWidth > 0 {
+		_, _ = fmt.Fprintf(text, ", bit width %d", section.BitWidth)
+	}
+	text.WriteString("\n")
+	if section.Error != "" {
+		_, _ = fmt.Fprintf(text, "  [red]Decoding stopped: %s[-]\n", tview.Escape(section.Error))
+	}
+
+	if len(section.Runs) > 0 {
+		_, _ = fmt.Fprintf(text, "  %-5s %-10s %8s %6s %8s %s\n", "Run", "Type", "Offset", "Bytes", "Values", "RLE Value")
+		for i, run := range section.Runs[:min(len(section.Runs), maxStructureRowsShown)] {
+			value := "-"
+			if run.Type == "RLE" {
+				value = fmt.Sprintf("%d", run.Value)
+			}
+			_, _ = fmt.Fprintf(text, "  %-5d %-10s %8d %6d %8d %s\n", i, run.Type, run.Offset, run.ByteLength, run.Count, value)
+		}
+		writeStructureMore(text, len(section.Runs))
+	}
+
+	if section.DeltaBinary != nil {
+		writeDeltaStream(text, "Deltas", *section.DeltaBinary)
+	}
+
+	if layout := section.DeltaLength; layout != nil {
+		writeDeltaStream(text, "Lengths", layout.LengthStream)
+		_, _ = fmt.Fprintf(text, "  [green]Data:[-] %d bytes at offset %d\n", layout.DataByteLength, layout.DataOffset)
+		writeStructureLengths(text, "Lengths", layout.Lengths)
+	}
+
+	if layout := section.DeltaByteArray; layout != nil {
+		writeDeltaStream(text, "Prefix lengths", layout.PrefixStream)
+		writeDeltaStream(text, "Suffix lengths", layout.SuffixStream)
+		_, _ = fmt.Fprintf(text, "  [green]Suffix data:[-] %d bytes at offset %d\n", layout.DataByteLength, layout.DataOffset)
+		_, _ = fmt.Fprintf(text, "  %-6s %8s %8s\n", "Value", "Prefix", "Suffix")
+		for i := 0; i < min(len(layout.PrefixLengths), maxStructureRowsShown); i++ {
+			suffix := "-"
+			if i < len(layout.SuffixLengths) {
+				suffix = fmt.Sprintf("%d", layout.SuffixLengths[i])
+			}
+			_, _ = fmt.Fprintf(text, "  %-6d %8d %8s\n", i, layout.PrefixLengths[i], suffix)
+		}
+		writeStructureMore(text, len(layout.PrefixLengths))
+	}
+
+	if layout := section.ByteStreamSplit; layout != nil {
+		_, _ = fmt.Fprintf(text, "  [green]%d values of %d bytes[-]\n", layout.NumValues, layout.TypeSize)
+		_, _ = fmt.Fprintf(text, "  %-6s %8s %6s %s\n", "Stream", "Offset", "Bytes", "Distinct Bytes")
+		for _, stream := range layout.Streams {
+			_, _ = fmt.Fprintf(text, "  %-6d %8d %6d %d\n", stream.Index, stream.Offset, stream.ByteLength, stream.DistinctBytes)
+		}
+	}
+}
+
+// writeDeltaStream writes the header and miniblocks of a DELTA_BINARY_PACKED stream
+func writeDeltaStream(text *strings.Builder, title string, stream model.DeltaBinaryPacked) {
+	_, _ = fmt.Fprintf(text, "  [green]%s:[-] %d bytes at offset %d, block size %d, %d miniblocks per block, %d values, first value %d\n",
+		title, stream.ByteLength, stream.Offset, stream.BlockSize, stream.MiniblocksPerBlock, stream.TotalValues, stream.FirstValue)
+	_, _ = fmt.Fprintf(text, "  %-5s %10s %9s %8s %9s %6s %s\n", "Block", "Min Delta", "Miniblock", "Offset", "Bit Width", "Bytes", "Values")
+	shown, total := 0, 0
+	for b, block := range stream.Blocks {
+		for m, mb := range block.Miniblocks {
+			total++
+			if shown < maxStructureRowsShown {
+				shown++
+				_, _ = fmt.Fprintf(text, "  %-5d %10d %9d %8d %9d %6d %d\n", b, block.MinDelta, m, mb.Offset, mb.BitWidth, mb.ByteLength, mb.Count)
+			}
+		}
+	}
+	writeStructureMore(text, total)
+}
+
+// writeStructureLengths writes per-value lengths on one line
+func writeStructureLengths(text *strings.Builder, title string, lengths []int64) {
+	parts := make([]string, 0, min(len(lengths), maxStructureRowsShown))
+	for _, length := range lengths[:min(len(lengths), maxStructureRowsShown)] {
+		parts = append(parts, fmt.Sprintf("%d", length))
+	}
+	_, _ = fmt.Fprintf(text, "  %s: %s\n", title, strings.Join(parts, " "))
+	writeStructureMore(text, len(lengths))
+}
+
+// writeStructureMore notes how many rows were left out
+func writeStructureMore(text *strings.Builder, total int) {
+	if total > maxStructureRowsShown {
+		_, _ = fmt.Fprintf(text, "  [gray]... %d more[-]\n", total-maxStructureRowsShown)
+	}
+}
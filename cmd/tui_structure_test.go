@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+func Test_TUIApp_showPageStructure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rowgroups/0/columnchunks/1/pages/2/structure", r.URL.Path)
+		_, _ = w.Write([]byte(`{"Path":"id","PhysicalType":"INT32","PageType":"DATA_PAGE","NumValues":2,"NumNonNull":2,` +
+			`"Values":{"Encoding":"RLE_DICTIONARY","BitWidth":1,"Runs":[{"Type":"RLE","Offset":1,"ByteLength":2,"Count":2,"Value":1}]}}`))
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showPageStructure(0, 1, 2)
+	})
+
+	primitive := waitForTUIPage(t, app, "structure")
+	require.IsType(t, &tview.TextView{}, primitive)
+	var text string
+	var hasLoading bool
+	queueTUIUpdate(t, app, func() {
+		text = primitive.(*tview.TextView).GetText(true)
+		hasLoading = app.pages.HasPage("structure-loading")
+	})
+	assert.Contains(t, text, "RLE_DICTIONARY")
+	assert.False(t, hasLoading)
+}
+
+func Test_TUIApp_showPageStructure_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "broken", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	app := NewTUIApp()
+	app.httpClient = newParquetClient(server.URL)
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showPageStructure(0, 0, 0)
+	})
+
+	primitive := waitForTUIPage(t, app, "structure-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_buildPageStructureText(t *testing.T) {
+	runs := make([]model.HybridRun, maxStructureRowsShown+3)
+	for i := range runs {
+		runs[i] = model.HybridRun{Type: "BIT_PACKED", Count: 8}
+	}
+	deltas := model.DeltaBinaryPacked{
+		BlockSize: 128, MiniblocksPerBlock: 4, TotalValues: 3, FirstValue: 5,
+		Blocks: []model.DeltaBlock{{MinDelta: -2, Miniblocks: []model.DeltaMiniblock{{Offset: 10, BitWidth: 3, ByteLength: 12, Count: 2}}}},
+	}
+	structure := model.PageStructure{
+		Path:             "a.b",
+		PhysicalType:     "BYTE_ARRAY",
+		PageType:         "DATA_PAGE_V2",
+		NumValues:        3,
+		NumNonNull:       2,
+		RepetitionLevels: &model.EncodedSection{Encoding: "RLE", MaxLevel: 1, BitWidth: 1, Runs: runs},
+		DefinitionLevels: &model.EncodedSection{Encoding: "RLE", MaxLevel: 2, BitWidth: 2, Error: "page data truncated"},
+		Values: model.EncodedSection{
+			Encoding: "DELTA_BYTE_ARRAY",
+			DeltaByteArray: &model.DeltaByteArray{
+				PrefixStream:  deltas,
+				SuffixStream:  deltas,
+				PrefixLengths: []int64{0, 4},
+				SuffixLengths: []int64{6, 2},
+			},
+		},
+	}
+
+	text := buildPageStructureText(structure)
+	for _, s := range []string{
+		"Column:[-] a.b", "2 non-null", "Repetition Levels[-] RLE", "max level 1", "... 3 more",
+		"Decoding stopped: page data truncated", "Prefix lengths:[-]", "first value 5", "Suffix data:[-]",
+	} {
+		assert.Contains(t, text, s)
+	}
+
+	structure.Values = model.EncodedSection{
+		Encoding:        "BYTE_STREAM_SPLIT",
+		ByteStreamSplit: &model.ByteStreamSplit{TypeSize: 4, NumValues: 2, Streams: []model.ByteStream{{Index: 0, ByteLength: 2, DistinctBytes: 1}}},
+	}
+	text = buildPageStructureText(structure)
+	assert.Contains(t, text, "2 values of 4 bytes")
+
+	structure.Values = model.EncodedSection{
+		Encoding:    "DELTA_LENGTH_BYTE_ARRAY",
+		DeltaLength: &model.DeltaLengthByteArray{LengthStream: deltas, Lengths: []int64{3, 4}, DataByteLength: 7},
+	}
+	text = buildPageStructureText(structure)
+	assert.Contains(t, text, "Lengths: 3 4")
+}
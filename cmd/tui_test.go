@@ -19,7 +19,7 @@ func Test_startHTTPServer_InvalidFile(t *testing.T) {
 	resultChan := make(chan serverResult, 1)
 
 	// Start server with invalid file
-	go startHTTPServer(ctx, "nonexistent.parquet", pio.ReadOption{}, resultChan)
+	go startHTTPServer(ctx, "nonexistent.parquet", pio.ReadOption{}, viewSettings{}, resultChan)
 
 	// Wait for result
 	select {
@@ -41,7 +41,7 @@ func Test_startHTTPServer_Cancellation(t *testing.T) {
 	cancel()
 
 	// Start server with cancelled context
-	go startHTTPServer(ctx, "test.parquet", pio.ReadOption{}, resultChan)
+	go startHTTPServer(ctx, "test.parquet", pio.ReadOption{}, viewSettings{}, resultChan)
 
 	// Should return quickly without sending to channel or send error
 	select {
@@ -62,7 +62,7 @@ func Test_startHTTPServer_RealFile(t *testing.T) {
 	testFileURL := "https://github.com/hangxie/parquet-tools/raw/refs/heads/main/testdata/good.parquet"
 
 	// Start server with real file
-	go startHTTPServer(ctx, testFileURL, pio.ReadOption{}, resultChan)
+	go startHTTPServer(ctx, testFileURL, pio.ReadOption{}, viewSettings{}, resultChan)
 
 	// Wait for result
 	select {
@@ -137,16 +137,19 @@ func Test_TUICmd_Run_ShowsMainView(t *testing.T) {
 				"CreatedBy": "test-writer"
 			}`))
 		case "/rowgroups":
-			_, _ = w.Write([]byte(`[
-				{
-					"Index": 0,
-					"NumRows": 1000,
-					"NumColumns": 2,
-					"CompressedSize": 512,
-					"UncompressedSize": 1024,
-					"CompressionRatio": 2.0
-				}
-			]`))
+			_, _ = w.Write([]byte(`{
+				"RowGroups": [
+					{
+						"Index": 0,
+						"NumRows": 1000,
+						"NumColumns": 2,
+						"CompressedSize": 512,
+						"UncompressedSize": 1024,
+						"CompressionRatio": 2.0
+					}
+				],
+				"Total": 1
+			}`))
 		default:
 			http.NotFound(w, r)
 		}
@@ -154,7 +157,7 @@ func Test_TUICmd_Run_ShowsMainView(t *testing.T) {
 	defer server.Close()
 
 	app := newTUIRunAppForTest(t)
-	withTUIRunHooks(t, app, func(ctx context.Context, uri string, readOpt pio.ReadOption, resultChan chan<- serverResult) {
+	withTUIRunHooks(t, app, func(ctx context.Context, uri string, readOpt pio.ReadOption, settings viewSettings, resultChan chan<- serverResult) {
 		select {
 		case <-ctx.Done():
 		case resultChan <- serverResult{serverURL: server.URL}:
@@ -189,7 +192,7 @@ func Test_TUICmd_Run_ShowsMainView(t *testing.T) {
 func Test_TUICmd_Run_ShowsStartupError(t *testing.T) {
 	app := newTUIRunAppForTest(t)
 	startErr := errors.New("startup failed")
-	withTUIRunHooks(t, app, func(ctx context.Context, uri string, readOpt pio.ReadOption, resultChan chan<- serverResult) {
+	withTUIRunHooks(t, app, func(ctx context.Context, uri string, readOpt pio.ReadOption, settings viewSettings, resultChan chan<- serverResult) {
 		select {
 		case <-ctx.Done():
 		case resultChan <- serverResult{err: startErr}:
@@ -211,7 +214,7 @@ func Test_TUICmd_Run_ShowsStartupError(t *testing.T) {
 
 func Test_TUICmd_Run_CancelOnEscape(t *testing.T) {
 	app := newTUIRunAppForTest(t)
-	withTUIRunHooks(t, app, func(ctx context.Context, uri string, readOpt pio.ReadOption, resultChan chan<- serverResult) {
+	withTUIRunHooks(t, app, func(ctx context.Context, uri string, readOpt pio.ReadOption, settings viewSettings, resultChan chan<- serverResult) {
 		<-ctx.Done()
 	})
 
@@ -239,7 +242,7 @@ func newTUIRunAppForTest(t *testing.T) *TUIApp {
 func withTUIRunHooks(
 	t *testing.T,
 	app *TUIApp,
-	startServer func(context.Context, string, pio.ReadOption, chan<- serverResult),
+	startServer func(context.Context, string, pio.ReadOption, viewSettings, chan<- serverResult),
 ) {
 	t.Helper()
 
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// maxHexDumpBytes caps the hex dump in the value detail popup, the copied
+// hex string is always complete
+const maxHexDumpBytes = 64 * 1024
+
+// copyToClipboard is swapped in tests, the system clipboard is not
+// available everywhere
+var copyToClipboard = clipboard.WriteAll
+
+// showValueDetail fetches a value in full and shows it in a popup, the fetch
+// is cancelled when the loading popup is closed
+func (app *TUIApp) showValueDetail(title string, fetch func(ctx context.Context) (model.ValueDetail, error)) {
+	loadingModal := tview.NewModal().
+		SetText("Loading value...\n\nPlease wait...\n\nPress ESC to cancel").
+		SetTextColor(tcell.ColorYellow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	loadingModal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape {
+			cancel()
+			app.pages.RemovePage("value-loading")
+			return nil
+		}
+		return event
+	})
+
+	app.pages.AddPage("value-loading", loadingModal, true, true)
+
+	go func() {
+		defer cancel()
+
+		detail, err := fetch(ctx)
+
+		// Cancelled by the user, the loading modal is already gone
+		if ctx.Err() != nil {
+			return
+		}
+
+		app.tviewApp.QueueUpdateDraw(func() {
+			app.pages.RemovePage("value-loading")
+
+			if err != nil {
+				errorModal := tview.NewModal().
+					SetText(fmt.Sprintf("Error reading value:\n%v\n\nPress ESC to go back", err)).
+					SetTextColor(tcell.ColorRed).
+					AddButtons([]string{"OK"}).
+					SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+						app.pages.RemovePage("value-error")
+					})
+				app.pages.AddPage("value-error", errorModal, true, true)
+				return
+			}
+
+			app.pages.AddPage("value", app.buildValueDetailView(title, detail), true, true)
+		})
+	}()
+}
+
+// buildValueDetailView lays out a value with a status line for copy results
+func (app *TUIApp) buildValueDetailView(title string, detail model.ValueDetail) *tview.Flex {
+	valueView := tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWrap(true).
+		SetText(buildValueDetailText(detail))
+
+	valueView.SetBorder(true).
+		SetTitle(fmt.Sprintf(" %s (↑↓ to scroll, ESC to close) ", title)).
+		SetTitleAlign(tview.AlignLeft)
+
+	statusLine := tview.NewTextView().
+		SetDynamicColors(true).
+		SetText(" [yellow]Keys:[-] ESC=close, ↑↓=scroll, y=copy value, h=copy hex, b=copy base64")
+
+	flex := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(valueView, 0, 1, true).
+		AddItem(statusLine, 1, 0, false)
+
+	flex.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			app.pages.RemovePage("value")
+			return nil
+		case tcell.KeyRune:
+			var name, text string
+			switch event.Rune() {
+			case 'y':
+				name, text = "value", detail.Formatted
+			case 'h':
+				name, text = "hex", detail.Hex
+			case 'b':
+				name, text = "base64", detail.Base64
+			default:
+				return event
+			}
+			if err := copyToClipboard(text); err != nil {
+				statusLine.SetText(fmt.Sprintf(" [red]Failed to copy: %v[-]", err))
+			} else {
+				statusLine.SetText(fmt.Sprintf(" [green]Copied %s to clipboard![-]", name))
+			}
+			return nil
+		}
+		return event
+	})
+
+	return flex
+}
+
+// buildValueDetailText renders a value in full as tview-colored text
+func buildValueDetailText(detail model.ValueDetail) string {
+	var text strings.Builder
+
+	_, _ = fmt.Fprintf(&text, "[yellow]Kind:[-] %s  ", detail.Kind)
+	if detail.Decoder != "" {
+		_, _ = fmt.Fprintf(&text, "[yellow]Decoder:[-] %s  ", detail.Decoder)
+	}
+	if detail.Null {
+		text.WriteString("[yellow]Value:[-] NULL\n")
+		return text.String()
+	}
+	_, _ = fmt.Fprintf(&text, "[yellow]Size:[-] %d bytes\n", detail.Size)
+	if detail.DecodeError != "" {
+		_, _ = fmt.Fprintf(&text, "[red]Not decoded as %s: %s[-]\n", detail.Decoder, tview.Escape(detail.DecodeError))
+	}
+
+	_, _ = fmt.Fprintf(&text, "\n[yellow]Value[-]\n%s\n", tview.Escape(detail.Formatted))
+	if detail.WKT != "" {
+		_, _ = fmt.Fprintf(&text, "\n[yellow]WKT[-]\n%s\n", tview.Escape(detail.WKT))
+	}
+
+	raw, _ := hex.DecodeString(detail.Hex)
+	text.WriteString("\n[yellow]Hex[-]\n")
+	text.WriteString(tview.Escape(hex.Dump(raw[:min(len(raw), maxHexDumpBytes)])))
+	if len(raw) > maxHexDumpBytes {
+		_, _ = fmt.Fprintf(&text, "[gray]... %d more bytes[-]\n", len(raw)-maxHexDumpBytes)
+	}
+
+	_, _ = fmt.Fprintf(&text, "\n[yellow]Base64[-]\n%s\n", detail.Base64)
+	return text.String()
+}
+
+// statisticForKey maps m to the min statistic and M to the max one
+func statisticForKey(key rune) string {
+	if key == 'M' {
+		return model.StatisticMax
+	}
+	return model.StatisticMin
+}
+
+// statisticLabel is the title-cased name of a statistic
+func statisticLabel(stat string) string {
+	if stat == model.StatisticMax {
+		return "Max"
+	}
+	return "Min"
+}
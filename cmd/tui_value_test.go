@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+func Test_TUIApp_showValueDetail(t *testing.T) {
+	app := NewTUIApp()
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showValueDetail("Value 1", func(context.Context) (model.ValueDetail, error) {
+			return model.ValueDetail{Kind: model.ValueKindJSON, Formatted: "{\n  \"a\": 1\n}", Size: 7, Hex: "7b2261223a317d", Base64: "eyJhIjoxfQ=="}, nil
+		})
+	})
+
+	primitive := waitForTUIPage(t, app, "value")
+	require.IsType(t, &tview.Flex{}, primitive)
+	var text string
+	var hasLoading bool
+	queueTUIUpdate(t, app, func() {
+		text = primitive.(*tview.Flex).GetItem(0).(*tview.TextView).GetText(true)
+		hasLoading = app.pages.HasPage("value-loading")
+	})
+	assert.Contains(t, text, "\"a\": 1")
+	assert.False(t, hasLoading)
+}
+
+func Test_TUIApp_showValueDetail_Error(t *testing.T) {
+	app := NewTUIApp()
+	stop := startTUIAppForTest(t, app)
+	defer stop()
+
+	queueTUIUpdate(t, app, func() {
+		app.showValueDetail("Value 1", func(context.Context) (model.ValueDetail, error) {
+			return model.ValueDetail{}, errors.New("broken")
+		})
+	})
+
+	primitive := waitForTUIPage(t, app, "value-error")
+	require.IsType(t, &tview.Modal{}, primitive)
+}
+
+func Test_TUIApp_buildValueDetailView_Copy(t *testing.T) {
+	var copied []string
+	original := copyToClipboard
+	copyToClipboard = func(text string) error {
+		if text == "fail" {
+			return errors.New("no clipboard")
+		}
+		copied = append(copied, text)
+		return nil
+	}
+	defer func() { copyToClipboard = original }()
+
+	app := NewTUIApp()
+	flex := app.buildValueDetailView("Value", model.ValueDetail{Formatted: "v", Hex: "6869", Base64: "fail"})
+	capture := flex.GetInputCapture()
+	status := flex.GetItem(1).(*tview.TextView)
+
+	require.Nil(t, capture(tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone)))
+	require.Nil(t, capture(tcell.NewEventKey(tcell.KeyRune, 'h', tcell.ModNone)))
+	require.Equal(t, []string{"v", "6869"}, copied)
+	require.Contains(t, status.GetText(true), "Copied hex")
+
+	require.Nil(t, capture(tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone)))
+	require.Contains(t, status.GetText(true), "Failed to copy: no clipboard")
+
+	// Other keys are passed through
+	require.NotNil(t, capture(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone)))
+}
+
+func Test_buildValueDetailText(t *testing.T) {
+	text := buildValueDetailText(model.ValueDetail{Kind: model.ValueKindScalar, Null: true, Formatted: "NULL"})
+	require.Equal(t, "[yellow]Kind:[-] scalar  [yellow]Value:[-] NULL\n", text)
+
+	text = buildValueDetailText(model.ValueDetail{
+		Kind: model.ValueKindGeometry, Formatted: "[1]", WKT: "POINT (0 0)", Size: 2, Hex: "0102", Base64: "AQI=",
+	})
+	require.Contains(t, text, "[yellow]Size:[-] 2 bytes")
+	require.Contains(t, text, "[1[]")
+	require.Contains(t, text, "POINT (0 0)")
+	require.Contains(t, text, "00000000  01 02")
+	require.Contains(t, text, "AQI=")
+
+	text = buildValueDetailText(model.ValueDetail{Kind: model.ValueKindBinary, Decoder: "msgpack", Formatted: "{}", Size: 1})
+	require.Contains(t, text, "[yellow]Decoder:[-] msgpack")
+	require.NotContains(t, text, "Not decoded")
+
+	text = buildValueDetailText(model.ValueDetail{Kind: model.ValueKindBinary, Decoder: "cbor", DecodeError: "bad [tag]", Size: 1})
+	require.Contains(t, text, "[red]Not decoded as cbor: bad [tag[]")
+
+	big := strings.Repeat("00", maxHexDumpBytes+5)
+	require.Contains(t, buildValueDetailText(model.ValueDetail{Hex: big}), "... 5 more bytes")
+}
+
+func Test_statisticForKey(t *testing.T) {
+	require.Equal(t, model.StatisticMin, statisticForKey('m'))
+	require.Equal(t, model.StatisticMax, statisticForKey('M'))
+	require.Equal(t, "Min", statisticLabel(model.StatisticMin))
+	require.Equal(t, "Max", statisticLabel(model.StatisticMax))
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// formatVariantColumns lists the leaf columns of each VARIANT with their
+// role, shredded columns highlighted
+func formatVariantColumns(variants []model.VariantColumn) string {
+	if len(variants) == 0 {
+		return "[gray]No VARIANT columns[-]"
+	}
+
+	var text strings.Builder
+	for i, variant := range variants {
+		if i > 0 {
+			text.WriteString("\n")
+		}
+		kind := "unshredded"
+		if variant.Shredded {
+			kind = "shredded"
+		}
+		_, _ = fmt.Fprintf(&text, "[yellow]%s[-] (VARIANT, %s)\n", tview.Escape(variant.Path), kind)
+		for _, col := range variant.Columns {
+			color := "white"
+			if col.Role == model.VariantRoleShredded {
+				color = "green"
+			}
+			role := tview.Escape(fmt.Sprintf("%-10s", "["+col.Role+"]"))
+			_, _ = fmt.Fprintf(&text, "  [%s]%s[-] #%-4d %s\n", color, role, col.ColumnIndex, tview.Escape(col.Path))
+		}
+	}
+	return text.String()
+}
+
+// formatVariantMember renders the VARIANT a column belongs to on one line, or
+// an empty string when there is none
+func formatVariantMember(variant *model.VariantMember) string {
+	if variant == nil {
+		return ""
+	}
+	text := fmt.Sprintf("[yellow]Variant:[-] %s  [yellow]Role:[-] %s", tview.Escape(variant.Path), variant.Role)
+	if variant.Shredded {
+		text += "  (shredded)"
+	}
+	return text
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+func Test_formatVariantColumns(t *testing.T) {
+	require.Equal(t, "[gray]No VARIANT columns[-]", formatVariantColumns(nil))
+
+	text := formatVariantColumns([]model.VariantColumn{
+		{Path: "a", Columns: []model.VariantLeaf{
+			{ColumnIndex: 0, Path: "a.metadata", Role: model.VariantRoleMetadata},
+			{ColumnIndex: 1, Path: "a.value", Role: model.VariantRoleValue},
+		}},
+		{Path: "b", Shredded: true, Columns: []model.VariantLeaf{
+			{ColumnIndex: 7, Path: "b.typed_value", Role: model.VariantRoleShredded},
+		}},
+	})
+	require.Equal(t, "[yellow]a[-] (VARIANT, unshredded)\n"+
+		"  [white][metadata[][-] #0    a.metadata\n"+
+		"  [white][value[]   [-] #1    a.value\n"+
+		"\n"+
+		"[yellow]b[-] (VARIANT, shredded)\n"+
+		"  [green][shredded[][-] #7    b.typed_value\n", text)
+}
+
+func Test_formatVariantMember(t *testing.T) {
+	require.Equal(t, "", formatVariantMember(nil))
+	require.Equal(t, "[yellow]Variant:[-] v  [yellow]Role:[-] value",
+		formatVariantMember(&model.VariantMember{Path: "v", Role: model.VariantRoleValue}))
+	require.Equal(t, "[yellow]Variant:[-] v  [yellow]Role:[-] shredded  (shredded)",
+		formatVariantMember(&model.VariantMember{Path: "v", Role: model.VariantRoleShredded, Shredded: true}))
+}
@@ -15,6 +15,11 @@ type WebUICmd struct {
 	Addr    string `short:"a" default:"" help:"Address to listen on (default: random port)."`
 	KeyFile string `name:"key-file" group:"Encryption" help:"path to a JSON file with {footer_key, aad_prefix, column_keys}. CLI flags override file values." default:""`
 	pio.ReadOption
+	DisplayOption
+	CacheOption
+	TimeoutOption
+	PrefetchOption
+	PoolOption
 }
 
 // Run starts the Web UI server
@@ -22,6 +27,14 @@ func (w WebUICmd) Run() error {
 	if err := loadKeyFile(w.KeyFile, &w.ReadOption); err != nil {
 		return err
 	}
+	settings, err := w.settings()
+	if err != nil {
+		return err
+	}
+	settings.cache = w.cacheOptions()
+	settings.requestTimeout = w.RequestTimeout
+	settings.prefetch = w.prefetchOptions()
+	settings.readers = w.Readers
 	// Set version getter for web UI
 	service.SetVersionGetter(GetVersion)
 
@@ -31,6 +44,9 @@ func (w WebUICmd) Run() error {
 		return fmt.Errorf("failed to create service: %w", err)
 	}
 	defer func() { _ = svc.Close() }()
+	if err := settings.apply(svc); err != nil {
+		return err
+	}
 
 	// If no address specified, find a random available port
 	addr := w.Addr
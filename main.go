@@ -14,6 +14,7 @@ var cli struct {
 	TUI     cmd.TUICmd     `cmd:"" help:"Browse Parquet file with TUI."`
 	Serve   cmd.ServeCmd   `cmd:"" help:"Start HTTP API server for Parquet file."`
 	WebUI   cmd.WebUICmd   `cmd:"" help:"Start Web UI server with HTMX interface."`
+	Sample  cmd.SampleCmd  `cmd:"" help:"Print a random or per row group sample of rows."`
 	Version cmd.VersionCmd `cmd:"" help:"Show build version."`
 }
 
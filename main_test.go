@@ -14,7 +14,7 @@ func TestNewParserRegistersCommands(t *testing.T) {
 		commandNames = append(commandNames, child.Name)
 	}
 
-	require.ElementsMatch(t, []string{"tui", "serve", "web-ui", "version"}, commandNames)
+	require.ElementsMatch(t, []string{"tui", "serve", "web-ui", "sample", "version"}, commandNames)
 }
 
 func TestNewParserParsesVersionCommand(t *testing.T) {
@@ -24,3 +24,37 @@ func TestNewParserParsesVersionCommand(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "version", ctx.Command())
 }
+
+func TestNewParserParsesSampleCommand(t *testing.T) {
+	parser := newParser()
+
+	ctx, err := parser.Parse([]string{"sample", "file.parquet", "-n", "5", "--mode", "stratified", "--seed", "42", "-c", "a.b", "-c", "c"})
+	require.NoError(t, err)
+	require.Equal(t, "sample <uri>", ctx.Command())
+
+	_, err = parser.Parse([]string{"sample", "file.parquet", "--mode", "bogus"})
+	require.Error(t, err)
+}
+
+func TestNewParserParsesCacheFlags(t *testing.T) {
+	parser := newParser()
+
+	ctx, err := parser.Parse([]string{"serve", "file.parquet", "--cache-size", "16", "--cache-check", "1m"})
+	require.NoError(t, err)
+	require.Equal(t, "serve <uri>", ctx.Command())
+
+	_, err = parser.Parse([]string{"web-ui", "file.parquet", "--cache-check", "soon"})
+	require.Error(t, err)
+}
+
+func TestNewParserParsesRequestTimeout(t *testing.T) {
+	parser := newParser()
+
+	for _, command := range []string{"serve", "web-ui", "tui"} {
+		_, err := parser.Parse([]string{command, "file.parquet", "--request-timeout", "30s"})
+		require.NoError(t, err, command)
+	}
+
+	_, err := parser.Parse([]string{"serve", "file.parquet", "--request-timeout", "soon"})
+	require.Error(t, err)
+}
@@ -0,0 +1,320 @@
+package model
+
+import (
+	"container/list"
+	"io"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/hangxie/parquet-go/v3/reader"
+)
+
+// DefaultCacheSize is the memory budget of the page cache when none is
+// configured
+const DefaultCacheSize = 64 << 20
+
+// DefaultCacheCheckInterval is how often the page cache checks by default
+// whether the file changed
+const DefaultCacheCheckInterval = 5 * time.Second
+
+// CacheOptions configures the page cache
+type CacheOptions struct {
+	MaxBytes      int64         // estimated memory of the cached entries, 0 disables the cache
+	CheckInterval time.Duration // how often the file is checked for changes, 0 never checks
+}
+
+// CacheStats reports the use of the page cache
+type CacheStats struct {
+	Enabled       bool
+	MaxBytes      int64
+	Bytes         int64 // estimated memory of the cached entries
+	Entries       int
+	Hits          int64
+	Misses        int64
+	Evictions     int64 // entries dropped to make room for others
+	Invalidations int64 // times the file changed and the cache was emptied
+}
+
+// cacheKind tells apart what a cache entry holds
+type cacheKind int
+
+const (
+	cachePageHeaders cacheKind = iota // page headers of a column chunk
+	cacheDictionary                   // decoded values of a dictionary page
+	cachePageValues                   // decoded values and levels of a data page
+)
+
+// cacheKey identifies a cache entry, fields that do not apply to its kind
+// are left zero
+type cacheKey struct {
+	kind      cacheKind
+	rgIndex   int
+	colIndex  int
+	pageIndex int
+	offset    int64
+}
+
+// cacheEntry is a cached value with its estimated size
+type cacheEntry struct {
+	key   cacheKey
+	value any
+	size  int64
+}
+
+// pageValues are the decoded values of a data page with their levels
+type pageValues struct {
+	values []interface{}
+	rls    []int32
+	dls    []int32
+}
+
+// fileFingerprint identifies a version of the file: rewriting it almost
+// always changes its size or the footer length in the trailer
+type fileFingerprint struct {
+	size    int64
+	trailer [8]byte
+}
+
+// PageCache keeps the page headers, dictionaries and decoded pages read from
+// a file, least recently used first out once the memory budget is reached.
+// Cached values are shared by all callers and must not be modified. A cache
+// belongs to one file, entries are keyed by position in that file.
+type PageCache struct {
+	mu      sync.Mutex
+	opts    CacheOptions
+	entries map[cacheKey]*list.Element
+	lru     *list.List // most recently used at the front
+	stats   CacheStats
+
+	fingerprint fileFingerprint
+	checked     time.Time // last time the file was checked for changes
+}
+
+// NewPageCache creates an empty page cache
+func NewPageCache(opts CacheOptions) *PageCache {
+	return &PageCache{
+		opts:    opts,
+		entries: make(map[cacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Stats returns the current use of the cache, a nil cache is disabled
+func (c *PageCache) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Enabled = c.opts.MaxBytes > 0
+	stats.MaxBytes = c.opts.MaxBytes
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// Invalidate empties the cache
+func (c *PageCache) Invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clear()
+	c.stats.Invalidations++
+}
+
+// clear drops every entry, the caller holds the lock
+func (c *PageCache) clear() {
+	c.entries = make(map[cacheKey]*list.Element)
+	c.lru.Init()
+	c.stats.Bytes = 0
+}
+
+// get returns a cached value and marks it as recently used
+func (c *PageCache) get(key cacheKey) (any, bool) {
+	if c == nil || c.opts.MaxBytes <= 0 {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	c.stats.Hits++
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).value, true
+}
+
+// put caches a value of the given estimated size, evicting the least
+// recently used entries to stay within budget. Values larger than the whole
+// budget are not cached.
+func (c *PageCache) put(key cacheKey, value any, size int64) {
+	if c == nil || size > c.opts.MaxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.stats.Bytes -= elem.Value.(*cacheEntry).size
+		c.lru.Remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, value: value, size: size})
+	c.stats.Bytes += size
+
+	for c.stats.Bytes > c.opts.MaxBytes {
+		oldest := c.lru.Back()
+		entry := oldest.Value.(*cacheEntry)
+		c.lru.Remove(oldest)
+		delete(c.entries, entry.key)
+		c.stats.Bytes -= entry.size
+		c.stats.Evictions++
+	}
+}
+
+// checkDue reports whether the file should be checked for changes now, and
+// if so claims the check so that concurrent callers skip it
+func (c *PageCache) checkDue(now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.opts.CheckInterval <= 0 || now.Sub(c.checked) < c.opts.CheckInterval {
+		return false
+	}
+	c.checked = now
+	return true
+}
+
+// observe records the fingerprint of the file and empties the cache when it
+// differs from the one the entries were read with. It reports whether the
+// file changed.
+func (c *PageCache) observe(fingerprint fileFingerprint) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if fingerprint == c.fingerprint {
+		return false
+	}
+	c.fingerprint = fingerprint
+	c.clear()
+	c.stats.Invalidations++
+	return true
+}
+
+// WithCache returns a reader over the same file that keeps what it reads in
+// a page cache with the given options, shared by the copies made with other
+// display options or decoders
+func (pr *ParquetReader) WithCache(opts CacheOptions) *ParquetReader {
+	copied := *pr
+	copied.cache = NewPageCache(opts)
+	copied.cache.checked = time.Now()
+	if fingerprint, err := pr.fileFingerprint(); err == nil {
+		copied.cache.fingerprint = fingerprint
+	}
+	return &copied
+}
+
+// CacheStats returns the use of the page cache
+func (pr *ParquetReader) CacheStats() CacheStats {
+	if pr == nil {
+		return CacheStats{}
+	}
+	return pr.cache.Stats()
+}
+
+// fromCache looks a value up in the page cache, first emptying the cache if
+// the file changed since it was last checked
+func (pr *ParquetReader) fromCache(key cacheKey) (any, bool) {
+	if pr.cache == nil {
+		return nil, false
+	}
+	pr.checkFile()
+	return pr.cache.get(key)
+}
+
+// toCache keeps a value read from the file in the page cache, unless the file
+// changed while it was read
+func (pr *ParquetReader) toCache(key cacheKey, value any, size int64) {
+	if pr.pool.isChanged() {
+		return
+	}
+	pr.cache.put(key, value, size)
+}
+
+// checkFile compares the file with the one the cache entries were read from
+// when a check is due. A changed file empties the cache, and the readers of
+// pr fail with ErrFileChanged since their footer describes the file that was
+// opened; Reopen reads the new one.
+func (pr *ParquetReader) checkFile() {
+	if pr.cache == nil || !pr.cache.checkDue(time.Now()) {
+		return
+	}
+	// A file that cannot be read now fails the read that follows
+	if fingerprint, err := pr.fileFingerprint(); err == nil && pr.cache.observe(fingerprint) {
+		pr.pool.fileChanged()
+	}
+}
+
+// FileChanged reports whether the file changed since pr opened it, checking
+// it first when a check is due. Only a cached reader checks the file.
+func (pr *ParquetReader) FileChanged() bool {
+	if pr == nil || pr.pool == nil {
+		return false
+	}
+	pr.checkFile()
+	return pr.pool.isChanged()
+}
+
+// fileFingerprint reads the size and trailing bytes of the file
+func (pr *ParquetReader) fileFingerprint() (fileFingerprint, error) {
+	var fingerprint fileFingerprint
+	err := pr.withReader(IOFingerprint, func(r *reader.ParquetReader) error {
+		size, err := r.PFile.Seek(0, io.SeekEnd)
+		if err != nil {
+			return err
+		}
+		fingerprint.size = size
+		if size < int64(len(fingerprint.trailer)) {
+			return nil
+		}
+		if _, err := r.PFile.Seek(size-int64(len(fingerprint.trailer)), io.SeekStart); err != nil {
+			return err
+		}
+		_, err = io.ReadFull(r.PFile, fingerprint.trailer[:])
+		return err
+	})
+	return fingerprint, err
+}
+
+// pageHeaderSize is the estimated memory of a page header without statistics
+const pageHeaderSize = int64(unsafe.Sizeof(reader.PageHeaderInfo{}))
+
+// headersSize estimates the memory of page headers
+func headersSize(headers []reader.PageHeaderInfo) int64 {
+	size := int64(len(headers)) * pageHeaderSize
+	for _, header := range headers {
+		if stats := header.Statistics; stats != nil {
+			size += int64(len(stats.Max) + len(stats.Min) + len(stats.MaxValue) + len(stats.MinValue))
+		}
+	}
+	return size
+}
+
+// valuesSize estimates the memory of decoded values, an interface each plus
+// what strings and byte slices point to
+func valuesSize(values []interface{}) int64 {
+	size := int64(len(values)) * 16
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			size += 16 + int64(len(v))
+		case []byte:
+			size += 24 + int64(len(v))
+		case nil, bool, int32, int64, float32, float64:
+		default:
+			size += 16
+		}
+	}
+	return size
+}
@@ -0,0 +1,233 @@
+package model
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hangxie/parquet-go/v3/reader"
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PageCache(t *testing.T) {
+	key := func(page int) cacheKey { return cacheKey{kind: cachePageValues, pageIndex: page} }
+
+	t.Run("Least recently used out first", func(t *testing.T) {
+		c := NewPageCache(CacheOptions{MaxBytes: 30})
+		c.put(key(0), "a", 10)
+		c.put(key(1), "b", 10)
+		c.put(key(2), "c", 10)
+
+		_, ok := c.get(key(0))
+		require.True(t, ok)
+		c.put(key(3), "d", 10)
+
+		_, ok = c.get(key(1))
+		require.False(t, ok)
+		value, ok := c.get(key(0))
+		require.True(t, ok)
+		require.Equal(t, "a", value)
+
+		stats := c.Stats()
+		require.True(t, stats.Enabled)
+		require.Equal(t, int64(30), stats.Bytes)
+		require.Equal(t, 3, stats.Entries)
+		require.Equal(t, int64(2), stats.Hits)
+		require.Equal(t, int64(1), stats.Misses)
+		require.Equal(t, int64(1), stats.Evictions)
+	})
+
+	t.Run("Entries larger than the budget are not kept", func(t *testing.T) {
+		c := NewPageCache(CacheOptions{MaxBytes: 30})
+		c.put(key(0), "a", 31)
+		_, ok := c.get(key(0))
+		require.False(t, ok)
+		require.Zero(t, c.Stats().Bytes)
+	})
+
+	t.Run("Replacing an entry keeps the accounting", func(t *testing.T) {
+		c := NewPageCache(CacheOptions{MaxBytes: 30})
+		c.put(key(0), "a", 10)
+		c.put(key(0), "b", 20)
+		require.Equal(t, int64(20), c.Stats().Bytes)
+		require.Equal(t, 1, c.Stats().Entries)
+	})
+
+	t.Run("Invalidate", func(t *testing.T) {
+		c := NewPageCache(CacheOptions{MaxBytes: 30})
+		c.put(key(0), "a", 10)
+		c.Invalidate()
+		_, ok := c.get(key(0))
+		require.False(t, ok)
+		require.Equal(t, int64(1), c.Stats().Invalidations)
+		require.Zero(t, c.Stats().Bytes)
+	})
+
+	t.Run("Disabled and nil", func(t *testing.T) {
+		c := NewPageCache(CacheOptions{})
+		c.put(key(0), "a", 0)
+		_, ok := c.get(key(0))
+		require.False(t, ok)
+		require.False(t, c.Stats().Enabled)
+
+		var nilCache *PageCache
+		nilCache.put(key(0), "a", 0)
+		nilCache.Invalidate()
+		_, ok = nilCache.get(key(0))
+		require.False(t, ok)
+		require.Equal(t, CacheStats{}, nilCache.Stats())
+	})
+}
+
+func Test_ParquetReader_WithCache(t *testing.T) {
+	uncached := openTestParquetReader(t)
+	pr := uncached.WithCache(CacheOptions{MaxBytes: DefaultCacheSize})
+
+	t.Run("Same results as without the cache", func(t *testing.T) {
+		for _, colIndex := range []int{1, 3, 46} {
+			want, err := uncached.GetPageMetadataList(0, colIndex)
+			require.NoError(t, err)
+			for range 2 {
+				pages, err := pr.GetPageMetadataList(0, colIndex)
+				require.NoError(t, err)
+				require.Equal(t, want, pages)
+
+				for pageIndex := range pages {
+					want, err := uncached.GetPageContentTyped(0, colIndex, pageIndex, GeoFormatGeoJSON)
+					require.NoError(t, err)
+					got, err := pr.GetPageContentTyped(0, colIndex, pageIndex, GeoFormatGeoJSON)
+					require.NoError(t, err)
+					require.Equal(t, want, got)
+				}
+			}
+		}
+	})
+
+	t.Run("Repeated reads hit", func(t *testing.T) {
+		before := pr.CacheStats()
+		_, err := pr.GetPageMetadata(0, 1, 1)
+		require.NoError(t, err)
+		_, err = pr.GetPageContent(0, 1, 1)
+		require.NoError(t, err)
+
+		after := pr.CacheStats()
+		require.Greater(t, after.Hits, before.Hits)
+		require.Equal(t, before.Misses, after.Misses)
+		require.Positive(t, after.Bytes)
+		require.Positive(t, after.Entries)
+	})
+
+	t.Run("Whole chunk reads cache each page apart", func(t *testing.T) {
+		pr := uncached.WithCache(CacheOptions{MaxBytes: DefaultCacheSize})
+		pages, err := pr.GetPageMetadataList(0, 1)
+		require.NoError(t, err)
+		_, _, _, err = pr.readChunkPageValues(0, 1, 1, pages)
+		require.NoError(t, err)
+
+		var dataPages int
+		for pageIndex, page := range pages {
+			if page.PageType != "DATA_PAGE" && page.PageType != "DATA_PAGE_V2" {
+				continue
+			}
+			dataPages++
+			cached, ok := pr.cache.get(cacheKey{kind: cachePageValues, rgIndex: 0, colIndex: 1, pageIndex: pageIndex})
+			require.True(t, ok)
+			values := cached.(pageValues)
+			require.Len(t, values.values, int(page.NumValues))
+			require.Equal(t, len(values.values), cap(values.values))
+			require.Equal(t, len(values.rls), cap(values.rls))
+			require.Equal(t, len(values.dls), cap(values.dls))
+		}
+		require.Greater(t, dataPages, 1)
+	})
+
+	t.Run("Shared with display copies", func(t *testing.T) {
+		copied := pr.WithDisplayOptions(DisplayOptions{Binary: "hex"})
+		require.Equal(t, pr.CacheStats(), copied.CacheStats())
+	})
+
+	t.Run("Not cached by default", func(t *testing.T) {
+		_, err := uncached.GetPageMetadataList(0, 1)
+		require.NoError(t, err)
+		require.Equal(t, CacheStats{}, uncached.CacheStats())
+	})
+}
+
+// copyTestFile copies a test file to path, replacing what path held
+func copyTestFile(t *testing.T, from, path string) {
+	t.Helper()
+	src, err := os.Open(from)
+	require.NoError(t, err)
+	defer func() { _ = src.Close() }()
+	dst, err := os.Create(path)
+	require.NoError(t, err)
+	_, err = io.Copy(dst, src)
+	require.NoError(t, err)
+	require.NoError(t, dst.Close())
+}
+
+func Test_ParquetReader_WithCache_FileChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changing.parquet")
+	copyTestFile(t, getTestParquetFilePath(), path)
+
+	open := func() (*reader.ParquetReader, error) {
+		return pio.NewParquetFileReader(path, pio.ReadOption{})
+	}
+	r, err := open()
+	require.NoError(t, err)
+	pr := NewParquetReader(r).WithReaderPool(open, 2).
+		WithCache(CacheOptions{MaxBytes: DefaultCacheSize, CheckInterval: time.Nanosecond})
+	t.Cleanup(func() { _ = pr.Close() })
+
+	_, err = pr.GetPageMetadataList(0, 1)
+	require.NoError(t, err)
+	_, err = pr.GetPageMetadataList(0, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), pr.CacheStats().Hits)
+	require.Zero(t, pr.CacheStats().Invalidations)
+	require.False(t, pr.FileChanged())
+
+	copyTestFile(t, filepath.Join("..", "build", "testdata", "row-group.parquet"), path)
+
+	// The footer describes the file that was opened, reads fail until the
+	// file is reopened
+	_, err = pr.GetPageMetadataList(0, 1)
+	require.ErrorIs(t, err, ErrFileChanged)
+	stats := pr.CacheStats()
+	require.Equal(t, int64(1), stats.Invalidations)
+	require.Equal(t, int64(1), stats.Hits)
+	require.Zero(t, stats.Entries)
+	require.True(t, pr.FileChanged())
+	_, err = pr.WithDisplayOptions(DisplayOptions{Binary: "hex"}).GetPageContentFormatted(0, 1, 0)
+	require.ErrorIs(t, err, ErrFileChanged)
+
+	hex := pr.WithDisplayOptions(DisplayOptions{Binary: BinaryHex})
+	reopened, err := hex.Reopen()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = reopened.Close() })
+	require.False(t, reopened.FileChanged())
+	require.Equal(t, DisplayOptions{Binary: BinaryHex}, reopened.DisplayOptions())
+	require.Equal(t, 2, reopened.GetFileInfo().NumRowGroups)
+	values, err := reopened.GetPageContentFormatted(1, 0, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, values)
+	stats = reopened.CacheStats()
+	require.Equal(t, int64(1), stats.Invalidations)
+	require.Positive(t, stats.Entries)
+
+	// The readers of the old file are closed
+	require.Eventually(t, func() bool {
+		handles := pr.HandleStats()
+		return handles.Readers == 0 && handles.Opened == handles.Closed
+	}, 2*time.Second, 10*time.Millisecond)
+	_, err = pr.GetPageMetadataList(0, 1)
+	require.ErrorIs(t, err, ErrFileChanged)
+
+	t.Run("Without an OpenFunc", func(t *testing.T) {
+		_, err := openTestParquetReader(t).Reopen()
+		require.ErrorIs(t, err, ErrFileChanged)
+	})
+}
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+)
+
+// LeafColumn is a leaf column of the schema
+type LeafColumn struct {
+	Index         int
+	Path          string
+	PhysicalType  string
+	LogicalType   string
+	ConvertedType string
+}
+
+// ColumnOverview is one leaf column across all row groups: its chunk in every
+// row group side by side, with file-wide totals
+type ColumnOverview struct {
+	LeafColumn
+	Chunks                []ColumnChunkInfo // one per row group, in row group order
+	NumValues             int64
+	NullCount             *int64 // nil unless every chunk has a null count
+	TotalCompressedSize   int64
+	TotalUncompressedSize int64
+	CompressionRatio      float64
+	Codecs                []string // distinct codecs in order of first use
+	Encodings             []string // distinct encodings in order of first use
+}
+
+// GetLeafColumns lists the leaf columns of the schema in column order
+func (pr *ParquetReader) GetLeafColumns() []LeafColumn {
+	if pr == nil {
+		return nil
+	}
+
+	columns := make([]LeafColumn, len(pr.columns))
+	for i := range pr.columns {
+		columns[i] = pr.leafColumn(i)
+	}
+	return columns
+}
+
+// leafColumn describes the leaf column at colIndex, which must be in range
+func (pr *ParquetReader) leafColumn(colIndex int) LeafColumn {
+	elem := pr.columns[colIndex].elem
+	column := LeafColumn{
+		Index:         colIndex,
+		Path:          pr.columnName(colIndex),
+		PhysicalType:  elem.GetType().String(),
+		LogicalType:   formatLogicalType(elem.LogicalType),
+		ConvertedType: "-",
+	}
+	if elem.ConvertedType != nil {
+		column.ConvertedType = elem.ConvertedType.String()
+	}
+	return column
+}
+
+// GetColumnOverview returns the chunks of a leaf column in every row group
+// along with file-wide totals
+func (pr *ParquetReader) GetColumnOverview(colIndex int) (ColumnOverview, error) {
+	if pr == nil || pr.metadata == nil {
+		return ColumnOverview{}, ErrInvalidColumnIndex
+	}
+
+	if colIndex < 0 || colIndex >= len(pr.columns) {
+		return ColumnOverview{}, fmt.Errorf("column index %d out of range [0, %d): %w",
+			colIndex, len(pr.columns), ErrInvalidColumnIndex)
+	}
+
+	overview := ColumnOverview{
+		LeafColumn: pr.leafColumn(colIndex),
+		Chunks:     make([]ColumnChunkInfo, 0, len(pr.metadata.RowGroups)),
+	}
+
+	var nullCount int64
+	allNullCounts := true
+	for rgIndex := range pr.metadata.RowGroups {
+		chunk, err := pr.GetColumnChunkInfo(rgIndex, colIndex)
+		if err != nil {
+			return ColumnOverview{}, err
+		}
+		overview.Chunks = append(overview.Chunks, chunk)
+
+		overview.NumValues += chunk.NumValues
+		overview.TotalCompressedSize += chunk.CompressedSize
+		overview.TotalUncompressedSize += chunk.UncompressedSize
+		if chunk.NullCount != nil {
+			nullCount += *chunk.NullCount
+		} else {
+			allNullCounts = false
+		}
+		overview.Codecs = appendDistinct(overview.Codecs, chunk.Codec)
+		overview.Encodings = appendDistinct(overview.Encodings, chunk.Encodings...)
+	}
+
+	if allNullCounts && len(overview.Chunks) > 0 {
+		overview.NullCount = &nullCount
+	}
+	if overview.TotalCompressedSize > 0 {
+		overview.CompressionRatio = float64(overview.TotalUncompressedSize) / float64(overview.TotalCompressedSize)
+	}
+
+	return overview, nil
+}
+
+// appendDistinct appends the values not already in list, keeping order
+func appendDistinct(list []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
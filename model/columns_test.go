@@ -0,0 +1,66 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetLeafColumns(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	columns := pr.GetLeafColumns()
+	require.Len(t, columns, countLeafColumns(pr.metadata.Schema))
+	for i, column := range columns {
+		require.Equal(t, i, column.Index)
+		require.Equal(t, formatColumnName(pr.metadata.RowGroups[0].Columns[i].MetaData.PathInSchema), column.Path)
+	}
+	require.Equal(t, LeafColumn{
+		Index: 46, Path: "Map.Key_value.Key", PhysicalType: "BYTE_ARRAY", LogicalType: "STRING", ConvertedType: "UTF8",
+	}, columns[46])
+
+	var nilReader *ParquetReader
+	require.Nil(t, nilReader.GetLeafColumns())
+}
+
+func Test_GetColumnOverview(t *testing.T) {
+	pr := openTestParquetReader(t)
+	numRowGroups := len(pr.metadata.RowGroups)
+
+	overview, err := pr.GetColumnOverview(1)
+	require.NoError(t, err)
+	require.Equal(t, "Int32", overview.Path)
+	require.Equal(t, "INT32", overview.PhysicalType)
+	require.Len(t, overview.Chunks, numRowGroups)
+
+	var numValues, compressed, uncompressed int64
+	for rgIndex, chunk := range overview.Chunks {
+		require.Equal(t, rgIndex, chunk.RowGroup)
+		require.Equal(t, 1, chunk.Index)
+		require.NotEmpty(t, chunk.Encodings)
+		numValues += chunk.NumValues
+		compressed += chunk.CompressedSize
+		uncompressed += chunk.UncompressedSize
+	}
+	require.Equal(t, numValues, overview.NumValues)
+	require.Equal(t, compressed, overview.TotalCompressedSize)
+	require.Equal(t, uncompressed, overview.TotalUncompressedSize)
+	require.InDelta(t, float64(uncompressed)/float64(compressed), overview.CompressionRatio, 1e-9)
+	require.Equal(t, []string{overview.Chunks[0].Codec}, overview.Codecs)
+	require.Subset(t, overview.Encodings, overview.Chunks[0].Encodings)
+	require.NotNil(t, overview.NullCount)
+
+	_, err = pr.GetColumnOverview(len(pr.columns))
+	require.ErrorIs(t, err, ErrInvalidColumnIndex)
+	_, err = pr.GetColumnOverview(-1)
+	require.ErrorIs(t, err, ErrInvalidColumnIndex)
+
+	var nilReader *ParquetReader
+	_, err = nilReader.GetColumnOverview(0)
+	require.ErrorIs(t, err, ErrInvalidColumnIndex)
+}
+
+func Test_appendDistinct(t *testing.T) {
+	require.Nil(t, appendDistinct(nil))
+	require.Equal(t, []string{"a", "b", "c"}, appendDistinct([]string{"a"}, "b", "a", "c", "b"))
+}
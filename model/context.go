@@ -0,0 +1,79 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hangxie/parquet-go/v3/source"
+)
+
+// ctxFile is a file handle whose reads fail once its context is cancelled,
+// so that a long read of a remote file stops between two requests to the
+// storage instead of running to the end for nobody. The handles cloned from
+// it, like those of the column buffers, stop with it.
+type ctxFile struct {
+	source.ParquetFileReader
+	ctx context.Context
+}
+
+// Read reads from the file unless the context is cancelled
+func (f *ctxFile) Read(p []byte) (int, error) {
+	if err := f.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return f.ParquetFileReader.Read(p)
+}
+
+// Seek moves in the file unless the context is cancelled
+func (f *ctxFile) Seek(offset int64, whence int) (int64, error) {
+	if err := f.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return f.ParquetFileReader.Seek(offset, whence)
+}
+
+// Open opens another file with the same context
+func (f *ctxFile) Open(name string) (source.ParquetFileReader, error) {
+	opened, err := f.ParquetFileReader.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &ctxFile{ParquetFileReader: opened, ctx: f.ctx}, nil
+}
+
+// Clone opens another handle of the file with the same context
+func (f *ctxFile) Clone() (source.ParquetFileReader, error) {
+	cloned, err := f.ParquetFileReader.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return &ctxFile{ParquetFileReader: cloned, ctx: f.ctx}, nil
+}
+
+// WithContext returns a reader over the same file whose reads stop with
+// ctx.Err() once ctx is cancelled or its deadline passes. It shares the file
+// handles, display options, decoders and cache of pr.
+func (pr *ParquetReader) WithContext(ctx context.Context) *ParquetReader {
+	copied := *pr
+	copied.ctx = ctx
+	return &copied
+}
+
+// context returns the context the reads of pr run in
+func (pr *ParquetReader) context() context.Context {
+	if pr.ctx == nil {
+		return context.Background()
+	}
+	return pr.ctx
+}
+
+// contextErr makes an error caused by a cancelled context match ctx.Err(),
+// the parquet-go readers do not always wrap the errors of the file handle
+func contextErr(ctx context.Context, err error) error {
+	ctxErr := ctx.Err()
+	if err == nil || ctxErr == nil || errors.Is(err, ctxErr) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ctxErr, err)
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ctxFile(t *testing.T) {
+	pr := openTestParquetReader(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	file := &ctxFile{ParquetFileReader: pr.Reader.PFile, ctx: ctx}
+
+	_, err := file.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+	magic := make([]byte, 4)
+	_, err = io.ReadFull(file, magic)
+	require.NoError(t, err)
+	require.Equal(t, "PAR1", string(magic))
+
+	cloned, err := file.Clone()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cloned.Close() })
+
+	cancel()
+	_, err = file.Read(magic)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = file.Seek(0, io.SeekStart)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = cloned.Read(magic)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_ParquetReader_WithContext(t *testing.T) {
+	pr := openTestReaderPool(t, 1)
+
+	t.Run("Cancelled before reading", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		cancelled := pr.WithContext(ctx)
+
+		_, err := cancelled.GetPageMetadataList(0, 1)
+		require.ErrorIs(t, err, context.Canceled)
+		_, err = cancelled.GetPageContent(0, 1, 0)
+		require.ErrorIs(t, err, context.Canceled)
+		_, err = cancelled.GetRecord(1)
+		require.ErrorIs(t, err, context.Canceled)
+		_, err = pr.GetHeatmap(ctx, HeatmapPageCount)
+		require.ErrorIs(t, err, context.Canceled)
+
+		_, err = pr.GetPageMetadataList(0, 1)
+		require.NoError(t, err)
+	})
+
+	t.Run("Cancelled while reading a column", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		columnReader, err := pr.WithContext(ctx).newColumnReader(IOColumnChunk, 1)
+		require.NoError(t, err)
+		defer pr.closeColumnReader(columnReader)
+
+		cancel()
+		_, _, _, err = columnReader.ReadColumnByIndex(1, 10)
+		require.Error(t, err)
+	})
+
+	t.Run("Deadline while waiting for a reader", func(t *testing.T) {
+		lent, err := pr.pool.acquire(context.Background())
+		require.NoError(t, err)
+		defer pr.pool.release(lent)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = pr.WithContext(ctx).GetPageMetadataList(0, 2)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Handles restored after reading", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		_, err := pr.WithContext(ctx).GetFileLayout()
+		require.NoError(t, err)
+		_, isCtxFile := pr.Reader.PFile.(*ctxFile)
+		require.False(t, isCtxFile)
+	})
+}
@@ -0,0 +1,446 @@
+package model
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/linkedin/goavro/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Built-in decoder types
+const (
+	DecoderProtobuf = "protobuf"
+	DecoderAvro     = "avro"
+	DecoderMsgPack  = "msgpack"
+	DecoderCBOR     = "cbor"
+	DecoderGzipJSON = "gzip-json"
+)
+
+// maxGzipJSONSize caps how much a gzip-json value may inflate to
+const maxGzipJSONSize = 64 << 20
+
+// ValueDecoder turns the bytes of a BYTE_ARRAY value into JSON-compatible
+// data: nil, bool, json.Number, string, []any and map[string]any
+type ValueDecoder interface {
+	Name() string // the decoder type, shown next to decoded values
+	Decode(raw []byte) (any, error)
+}
+
+// DecoderConfig configures the decoder of one column
+type DecoderConfig struct {
+	Type          string `json:"type"`
+	DescriptorSet string `json:"descriptor_set,omitempty"` // protobuf: FileDescriptorSet file, as written by protoc --descriptor_set_out
+	Message       string `json:"message,omitempty"`        // protobuf: fully-qualified message name
+	Schema        string `json:"schema,omitempty"`         // avro: schema file
+}
+
+// DecoderFactory builds a decoder from its config, relative file names in the
+// config are resolved against baseDir
+type DecoderFactory func(cfg DecoderConfig, baseDir string) (ValueDecoder, error)
+
+var (
+	decoderFactoriesMu sync.RWMutex
+	decoderFactories   = map[string]DecoderFactory{
+		DecoderProtobuf: newProtobufDecoder,
+		DecoderAvro:     newAvroDecoder,
+		DecoderMsgPack:  func(DecoderConfig, string) (ValueDecoder, error) { return msgpackDecoder{}, nil },
+		DecoderCBOR:     func(DecoderConfig, string) (ValueDecoder, error) { return cborDecoder{}, nil },
+		DecoderGzipJSON: func(DecoderConfig, string) (ValueDecoder, error) { return gzipJSONDecoder{}, nil },
+	}
+)
+
+// RegisterDecoderType makes a decoder type available to decoder config
+// files, replacing any decoder type of the same name
+func RegisterDecoderType(name string, factory DecoderFactory) {
+	decoderFactoriesMu.Lock()
+	defer decoderFactoriesMu.Unlock()
+	decoderFactories[name] = factory
+}
+
+// NewDecoder builds the decoder a config asks for
+func NewDecoder(cfg DecoderConfig, baseDir string) (ValueDecoder, error) {
+	decoderFactoriesMu.RLock()
+	factory, ok := decoderFactories[cfg.Type]
+	decoderFactoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown decoder type %q", ErrInvalidDecoderConfig, cfg.Type)
+	}
+	return factory(cfg, baseDir)
+}
+
+// DecoderRegistry maps dotted column paths, as in ColumnIndexByPath, to the
+// decoders of their values
+type DecoderRegistry struct {
+	decoders map[string]ValueDecoder
+}
+
+// NewDecoderRegistry returns an empty registry
+func NewDecoderRegistry() *DecoderRegistry {
+	return &DecoderRegistry{decoders: make(map[string]ValueDecoder)}
+}
+
+// Register sets the decoder of a column
+func (r *DecoderRegistry) Register(columnPath string, decoder ValueDecoder) {
+	r.decoders[columnPath] = decoder
+}
+
+// Lookup returns the decoder of a column, nil when it has none
+func (r *DecoderRegistry) Lookup(columnPath string) ValueDecoder {
+	if r == nil {
+		return nil
+	}
+	return r.decoders[columnPath]
+}
+
+// Columns returns the paths of the columns with a decoder, sorted
+func (r *DecoderRegistry) Columns() []string {
+	if r == nil {
+		return nil
+	}
+	paths := make([]string, 0, len(r.decoders))
+	for path := range r.decoders {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// decoderConfigFile is the layout of a decoder config file
+type decoderConfigFile struct {
+	Columns map[string]DecoderConfig `json:"columns"`
+}
+
+// LoadDecoderRegistry reads a JSON decoder config file of the form
+// {"columns": {"path.to.column": {"type": "msgpack"}}}. Files named in the
+// config are relative to the config file.
+func LoadDecoderRegistry(path string) (*DecoderRegistry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read decoder config: %w", err)
+	}
+
+	var file decoderConfigFile
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDecoderConfig, err)
+	}
+
+	registry := NewDecoderRegistry()
+	baseDir := filepath.Dir(path)
+	for columnPath, cfg := range file.Columns {
+		decoder, err := NewDecoder(cfg, baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", columnPath, err)
+		}
+		registry.Register(columnPath, decoder)
+	}
+	return registry, nil
+}
+
+// WithDecoders returns a reader over the same file that shows the values of
+// the columns in registry decoded. Every column must exist and hold binary
+// values.
+func (pr *ParquetReader) WithDecoders(registry *DecoderRegistry) (*ParquetReader, error) {
+	for _, columnPath := range registry.Columns() {
+		colIndex, err := pr.ColumnIndexByPath(columnPath)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidDecoderConfig, err)
+		}
+		switch pr.metadata.RowGroups[0].Columns[colIndex].MetaData.Type {
+		case parquet.Type_BYTE_ARRAY, parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		default:
+			return nil, fmt.Errorf("%w: column %q does not hold binary values", ErrInvalidDecoderConfig, columnPath)
+		}
+	}
+
+	copied := *pr
+	copied.decoders = registry
+	return &copied, nil
+}
+
+// Decoders returns the decoders values are shown with, nil when there are none
+func (pr *ParquetReader) Decoders() *DecoderRegistry {
+	return pr.decoders
+}
+
+// decoderFor returns the decoder of a column, nil when it has none
+func (pr *ParquetReader) decoderFor(colIndex int) ValueDecoder {
+	return pr.decoders.Lookup(pr.columnName(colIndex))
+}
+
+// decodeValue decodes a value read from a page or a statistic, ok is false
+// when there is no decoder or the value does not decode
+func decodeValue(decoder ValueDecoder, val any) (any, bool) {
+	if decoder == nil || val == nil {
+		return nil, false
+	}
+	raw := physicalBytes(val)
+	if raw == nil {
+		return nil, false
+	}
+	decoded, err := decoder.Decode(raw)
+	if err != nil {
+		return nil, false
+	}
+	return decoded, true
+}
+
+// formatDecodedValue renders a decoded value as compact JSON, ok is false
+// when there is no decoder or the value does not decode
+func formatDecodedValue(decoder ValueDecoder, val any) (string, bool) {
+	decoded, ok := decodeValue(decoder, val)
+	if !ok {
+		return "", false
+	}
+	text, err := json.Marshal(decoded)
+	if err != nil {
+		return "", false
+	}
+	return string(text), true
+}
+
+// decodeDetail shows a value in full as the pretty-printed output of the
+// decoder of its column
+func decodeDetail(detail *ValueDetail, decoder ValueDecoder, raw []byte) {
+	if decoder == nil || detail.Null {
+		return
+	}
+	detail.Decoder = decoder.Name()
+	decoded, err := decoder.Decode(raw)
+	if err == nil {
+		var text []byte
+		if text, err = json.MarshalIndent(decoded, "", "  "); err == nil {
+			detail.Formatted = string(text)
+			return
+		}
+	}
+	detail.DecodeError = err.Error()
+}
+
+// formatValue is DisplayOptions.FormatValue with the value decoded when the
+// column has a decoder
+func formatValue(display DisplayOptions, decoder ValueDecoder, val any, parquetType parquet.Type, schemaElem *parquet.SchemaElement) string {
+	if text, ok := formatDecodedValue(decoder, val); ok {
+		return truncateDisplayValue(text)
+	}
+	return display.FormatValue(val, parquetType, schemaElem)
+}
+
+// formatStatValue is DisplayOptions.FormatStatValue with the value decoded
+// when the column has a decoder
+func formatStatValue(display DisplayOptions, decoder ValueDecoder, value []byte, columnMeta *parquet.ColumnMetaData, schemaElem *parquet.SchemaElement) string {
+	if text, ok := formatDecodedValue(decoder, value); ok {
+		return truncateStatValue(text)
+	}
+	return display.FormatStatValue(value, columnMeta, schemaElem)
+}
+
+// jsonCompatible converts decoded data to what encoding/json can marshal:
+// map keys become strings and binaries become base64
+func jsonCompatible(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = jsonCompatible(item)
+		}
+		return v
+	case map[any]any:
+		converted := make(map[string]any, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = jsonCompatible(item)
+		}
+		return converted
+	case []any:
+		for i, item := range v {
+			v[i] = jsonCompatible(item)
+		}
+		return v
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	}
+	return jsonFloat(val)
+}
+
+// unmarshalJSON decodes JSON text keeping numbers exact
+func unmarshalJSON(text []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(text))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+// protobufDecoder decodes serialized messages of one type
+type protobufDecoder struct {
+	message protoreflect.MessageDescriptor
+}
+
+func newProtobufDecoder(cfg DecoderConfig, baseDir string) (ValueDecoder, error) {
+	if cfg.DescriptorSet == "" || cfg.Message == "" {
+		return nil, fmt.Errorf("%w: protobuf decoder needs descriptor_set and message", ErrInvalidDecoderConfig)
+	}
+	raw, err := os.ReadFile(resolveConfigPath(baseDir, cfg.DescriptorSet))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDecoderConfig, err)
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("%w: descriptor set %s: %v", ErrInvalidDecoderConfig, cfg.DescriptorSet, err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("%w: descriptor set %s: %v", ErrInvalidDecoderConfig, cfg.DescriptorSet, err)
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(cfg.Message))
+	if err != nil {
+		return nil, fmt.Errorf("%w: message %s: %v", ErrInvalidDecoderConfig, cfg.Message, err)
+	}
+	message, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not a message", ErrInvalidDecoderConfig, cfg.Message)
+	}
+	return protobufDecoder{message: message}, nil
+}
+
+func (protobufDecoder) Name() string { return DecoderProtobuf }
+
+func (d protobufDecoder) Decode(raw []byte) (any, error) {
+	msg := dynamicpb.NewMessage(d.message)
+	if err := proto.Unmarshal(raw, msg); err != nil {
+		return nil, err
+	}
+	text, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalJSON(text)
+}
+
+// avroDecoder decodes Avro binary values of one schema, with or without the
+// single-object encoding header
+type avroDecoder struct {
+	codec *goavro.Codec
+}
+
+func newAvroDecoder(cfg DecoderConfig, baseDir string) (ValueDecoder, error) {
+	if cfg.Schema == "" {
+		return nil, fmt.Errorf("%w: avro decoder needs schema", ErrInvalidDecoderConfig)
+	}
+	schema, err := os.ReadFile(resolveConfigPath(baseDir, cfg.Schema))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDecoderConfig, err)
+	}
+	codec, err := goavro.NewCodec(string(schema))
+	if err != nil {
+		return nil, fmt.Errorf("%w: avro schema %s: %v", ErrInvalidDecoderConfig, cfg.Schema, err)
+	}
+	return avroDecoder{codec: codec}, nil
+}
+
+func (avroDecoder) Name() string { return DecoderAvro }
+
+func (d avroDecoder) Decode(raw []byte) (any, error) {
+	decode := d.codec.NativeFromBinary
+	if bytes.HasPrefix(raw, []byte{0xC3, 0x01}) {
+		decode = d.codec.NativeFromSingle
+	}
+	native, rest, err := decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("%d unexpected bytes after avro value", len(rest))
+	}
+	text, err := d.codec.TextualFromNative(nil, native)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalJSON(text)
+}
+
+// msgpackDecoder decodes a MessagePack value
+type msgpackDecoder struct{}
+
+func (msgpackDecoder) Name() string { return DecoderMsgPack }
+
+func (msgpackDecoder) Decode(raw []byte) (any, error) {
+	// Unmarshal ignores trailing bytes, which would let most text pass as a
+	// single small integer
+	r := bytes.NewReader(raw)
+	var v any
+	if err := msgpack.NewDecoder(r).Decode(&v); err != nil {
+		return nil, err
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("%d unexpected bytes after msgpack value", r.Len())
+	}
+	return jsonCompatible(v), nil
+}
+
+// cborDecoder decodes a CBOR value
+type cborDecoder struct{}
+
+func (cborDecoder) Name() string { return DecoderCBOR }
+
+func (cborDecoder) Decode(raw []byte) (any, error) {
+	var v any
+	if err := cbor.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return jsonCompatible(v), nil
+}
+
+// gzipJSONDecoder decodes gzip-compressed JSON text
+type gzipJSONDecoder struct{}
+
+func (gzipJSONDecoder) Name() string { return DecoderGzipJSON }
+
+func (gzipJSONDecoder) Decode(raw []byte) (any, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = zr.Close() }()
+
+	text, err := io.ReadAll(io.LimitReader(zr, maxGzipJSONSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(text) > maxGzipJSONSize {
+		return nil, fmt.Errorf("decompressed value larger than %d bytes", maxGzipJSONSize)
+	}
+	return unmarshalJSON(text)
+}
+
+// resolveConfigPath resolves a file named in a config file against the
+// directory of the config file
+func resolveConfigPath(baseDir, name string) string {
+	if filepath.IsAbs(name) || baseDir == "" {
+		return name
+	}
+	return filepath.Join(baseDir, name)
+}
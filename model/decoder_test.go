@@ -0,0 +1,344 @@
+package model
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/hangxie/parquet-go/v3/source/local"
+	"github.com/hangxie/parquet-go/v3/writer"
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type blobRow struct {
+	ID    int64  `parquet:"name=id, type=INT64"`
+	Pack  string `parquet:"name=pack, type=BYTE_ARRAY"`
+	Card  string `parquet:"name=card, type=BYTE_ARRAY"`
+	Zip   string `parquet:"name=zip, type=BYTE_ARRAY"`
+	Proto string `parquet:"name=proto, type=BYTE_ARRAY"`
+	Avro  string `parquet:"name=avro, type=BYTE_ARRAY"`
+}
+
+const testAvroSchema = `{"type": "record", "name": "User", "fields": [
+	{"name": "name", "type": "string"},
+	{"name": "age", "type": "int"}
+]}`
+
+// testDescriptorSet describes the message test.User {string name = 1; int32 age = 2;}
+func testDescriptorSet() *descriptorpb.FileDescriptorSet {
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("user.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("User"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("name"), JsonName: proto.String("name"), Number: proto.Int32(1), Label: optional,
+					Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()},
+				{Name: proto.String("age"), JsonName: proto.String("age"), Number: proto.Int32(2), Label: optional,
+					Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum()},
+			},
+		}},
+	}}}
+}
+
+// writeDecoderFiles writes the protobuf descriptor set, the avro schema and a
+// decoder config for every column of the blob fixture to dir
+func writeDecoderFiles(t *testing.T, dir string) string {
+	t.Helper()
+	raw, err := proto.Marshal(testDescriptorSet())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "user.pb"), raw, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "user.avsc"), []byte(testAvroSchema), 0o600))
+
+	config := filepath.Join(dir, "decoders.json")
+	require.NoError(t, os.WriteFile(config, []byte(`{"columns": {
+		"Pack":  {"type": "msgpack"},
+		"Card":  {"type": "cbor"},
+		"Zip":   {"type": "gzip-json"},
+		"Proto": {"type": "protobuf", "descriptor_set": "user.pb", "message": "test.User"},
+		"Avro":  {"type": "avro", "schema": "user.avsc"}
+	}}`), 0o600))
+	return config
+}
+
+// encodeTestUser serializes a user in every format of the blob fixture
+func encodeTestUser(t *testing.T, id int64, name string, age int) blobRow {
+	t.Helper()
+	user := map[string]any{"name": name, "age": age}
+	row := blobRow{ID: id}
+
+	pack, err := msgpack.Marshal(user)
+	require.NoError(t, err)
+	row.Pack = string(pack)
+
+	card, err := cbor.Marshal(user)
+	require.NoError(t, err)
+	row.Card = string(card)
+
+	var zipped bytes.Buffer
+	zw := gzip.NewWriter(&zipped)
+	require.NoError(t, json.NewEncoder(zw).Encode(user))
+	require.NoError(t, zw.Close())
+	row.Zip = zipped.String()
+
+	files, err := protodesc.NewFiles(testDescriptorSet())
+	require.NoError(t, err)
+	desc, err := files.FindDescriptorByName("test.User")
+	require.NoError(t, err)
+	msgDesc := desc.(protoreflect.MessageDescriptor)
+	msg := dynamicpb.NewMessage(msgDesc)
+	msg.Set(msgDesc.Fields().ByName("name"), protoreflect.ValueOfString(name))
+	msg.Set(msgDesc.Fields().ByName("age"), protoreflect.ValueOfInt32(int32(age)))
+	encoded, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	row.Proto = string(encoded)
+
+	codec, err := goavro.NewCodec(testAvroSchema)
+	require.NoError(t, err)
+	avro, err := codec.BinaryFromNative(nil, user)
+	require.NoError(t, err)
+	row.Avro = string(avro)
+
+	return row
+}
+
+// openBlobReader writes and opens a file whose binary columns hold the same
+// two users as MessagePack, CBOR, gzip-compressed JSON, protobuf and Avro,
+// along with a decoder config for them
+func openBlobReader(t *testing.T) (*ParquetReader, string) {
+	t.Helper()
+	dir := t.TempDir()
+	config := writeDecoderFiles(t, dir)
+
+	path := filepath.Join(dir, "blobs.parquet")
+	fw, err := local.NewLocalFileWriter(path)
+	require.NoError(t, err)
+	pw, err := writer.NewParquetWriter(fw, new(blobRow))
+	require.NoError(t, err)
+	require.NoError(t, pw.Write(encodeTestUser(t, 1, "ann", 31)))
+	require.NoError(t, pw.Write(encodeTestUser(t, 2, "bob", 42)))
+	require.NoError(t, pw.WriteStop())
+	require.NoError(t, fw.Close())
+
+	pr, err := pio.NewParquetFileReader(path, pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pr.ReadStop() })
+	return NewParquetReader(pr), config
+}
+
+func Test_LoadDecoderRegistry(t *testing.T) {
+	dir := t.TempDir()
+	config := writeDecoderFiles(t, dir)
+
+	registry, err := LoadDecoderRegistry(config)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Avro", "Card", "Pack", "Proto", "Zip"}, registry.Columns())
+	require.Equal(t, DecoderProtobuf, registry.Lookup("Proto").Name())
+	require.Equal(t, DecoderAvro, registry.Lookup("Avro").Name())
+	require.Nil(t, registry.Lookup("ID"))
+
+	testCases := map[string]struct {
+		config string
+		errMsg string
+	}{
+		"bad-json":          {config: `{`, errMsg: "invalid decoder config"},
+		"unknown-field":     {config: `{"decoders": {}}`, errMsg: "invalid decoder config"},
+		"unknown-type":      {config: `{"columns": {"a": {"type": "xml"}}}`, errMsg: `unknown decoder type "xml"`},
+		"protobuf-no-msg":   {config: `{"columns": {"a": {"type": "protobuf", "descriptor_set": "user.pb"}}}`, errMsg: "needs descriptor_set and message"},
+		"protobuf-no-file":  {config: `{"columns": {"a": {"type": "protobuf", "descriptor_set": "nope.pb", "message": "test.User"}}}`, errMsg: "nope.pb"},
+		"protobuf-bad-name": {config: `{"columns": {"a": {"type": "protobuf", "descriptor_set": "user.pb", "message": "test.Nobody"}}}`, errMsg: "test.Nobody"},
+		"protobuf-not-msg":  {config: `{"columns": {"a": {"type": "protobuf", "descriptor_set": "user.pb", "message": "test"}}}`, errMsg: "test"},
+		"avro-no-schema":    {config: `{"columns": {"a": {"type": "avro"}}}`, errMsg: "needs schema"},
+		"avro-bad-schema":   {config: `{"columns": {"a": {"type": "avro", "schema": "decoders.json"}}}`, errMsg: "avro schema"},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name+".json")
+			require.NoError(t, os.WriteFile(path, []byte(tc.config), 0o600))
+			_, err := LoadDecoderRegistry(path)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, ErrInvalidDecoderConfig))
+			require.Contains(t, err.Error(), tc.errMsg)
+		})
+	}
+
+	_, err = LoadDecoderRegistry(filepath.Join(dir, "missing.json"))
+	require.ErrorContains(t, err, "read decoder config")
+}
+
+// upperDecoder is a custom decoder type for the registration test
+type upperDecoder struct{ prefix string }
+
+func (upperDecoder) Name() string { return "upper" }
+
+func (d upperDecoder) Decode(raw []byte) (any, error) {
+	return d.prefix + string(bytes.ToUpper(raw)), nil
+}
+
+func Test_RegisterDecoderType(t *testing.T) {
+	RegisterDecoderType("upper", func(cfg DecoderConfig, baseDir string) (ValueDecoder, error) {
+		return upperDecoder{prefix: cfg.Message}, nil
+	})
+	t.Cleanup(func() {
+		decoderFactoriesMu.Lock()
+		delete(decoderFactories, "upper")
+		decoderFactoriesMu.Unlock()
+	})
+
+	decoder, err := NewDecoder(DecoderConfig{Type: "upper", Message: "> "}, "")
+	require.NoError(t, err)
+	decoded, err := decoder.Decode([]byte("abc"))
+	require.NoError(t, err)
+	require.Equal(t, "> ABC", decoded)
+}
+
+func Test_WithDecoders(t *testing.T) {
+	pr, config := openBlobReader(t)
+	registry, err := LoadDecoderRegistry(config)
+	require.NoError(t, err)
+
+	decoded, err := pr.WithDecoders(registry)
+	require.NoError(t, err)
+	require.Same(t, registry, decoded.Decoders())
+	require.Nil(t, pr.Decoders())
+
+	unknown := NewDecoderRegistry()
+	unknown.Register("Nope", msgpackDecoder{})
+	_, err = pr.WithDecoders(unknown)
+	require.True(t, errors.Is(err, ErrInvalidDecoderConfig))
+	require.True(t, errors.Is(err, ErrInvalidColumnPath))
+
+	numeric := NewDecoderRegistry()
+	numeric.Register("Id", msgpackDecoder{})
+	_, err = pr.WithDecoders(numeric)
+	require.True(t, errors.Is(err, ErrInvalidDecoderConfig))
+	require.Contains(t, err.Error(), "does not hold binary values")
+
+	_, err = pr.WithDecoders(nil)
+	require.NoError(t, err)
+}
+
+func Test_DecodedViews(t *testing.T) {
+	pr, config := openBlobReader(t)
+	registry, err := LoadDecoderRegistry(config)
+	require.NoError(t, err)
+	pr, err = pr.WithDecoders(registry)
+	require.NoError(t, err)
+
+	for colIndex := 1; colIndex <= 5; colIndex++ {
+		path := formatColumnName(pr.metadata.RowGroups[0].Columns[colIndex].MetaData.PathInSchema)
+		t.Run(path, func(t *testing.T) {
+			// The writer puts each row in a page of its own
+			values, err := pr.GetPageContentFormatted(0, colIndex, 0)
+			require.NoError(t, err)
+			require.Len(t, values, 1)
+			require.JSONEq(t, `{"age": 31, "name": "ann"}`, values[0])
+
+			typed, err := pr.GetPageContentTyped(0, colIndex, 0, GeoFormatGeoJSON)
+			require.NoError(t, err)
+			require.Equal(t, map[string]any{"age": json.Number("31"), "name": "ann"}, normalizeNumbers(typed[0].Value))
+
+			detail, err := pr.GetPageValueDetail(0, colIndex, 1, 0)
+			require.NoError(t, err)
+			require.Equal(t, registry.Lookup(path).Name(), detail.Decoder)
+			require.Empty(t, detail.DecodeError)
+			require.Contains(t, detail.Formatted, "\n  \"name\": \"bob\"")
+
+			info, err := pr.GetColumnChunkInfo(0, colIndex)
+			require.NoError(t, err)
+			require.Contains(t, []string{info.MinValue, info.MaxValue}, `{"age":31,"name":"ann"}`)
+
+			stat, err := pr.GetColumnChunkStatistic(0, colIndex, StatisticMin)
+			require.NoError(t, err)
+			require.NotEmpty(t, stat.Decoder)
+		})
+	}
+
+	// Values that do not decode fall back to the regular rendering
+	broken := NewDecoderRegistry()
+	broken.Register("Pack", gzipJSONDecoder{})
+	pr, err = pr.WithDecoders(broken)
+	require.NoError(t, err)
+	detail, err := pr.GetPageValueDetail(0, 1, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, DecoderGzipJSON, detail.Decoder)
+	require.NotEmpty(t, detail.DecodeError)
+	require.Equal(t, detail.Base64, detail.Formatted)
+
+	profile, err := pr.WithDecoders(registry)
+	require.NoError(t, err)
+	result, err := profile.ProfileColumn(t.Context(), 1, ProfileOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.TopValues)
+	require.JSONEq(t, `{"age": 31, "name": "ann"}`, result.TopValues[0].Value)
+}
+
+// normalizeNumbers turns the numbers of decoded data into json.Number, the
+// decoders differ in the Go types they produce
+func normalizeNumbers(val any) any {
+	text, _ := json.Marshal(val)
+	normalized, _ := unmarshalJSON(text)
+	return normalized
+}
+
+func Test_jsonCompatible(t *testing.T) {
+	converted := jsonCompatible(map[any]any{
+		1:     []any{[]byte("hi"), map[string]any{"x": float32(1.5)}},
+		"nan": float64Nan(),
+	})
+	text, err := json.Marshal(converted)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"1": ["aGk=", {"x": 1.5}], "nan": "NaN"}`, string(text))
+}
+
+func float64Nan() float64 {
+	zero := 0.0
+	return zero / zero
+}
+
+func Test_builtinDecoders_Errors(t *testing.T) {
+	for _, decoder := range []ValueDecoder{msgpackDecoder{}, cborDecoder{}, gzipJSONDecoder{}} {
+		t.Run(decoder.Name(), func(t *testing.T) {
+			_, err := decoder.Decode([]byte{0xc1})
+			require.Error(t, err)
+		})
+	}
+
+	_, err := msgpackDecoder{}.Decode([]byte("ByteArray-0"))
+	require.ErrorContains(t, err, "unexpected bytes")
+
+	var zipped bytes.Buffer
+	zw := gzip.NewWriter(&zipped)
+	_, _ = zw.Write([]byte(`{"a": 1} {"b": 2}`))
+	require.NoError(t, zw.Close())
+	_, err = gzipJSONDecoder{}.Decode(zipped.Bytes())
+	require.ErrorContains(t, err, "unexpected data")
+
+	codec, err := goavro.NewCodec(testAvroSchema)
+	require.NoError(t, err)
+	avro := avroDecoder{codec: codec}
+	_, err = avro.Decode([]byte{0x02})
+	require.Error(t, err)
+	single, err := codec.SingleFromNative(nil, map[string]any{"name": "cy", "age": 7})
+	require.NoError(t, err)
+	decoded, err := avro.Decode(single)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"age": json.Number("7"), "name": "cy"}, decoded)
+	_, err = avro.Decode(append(single, 0))
+	require.ErrorContains(t, err, "unexpected bytes")
+}
@@ -0,0 +1,323 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/reader"
+)
+
+// DictionaryEntry is one dictionary value and how many values of the column
+// chunk's data pages reference it
+type DictionaryEntry struct {
+	Index      int
+	Value      string
+	References int64
+	key        any // the decoded value, distinct where Value may not be
+}
+
+// RowGroupDictionary describes the dictionary of one column chunk and how its
+// data pages use it
+type RowGroupDictionary struct {
+	RowGroup         int
+	HasDictionary    bool
+	NumEntries       int
+	CompressedSize   int32
+	UncompressedSize int32
+	// DataPages counts all data pages; DictionaryEncodedPages the data pages
+	// encoded with PLAIN_DICTIONARY or RLE_DICTIONARY, FallbackPages the rest.
+	// The dictionary page itself is in none of them.
+	DataPages              int
+	DictionaryEncodedPages int
+	FallbackPages          int
+	FallbackEncodings      []string
+	DictionaryValues       int64 // non-null values read through the dictionary
+	FallbackValues         int64 // values (including nulls) in fallback pages
+	UnusedEntries          int
+	Entries                []DictionaryEntry
+}
+
+// DictionaryValue is one distinct dictionary value across all row groups
+type DictionaryValue struct {
+	Value      string
+	RowGroups  int // number of row group dictionaries containing the value
+	References int64
+}
+
+// DictionaryAnalysis summarizes dictionary encoding of a column across all row groups
+type DictionaryAnalysis struct {
+	ColumnIndex            int
+	Path                   string
+	PhysicalType           string
+	RowGroups              []RowGroupDictionary
+	TotalCompressedSize    int64
+	TotalUncompressedSize  int64
+	TotalEntries           int
+	DataPages              int
+	DictionaryEncodedPages int
+	FallbackPages          int
+	DictionaryEncodedRatio float64 // share of data pages that are dictionary-encoded
+	DistinctValues         int
+	Values                 []DictionaryValue // ordered by references, most referenced first
+}
+
+// isDictionaryEncoding reports whether data page values are dictionary indices
+func isDictionaryEncoding(encoding parquet.Encoding) bool {
+	return encoding == parquet.Encoding_PLAIN_DICTIONARY || encoding == parquet.Encoding_RLE_DICTIONARY
+}
+
+// dictionaryKey returns a comparable key for a decoded dictionary value.
+// Floats are keyed by their bits so that NaN matches itself.
+func dictionaryKey(v any) any {
+	switch v := v.(type) {
+	case float32:
+		return math.Float32bits(v)
+	case float64:
+		return math.Float64bits(v)
+	}
+	return v
+}
+
+// AnalyzeDictionary reports per row group dictionary sizes, the share of data
+// pages that are dictionary-encoded versus fallen back to another encoding,
+// how often each dictionary entry is referenced, and the union of dictionary
+// values across all row groups
+func (pr *ParquetReader) AnalyzeDictionary(ctx context.Context, colIndex int) (DictionaryAnalysis, error) {
+	if pr == nil || pr.metadata == nil || len(pr.metadata.RowGroups) == 0 {
+		return DictionaryAnalysis{}, ErrInvalidColumnIndex
+	}
+	pr = pr.WithContext(ctx)
+
+	numColumns := len(pr.metadata.RowGroups[0].Columns)
+	if colIndex < 0 || colIndex >= numColumns {
+		return DictionaryAnalysis{}, fmt.Errorf("column index %d out of range [0, %d): %w",
+			colIndex, numColumns, ErrInvalidColumnIndex)
+	}
+
+	meta := pr.metadata.RowGroups[0].Columns[colIndex].MetaData
+	analysis := DictionaryAnalysis{
+		ColumnIndex:  colIndex,
+		Path:         pr.columnName(colIndex),
+		PhysicalType: meta.Type.String(),
+		RowGroups:    make([]RowGroupDictionary, 0, len(pr.metadata.RowGroups)),
+	}
+
+	// Keyed on decoded values: formatted ones are cut short and rounded to
+	// the display options, which can merge distinct values
+	union := make(map[any]*DictionaryValue)
+	for rgIndex := range pr.metadata.RowGroups {
+		if err := ctx.Err(); err != nil {
+			return DictionaryAnalysis{}, err
+		}
+
+		rgDict, err := pr.analyzeChunkDictionary(ctx, rgIndex, colIndex)
+		if err != nil {
+			return DictionaryAnalysis{}, fmt.Errorf("row group %d: %w", rgIndex, err)
+		}
+
+		analysis.TotalCompressedSize += int64(rgDict.CompressedSize)
+		analysis.TotalUncompressedSize += int64(rgDict.UncompressedSize)
+		analysis.TotalEntries += rgDict.NumEntries
+		analysis.DataPages += rgDict.DataPages
+		analysis.DictionaryEncodedPages += rgDict.DictionaryEncodedPages
+		analysis.FallbackPages += rgDict.FallbackPages
+
+		seen := make(map[any]bool, len(rgDict.Entries))
+		for _, entry := range rgDict.Entries {
+			v, ok := union[entry.key]
+			if !ok {
+				v = &DictionaryValue{Value: entry.Value}
+				union[entry.key] = v
+			}
+			if !seen[entry.key] {
+				seen[entry.key] = true
+				v.RowGroups++
+			}
+			v.References += entry.References
+		}
+
+		analysis.RowGroups = append(analysis.RowGroups, rgDict)
+	}
+
+	if analysis.DataPages > 0 {
+		analysis.DictionaryEncodedRatio = float64(analysis.DictionaryEncodedPages) / float64(analysis.DataPages)
+	}
+
+	analysis.DistinctValues = len(union)
+	analysis.Values = make([]DictionaryValue, 0, len(union))
+	for _, v := range union {
+		analysis.Values = append(analysis.Values, *v)
+	}
+	sort.Slice(analysis.Values, func(i, j int) bool {
+		if analysis.Values[i].References != analysis.Values[j].References {
+			return analysis.Values[i].References > analysis.Values[j].References
+		}
+		return analysis.Values[i].Value < analysis.Values[j].Value
+	})
+
+	return analysis, nil
+}
+
+// analyzeChunkDictionary analyzes the dictionary of one column chunk
+//
+//nolint:gocognit // Walks every page of the chunk and branches on page type and encoding
+func (pr *ParquetReader) analyzeChunkDictionary(ctx context.Context, rgIndex, colIndex int) (RowGroupDictionary, error) {
+	meta := pr.metadata.RowGroups[rgIndex].Columns[colIndex].MetaData
+	schemaElem := pr.schemaElement(colIndex)
+	maxDef, maxRep := columnLevels(pr.metadata.Schema, meta.PathInSchema)
+
+	headers, err := pr.pageHeaders(rgIndex, colIndex)
+	if err != nil {
+		return RowGroupDictionary{}, err
+	}
+
+	result := RowGroupDictionary{RowGroup: rgIndex}
+	fallbackEncodings := make(map[string]bool)
+	for _, header := range headers {
+		if err := ctx.Err(); err != nil {
+			return RowGroupDictionary{}, err
+		}
+
+		switch header.PageType {
+		case parquet.PageType_DICTIONARY_PAGE:
+			values, err := pr.readDictionaryValues(meta, header, schemaElem)
+			if err != nil {
+				return RowGroupDictionary{}, err
+			}
+			result.HasDictionary = true
+			result.NumEntries = len(values)
+			result.CompressedSize = header.CompressedSize
+			result.UncompressedSize = header.UncompressedSize
+			result.Entries = make([]DictionaryEntry, len(values))
+			decoder := pr.decoderFor(colIndex)
+			for i, v := range values {
+				result.Entries[i] = DictionaryEntry{
+					Index: i,
+					Value: formatValue(pr.display, decoder, v, meta.Type, schemaElem),
+					key:   dictionaryKey(v),
+				}
+			}
+
+		case parquet.PageType_DATA_PAGE, parquet.PageType_DATA_PAGE_V2:
+			result.DataPages++
+			if !isDictionaryEncoding(header.Encoding) {
+				result.FallbackPages++
+				result.FallbackValues += int64(header.NumValues)
+				fallbackEncodings[header.Encoding.String()] = true
+				continue
+			}
+
+			result.DictionaryEncodedPages++
+			indices, err := pr.readDictionaryIndices(meta, header, maxDef, maxRep)
+			if err != nil {
+				return RowGroupDictionary{}, fmt.Errorf("page at offset %d: %w", header.Offset, err)
+			}
+			result.DictionaryValues += int64(len(indices))
+			for _, idx := range indices {
+				if int(idx) >= len(result.Entries) {
+					return RowGroupDictionary{}, fmt.Errorf("page at offset %d: dictionary index %d out of range [0, %d)",
+						header.Offset, idx, len(result.Entries))
+				}
+				result.Entries[idx].References++
+			}
+		}
+	}
+
+	for _, entry := range result.Entries {
+		if entry.References == 0 {
+			result.UnusedEntries++
+		}
+	}
+	for encoding := range fallbackEncodings {
+		result.FallbackEncodings = append(result.FallbackEncodings, encoding)
+	}
+	sort.Strings(result.FallbackEncodings)
+
+	return result, nil
+}
+
+// readDictionaryIndices decodes the dictionary indices stored in a
+// dictionary-encoded data page, one per non-null value
+func (pr *ParquetReader) readDictionaryIndices(meta *parquet.ColumnMetaData, header reader.PageHeaderInfo, maxDef, maxRep int32) ([]uint32, error) {
+	data, err := pr.readPageData(meta, header)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := splitDataPage(data, header, maxDef, maxRep)
+	if err != nil {
+		return nil, err
+	}
+
+	numNonNull, err := countNonNull(sections, header, maxDef)
+	if err != nil {
+		return nil, err
+	}
+	if numNonNull == 0 {
+		return nil, nil
+	}
+
+	// The value section starts with one byte holding the index bit width
+	if len(sections.values) == 0 {
+		return nil, fmt.Errorf("missing dictionary index bit width: %w", errTruncatedPage)
+	}
+	indices, _, err := decodeHybrid(sections.values[1:], int(sections.values[0]), numNonNull)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary indices: %w", err)
+	}
+	return indices, nil
+}
+
+// readDictionaryValues reads and decodes the values of a dictionary page, or
+// takes them from the page cache
+func (pr *ParquetReader) readDictionaryValues(meta *parquet.ColumnMetaData, header reader.PageHeaderInfo, schemaElem *parquet.SchemaElement) ([]interface{}, error) {
+	key := cacheKey{kind: cacheDictionary, offset: header.Offset}
+	if cached, ok := pr.fromCache(key); ok {
+		return cached.([]interface{}), nil
+	}
+	values, err := pr.decodeDictionaryPage(meta, header, schemaElem)
+	if err != nil {
+		return nil, err
+	}
+	pr.toCache(key, values, valuesSize(values))
+	return values, nil
+}
+
+// decodeDictionaryPage reads and decodes the values of a dictionary page
+func (pr *ParquetReader) decodeDictionaryPage(meta *parquet.ColumnMetaData, header reader.PageHeaderInfo, schemaElem *parquet.SchemaElement) ([]interface{}, error) {
+	if meta.Type != parquet.Type_FIXED_LEN_BYTE_ARRAY {
+		var values []interface{}
+		err := pr.withReader(IODictionary, func(r *reader.ParquetReader) error {
+			var err error
+			values, err = r.ReadDictionaryPageValues(header.Offset, meta.Codec, meta.Type)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dictionary page: %w", err)
+		}
+		return values, nil
+	}
+
+	// The reader's offset-based helper has no type length, so fixed length
+	// values are split here using the schema's type length
+	if schemaElem == nil || schemaElem.GetTypeLength() <= 0 {
+		return nil, fmt.Errorf("failed to read dictionary page: unknown type length for %s", formatColumnName(meta.PathInSchema))
+	}
+	data, err := pr.readPageData(meta, header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dictionary page: %w", err)
+	}
+	size := int(schemaElem.GetTypeLength())
+	count := int(header.NumValues)
+	if count*size > len(data) {
+		return nil, fmt.Errorf("failed to read dictionary page: %d values of %d bytes: %w", count, size, errTruncatedPage)
+	}
+	values := make([]interface{}, count)
+	for i := range values {
+		values[i] = string(data[i*size : (i+1)*size])
+	}
+	return values, nil
+}
@@ -0,0 +1,178 @@
+package model
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hangxie/parquet-go/v3/source/local"
+	"github.com/hangxie/parquet-go/v3/writer"
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AnalyzeDictionary(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	t.Run("Dictionary encoded", func(t *testing.T) {
+		analysis, err := pr.AnalyzeDictionary(context.Background(), 1)
+		require.NoError(t, err)
+		require.Equal(t, "Int32", analysis.Path)
+		require.Equal(t, "INT32", analysis.PhysicalType)
+		require.Len(t, analysis.RowGroups, 1)
+		require.Equal(t, 3, analysis.DataPages)
+		require.Equal(t, 3, analysis.DictionaryEncodedPages)
+		require.Zero(t, analysis.FallbackPages)
+		require.Equal(t, 1.0, analysis.DictionaryEncodedRatio)
+		require.Equal(t, 5, analysis.DistinctValues)
+		require.Equal(t, 5, analysis.TotalEntries)
+		require.Positive(t, analysis.TotalCompressedSize)
+
+		rg := analysis.RowGroups[0]
+		require.True(t, rg.HasDictionary)
+		require.Equal(t, 5, rg.NumEntries)
+		require.Equal(t, int64(5), rg.DictionaryValues)
+		require.Zero(t, rg.UnusedEntries)
+		for _, entry := range rg.Entries {
+			require.Equal(t, int64(1), entry.References)
+		}
+	})
+
+	t.Run("Fallback to plain", func(t *testing.T) {
+		analysis, err := pr.AnalyzeDictionary(context.Background(), 3)
+		require.NoError(t, err)
+		require.Equal(t, 3, analysis.FallbackPages)
+		require.Zero(t, analysis.DictionaryEncodedPages)
+		require.Zero(t, analysis.DictionaryEncodedRatio)
+		require.Zero(t, analysis.DistinctValues)
+
+		rg := analysis.RowGroups[0]
+		require.False(t, rg.HasDictionary)
+		require.Equal(t, []string{"PLAIN"}, rg.FallbackEncodings)
+		require.Equal(t, int64(5), rg.FallbackValues)
+	})
+
+	t.Run("Repeated column", func(t *testing.T) {
+		analysis, err := pr.AnalyzeDictionary(context.Background(), 46)
+		require.NoError(t, err)
+		require.Equal(t, "Map.Key_value.Key", analysis.Path)
+		require.Equal(t, int64(10), analysis.RowGroups[0].DictionaryValues)
+		require.Equal(t, []DictionaryValue{
+			{Value: "Composite-0", RowGroups: 1, References: 4},
+			{Value: "Composite-1", RowGroups: 1, References: 3},
+			{Value: "Composite-2", RowGroups: 1, References: 2},
+			{Value: "Composite-3", RowGroups: 1, References: 1},
+		}, analysis.Values)
+	})
+
+	t.Run("Optional column with nulls", func(t *testing.T) {
+		analysis, err := pr.AnalyzeDictionary(context.Background(), 45)
+		require.NoError(t, err)
+		require.Equal(t, 2, analysis.RowGroups[0].NumEntries)
+		require.Equal(t, int64(2), analysis.RowGroups[0].DictionaryValues)
+	})
+
+	t.Run("Fixed length values", func(t *testing.T) {
+		analysis, err := pr.AnalyzeDictionary(context.Background(), 16)
+		require.NoError(t, err)
+		require.Equal(t, 5, analysis.DistinctValues)
+		for _, v := range analysis.Values {
+			require.NotEmpty(t, v.Value)
+		}
+	})
+
+	t.Run("Invalid index", func(t *testing.T) {
+		_, err := pr.AnalyzeDictionary(context.Background(), 999)
+		require.ErrorIs(t, err, ErrInvalidColumnIndex)
+
+		_, err = pr.AnalyzeDictionary(context.Background(), -1)
+		require.ErrorIs(t, err, ErrInvalidColumnIndex)
+
+		var nilReader *ParquetReader
+		_, err = nilReader.AnalyzeDictionary(context.Background(), 0)
+		require.ErrorIs(t, err, ErrInvalidColumnIndex)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := pr.AnalyzeDictionary(ctx, 1)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+type similarValuesRow struct {
+	Text  string  `parquet:"name=text, type=BYTE_ARRAY, convertedtype=UTF8, encoding=RLE_DICTIONARY"`
+	Ratio float64 `parquet:"name=ratio, type=DOUBLE, encoding=RLE_DICTIONARY"`
+}
+
+func Test_AnalyzeDictionary_SimilarValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "similar.parquet")
+	fw, err := local.NewLocalFileWriter(path)
+	require.NoError(t, err)
+	pw, err := writer.NewParquetWriter(fw, new(similarValuesRow))
+	require.NoError(t, err)
+	prefix := strings.Repeat("x", 250)
+	for i := range 6 {
+		require.NoError(t, pw.Write(similarValuesRow{
+			Text:  prefix + string(rune('a'+i%3)),
+			Ratio: 0.11 + float64(i%2)/100,
+		}))
+	}
+	require.NoError(t, pw.WriteStop())
+	require.NoError(t, fw.Close())
+
+	r, err := pio.NewParquetFileReader(path, pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.ReadStop() })
+	pr := NewParquetReader(r).WithDisplayOptions(DisplayOptions{FloatPrecision: 1})
+
+	// The texts are cut to the same 200 characters and the ratios both show
+	// as 0.1, the analysis still tells them apart
+	analysis, err := pr.AnalyzeDictionary(context.Background(), 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, analysis.DistinctValues)
+	for _, v := range analysis.Values {
+		require.Equal(t, int64(2), v.References)
+		require.Equal(t, analysis.Values[0].Value, v.Value)
+	}
+
+	analysis, err = pr.AnalyzeDictionary(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, analysis.DistinctValues)
+	require.Equal(t, []DictionaryValue{
+		{Value: "0.1", RowGroups: 1, References: 3},
+		{Value: "0.1", RowGroups: 1, References: 3},
+	}, analysis.Values)
+}
+
+func Test_dictionaryKey(t *testing.T) {
+	require.Equal(t, dictionaryKey(math.NaN()), dictionaryKey(math.NaN()))
+	require.NotEqual(t, dictionaryKey(0.0), dictionaryKey(math.Copysign(0, -1)))
+	require.NotEqual(t, dictionaryKey(float32(0.11)), dictionaryKey(float32(0.12)))
+	require.Equal(t, "abc", dictionaryKey("abc"))
+	require.Equal(t, int32(7), dictionaryKey(int32(7)))
+}
+
+func Test_isDictionaryEncoding(t *testing.T) {
+	pr := openTestParquetReader(t)
+	pages, err := pr.GetPageMetadataList(0, 1)
+	require.NoError(t, err)
+	require.Equal(t, "RLE_DICTIONARY", pages[1].Encoding)
+
+	headers, err := pr.Reader.GetAllPageHeaders(0, 1)
+	require.NoError(t, err)
+	require.False(t, isDictionaryEncoding(headers[0].Encoding))
+	require.True(t, isDictionaryEncoding(headers[1].Encoding))
+}
+
+func Test_ReadDictionaryPageContent_FixedLenByteArray(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	values, err := pr.GetPageContentFormatted(0, 16, 0)
+	require.NoError(t, err)
+	require.Len(t, values, 5)
+	require.Equal(t, "Rml4ZWQtMDAwMA==", values[0])
+}
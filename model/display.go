@@ -0,0 +1,301 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+)
+
+// Renderings of TIMESTAMP, TIME, DATE and INT96 values
+const (
+	TemporalISO   = "iso"   // ISO 8601 text
+	TemporalEpoch = "epoch" // the stored integer, nanoseconds since epoch for INT96
+)
+
+// Renderings of BYTE_ARRAY and FIXED_LEN_BYTE_ARRAY values without a logical type
+const (
+	BinaryBase64 = "base64"
+	BinaryHex    = "hex"
+	BinaryUTF8   = "utf8" // invalid sequences are replaced with U+FFFD
+)
+
+// Renderings of DECIMAL values
+const (
+	DecimalScaled   = "scaled"   // the scale applied, e.g. 1.05
+	DecimalUnscaled = "unscaled" // the stored integer, e.g. 105
+)
+
+// maxFloatPrecision is the largest number of digits a float64 holds after the
+// decimal point that are worth showing
+const maxFloatPrecision = 17
+
+// FloatShortest is the float precision that asks for the shortest form. A zero
+// precision leaves the option unset, so only FloatShortest takes a precision
+// set elsewhere back to the shortest form when options are merged.
+const FloatShortest = -1
+
+// DisplayOptions are the preferences used to render values as text. The zero
+// value renders values the way they always were: ISO timestamps in UTC, the
+// scale applied to decimals, binaries as base64 and floats in their shortest
+// form.
+type DisplayOptions struct {
+	TimeZone       string `json:"timezone,omitempty"`        // IANA name for UTC-adjusted timestamps, UTC when empty
+	Temporal       string `json:"temporal,omitempty"`        // TemporalISO or TemporalEpoch
+	Binary         string `json:"binary,omitempty"`          // BinaryBase64, BinaryHex or BinaryUTF8
+	Decimal        string `json:"decimal,omitempty"`         // DecimalScaled or DecimalUnscaled
+	FloatPrecision int    `json:"float_precision,omitempty"` // digits after the decimal point, 0 or FloatShortest for the shortest form
+}
+
+// Validate checks every option, including that the time zone is known
+func (o DisplayOptions) Validate() error {
+	if o.TimeZone != "" {
+		if _, err := loadLocation(o.TimeZone); err != nil {
+			return fmt.Errorf("%w: time zone %q: %v", ErrInvalidDisplayOption, o.TimeZone, err)
+		}
+	}
+	if err := checkChoice("temporal", o.Temporal, TemporalISO, TemporalEpoch); err != nil {
+		return err
+	}
+	if err := checkChoice("binary", o.Binary, BinaryBase64, BinaryHex, BinaryUTF8); err != nil {
+		return err
+	}
+	if err := checkChoice("decimal", o.Decimal, DecimalScaled, DecimalUnscaled); err != nil {
+		return err
+	}
+	if o.FloatPrecision < FloatShortest || o.FloatPrecision > maxFloatPrecision {
+		return fmt.Errorf("%w: float precision %d out of range [%d, %d]", ErrInvalidDisplayOption, o.FloatPrecision, FloatShortest, maxFloatPrecision)
+	}
+	return nil
+}
+
+// checkChoice accepts an empty value or one of choices
+func checkChoice(name, value string, choices ...string) error {
+	if value == "" {
+		return nil
+	}
+	for _, choice := range choices {
+		if value == choice {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s %q (expected one of %s)", ErrInvalidDisplayOption, name, value, strings.Join(choices, ", "))
+}
+
+// Merge returns o with every option set in override replacing its own. Empty
+// strings and a zero precision are unset; an option goes back to its default
+// when override names the default: TemporalISO, BinaryBase64, DecimalScaled,
+// "UTC" or FloatShortest.
+func (o DisplayOptions) Merge(override DisplayOptions) DisplayOptions {
+	if override.TimeZone != "" {
+		o.TimeZone = override.TimeZone
+	}
+	if override.Temporal != "" {
+		o.Temporal = override.Temporal
+	}
+	if override.Binary != "" {
+		o.Binary = override.Binary
+	}
+	if override.Decimal != "" {
+		o.Decimal = override.Decimal
+	}
+	if override.FloatPrecision != 0 {
+		o.FloatPrecision = override.FloatPrecision
+	}
+	return o
+}
+
+// locations caches loaded time zones, loading one reads the zone database
+var locations sync.Map
+
+// loadLocation is time.LoadLocation with a cache
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// FormatValue formats a page value for display with these options
+func (o DisplayOptions) FormatValue(val any, parquetType parquet.Type, schemaElem *parquet.SchemaElement) string {
+	return truncateDisplayValue(o.formatFullValue(val, parquetType, schemaElem))
+}
+
+// FormatStatValue formats a min/max statistic for display with these options
+func (o DisplayOptions) FormatStatValue(value []byte, columnMeta *parquet.ColumnMetaData, schemaElem *parquet.SchemaElement) string {
+	if len(value) == 0 {
+		return "-"
+	}
+
+	// Geospatial min/max are not meaningful, bounds live in GeospatialStatistics
+	if isGeospatial(schemaElem) {
+		return "-"
+	}
+
+	// Retrieve the raw value from bytes
+	rawValue := retrieveStatValue(value, columnMeta.Type)
+	if rawValue == nil {
+		return "-"
+	}
+
+	return truncateStatValue(formatConverted(o.convertValue(rawValue, columnMeta.Type, schemaElem)))
+}
+
+// FormatGeoValue is FormatValue with GEOMETRY and GEOGRAPHY values rendered
+// as WKT or GeoJSON. Values that are not valid WKB fall back to the regular
+// formatting.
+func (o DisplayOptions) FormatGeoValue(val any, parquetType parquet.Type, schemaElem *parquet.SchemaElement, format GeoFormat) string {
+	if format == GeoFormatWKT && isGeospatial(schemaElem) {
+		if wkb := physicalBytes(val); wkb != nil {
+			if wkt, err := WKBToWKT(wkb); err == nil {
+				return wkt
+			}
+		}
+	}
+	return o.FormatValue(val, parquetType, schemaElem)
+}
+
+// formatFullValue is FormatValue without truncation
+func (o DisplayOptions) formatFullValue(val any, parquetType parquet.Type, schemaElem *parquet.SchemaElement) string {
+	if val == nil {
+		return "NULL"
+	}
+
+	// Check for empty string - should display as empty, not NULL
+	if str, ok := val.(string); ok && str == "" {
+		return ""
+	}
+
+	return formatConverted(o.convertValue(val, parquetType, schemaElem))
+}
+
+// convertValue is convertValue with these options applied
+func (o DisplayOptions) convertValue(val any, parquetType parquet.Type, schemaElem *parquet.SchemaElement) any {
+	if o.Temporal == TemporalEpoch {
+		if epoch, ok := epochValue(val, parquetType, schemaElem); ok {
+			return epoch
+		}
+	}
+	if o.TimeZone != "" {
+		if t, layout, ok := utcTimestamp(val, parquetType, schemaElem); ok {
+			if loc, err := loadLocation(o.TimeZone); err == nil {
+				return t.In(loc).Format(layout)
+			}
+		}
+	}
+	if o.Decimal == DecimalUnscaled && schemaElem != nil {
+		if _, ok := decimalScale(schemaElem); ok {
+			if str, ok := formatDecimal(val, parquetType, 0); ok {
+				return str
+			}
+		}
+	}
+	if o.Binary != "" && valueKind(parquetType, schemaElem) == ValueKindBinary {
+		if raw := physicalBytes(val); raw != nil {
+			switch o.Binary {
+			case BinaryHex:
+				return hex.EncodeToString(raw)
+			case BinaryUTF8:
+				return strings.ToValidUTF8(string(raw), "\uFFFD")
+			case BinaryBase64:
+				return base64.StdEncoding.EncodeToString(raw)
+			}
+		}
+	}
+
+	converted := convertValue(val, parquetType, schemaElem)
+	if o.FloatPrecision > 0 {
+		switch f := converted.(type) {
+		case float32:
+			return strconv.FormatFloat(float64(f), 'f', o.FloatPrecision, 32)
+		case float64:
+			return strconv.FormatFloat(f, 'f', o.FloatPrecision, 64)
+		}
+	}
+	return converted
+}
+
+// epochValue returns the stored integer of a TIMESTAMP, TIME or DATE value,
+// and nanoseconds since epoch for INT96. ok is false for other values.
+func epochValue(val any, parquetType parquet.Type, se *parquet.SchemaElement) (any, bool) {
+	if parquetType == parquet.Type_INT96 {
+		if t, ok := int96Time(val); ok {
+			return t.UnixNano(), true
+		}
+		return nil, false
+	}
+	if se == nil {
+		return nil, false
+	}
+
+	temporal := false
+	if lt := se.LogicalType; lt != nil {
+		temporal = lt.IsSetTIMESTAMP() || lt.IsSetTIME() || lt.IsSetDATE()
+	} else if se.ConvertedType != nil {
+		switch *se.ConvertedType {
+		case parquet.ConvertedType_DATE, parquet.ConvertedType_TIME_MILLIS, parquet.ConvertedType_TIME_MICROS,
+			parquet.ConvertedType_TIMESTAMP_MILLIS, parquet.ConvertedType_TIMESTAMP_MICROS:
+			temporal = true
+		}
+	}
+	if !temporal {
+		return nil, false
+	}
+	switch val.(type) {
+	case int32, int64:
+		return val, true
+	}
+	return nil, false
+}
+
+// utcTimestamp decodes a UTC-adjusted timestamp along with the layout that
+// keeps its precision. Timestamps that are not adjusted to UTC have no time
+// zone to convert from, ok is false for them.
+func utcTimestamp(val any, parquetType parquet.Type, se *parquet.SchemaElement) (time.Time, string, bool) {
+	const (
+		millis = "2006-01-02T15:04:05.000Z07:00"
+		micros = "2006-01-02T15:04:05.000000Z07:00"
+		nanos  = "2006-01-02T15:04:05.000000000Z07:00"
+	)
+
+	if parquetType == parquet.Type_INT96 {
+		t, ok := int96Time(val)
+		return t, nanos, ok
+	}
+	v, ok := val.(int64)
+	if !ok || se == nil {
+		return time.Time{}, "", false
+	}
+
+	if lt := se.LogicalType; lt != nil {
+		if !lt.IsSetTIMESTAMP() || !lt.GetTIMESTAMP().IsAdjustedToUTC {
+			return time.Time{}, "", false
+		}
+		switch unit := lt.GetTIMESTAMP().Unit; {
+		case unit != nil && unit.IsSetNANOS():
+			return time.Unix(0, v).UTC(), nanos, true
+		case unit != nil && unit.IsSetMICROS():
+			return time.UnixMicro(v).UTC(), micros, true
+		default:
+			return time.UnixMilli(v).UTC(), millis, true
+		}
+	}
+
+	switch se.GetConvertedType() {
+	case parquet.ConvertedType_TIMESTAMP_MILLIS:
+		return time.UnixMilli(v).UTC(), millis, true
+	case parquet.ConvertedType_TIMESTAMP_MICROS:
+		return time.UnixMicro(v).UTC(), micros, true
+	}
+	return time.Time{}, "", false
+}
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DisplayOptions_Validate(t *testing.T) {
+	valid := []DisplayOptions{
+		{},
+		{TimeZone: "America/New_York", Temporal: TemporalEpoch, Binary: BinaryUTF8, Decimal: DecimalUnscaled, FloatPrecision: maxFloatPrecision},
+		{FloatPrecision: FloatShortest},
+	}
+	for _, opts := range valid {
+		require.NoError(t, opts.Validate())
+	}
+
+	invalid := map[string]DisplayOptions{
+		"time zone":       {TimeZone: "Mars/Olympus"},
+		"temporal":        {Temporal: "unix"},
+		"binary":          {Binary: "octal"},
+		"decimal":         {Decimal: "rounded"},
+		"float precision": {FloatPrecision: FloatShortest - 1},
+		"float too large": {FloatPrecision: maxFloatPrecision + 1},
+	}
+	for name, opts := range invalid {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, opts.Validate(), ErrInvalidDisplayOption)
+		})
+	}
+}
+
+func Test_DisplayOptions_Merge(t *testing.T) {
+	base := DisplayOptions{TimeZone: "UTC", Temporal: TemporalISO, Binary: BinaryHex, Decimal: DecimalScaled, FloatPrecision: 3}
+	require.Equal(t, base, base.Merge(DisplayOptions{}))
+	require.Equal(t,
+		DisplayOptions{TimeZone: "Asia/Tokyo", Temporal: TemporalEpoch, Binary: BinaryUTF8, Decimal: DecimalUnscaled, FloatPrecision: 1},
+		base.Merge(DisplayOptions{TimeZone: "Asia/Tokyo", Temporal: TemporalEpoch, Binary: BinaryUTF8, Decimal: DecimalUnscaled, FloatPrecision: 1}))
+
+	t.Run("Back to the defaults", func(t *testing.T) {
+		custom := DisplayOptions{TimeZone: "Asia/Tokyo", Temporal: TemporalEpoch, Binary: BinaryHex, Decimal: DecimalUnscaled, FloatPrecision: 3}
+		merged := custom.Merge(DisplayOptions{TimeZone: "UTC", Temporal: TemporalISO, Binary: BinaryBase64, Decimal: DecimalScaled, FloatPrecision: FloatShortest})
+		require.NoError(t, merged.Validate())
+		require.Equal(t, FloatShortest, merged.FloatPrecision)
+		require.Equal(t, "0.3333333333333333", merged.FormatValue(1.0/3, parquet.Type_DOUBLE, nil))
+		require.Equal(t, DisplayOptions{}.FormatValue(1.0/3, parquet.Type_DOUBLE, nil), merged.FormatValue(1.0/3, parquet.Type_DOUBLE, nil))
+		require.Equal(t, DisplayOptions{}.FormatValue("abc", parquet.Type_BYTE_ARRAY, nil), merged.FormatValue("abc", parquet.Type_BYTE_ARRAY, nil))
+	})
+}
+
+func Test_DisplayOptions_FormatValue(t *testing.T) {
+	utcMillis := &parquet.SchemaElement{LogicalType: &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{
+		IsAdjustedToUTC: true, Unit: &parquet.TimeUnit{MILLIS: parquet.NewMilliSeconds()},
+	}}}
+	localMicros := &parquet.SchemaElement{LogicalType: &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{
+		IsAdjustedToUTC: false, Unit: &parquet.TimeUnit{MICROS: parquet.NewMicroSeconds()},
+	}}}
+	utcNanos := &parquet.SchemaElement{LogicalType: &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{
+		IsAdjustedToUTC: true, Unit: &parquet.TimeUnit{NANOS: parquet.NewNanoSeconds()},
+	}}}
+	date := &parquet.SchemaElement{LogicalType: &parquet.LogicalType{DATE: parquet.NewDateType()}}
+	timestampMicros := parquet.ConvertedType_TIMESTAMP_MICROS
+	decimal := &parquet.SchemaElement{LogicalType: &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: 9, Scale: 2}}}
+	utf8 := &parquet.SchemaElement{LogicalType: &parquet.LogicalType{STRING: parquet.NewStringType()}}
+	int96 := string([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0xbd, 0x87, 0x25, 0})
+	tokyo := DisplayOptions{TimeZone: "Asia/Tokyo"}
+
+	tests := []struct {
+		name     string
+		opts     DisplayOptions
+		val      any
+		pT       parquet.Type
+		se       *parquet.SchemaElement
+		expected string
+	}{
+		{"default timestamp", DisplayOptions{}, int64(1640995200004), parquet.Type_INT64, utcMillis, "2022-01-01T00:00:00.004Z"},
+		{"UTC keeps Z", DisplayOptions{TimeZone: "UTC"}, int64(1640995200004), parquet.Type_INT64, utcMillis, "2022-01-01T00:00:00.004Z"},
+		{"time zone millis", tokyo, int64(1640995200004), parquet.Type_INT64, utcMillis, "2022-01-01T09:00:00.004+09:00"},
+		{"time zone nanos", tokyo, int64(1640995200000000004), parquet.Type_INT64, utcNanos, "2022-01-01T09:00:00.000000004+09:00"},
+		{"time zone converted type", tokyo, int64(1640995200000002), parquet.Type_INT64, &parquet.SchemaElement{ConvertedType: &timestampMicros}, "2022-01-01T09:00:00.000002+09:00"},
+		{"time zone INT96", tokyo, int96, parquet.Type_INT96, nil, "2022-01-01T09:00:00.000000000+09:00"},
+		{"time zone skips local timestamps", tokyo, int64(1640995200000002), parquet.Type_INT64, localMicros, "2022-01-01T00:00:00.000002Z"},
+		{"epoch timestamp", DisplayOptions{Temporal: TemporalEpoch}, int64(1640995200004), parquet.Type_INT64, utcMillis, "1640995200004"},
+		{"epoch date", DisplayOptions{Temporal: TemporalEpoch}, int32(2000), parquet.Type_INT32, date, "2000"},
+		{"epoch INT96", DisplayOptions{Temporal: TemporalEpoch}, int96, parquet.Type_INT96, nil, "1640995200000000000"},
+		{"epoch leaves other values", DisplayOptions{Temporal: TemporalEpoch}, int32(7), parquet.Type_INT32, nil, "7"},
+		{"unscaled decimal", DisplayOptions{Decimal: DecimalUnscaled}, int32(-105), parquet.Type_INT32, decimal, "-105"},
+		{"scaled decimal", DisplayOptions{Decimal: DecimalScaled}, int32(-105), parquet.Type_INT32, decimal, "-1.05"},
+		{"binary default", DisplayOptions{}, "hi", parquet.Type_BYTE_ARRAY, nil, "aGk="},
+		{"binary hex", DisplayOptions{Binary: BinaryHex}, "hi", parquet.Type_BYTE_ARRAY, nil, "6869"},
+		{"binary base64", DisplayOptions{Binary: BinaryBase64}, []byte("hi"), parquet.Type_FIXED_LEN_BYTE_ARRAY, nil, "aGk="},
+		{"binary utf8", DisplayOptions{Binary: BinaryUTF8}, "h\xffi", parquet.Type_BYTE_ARRAY, nil, "h�i"},
+		{"binary option skips strings", DisplayOptions{Binary: BinaryHex}, "hi", parquet.Type_BYTE_ARRAY, utf8, "hi"},
+		{"float default", DisplayOptions{}, 1.0 / 3, parquet.Type_DOUBLE, nil, "0.3333333333333333"},
+		{"float precision", DisplayOptions{FloatPrecision: 2}, 1.0 / 3, parquet.Type_DOUBLE, nil, "0.33"},
+		{"float32 precision", DisplayOptions{FloatPrecision: 3}, float32(2.5), parquet.Type_FLOAT, nil, "2.500"},
+		{"null", tokyo, nil, parquet.Type_INT64, utcMillis, "NULL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.opts.FormatValue(tt.val, tt.pT, tt.se))
+		})
+	}
+}
+
+func Test_DisplayOptions_FormatStatValue(t *testing.T) {
+	meta := &parquet.ColumnMetaData{Type: parquet.Type_DOUBLE}
+	raw := []byte{0x55, 0x55, 0x55, 0x55, 0x55, 0x55, 0xd5, 0x3f} // 1/3
+	require.Equal(t, "0.3333", DisplayOptions{FloatPrecision: 4}.FormatStatValue(raw, meta, nil))
+	require.Equal(t, FormatStatValue(raw, meta, nil), DisplayOptions{}.FormatStatValue(raw, meta, nil))
+	require.Equal(t, "-", DisplayOptions{FloatPrecision: 4}.FormatStatValue(nil, meta, nil))
+}
+
+func Test_ParquetReader_WithDisplayOptions(t *testing.T) {
+	pr := openTestParquetReader(t)
+	opts := DisplayOptions{Temporal: TemporalEpoch}
+	epochReader := pr.WithDisplayOptions(opts)
+	require.Equal(t, opts, epochReader.DisplayOptions())
+	require.Equal(t, DisplayOptions{}, pr.DisplayOptions())
+
+	// INT96 column, values and statistics follow the options
+	values, err := epochReader.GetPageContentFormatted(0, 3, 0)
+	require.NoError(t, err)
+	require.Equal(t, "1640995200000000000", values[0])
+
+	// Decimal1 column statistics
+	scaled, err := pr.GetColumnChunkInfo(0, 40)
+	require.NoError(t, err)
+	unscaled, err := pr.WithDisplayOptions(DisplayOptions{Decimal: DecimalUnscaled}).GetColumnChunkInfo(0, 40)
+	require.NoError(t, err)
+	require.Contains(t, scaled.MaxValue, ".")
+	require.NotContains(t, unscaled.MaxValue, ".")
+}
+
+func Test_utcTimestamp(t *testing.T) {
+	_, _, ok := utcTimestamp(int32(1), parquet.Type_INT32, nil)
+	require.False(t, ok)
+	_, _, ok = utcTimestamp(int64(1), parquet.Type_INT64, &parquet.SchemaElement{})
+	require.False(t, ok)
+
+	// TIMESTAMP without a unit is read as milliseconds
+	ts, layout, ok := utcTimestamp(int64(1), parquet.Type_INT64, &parquet.SchemaElement{LogicalType: &parquet.LogicalType{
+		TIMESTAMP: &parquet.TimestampType{IsAdjustedToUTC: true},
+	}})
+	require.True(t, ok)
+	require.Equal(t, "1970-01-01T00:00:00.001Z", ts.Format(layout))
+}
+
+func Test_epochValue(t *testing.T) {
+	timeMillis := parquet.ConvertedType_TIME_MILLIS
+	v, ok := epochValue(int32(5), parquet.Type_INT32, &parquet.SchemaElement{ConvertedType: &timeMillis})
+	require.True(t, ok)
+	require.Equal(t, int32(5), v)
+
+	_, ok = epochValue("x", parquet.Type_INT96, nil)
+	require.False(t, ok)
+	_, ok = epochValue(int32(5), parquet.Type_INT32, nil)
+	require.False(t, ok)
+}
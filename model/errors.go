@@ -6,12 +6,37 @@ var (
 	// ErrInvalidRowGroupIndex is returned when an invalid row group index is requested
 	ErrInvalidRowGroupIndex = errors.New("invalid row group index")
 
+	// ErrInvalidRowIndex is returned when a row outside the file is requested
+	ErrInvalidRowIndex = errors.New("invalid row index")
+
 	// ErrInvalidColumnIndex is returned when an invalid column index is requested
 	ErrInvalidColumnIndex = errors.New("invalid column index")
 
+	// ErrInvalidColumnPath is returned when no leaf column matches the requested path
+	ErrInvalidColumnPath = errors.New("invalid column path")
+
 	// ErrInvalidPageIndex is returned when an invalid page index is requested
 	ErrInvalidPageIndex = errors.New("invalid page index")
 
 	// ErrInvalidPageType is returned when trying to read content from a non-data page
 	ErrInvalidPageType = errors.New("cannot read content from non-data page")
+
+	// ErrInvalidValueIndex is returned when an invalid value index within a page is requested
+	ErrInvalidValueIndex = errors.New("invalid value index")
+
+	// ErrInvalidStatistic is returned for a statistic other than min or max
+	ErrInvalidStatistic = errors.New("invalid statistic")
+
+	// ErrNoStatistic is returned when the requested statistic was not written
+	ErrNoStatistic = errors.New("statistic not available")
+
+	// ErrInvalidDisplayOption is returned for an unknown display preference value
+	ErrInvalidDisplayOption = errors.New("invalid display option")
+
+	// ErrInvalidDecoderConfig is returned for a value decoder that cannot be set up
+	ErrInvalidDecoderConfig = errors.New("invalid decoder config")
+
+	// ErrFileChanged is returned for reads after the file changed on disk, as
+	// the footer and the readers still describe the file that was opened
+	ErrFileChanged = errors.New("parquet file changed since it was opened")
 )
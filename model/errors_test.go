@@ -18,6 +18,11 @@ func Test_ErrorVariables(t *testing.T) {
 			err:      ErrInvalidRowGroupIndex,
 			expected: "invalid row group index",
 		},
+		{
+			name:     "ErrInvalidRowIndex",
+			err:      ErrInvalidRowIndex,
+			expected: "invalid row index",
+		},
 		{
 			name:     "ErrInvalidColumnIndex",
 			err:      ErrInvalidColumnIndex,
@@ -33,6 +38,31 @@ func Test_ErrorVariables(t *testing.T) {
 			err:      ErrInvalidPageType,
 			expected: "cannot read content from non-data page",
 		},
+		{
+			name:     "ErrInvalidValueIndex",
+			err:      ErrInvalidValueIndex,
+			expected: "invalid value index",
+		},
+		{
+			name:     "ErrInvalidStatistic",
+			err:      ErrInvalidStatistic,
+			expected: "invalid statistic",
+		},
+		{
+			name:     "ErrNoStatistic",
+			err:      ErrNoStatistic,
+			expected: "statistic not available",
+		},
+		{
+			name:     "ErrInvalidDisplayOption",
+			err:      ErrInvalidDisplayOption,
+			expected: "invalid display option",
+		},
+		{
+			name:     "ErrInvalidDecoderConfig",
+			err:      ErrInvalidDecoderConfig,
+			expected: "invalid decoder config",
+		},
 	}
 
 	for _, tt := range tests {
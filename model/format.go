@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
+	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -17,6 +20,18 @@ var geospatialOpt = types.WithGeospatialConfig(types.NewGeospatialConfig(
 	types.WithGeographyJSONMode(types.GeospatialModeGeoJSON),
 ))
 
+// julianDayOfEpoch is the Julian day of 1970-01-01, the base of INT96 days
+const (
+	julianDayOfEpoch = 2440588
+	secondsPerDay    = 86400
+)
+
+// maxDisplayValueLength is the length past which page values are truncated
+const maxDisplayValueLength = 200
+
+// maxStatValueLength is the length past which min/max statistics are truncated
+const maxStatValueLength = 50
+
 // FormatBytes formats bytes as human readable size
 func FormatBytes(bytes int64) string {
 	const unit = 1024
@@ -31,59 +46,16 @@ func FormatBytes(bytes int64) string {
 	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
 }
 
-// FormatStatValue formats statistics values (min/max) based on column type information
-// This uses parquet-go's types.ParquetTypeToJSONTypeWithLogical function
+// FormatStatValue formats statistics values (min/max) based on column type
+// information, with the default display options
 func FormatStatValue(value []byte, columnMeta *parquet.ColumnMetaData, schemaElem *parquet.SchemaElement) string {
-	if len(value) == 0 {
-		return "-"
-	}
-
-	// Check if this is a type where min/max don't apply (geospatial or interval types)
-	// These types don't have meaningful min/max values in their statistics
-	if schemaElem != nil {
-		// Check for geospatial types (GEOMETRY or GEOGRAPHY)
-		if schemaElem.LogicalType != nil {
-			if schemaElem.LogicalType.IsSetGEOMETRY() || schemaElem.LogicalType.IsSetGEOGRAPHY() {
-				return "-"
-			}
-		}
-		// Check for interval type (deprecated converted type)
-		if schemaElem.ConvertedType != nil && *schemaElem.ConvertedType == parquet.ConvertedType_INTERVAL {
-			return "-"
-		}
-	}
-
-	// Retrieve the raw value from bytes
-	rawValue := retrieveStatValue(value, columnMeta.Type)
-	if rawValue == nil {
-		return "-"
-	}
-
-	// Build a SchemaElement for type conversion
-	se := schemaElem
-	if se == nil {
-		se = &parquet.SchemaElement{Type: &columnMeta.Type}
-	}
-
-	jsonValue := types.ConvertToJSONType(rawValue, se, geospatialOpt)
-
-	// Format for display
-	// For complex types (maps, slices), use JSON encoding for proper formatting
-	switch jsonValue.(type) {
-	case map[string]any, []any, []map[string]any:
-		if jsonBytes, err := json.Marshal(jsonValue); err == nil {
-			str := string(jsonBytes)
-			if len(str) > 50 {
-				return str[:50] + "..."
-			}
-			return str
-		}
-	}
+	return DisplayOptions{}.FormatStatValue(value, columnMeta, schemaElem)
+}
 
-	// For simple types, use standard formatting
-	str := fmt.Sprintf("%v", jsonValue)
-	if len(str) > 50 {
-		return str[:50] + "..."
+// truncateStatValue cuts statistic values longer than maxStatValueLength
+func truncateStatValue(str string) string {
+	if len(str) > maxStatValueLength {
+		return str[:maxStatValueLength] + "..."
 	}
 	return str
 }
@@ -133,6 +105,135 @@ func retrieveStatValue(value []byte, parquetType parquet.Type) any {
 	}
 }
 
+// convertValue applies the logical type of a column to a physical value. It
+// defers to parquet-go's conversion except for decimals, which are formatted
+// exactly instead of going through float64, and intervals, which are split
+// into their months, days and milliseconds
+func convertValue(val any, parquetType parquet.Type, schemaElem *parquet.SchemaElement) any {
+	se := schemaElem
+	if se == nil {
+		se = &parquet.SchemaElement{}
+	}
+	if se.Type == nil || *se.Type != parquetType {
+		copied := *se
+		copied.Type = &parquetType
+		se = &copied
+	}
+
+	if parquetType == parquet.Type_INT96 {
+		if str, ok := formatINT96(val); ok {
+			return str
+		}
+	}
+	if scale, ok := decimalScale(se); ok {
+		if str, ok := formatDecimal(val, parquetType, scale); ok {
+			return str
+		}
+	}
+	if se.ConvertedType != nil && *se.ConvertedType == parquet.ConvertedType_INTERVAL {
+		if str, ok := formatInterval(val); ok {
+			return str
+		}
+	}
+
+	return types.ConvertToJSONType(val, se, geospatialOpt)
+}
+
+// formatINT96 renders a legacy INT96 timestamp (nanoseconds of the day
+// followed by the Julian day) with full nanosecond precision
+func formatINT96(val any) (string, bool) {
+	t, ok := int96Time(val)
+	if !ok {
+		return "", false
+	}
+	return t.Format("2006-01-02T15:04:05.000000000Z"), true
+}
+
+// int96Time decodes a legacy INT96 timestamp
+func int96Time(val any) (time.Time, bool) {
+	str, ok := val.(string)
+	if !ok || len(str) != 12 {
+		return time.Time{}, false
+	}
+	nanos := binary.LittleEndian.Uint64([]byte(str[0:8]))
+	days := int64(binary.LittleEndian.Uint32([]byte(str[8:12])))
+	return time.Unix((days-julianDayOfEpoch)*secondsPerDay, 0).Add(time.Duration(nanos)).UTC(), true
+}
+
+// decimalScale returns the scale of a DECIMAL column, preferring the logical
+// type over the legacy converted type
+func decimalScale(se *parquet.SchemaElement) (int, bool) {
+	if se.LogicalType != nil && se.LogicalType.IsSetDECIMAL() {
+		return int(se.LogicalType.GetDECIMAL().GetScale()), true
+	}
+	if se.ConvertedType != nil && *se.ConvertedType == parquet.ConvertedType_DECIMAL {
+		return int(se.GetScale()), true
+	}
+	return 0, false
+}
+
+// formatDecimal renders an unscaled decimal value with its scale applied,
+// without losing digits for values beyond float64 precision
+func formatDecimal(val any, parquetType parquet.Type, scale int) (string, bool) {
+	switch v := val.(type) {
+	case int32:
+		return formatUnscaled(big.NewInt(int64(v)), scale), true
+	case int64:
+		return formatUnscaled(big.NewInt(v), scale), true
+	case string:
+		if parquetType == parquet.Type_BYTE_ARRAY || parquetType == parquet.Type_FIXED_LEN_BYTE_ARRAY {
+			return formatUnscaled(twosComplement([]byte(v)), scale), true
+		}
+	case []byte:
+		return formatUnscaled(twosComplement(v), scale), true
+	}
+	return "", false
+}
+
+// twosComplement reads a big-endian two's complement integer of any width
+func twosComplement(b []byte) *big.Int {
+	n := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+	}
+	return n
+}
+
+// formatUnscaled places the decimal point scale digits from the right,
+// keeping trailing zeros so every value of a column shows the same scale
+func formatUnscaled(unscaled *big.Int, scale int) string {
+	digits := new(big.Int).Abs(unscaled).Text(10)
+	if scale > 0 {
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if unscaled.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// formatInterval renders a 12-byte INTERVAL as its three little-endian
+// unsigned components
+func formatInterval(val any) (string, bool) {
+	var b []byte
+	switch v := val.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	}
+	if len(b) != 12 {
+		return "", false
+	}
+	return fmt.Sprintf("months=%d days=%d millis=%d",
+		binary.LittleEndian.Uint32(b[0:4]),
+		binary.LittleEndian.Uint32(b[4:8]),
+		binary.LittleEndian.Uint32(b[8:12])), true
+}
+
 // IsValidUTF8 checks if a string contains valid and mostly printable UTF-8
 func IsValidUTF8(s string) bool {
 	// Check if valid UTF-8
@@ -155,43 +256,27 @@ func IsValidUTF8(s string) bool {
 }
 
 // FormatValue formats a value for display, applying logical type conversions
-// This is the main entry point for formatting page content values
+// with the default display options
 func FormatValue(val interface{}, parquetType parquet.Type, schemaElem *parquet.SchemaElement) string {
-	if val == nil {
-		return "NULL"
-	}
-
-	// Check for empty string - should display as empty, not NULL
-	if str, ok := val.(string); ok && str == "" {
-		return ""
-	}
-
-	// Build a SchemaElement for type conversion
-	se := schemaElem
-	if se == nil {
-		se = &parquet.SchemaElement{Type: &parquetType}
-	}
-
-	// Use parquet-go's type conversion function
-	formattedVal := types.ConvertToJSONType(val, se, geospatialOpt)
+	return DisplayOptions{}.FormatValue(val, parquetType, schemaElem)
+}
 
-	// Convert to string for display
-	// For complex types (maps, slices), use JSON encoding for proper formatting
-	switch formattedVal.(type) {
+// formatConverted renders a converted value, complex types (maps, slices) as
+// JSON and everything else with standard formatting
+func formatConverted(value any) string {
+	switch value.(type) {
 	case map[string]any, []any, []map[string]any:
-		if jsonBytes, err := json.Marshal(formattedVal); err == nil {
-			str := string(jsonBytes)
-			if len(str) > 200 {
-				return str[:200] + "..."
-			}
-			return str
+		if jsonBytes, err := json.Marshal(value); err == nil {
+			return string(jsonBytes)
 		}
 	}
+	return fmt.Sprintf("%v", value)
+}
 
-	// For simple types, use standard formatting
-	str := fmt.Sprintf("%v", formattedVal)
-	if len(str) > 200 {
-		return str[:200] + "..."
+// truncateDisplayValue cuts values longer than maxDisplayValueLength
+func truncateDisplayValue(str string) string {
+	if len(str) > maxDisplayValueLength {
+		return str[:maxDisplayValueLength] + "..."
 	}
 	return str
 }
@@ -1,10 +1,14 @@
 package model
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -313,74 +317,6 @@ func Test_FormatValue_WithLogicalType(t *testing.T) {
 	require.Equal(t, "hello", result)
 }
 
-func Test_FindSchemaElementByPath(t *testing.T) {
-	// Create a simple schema for testing
-	schema := []*parquet.SchemaElement{
-		{Name: "Parquet_go_root", NumChildren: intPtr(2)},
-		{Name: "field1", Type: parquetTypePtr(parquet.Type_INT32)},
-		{Name: "field2", Type: parquetTypePtr(parquet.Type_BYTE_ARRAY)},
-	}
-
-	tests := []struct {
-		name         string
-		schema       []*parquet.SchemaElement
-		path         []string
-		expectNil    bool
-		expectedName string
-	}{
-		{
-			name:         "Find existing field",
-			schema:       schema,
-			path:         []string{"field1"},
-			expectNil:    false,
-			expectedName: "field1",
-		},
-		{
-			name:         "Find second field",
-			schema:       schema,
-			path:         []string{"field2"},
-			expectNil:    false,
-			expectedName: "field2",
-		},
-		{
-			name:      "Empty path",
-			schema:    schema,
-			path:      []string{},
-			expectNil: true,
-		},
-		{
-			name:      "Non-existent field",
-			schema:    schema,
-			path:      []string{"nonexistent"},
-			expectNil: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := findSchemaElement(tt.schema, tt.path)
-			if tt.expectNil {
-				require.Nil(t, result)
-			} else {
-				require.NotNil(t, result)
-				require.Equal(t, tt.expectedName, result.Name)
-			}
-		})
-	}
-}
-
-func Test_FindSchemaElementByPath_CaseInsensitive(t *testing.T) {
-	schema := []*parquet.SchemaElement{
-		{Name: "Parquet_go_root", NumChildren: intPtr(1)},
-		{Name: "MyField", Type: parquetTypePtr(parquet.Type_INT32)},
-	}
-
-	// Should match case-insensitively
-	result := findSchemaElement(schema, []string{"myfield"})
-	require.NotNil(t, result)
-	require.Equal(t, "MyField", result.Name)
-}
-
 // Test retrieveStatValue error paths - when binary.Read fails
 func Test_RetrieveRawValue_ErrorPaths(t *testing.T) {
 	tests := []struct {
@@ -568,6 +504,155 @@ func Test_RetrieveRawValue_MinMaxValues(t *testing.T) {
 	}
 }
 
+// le encodes an integer as little-endian bytes of the given width
+func le(width int, v uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, v)
+	return b[:width]
+}
+
+// interval encodes a 12-byte INTERVAL value
+func interval(months, days, millis uint32) []byte {
+	return append(append(le(4, uint64(months)), le(4, uint64(days))...), le(4, uint64(millis))...)
+}
+
+// Test_ValueConformance checks that each physical/logical type combination
+// decodes to the same display string from statistics bytes and from the
+// values returned by the column reader
+func Test_ValueConformance(t *testing.T) {
+	int32Type, int64Type := parquet.Type_INT32, parquet.Type_INT64
+	int96Type, flbaType, byteArrayType := parquet.Type_INT96, parquet.Type_FIXED_LEN_BYTE_ARRAY, parquet.Type_BYTE_ARRAY
+	converted := func(pT *parquet.Type, cT parquet.ConvertedType) *parquet.SchemaElement {
+		return &parquet.SchemaElement{Type: pT, ConvertedType: &cT}
+	}
+	logical := func(pT *parquet.Type, lT *parquet.LogicalType) *parquet.SchemaElement {
+		return &parquet.SchemaElement{Type: pT, LogicalType: lT}
+	}
+	unsigned := func(pT *parquet.Type, width int8) *parquet.SchemaElement {
+		return logical(pT, &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: width, IsSigned: false}})
+	}
+	decimal := func(pT *parquet.Type, precision, scale int32) *parquet.SchemaElement {
+		return logical(pT, &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: precision, Scale: scale}})
+	}
+	legacyDecimal := func(pT *parquet.Type, precision, scale int32) *parquet.SchemaElement {
+		se := converted(pT, parquet.ConvertedType_DECIMAL)
+		se.Precision, se.Scale = &precision, &scale
+		return se
+	}
+	// 2^95 - 1 and -2^95 as 12-byte big-endian two's complement
+	maxFLBA := []byte{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	minFLBA := []byte{0x80, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	int96 := types.TimeToINT96(time.Date(2024, 2, 29, 23, 59, 59, 0, time.UTC))
+	preciseINT96 := string(append(le(8, 123456789), int96[8:]...))
+
+	tests := []struct {
+		name     string
+		elem     *parquet.SchemaElement
+		stat     []byte
+		value    any
+		expected string
+	}{
+		{"INT96 timestamp", &parquet.SchemaElement{Type: &int96Type}, []byte(int96), int96, "2024-02-29T23:59:59.000000000Z"},
+		{"INT96 nanoseconds", &parquet.SchemaElement{Type: &int96Type}, []byte(preciseINT96), preciseINT96, "2024-02-29T00:00:00.123456789Z"},
+		{"INT96 before epoch", &parquet.SchemaElement{Type: &int96Type}, append(le(8, 0), le(4, 2440587)...), string(append(le(8, 0), le(4, 2440587)...)), "1969-12-31T00:00:00.000000000Z"},
+		{"INTERVAL", converted(&flbaType, parquet.ConvertedType_INTERVAL), interval(14, 3, 4500), string(interval(14, 3, 4500)), "months=14 days=3 millis=4500"},
+		{"INTERVAL max", converted(&flbaType, parquet.ConvertedType_INTERVAL), interval(0xffffffff, 0, 0), string(interval(0xffffffff, 0, 0)), "months=4294967295 days=0 millis=0"},
+		{"FLOAT16", logical(&flbaType, &parquet.LogicalType{FLOAT16: parquet.NewFloat16Type()}), []byte{0x00, 0x3e}, string([]byte{0x00, 0x3e}), "1.5"},
+		{"FLOAT16 negative", logical(&flbaType, &parquet.LogicalType{FLOAT16: parquet.NewFloat16Type()}), []byte{0x00, 0xc0}, string([]byte{0x00, 0xc0}), "-2"},
+		{"UINT_8 logical", unsigned(&int32Type, 8), le(4, 255), int32(255), "255"},
+		{"UINT_32 logical", unsigned(&int32Type, 32), le(4, 0xffffffff), int32(-1), "4294967295"},
+		{"UINT_64 logical", unsigned(&int64Type, 64), le(8, 0xffffffffffffffff), int64(-1), "18446744073709551615"},
+		{"UINT_32 converted", converted(&int32Type, parquet.ConvertedType_UINT_32), le(4, 0x80000000), int32(-2147483648), "2147483648"},
+		{"UINT_64 converted", converted(&int64Type, parquet.ConvertedType_UINT_64), le(8, 1<<63), int64(-1 << 63), "9223372036854775808"},
+		{"DECIMAL INT32", decimal(&int32Type, 9, 2), le(4, 22), int32(22), "0.22"},
+		{"DECIMAL INT32 negative", decimal(&int32Type, 9, 4), le(4, 0xfffffffb), int32(-5), "-0.0005"},
+		{"DECIMAL INT64 beyond float64", decimal(&int64Type, 18, 2), le(8, 123456789012345678), int64(123456789012345678), "1234567890123456.78"},
+		{"DECIMAL INT64 zero scale", decimal(&int64Type, 18, 0), le(8, 42), int64(42), "42"},
+		{"DECIMAL FLBA max", decimal(&flbaType, 28, 4), maxFLBA, string(maxFLBA), "3961408125713216879677197.5167"},
+		{"DECIMAL FLBA min", decimal(&flbaType, 28, 4), minFLBA, string(minFLBA), "-3961408125713216879677197.5168"},
+		{"DECIMAL BYTE_ARRAY", decimal(&byteArrayType, 38, 10), []byte{0x01, 0x00}, string([]byte{0x01, 0x00}), "0.0000000256"},
+		{"DECIMAL BYTE_ARRAY negative", decimal(&byteArrayType, 38, 1), []byte{0xff, 0x00}, string([]byte{0xff, 0x00}), "-25.6"},
+		{"DECIMAL converted FLBA", legacyDecimal(&flbaType, 10, 2), []byte{0x00, 0x00, 0x30, 0x39}, string([]byte{0x00, 0x00, 0x30, 0x39}), "123.45"},
+		{"DECIMAL converted INT32", legacyDecimal(&int32Type, 5, 3), le(4, 12345), int32(12345), "12.345"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := &parquet.ColumnMetaData{Type: *tt.elem.Type}
+			require.Equal(t, tt.expected, FormatStatValue(tt.stat, meta, tt.elem), "statistics")
+			require.Equal(t, tt.expected, FormatValue(tt.value, *tt.elem.Type, tt.elem), "page value")
+		})
+	}
+}
+
+func Test_ValueConformance_File(t *testing.T) {
+	pr := openTestParquetReader(t)
+	tests := []struct {
+		column   int
+		min, max string
+		values   []string
+	}{
+		{3, "2022-01-01T00:00:00.000000000Z", "2022-01-01T04:04:04.004004000Z", []string{"2022-01-01T00:00:00.000000000Z", "2022-01-01T01:01:01.001001000Z"}},
+		{25, "0", "4", []string{"0", "1"}},
+		{39, "-", "-", []string{"months=0 days=0 millis=0", "months=1 days=1 millis=1"}},
+		{40, "0.00", "44.44", []string{"0.22", "3.33"}},
+		{42, "0.00", "0.44", []string{"0.00", "0.00"}},
+		{43, "0.00", "0.44", []string{"0.44", "0.00"}},
+	}
+	for _, tt := range tests {
+		info, err := pr.GetColumnChunkInfo(0, tt.column)
+		require.NoError(t, err)
+		require.Equal(t, tt.min, info.MinValue, info.PathInSchema)
+		require.Equal(t, tt.max, info.MaxValue, info.PathInSchema)
+
+		values, err := pr.GetPageContentFormatted(0, tt.column, 0)
+		require.NoError(t, err)
+		require.Equal(t, tt.values, values[:len(tt.values)], info.PathInSchema)
+	}
+}
+
+func Test_convertValue(t *testing.T) {
+	// Without a schema element only the physical type applies
+	require.Equal(t, int32(7), convertValue(int32(7), parquet.Type_INT32, nil))
+	// A schema element without its type still converts by the given one
+	require.Equal(t, "0.07", convertValue(int32(7), parquet.Type_INT32, &parquet.SchemaElement{
+		LogicalType: &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: 5, Scale: 2}},
+	}))
+	// Unexpected Go types fall through to parquet-go
+	require.Equal(t, 1.5, convertValue(1.5, parquet.Type_INT32, &parquet.SchemaElement{
+		LogicalType: &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: 5, Scale: 2}},
+	}))
+}
+
+func Test_formatINT96(t *testing.T) {
+	_, ok := formatINT96("short")
+	require.False(t, ok)
+	_, ok = formatINT96(42)
+	require.False(t, ok)
+}
+
+func Test_formatInterval(t *testing.T) {
+	str, ok := formatInterval(interval(1, 2, 3))
+	require.True(t, ok)
+	require.Equal(t, "months=1 days=2 millis=3", str)
+	_, ok = formatInterval("short")
+	require.False(t, ok)
+}
+
+func Test_twosComplement(t *testing.T) {
+	require.Equal(t, big.NewInt(0), twosComplement(nil))
+	require.Equal(t, big.NewInt(-1), twosComplement([]byte{0xff}))
+	require.Equal(t, big.NewInt(-256), twosComplement([]byte{0xff, 0x00}))
+	require.Equal(t, big.NewInt(255), twosComplement([]byte{0x00, 0xff}))
+}
+
+func Test_formatUnscaled(t *testing.T) {
+	require.Equal(t, "0.05", formatUnscaled(big.NewInt(5), 2))
+	require.Equal(t, "-1.50", formatUnscaled(big.NewInt(-150), 2))
+	require.Equal(t, "150", formatUnscaled(big.NewInt(150), 0))
+	require.Equal(t, "0", formatUnscaled(big.NewInt(0), 0))
+}
+
 // Helper functions for tests
 func intPtr(i int32) *int32 {
 	return &i
@@ -0,0 +1,476 @@
+package model
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/types"
+)
+
+// GeoFormat selects how GEOMETRY and GEOGRAPHY values are rendered
+type GeoFormat string
+
+const (
+	GeoFormatGeoJSON GeoFormat = "geojson"
+	GeoFormatWKT     GeoFormat = "wkt"
+)
+
+var (
+	// ErrInvalidGeoFormat is returned for an unknown geospatial rendering format
+	ErrInvalidGeoFormat = errors.New("invalid geospatial format")
+	// ErrNotGeospatial is returned when a column is not GEOMETRY or GEOGRAPHY
+	ErrNotGeospatial = errors.New("not a GEOMETRY or GEOGRAPHY column")
+)
+
+// ParseGeoFormat parses a geospatial rendering format, empty means GeoJSON
+func ParseGeoFormat(s string) (GeoFormat, error) {
+	switch GeoFormat(strings.ToLower(s)) {
+	case "", GeoFormatGeoJSON:
+		return GeoFormatGeoJSON, nil
+	case GeoFormatWKT:
+		return GeoFormatWKT, nil
+	default:
+		return "", fmt.Errorf("%w: %q (expected %q or %q)", ErrInvalidGeoFormat, s, GeoFormatGeoJSON, GeoFormatWKT)
+	}
+}
+
+// BoundingBox is the extent of geospatial values. Z and M ranges are only
+// set when the values carry those dimensions.
+type BoundingBox struct {
+	Xmin float64
+	Xmax float64
+	Ymin float64
+	Ymax float64
+	Zmin *float64 `json:",omitempty"`
+	Zmax *float64 `json:",omitempty"`
+	Mmin *float64 `json:",omitempty"`
+	Mmax *float64 `json:",omitempty"`
+}
+
+// GeospatialStatistics holds the bounding box and the geometry types that
+// writers record for GEOMETRY and GEOGRAPHY column chunks
+type GeospatialStatistics struct {
+	BoundingBox   *BoundingBox
+	GeometryTypes []string // e.g. "Point", "Polygon Z"
+}
+
+// RowGroupBounds is the extent of a geospatial column in one row group
+type RowGroupBounds struct {
+	RowGroup      int
+	NumRows       int64
+	BoundingBox   *BoundingBox // nil when the chunk holds no coordinates
+	GeometryTypes []string
+	Source        string // "statistics" or "values" when computed from page content
+}
+
+// GeospatialBounds describes the extent of a geospatial column per row group
+type GeospatialBounds struct {
+	ColumnIndex int
+	Path        string
+	LogicalType string
+	CRS         string
+	RowGroups   []RowGroupBounds
+	BoundingBox *BoundingBox // union of all row groups
+}
+
+// Sources of a row group bounding box
+const (
+	BoundsFromStatistics = "statistics"
+	BoundsFromValues     = "values"
+)
+
+// convertGeospatialStatistics converts the thrift geospatial statistics of
+// a column chunk, returning nil when nothing is recorded
+func convertGeospatialStatistics(stats *parquet.GeospatialStatistics) *GeospatialStatistics {
+	if stats == nil || (stats.Bbox == nil && len(stats.GeospatialTypes) == 0) {
+		return nil
+	}
+	result := &GeospatialStatistics{}
+	if bbox := stats.Bbox; bbox != nil {
+		result.BoundingBox = &BoundingBox{
+			Xmin: bbox.Xmin, Xmax: bbox.Xmax, Ymin: bbox.Ymin, Ymax: bbox.Ymax,
+			Zmin: bbox.Zmin, Zmax: bbox.Zmax, Mmin: bbox.Mmin, Mmax: bbox.Mmax,
+		}
+	}
+	for _, code := range stats.GeospatialTypes {
+		result.GeometryTypes = append(result.GeometryTypes, GeometryTypeName(code))
+	}
+	return result
+}
+
+// geometryTypeNames are the WKB geometry type codes 1 to 7
+var geometryTypeNames = []string{"Point", "LineString", "Polygon", "MultiPoint", "MultiLineString", "MultiPolygon", "GeometryCollection"}
+
+// GeometryTypeName names an ISO WKB geometry type code, where 1000, 2000
+// and 3000 are added for Z, M and ZM coordinates
+func GeometryTypeName(code int32) string {
+	base, dims := code%1000, code/1000
+	if code < 0 || base < 1 || int(base) > len(geometryTypeNames) || dims > 3 {
+		return fmt.Sprintf("UNKNOWN(%d)", code)
+	}
+	return geometryTypeNames[base-1] + []string{"", " Z", " M", " ZM"}[dims]
+}
+
+// isGeospatial reports whether a schema element is a GEOMETRY or GEOGRAPHY column
+func isGeospatial(se *parquet.SchemaElement) bool {
+	return se != nil && se.LogicalType != nil && (se.LogicalType.IsSetGEOMETRY() || se.LogicalType.IsSetGEOGRAPHY())
+}
+
+// geospatialCRS returns the CRS of a geospatial column, OGC:CRS84 by default
+func geospatialCRS(se *parquet.SchemaElement) string {
+	var crs *string
+	switch {
+	case se.LogicalType.IsSetGEOMETRY():
+		crs = se.LogicalType.GEOMETRY.CRS
+	case se.LogicalType.IsSetGEOGRAPHY():
+		crs = se.LogicalType.GEOGRAPHY.CRS
+	}
+	if crs == nil || *crs == "" {
+		return "OGC:CRS84"
+	}
+	return *crs
+}
+
+// FormatGeoValue formats a GEOMETRY or GEOGRAPHY value as WKT or GeoJSON
+// with the default display options. Values that are not valid WKB fall back
+// to the regular formatting.
+func FormatGeoValue(val any, parquetType parquet.Type, schemaElem *parquet.SchemaElement, format GeoFormat) string {
+	return DisplayOptions{}.FormatGeoValue(val, parquetType, schemaElem, format)
+}
+
+// GetGeospatialBounds returns the bounding box of a GEOMETRY or GEOGRAPHY
+// column per row group. Bounding boxes come from the geospatial statistics,
+// or are computed from the values when a chunk has none.
+func (pr *ParquetReader) GetGeospatialBounds(ctx context.Context, colIndex int) (GeospatialBounds, error) {
+	if pr == nil || pr.metadata == nil || len(pr.metadata.RowGroups) == 0 {
+		return GeospatialBounds{}, ErrInvalidColumnIndex
+	}
+	pr = pr.WithContext(ctx)
+
+	numColumns := len(pr.metadata.RowGroups[0].Columns)
+	if colIndex < 0 || colIndex >= numColumns {
+		return GeospatialBounds{}, fmt.Errorf("column index %d out of range [0, %d): %w",
+			colIndex, numColumns, ErrInvalidColumnIndex)
+	}
+
+	path := pr.columnName(colIndex)
+	schemaElem := pr.schemaElement(colIndex)
+	if !isGeospatial(schemaElem) {
+		return GeospatialBounds{}, fmt.Errorf("column %q: %w", path, ErrNotGeospatial)
+	}
+
+	bounds := GeospatialBounds{
+		ColumnIndex: colIndex,
+		Path:        path,
+		LogicalType: formatLogicalType(schemaElem.LogicalType),
+		CRS:         geospatialCRS(schemaElem),
+		RowGroups:   make([]RowGroupBounds, 0, len(pr.metadata.RowGroups)),
+	}
+
+	for rgIndex, rg := range pr.metadata.RowGroups {
+		if err := ctx.Err(); err != nil {
+			return GeospatialBounds{}, err
+		}
+
+		rgBounds := RowGroupBounds{RowGroup: rgIndex, NumRows: rg.NumRows}
+		if stats := convertGeospatialStatistics(rg.Columns[colIndex].MetaData.GeospatialStatistics); stats != nil && stats.BoundingBox != nil {
+			rgBounds.BoundingBox = stats.BoundingBox
+			rgBounds.GeometryTypes = stats.GeometryTypes
+			rgBounds.Source = BoundsFromStatistics
+		} else {
+			bbox, err := pr.computeChunkBounds(ctx, rgIndex, colIndex)
+			if err != nil {
+				return GeospatialBounds{}, fmt.Errorf("row group %d: %w", rgIndex, err)
+			}
+			rgBounds.BoundingBox = bbox
+			rgBounds.Source = BoundsFromValues
+		}
+
+		bounds.BoundingBox = unionBoundingBox(bounds.BoundingBox, rgBounds.BoundingBox)
+		bounds.RowGroups = append(bounds.RowGroups, rgBounds)
+	}
+
+	return bounds, nil
+}
+
+// computeChunkBounds computes the XY bounding box of a column chunk from its
+// WKB values, or nil when no value holds coordinates
+func (pr *ParquetReader) computeChunkBounds(ctx context.Context, rgIndex, colIndex int) (*BoundingBox, error) {
+	pages, err := pr.GetPageMetadataList(rgIndex, colIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	calc := types.NewBoundingBoxCalculator()
+	for pageIndex, page := range pages {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// Dictionary entries are counted through the data pages that use them
+		if page.PageType == parquet.PageType_DICTIONARY_PAGE.String() {
+			continue
+		}
+		values, err := pr.GetPageContent(rgIndex, colIndex, pageIndex)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range values {
+			if s, ok := v.(string); ok {
+				// Values that are not valid WKB are left out of the extent
+				_ = calc.AddWKB([]byte(s))
+			}
+		}
+	}
+
+	xmin, ymin, xmax, ymax, ok := calc.GetBounds()
+	if !ok {
+		return nil, nil
+	}
+	return &BoundingBox{Xmin: xmin, Xmax: xmax, Ymin: ymin, Ymax: ymax}, nil
+}
+
+// unionBoundingBox returns the XY extent covering both boxes, either may be nil
+func unionBoundingBox(a, b *BoundingBox) *BoundingBox {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	return &BoundingBox{
+		Xmin: math.Min(a.Xmin, b.Xmin), Xmax: math.Max(a.Xmax, b.Xmax),
+		Ymin: math.Min(a.Ymin, b.Ymin), Ymax: math.Max(a.Ymax, b.Ymax),
+	}
+}
+
+// EWKB flags, for writers that emit PostGIS extended WKB
+const (
+	ewkbZ    = 0x80000000
+	ewkbM    = 0x40000000
+	ewkbSRID = 0x20000000
+)
+
+// errWKBTruncated is returned when WKB ends before the geometry does
+var errWKBTruncated = errors.New("truncated WKB")
+
+// WKBToWKT converts a WKB (ISO or extended) geometry to well-known text
+func WKBToWKT(wkb []byte) (string, error) {
+	r := &wkbReader{data: wkb}
+	var text strings.Builder
+	if err := r.writeGeometry(&text, true); err != nil {
+		return "", err
+	}
+	if r.pos != len(wkb) {
+		return "", fmt.Errorf("%d trailing bytes after WKB geometry", len(wkb)-r.pos)
+	}
+	return text.String(), nil
+}
+
+// wkbReader walks a WKB buffer
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if r.pos+4 > len(r.data) {
+		return 0, errWKBTruncated
+	}
+	v := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+func (r *wkbReader) float64() (float64, error) {
+	if r.pos+8 > len(r.data) {
+		return 0, errWKBTruncated
+	}
+	v := math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+	r.pos += 8
+	return v, nil
+}
+
+// count reads an element count, rejecting counts the remaining bytes cannot hold
+func (r *wkbReader) count(minSize int) (int, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+	if int64(n)*int64(minSize) > int64(len(r.data)-r.pos) {
+		return 0, errWKBTruncated
+	}
+	return int(n), nil
+}
+
+// header reads the byte order and geometry type of a geometry, returning
+// the base type (1 to 7) and the number of coordinates per point
+func (r *wkbReader) header() (uint32, int, string, error) {
+	if r.pos >= len(r.data) {
+		return 0, 0, "", errWKBTruncated
+	}
+	switch r.data[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return 0, 0, "", fmt.Errorf("invalid WKB byte order %d", r.data[r.pos])
+	}
+	r.pos++
+
+	code, err := r.uint32()
+	if err != nil {
+		return 0, 0, "", err
+	}
+	hasZ, hasM := code&ewkbZ != 0, code&ewkbM != 0
+	if code&ewkbSRID != 0 {
+		if _, err := r.uint32(); err != nil {
+			return 0, 0, "", err
+		}
+	}
+	code &^= ewkbZ | ewkbM | ewkbSRID
+	switch code / 1000 {
+	case 1:
+		hasZ = true
+	case 2:
+		hasM = true
+	case 3:
+		hasZ, hasM = true, true
+	}
+	base := code % 1000
+	if base < 1 || int(base) > len(geometryTypeNames) || code/1000 > 3 {
+		return 0, 0, "", fmt.Errorf("unsupported WKB geometry type %d", code)
+	}
+
+	dims, suffix := 2, ""
+	switch {
+	case hasZ && hasM:
+		dims, suffix = 4, " ZM"
+	case hasZ:
+		dims, suffix = 3, " Z"
+	case hasM:
+		dims, suffix = 3, " M"
+	}
+	return base, dims, suffix, nil
+}
+
+// writeGeometry writes one geometry, with its type keyword when tagged
+// (members of MULTI* geometries are written without it)
+func (r *wkbReader) writeGeometry(text *strings.Builder, tagged bool) error {
+	base, dims, suffix, err := r.header()
+	if err != nil {
+		return err
+	}
+	if tagged {
+		text.WriteString(strings.ToUpper(geometryTypeNames[base-1]) + suffix + " ")
+	}
+
+	switch base {
+	case 1: // Point
+		return r.writePoint(text, dims)
+	case 2: // LineString
+		return r.writePoints(text, dims)
+	case 3: // Polygon
+		return r.writeRings(text, dims)
+	default: // Multi* and GeometryCollection
+		n, err := r.count(5)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			text.WriteString("EMPTY")
+			return nil
+		}
+		text.WriteString("(")
+		for i := range n {
+			if i > 0 {
+				text.WriteString(", ")
+			}
+			if err := r.writeGeometry(text, base == 7); err != nil {
+				return err
+			}
+		}
+		text.WriteString(")")
+		return nil
+	}
+}
+
+// writePoint writes a point, all-NaN coordinates being an empty point
+func (r *wkbReader) writePoint(text *strings.Builder, dims int) error {
+	coords := make([]string, dims)
+	empty := true
+	for i := range coords {
+		f, err := r.float64()
+		if err != nil {
+			return err
+		}
+		empty = empty && math.IsNaN(f)
+		coords[i] = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	if empty {
+		text.WriteString("EMPTY")
+		return nil
+	}
+	text.WriteString("(" + strings.Join(coords, " ") + ")")
+	return nil
+}
+
+// writePoints writes a counted list of points
+func (r *wkbReader) writePoints(text *strings.Builder, dims int) error {
+	n, err := r.count(dims * 8)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		text.WriteString("EMPTY")
+		return nil
+	}
+	text.WriteString("(")
+	for i := range n {
+		if i > 0 {
+			text.WriteString(", ")
+		}
+		for d := range dims {
+			f, err := r.float64()
+			if err != nil {
+				return err
+			}
+			if d > 0 {
+				text.WriteString(" ")
+			}
+			text.WriteString(strconv.FormatFloat(f, 'f', -1, 64))
+		}
+	}
+	text.WriteString(")")
+	return nil
+}
+
+// writeRings writes a counted list of polygon rings
+func (r *wkbReader) writeRings(text *strings.Builder, dims int) error {
+	n, err := r.count(4)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		text.WriteString("EMPTY")
+		return nil
+	}
+	text.WriteString("(")
+	for i := range n {
+		if i > 0 {
+			text.WriteString(", ")
+		}
+		if err := r.writePoints(text, dims); err != nil {
+			return err
+		}
+	}
+	text.WriteString(")")
+	return nil
+}
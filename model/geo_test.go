@@ -0,0 +1,235 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+)
+
+// openGeoTestReader opens the GEOMETRY and GEOGRAPHY test file
+func openGeoTestReader(t *testing.T) *ParquetReader {
+	t.Helper()
+	pr, err := pio.NewParquetFileReader("../build/testdata/geospatial.parquet", pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pr.ReadStop() })
+	return NewParquetReader(pr)
+}
+
+// wkb encodes a little endian WKB geometry from its type code and the
+// counts and coordinates that follow it
+func wkb(code uint32, parts ...any) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(1)
+	_ = binary.Write(&buf, binary.LittleEndian, code)
+	for _, p := range parts {
+		switch v := p.(type) {
+		case int:
+			_ = binary.Write(&buf, binary.LittleEndian, uint32(v))
+		case float64:
+			_ = binary.Write(&buf, binary.LittleEndian, v)
+		case []byte:
+			buf.Write(v)
+		}
+	}
+	return buf.Bytes()
+}
+
+func Test_GeospatialStatistics_ColumnChunk(t *testing.T) {
+	pr := openGeoTestReader(t)
+
+	info, err := pr.GetColumnChunkInfo(0, 0)
+	require.NoError(t, err)
+	require.NotNil(t, info.GeospatialStatistics)
+	require.Equal(t, &BoundingBox{Xmin: -3, Xmax: 16, Ymin: -8, Ymax: 11}, info.GeospatialStatistics.BoundingBox)
+	require.ElementsMatch(t, []string{
+		"Point", "LineString", "Polygon", "MultiPoint", "MultiLineString", "MultiPolygon", "GeometryCollection",
+	}, info.GeospatialStatistics.GeometryTypes)
+
+	// The writer records no geospatial statistics for GEOGRAPHY
+	info, err = pr.GetColumnChunkInfo(0, 1)
+	require.NoError(t, err)
+	require.Nil(t, info.GeospatialStatistics)
+
+	// Other columns never have them
+	info, err = openTestParquetReader(t).GetColumnChunkInfo(0, 0)
+	require.NoError(t, err)
+	require.Nil(t, info.GeospatialStatistics)
+}
+
+func Test_GetPageContentFormattedGeo(t *testing.T) {
+	pr := openGeoTestReader(t)
+
+	values, err := pr.GetPageContentFormattedGeo(0, 0, 0, GeoFormatWKT)
+	require.NoError(t, err)
+	require.Equal(t, []string{"POINT (0 0)", "LINESTRING (0 0, 1 1, 2 -1)", "POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))"}, values)
+
+	values, err = pr.GetPageContentFormattedGeo(0, 0, 0, GeoFormatGeoJSON)
+	require.NoError(t, err)
+	require.Contains(t, values[0], `"type":"Point"`)
+
+	defaultValues, err := pr.GetPageContentFormatted(0, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, values, defaultValues)
+
+	values, err = pr.GetPageContentFormattedGeo(0, 1, 0, GeoFormatWKT)
+	require.NoError(t, err)
+	require.Equal(t, "LINESTRING (0.5 1.5, 1.5 2.5)", values[1])
+}
+
+func Test_GetGeospatialBounds(t *testing.T) {
+	pr := openGeoTestReader(t)
+
+	bounds, err := pr.GetGeospatialBounds(context.Background(), 0)
+	require.NoError(t, err)
+	require.Equal(t, "Geometry", bounds.Path)
+	require.Equal(t, "GEOMETRY", bounds.LogicalType)
+	require.Equal(t, "OGC:CRS84", bounds.CRS)
+	require.Len(t, bounds.RowGroups, 1)
+	require.Equal(t, BoundsFromStatistics, bounds.RowGroups[0].Source)
+	require.Equal(t, int64(10), bounds.RowGroups[0].NumRows)
+	require.Equal(t, &BoundingBox{Xmin: -3, Xmax: 16, Ymin: -8, Ymax: 11}, bounds.BoundingBox)
+
+	// Without statistics the extent is computed from the values
+	bounds, err = pr.GetGeospatialBounds(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "GEOGRAPHY", bounds.LogicalType)
+	require.Equal(t, BoundsFromValues, bounds.RowGroups[0].Source)
+	require.Equal(t, &BoundingBox{Xmin: 0, Xmax: 10.5, Ymin: 0, Ymax: 10.5}, bounds.BoundingBox)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = pr.GetGeospatialBounds(ctx, 1)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = pr.GetGeospatialBounds(context.Background(), 2)
+	require.ErrorIs(t, err, ErrInvalidColumnIndex)
+
+	_, err = openTestParquetReader(t).GetGeospatialBounds(context.Background(), 0)
+	require.ErrorIs(t, err, ErrNotGeospatial)
+}
+
+func Test_WKBToWKT(t *testing.T) {
+	point := wkb(1, 1.0, 2.0)
+	tests := []struct {
+		name     string
+		wkb      []byte
+		expected string
+	}{
+		{"Point", point, "POINT (1 2)"},
+		{"Empty point", wkb(1, math.NaN(), math.NaN()), "POINT EMPTY"},
+		{"Point Z", wkb(1001, 1.0, 2.0, 3.5), "POINT Z (1 2 3.5)"},
+		{"Point M", wkb(2001, 1.0, 2.0, 4.0), "POINT M (1 2 4)"},
+		{"Point ZM", wkb(3001, 1.0, 2.0, 3.0, 4.0), "POINT ZM (1 2 3 4)"},
+		{"EWKB point Z with SRID", wkb(0x80000000|0x20000000|1, 4326, 1.0, 2.0, 3.0), "POINT Z (1 2 3)"},
+		{"LineString", wkb(2, 2, 0.0, 0.0, 1.5, -1.0), "LINESTRING (0 0, 1.5 -1)"},
+		{"Empty LineString", wkb(2, 0), "LINESTRING EMPTY"},
+		{"Polygon", wkb(3, 1, 4, 0.0, 0.0, 1.0, 0.0, 1.0, 1.0, 0.0, 0.0), "POLYGON ((0 0, 1 0, 1 1, 0 0))"},
+		{"MultiPoint", wkb(4, 2, point, wkb(1, 3.0, 4.0)), "MULTIPOINT ((1 2), (3 4))"},
+		{"MultiLineString", wkb(5, 1, wkb(2, 2, 0.0, 0.0, 1.0, 1.0)), "MULTILINESTRING ((0 0, 1 1))"},
+		{"MultiPolygon", wkb(6, 1, wkb(3, 1, 3, 0.0, 0.0, 1.0, 0.0, 0.0, 0.0)), "MULTIPOLYGON (((0 0, 1 0, 0 0)))"},
+		{"GeometryCollection", wkb(7, 2, point, wkb(2, 0)), "GEOMETRYCOLLECTION (POINT (1 2), LINESTRING EMPTY)"},
+		{"Empty GeometryCollection", wkb(7, 0), "GEOMETRYCOLLECTION EMPTY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wkt, err := WKBToWKT(tt.wkb)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, wkt)
+		})
+	}
+
+	bigEndian := []byte{0, 0, 0, 0, 1, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0}
+	wkt, err := WKBToWKT(bigEndian)
+	require.NoError(t, err)
+	require.Equal(t, "POINT (1 2)", wkt)
+}
+
+func Test_WKBToWKT_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		wkb    []byte
+		errMsg string
+	}{
+		{"Empty", nil, "truncated WKB"},
+		{"Bad byte order", []byte{2, 1, 0, 0, 0}, "invalid WKB byte order 2"},
+		{"Unknown type", wkb(8), "unsupported WKB geometry type 8"},
+		{"Truncated point", wkb(1, 1.0), "truncated WKB"},
+		{"Huge count", wkb(2, 1000000), "truncated WKB"},
+		{"Trailing bytes", append(wkb(1, 1.0, 2.0), 0), "1 trailing bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := WKBToWKT(tt.wkb)
+			require.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_GeometryTypeName(t *testing.T) {
+	require.Equal(t, "Point", GeometryTypeName(1))
+	require.Equal(t, "Polygon Z", GeometryTypeName(1003))
+	require.Equal(t, "LineString M", GeometryTypeName(2002))
+	require.Equal(t, "GeometryCollection ZM", GeometryTypeName(3007))
+	require.Equal(t, "UNKNOWN(8)", GeometryTypeName(8))
+	require.Equal(t, "UNKNOWN(4001)", GeometryTypeName(4001))
+	require.Equal(t, "UNKNOWN(-1)", GeometryTypeName(-1))
+}
+
+func Test_ParseGeoFormat(t *testing.T) {
+	for input, expected := range map[string]GeoFormat{"": GeoFormatGeoJSON, "geojson": GeoFormatGeoJSON, "WKT": GeoFormatWKT} {
+		format, err := ParseGeoFormat(input)
+		require.NoError(t, err)
+		require.Equal(t, expected, format)
+	}
+
+	_, err := ParseGeoFormat("kml")
+	require.True(t, errors.Is(err, ErrInvalidGeoFormat))
+}
+
+func Test_FormatGeoValue(t *testing.T) {
+	geometry := &parquet.SchemaElement{LogicalType: &parquet.LogicalType{GEOMETRY: &parquet.GeometryType{}}}
+	point := string(wkb(1, 1.0, 2.0))
+
+	require.Equal(t, "POINT (1 2)", FormatGeoValue(point, parquet.Type_BYTE_ARRAY, geometry, GeoFormatWKT))
+	require.Contains(t, FormatGeoValue(point, parquet.Type_BYTE_ARRAY, geometry, GeoFormatGeoJSON), `"coordinates":[1,2]`)
+	require.Equal(t, "NULL", FormatGeoValue(nil, parquet.Type_BYTE_ARRAY, geometry, GeoFormatWKT))
+	// Invalid WKB falls back to the regular formatting
+	require.Contains(t, FormatGeoValue("xx", parquet.Type_BYTE_ARRAY, geometry, GeoFormatWKT), "7878")
+	// Non-geospatial columns ignore the format
+	require.Equal(t, FormatValue("abc", parquet.Type_BYTE_ARRAY, nil), FormatGeoValue("abc", parquet.Type_BYTE_ARRAY, nil, GeoFormatWKT))
+}
+
+func Test_convertGeospatialStatistics(t *testing.T) {
+	require.Nil(t, convertGeospatialStatistics(nil))
+	require.Nil(t, convertGeospatialStatistics(&parquet.GeospatialStatistics{}))
+
+	zmin, zmax := 1.0, 2.0
+	stats := convertGeospatialStatistics(&parquet.GeospatialStatistics{
+		Bbox:            &parquet.BoundingBox{Xmin: 1, Xmax: 2, Ymin: 3, Ymax: 4, Zmin: &zmin, Zmax: &zmax},
+		GeospatialTypes: []int32{1001},
+	})
+	require.Equal(t, &BoundingBox{Xmin: 1, Xmax: 2, Ymin: 3, Ymax: 4, Zmin: &zmin, Zmax: &zmax}, stats.BoundingBox)
+	require.Equal(t, []string{"Point Z"}, stats.GeometryTypes)
+
+	stats = convertGeospatialStatistics(&parquet.GeospatialStatistics{GeospatialTypes: []int32{3}})
+	require.Nil(t, stats.BoundingBox)
+	require.Equal(t, []string{"Polygon"}, stats.GeometryTypes)
+}
+
+func Test_unionBoundingBox(t *testing.T) {
+	a := &BoundingBox{Xmin: 0, Xmax: 1, Ymin: 0, Ymax: 1}
+	b := &BoundingBox{Xmin: -1, Xmax: 0.5, Ymin: 0.5, Ymax: 3}
+	require.Nil(t, unionBoundingBox(nil, nil))
+	require.Equal(t, a, unionBoundingBox(a, nil))
+	require.Equal(t, b, unionBoundingBox(nil, b))
+	require.Equal(t, &BoundingBox{Xmin: -1, Xmax: 1, Ymin: 0, Ymax: 3}, unionBoundingBox(a, b))
+}
@@ -0,0 +1,212 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// HeatmapMetric selects what the cells of the row group × column heatmap show
+type HeatmapMetric string
+
+const (
+	HeatmapCompressedSize   HeatmapMetric = "size"
+	HeatmapCompressionRatio HeatmapMetric = "ratio"
+	HeatmapNullFraction     HeatmapMetric = "nulls"
+	HeatmapPageCount        HeatmapMetric = "pages"
+	HeatmapHasStatistics    HeatmapMetric = "stats"
+	HeatmapHasDictionary    HeatmapMetric = "dictionary"
+)
+
+// HeatmapMetrics lists the heatmap metrics in display order
+var HeatmapMetrics = []HeatmapMetric{
+	HeatmapCompressedSize, HeatmapCompressionRatio, HeatmapNullFraction,
+	HeatmapPageCount, HeatmapHasStatistics, HeatmapHasDictionary,
+}
+
+// Title names the metric for display
+func (m HeatmapMetric) Title() string {
+	switch m {
+	case HeatmapCompressedSize:
+		return "Compressed Size"
+	case HeatmapCompressionRatio:
+		return "Compression Ratio"
+	case HeatmapNullFraction:
+		return "Null Fraction"
+	case HeatmapPageCount:
+		return "Page Count"
+	case HeatmapHasStatistics:
+		return "Has Statistics"
+	case HeatmapHasDictionary:
+		return "Has Dictionary"
+	}
+	return string(m)
+}
+
+// HeatmapLevels is the number of color levels heatmap cells are bucketed into
+const HeatmapLevels = 4
+
+// ErrInvalidHeatmapMetric is returned for an unknown heatmap metric
+var ErrInvalidHeatmapMetric = errors.New("invalid heatmap metric")
+
+// ParseHeatmapMetric parses a heatmap metric, empty means compressed size
+func ParseHeatmapMetric(s string) (HeatmapMetric, error) {
+	if s == "" {
+		return HeatmapCompressedSize, nil
+	}
+	for _, metric := range HeatmapMetrics {
+		if HeatmapMetric(strings.ToLower(s)) == metric {
+			return metric, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q (expected one of %v)", ErrInvalidHeatmapMetric, s, HeatmapMetrics)
+}
+
+// HeatmapCell is one column chunk of the heatmap
+type HeatmapCell struct {
+	Value float64
+	Valid bool   // false when the chunk does not record the metric, e.g. no null count
+	Level int    // 0 to HeatmapLevels-1, where Value falls between Min and Max
+	Label string // Value formatted for display
+}
+
+// Heatmap is a row group × column matrix of one metric of every column chunk
+type Heatmap struct {
+	Metric    HeatmapMetric
+	Columns   []string        // dotted column paths, in column order
+	Cells     [][]HeatmapCell // Cells[rowGroup][column]
+	Min       float64         // smallest valid value
+	Max       float64         // largest valid value
+	MinLabel  string
+	MaxLabel  string
+	LogScale  bool // levels are spread on a logarithmic scale
+	NumValid  int
+	NumChunks int
+}
+
+// GetHeatmap builds a row group × column matrix of the given metric from the
+// column chunk metadata of every row group. Page counts read the page
+// headers of every chunk, all other metrics come from the footer alone.
+func (pr *ParquetReader) GetHeatmap(ctx context.Context, metric HeatmapMetric) (Heatmap, error) {
+	if pr == nil || pr.metadata == nil {
+		return Heatmap{}, ErrInvalidRowGroupIndex
+	}
+	pr = pr.WithContext(ctx)
+	metric, err := ParseHeatmapMetric(string(metric))
+	if err != nil {
+		return Heatmap{}, err
+	}
+
+	heatmap := Heatmap{
+		Metric:   metric,
+		Columns:  make([]string, len(pr.columns)),
+		Cells:    make([][]HeatmapCell, len(pr.metadata.RowGroups)),
+		LogScale: metric == HeatmapCompressedSize || metric == HeatmapPageCount,
+	}
+	for i := range pr.columns {
+		heatmap.Columns[i] = pr.columnName(i)
+	}
+
+	for rgIndex := range pr.metadata.RowGroups {
+		if err := ctx.Err(); err != nil {
+			return Heatmap{}, err
+		}
+
+		chunks, err := pr.GetAllColumnChunksInfo(rgIndex)
+		if err != nil {
+			return Heatmap{}, err
+		}
+
+		row := make([]HeatmapCell, len(chunks))
+		for colIndex, chunk := range chunks {
+			row[colIndex], err = pr.heatmapCell(metric, chunk)
+			if err != nil {
+				return Heatmap{}, fmt.Errorf("row group %d, column %s: %w", rgIndex, chunk.Path, err)
+			}
+			if !row[colIndex].Valid {
+				continue
+			}
+			if heatmap.NumValid == 0 || row[colIndex].Value < heatmap.Min {
+				heatmap.Min = row[colIndex].Value
+				heatmap.MinLabel = row[colIndex].Label
+			}
+			if heatmap.NumValid == 0 || row[colIndex].Value > heatmap.Max {
+				heatmap.Max = row[colIndex].Value
+				heatmap.MaxLabel = row[colIndex].Label
+			}
+			heatmap.NumValid++
+		}
+		heatmap.Cells[rgIndex] = row
+		heatmap.NumChunks += len(row)
+	}
+
+	// Null fractions and flags have a fixed range so that colors mean the
+	// same thing in every file
+	low, high := heatmap.Min, heatmap.Max
+	switch metric {
+	case HeatmapNullFraction, HeatmapHasStatistics, HeatmapHasDictionary:
+		low, high = 0, 1
+	}
+	for _, row := range heatmap.Cells {
+		for i := range row {
+			if row[i].Valid {
+				row[i].Level = heatmapLevel(row[i].Value, low, high, heatmap.LogScale)
+			}
+		}
+	}
+
+	return heatmap, nil
+}
+
+// heatmapCell computes the metric of one column chunk
+func (pr *ParquetReader) heatmapCell(metric HeatmapMetric, chunk ColumnChunkInfo) (HeatmapCell, error) {
+	switch metric {
+	case HeatmapCompressedSize:
+		return HeatmapCell{Value: float64(chunk.CompressedSize), Valid: true, Label: FormatBytes(chunk.CompressedSize)}, nil
+	case HeatmapCompressionRatio:
+		if chunk.CompressedSize <= 0 {
+			return HeatmapCell{Label: "-"}, nil
+		}
+		return HeatmapCell{Value: chunk.CompressionRatio, Valid: true, Label: fmt.Sprintf("%.2fx", chunk.CompressionRatio)}, nil
+	case HeatmapNullFraction:
+		if chunk.NullCount == nil || chunk.NumValues <= 0 {
+			return HeatmapCell{Label: "-"}, nil
+		}
+		fraction := float64(*chunk.NullCount) / float64(chunk.NumValues)
+		return HeatmapCell{Value: fraction, Valid: true, Label: fmt.Sprintf("%.1f%%", fraction*100)}, nil
+	case HeatmapPageCount:
+		headers, err := pr.pageHeaders(chunk.RowGroup, chunk.Index)
+		if err != nil {
+			return HeatmapCell{}, err
+		}
+		return HeatmapCell{Value: float64(len(headers)), Valid: true, Label: fmt.Sprintf("%d", len(headers))}, nil
+	case HeatmapHasStatistics:
+		return heatmapFlag(chunk.HasStatistics), nil
+	case HeatmapHasDictionary:
+		return heatmapFlag(chunk.HasDictionary), nil
+	}
+	return HeatmapCell{}, fmt.Errorf("%w: %q", ErrInvalidHeatmapMetric, metric)
+}
+
+// heatmapFlag is the cell of a yes/no metric
+func heatmapFlag(set bool) HeatmapCell {
+	if set {
+		return HeatmapCell{Value: 1, Valid: true, Label: "yes"}
+	}
+	return HeatmapCell{Value: 0, Valid: true, Label: "no"}
+}
+
+// heatmapLevel buckets value between low and high into one of HeatmapLevels
+// levels. With no spread every value gets the lowest level.
+func heatmapLevel(value, low, high float64, logScale bool) int {
+	if logScale {
+		value, low, high = math.Log1p(max(value, 0)), math.Log1p(max(low, 0)), math.Log1p(max(high, 0))
+	}
+	if high <= low {
+		return 0
+	}
+	level := int((value - low) / (high - low) * HeatmapLevels)
+	return min(max(level, 0), HeatmapLevels-1)
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseHeatmapMetric(t *testing.T) {
+	metric, err := ParseHeatmapMetric("")
+	require.NoError(t, err)
+	require.Equal(t, HeatmapCompressedSize, metric)
+
+	for _, want := range HeatmapMetrics {
+		metric, err = ParseHeatmapMetric(string(want))
+		require.NoError(t, err)
+		require.Equal(t, want, metric)
+	}
+
+	metric, err = ParseHeatmapMetric("Pages")
+	require.NoError(t, err)
+	require.Equal(t, HeatmapPageCount, metric)
+
+	_, err = ParseHeatmapMetric("bogus")
+	require.ErrorIs(t, err, ErrInvalidHeatmapMetric)
+}
+
+func Test_HeatmapMetric_Title(t *testing.T) {
+	for _, metric := range HeatmapMetrics {
+		require.NotEqual(t, string(metric), metric.Title())
+	}
+	require.Equal(t, "Page Count", HeatmapPageCount.Title())
+	require.Equal(t, "bogus", HeatmapMetric("bogus").Title())
+}
+
+func Test_GetHeatmap(t *testing.T) {
+	pr := openTestParquetReader(t)
+	numColumns := countLeafColumns(pr.metadata.Schema)
+
+	t.Run("Compressed size", func(t *testing.T) {
+		heatmap, err := pr.GetHeatmap(context.Background(), HeatmapCompressedSize)
+		require.NoError(t, err)
+		require.Equal(t, HeatmapCompressedSize, heatmap.Metric)
+		require.True(t, heatmap.LogScale)
+		require.Len(t, heatmap.Columns, numColumns)
+		require.Equal(t, "Map.Key_value.Key", heatmap.Columns[46])
+		require.Len(t, heatmap.Cells, len(pr.metadata.RowGroups))
+		require.Equal(t, numColumns*len(pr.metadata.RowGroups), heatmap.NumChunks)
+		require.Equal(t, heatmap.NumChunks, heatmap.NumValid)
+
+		chunk, err := pr.GetColumnChunkInfo(0, 1)
+		require.NoError(t, err)
+		cell := heatmap.Cells[0][1]
+		require.True(t, cell.Valid)
+		require.Equal(t, float64(chunk.CompressedSize), cell.Value)
+		require.Equal(t, FormatBytes(chunk.CompressedSize), cell.Label)
+
+		sawLowest, sawHighest := false, false
+		for _, cell := range heatmap.Cells[0] {
+			require.GreaterOrEqual(t, cell.Value, heatmap.Min)
+			require.LessOrEqual(t, cell.Value, heatmap.Max)
+			sawLowest = sawLowest || cell.Level == 0
+			sawHighest = sawHighest || cell.Level == HeatmapLevels-1
+		}
+		require.True(t, sawLowest)
+		require.True(t, sawHighest)
+	})
+
+	t.Run("Page count", func(t *testing.T) {
+		heatmap, err := pr.GetHeatmap(context.Background(), HeatmapPageCount)
+		require.NoError(t, err)
+		pages, err := pr.GetPageMetadataList(0, 1)
+		require.NoError(t, err)
+		require.Equal(t, float64(len(pages)), heatmap.Cells[0][1].Value)
+	})
+
+	t.Run("Flags", func(t *testing.T) {
+		heatmap, err := pr.GetHeatmap(context.Background(), HeatmapHasDictionary)
+		require.NoError(t, err)
+		require.Equal(t, "yes", heatmap.Cells[0][1].Label)
+		require.Equal(t, HeatmapLevels-1, heatmap.Cells[0][1].Level)
+
+		heatmap, err = pr.GetHeatmap(context.Background(), HeatmapHasStatistics)
+		require.NoError(t, err)
+		require.False(t, heatmap.LogScale)
+		for _, cell := range heatmap.Cells[0] {
+			require.Contains(t, []string{"yes", "no"}, cell.Label)
+		}
+	})
+
+	t.Run("Null fraction", func(t *testing.T) {
+		heatmap, err := pr.GetHeatmap(context.Background(), HeatmapNullFraction)
+		require.NoError(t, err)
+		for _, cell := range heatmap.Cells[0] {
+			if cell.Valid {
+				require.GreaterOrEqual(t, cell.Value, 0.0)
+				require.LessOrEqual(t, cell.Value, 1.0)
+			}
+		}
+	})
+
+	t.Run("Invalid metric", func(t *testing.T) {
+		_, err := pr.GetHeatmap(context.Background(), "bogus")
+		require.ErrorIs(t, err, ErrInvalidHeatmapMetric)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := pr.GetHeatmap(ctx, HeatmapCompressedSize)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Nil reader", func(t *testing.T) {
+		var nilReader *ParquetReader
+		_, err := nilReader.GetHeatmap(context.Background(), HeatmapCompressedSize)
+		require.ErrorIs(t, err, ErrInvalidRowGroupIndex)
+	})
+}
+
+func Test_heatmapLevel(t *testing.T) {
+	require.Equal(t, 0, heatmapLevel(5, 5, 5, false))
+	require.Equal(t, 0, heatmapLevel(0, 0, 1, false))
+	require.Equal(t, 1, heatmapLevel(0.3, 0, 1, false))
+	require.Equal(t, HeatmapLevels-1, heatmapLevel(1, 0, 1, false))
+	require.Equal(t, HeatmapLevels-1, heatmapLevel(2, 0, 1, false))
+	require.Equal(t, 0, heatmapLevel(-1, 0, 1, false))
+
+	// 1 KB in 0 .. 1 MB sits half way on a log scale, at the bottom on a linear one
+	require.Equal(t, 2, heatmapLevel(1<<10, 0, 1<<20, true))
+	require.Equal(t, 0, heatmapLevel(1<<10, 0, 1<<20, false))
+}
+
+func Test_ColumnChunkInfo_Flags(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	chunk, err := pr.GetColumnChunkInfo(0, 1)
+	require.NoError(t, err)
+	require.True(t, chunk.HasDictionary)
+	require.True(t, chunk.HasStatistics)
+}
@@ -0,0 +1,351 @@
+package model
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hangxie/parquet-go/v3/source"
+)
+
+// IOOperation names what a read of the file was made for
+type IOOperation string
+
+const (
+	IOFingerprint IOOperation = "fingerprint"  // size and trailing bytes checked by the cache
+	IOPageHeaders IOOperation = "page_headers" // page header scans of column chunks
+	IOPageData    IOOperation = "page_data"    // compressed pages read one at a time
+	IODictionary  IOOperation = "dictionary"   // dictionary pages
+	IOOffsetIndex IOOperation = "offset_index"
+	IOColumnIndex IOOperation = "column_index"
+	IOColumnChunk IOOperation = "column_chunk" // whole column chunks read for their values
+	IOProfile     IOOperation = "profile"      // column chunks read to profile a column
+	IORows        IOOperation = "rows"         // whole rows located or sampled
+	IOLayout      IOOperation = "layout"       // headers and indexes placed on the file layout
+)
+
+// DefaultPrefetchMinBlock is the first read-ahead size of a file handle
+const DefaultPrefetchMinBlock = 64 << 10
+
+// PrefetchOptions configures reading ahead of small reads, so that a scan of
+// page headers takes a few large range requests instead of one per header.
+// A handle reads ahead MinBlock bytes at first, doubling up to MaxBlock while
+// the reads that follow land in the bytes read ahead and halving when they
+// do not.
+type PrefetchOptions struct {
+	MinBlock int // first read-ahead size in bytes
+	MaxBlock int // largest read-ahead size in bytes, 0 disables prefetching
+}
+
+// enabled reports whether reads are prefetched
+func (o PrefetchOptions) enabled() bool {
+	return o.MaxBlock > 0
+}
+
+// OperationIOStats counts the reads of the file made for an operation. Reads
+// are the calls to the file handle underneath: page headers are decoded a few
+// bytes at a time, so a header scan without read-ahead takes several reads
+// per page. How a remote file turns reads into range requests is up to its
+// handle.
+type OperationIOStats struct {
+	Operation  IOOperation
+	Reads      int64         // reads of the file handle underneath, not the calls served from bytes read ahead
+	Bytes      int64         // bytes read from the file
+	Latency    time.Duration // time spent waiting on the file
+	MaxLatency time.Duration // longest single read
+	Prefetched int64         // reads served from bytes read ahead, without reading the file
+}
+
+// add counts the reads of other as well
+func (s *OperationIOStats) add(other OperationIOStats) {
+	s.Reads += other.Reads
+	s.Bytes += other.Bytes
+	s.Latency += other.Latency
+	s.MaxLatency = max(s.MaxLatency, other.MaxLatency)
+	s.Prefetched += other.Prefetched
+}
+
+// Summary describes the reads in a few words, such as "12 reads, 1.5 MB in
+// 40ms, 30 prefetched"
+func (s OperationIOStats) Summary() string {
+	summary := fmt.Sprintf("%d reads, %s in %s", s.Reads, FormatBytes(s.Bytes), FormatLatency(s.Latency))
+	if s.Prefetched > 0 {
+		summary += fmt.Sprintf(", %d prefetched", s.Prefetched)
+	}
+	return summary
+}
+
+// FormatLatency formats a time spent reading to the millisecond, or to the
+// microsecond below a millisecond
+func FormatLatency(d time.Duration) string {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// IOStats counts the reads of the file since it was opened, by operation.
+// The footer reads of opening the file are not counted.
+type IOStats struct {
+	Operations []OperationIOStats // operations that read the file, by name
+	Total      OperationIOStats   // every operation together
+	Prefetch   PrefetchOptions
+}
+
+// ioCounters accumulates the reads of every handle of a file
+type ioCounters struct {
+	mu  sync.Mutex
+	ops map[IOOperation]*OperationIOStats
+}
+
+// newIOCounters creates counters with no reads yet
+func newIOCounters() *ioCounters {
+	return &ioCounters{ops: map[IOOperation]*OperationIOStats{}}
+}
+
+// operation returns the counters of an operation, the caller holds mu
+func (c *ioCounters) operation(op IOOperation) *OperationIOStats {
+	stats, ok := c.ops[op]
+	if !ok {
+		stats = &OperationIOStats{Operation: op}
+		c.ops[op] = stats
+	}
+	return stats
+}
+
+// read counts a read of the file handle underneath
+func (c *ioCounters) read(op IOOperation, n int, elapsed time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.operation(op)
+	stats.Reads++
+	stats.Bytes += int64(n)
+	stats.Latency += elapsed
+	stats.MaxLatency = max(stats.MaxLatency, elapsed)
+}
+
+// prefetched counts a read served from bytes read ahead
+func (c *ioCounters) prefetched(op IOOperation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.operation(op).Prefetched++
+}
+
+// stats returns the counts of every operation and their total
+func (c *ioCounters) stats() IOStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var stats IOStats
+	for _, op := range c.ops {
+		stats.Operations = append(stats.Operations, *op)
+		stats.Total.add(*op)
+	}
+	slices.SortFunc(stats.Operations, func(a, b OperationIOStats) int {
+		return cmp.Compare(a.Operation, b.Operation)
+	})
+	return stats
+}
+
+// ioFile is a file handle that counts its reads for an operation and, with
+// prefetching enabled, reads ahead of small reads to serve the ones that
+// follow from memory. Seeks only move the position of the next read, the
+// handle underneath is moved when a read needs it. The handles opened or
+// cloned from it count their reads for the same operation.
+type ioFile struct {
+	source.ParquetFileReader
+	op       IOOperation
+	counters *ioCounters
+	prefetch PrefetchOptions
+
+	pos      int64  // position of the next read
+	filePos  int64  // position of the handle underneath, -1 when unknown
+	buf      []byte // bytes read ahead, from bufStart
+	bufStart int64
+	bufReads int // reads served from buf since it was filled
+	block    int // bytes to read ahead next
+}
+
+// newIOFile wraps a file handle, reads start where the handle is
+func newIOFile(pFile source.ParquetFileReader, op IOOperation, counters *ioCounters, prefetch PrefetchOptions) *ioFile {
+	f := &ioFile{
+		ParquetFileReader: pFile,
+		op:                op,
+		counters:          counters,
+		prefetch:          prefetch,
+		filePos:           -1,
+		block:             max(1, min(prefetch.MinBlock, prefetch.MaxBlock)),
+	}
+	if pos, err := pFile.Seek(0, io.SeekCurrent); err == nil {
+		f.pos, f.filePos = pos, pos
+	}
+	return f
+}
+
+// Seek moves the position of the next read. Only a seek from the end goes
+// to the handle underneath, which knows the size of the file.
+func (f *ioFile) Seek(offset int64, whence int) (int64, error) {
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = f.pos + offset
+	case io.SeekEnd:
+		end, err := f.ParquetFileReader.Seek(offset, io.SeekEnd)
+		if err != nil {
+			f.filePos = -1
+			return 0, err
+		}
+		f.filePos = end
+		pos = end
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if pos < 0 {
+		return 0, errors.New("negative position")
+	}
+	f.pos = pos
+	return pos, nil
+}
+
+// Read reads from the position of the handle until p is full or the file
+// ends, from the bytes read ahead when they hold it. Readers of pages read
+// them with a single call, so a read never stops at the end of the bytes
+// read ahead.
+func (f *ioFile) Read(p []byte) (int, error) {
+	var n int
+	for n < len(p) {
+		read, err := f.readOnce(p[n:])
+		n += read
+		if err != nil {
+			if errors.Is(err, io.EOF) && n > 0 {
+				return n, nil
+			}
+			return n, err
+		}
+		if read == 0 {
+			break
+		}
+	}
+	return n, nil
+}
+
+// readOnce reads from the bytes read ahead when they hold the position, or
+// else from the handle underneath once
+func (f *ioFile) readOnce(p []byte) (int, error) {
+	if f.pos >= f.bufStart && f.pos < f.bufStart+int64(len(f.buf)) {
+		n := copy(p, f.buf[f.pos-f.bufStart:])
+		f.pos += int64(n)
+		f.bufReads++
+		f.counters.prefetched(f.op)
+		return n, nil
+	}
+	if !f.prefetch.enabled() || len(p) >= f.block {
+		n, err := f.readAt(p, f.pos)
+		f.pos += int64(n)
+		return n, err
+	}
+
+	f.adapt()
+	n, err := f.fill()
+	if n == 0 {
+		return 0, err
+	}
+	n = copy(p, f.buf)
+	f.pos += int64(n)
+	return n, nil
+}
+
+// adapt grows the read-ahead when the reads that followed the last one were
+// served from it, and shrinks it when they were not
+func (f *ioFile) adapt() {
+	if f.buf == nil {
+		return
+	}
+	if f.bufReads > 0 {
+		f.block = min(f.block*2, f.prefetch.MaxBlock)
+	} else {
+		f.block = max(f.block/2, min(f.prefetch.MinBlock, f.prefetch.MaxBlock), 1)
+	}
+}
+
+// fill reads ahead block bytes from the position, fewer at the end of the
+// file, and returns how many it read
+func (f *ioFile) fill() (int, error) {
+	if cap(f.buf) < f.block {
+		f.buf = make([]byte, f.block)
+	}
+	f.buf = f.buf[:f.block]
+	f.bufStart, f.bufReads = f.pos, 0
+
+	var n int
+	var err error
+	for n < len(f.buf) && err == nil {
+		var read int
+		read, err = f.readAt(f.buf[n:], f.bufStart+int64(n))
+		n += read
+	}
+	f.buf = f.buf[:n]
+	if errors.Is(err, io.EOF) && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// readAt reads once from the handle underneath at pos and counts the read
+func (f *ioFile) readAt(p []byte, pos int64) (int, error) {
+	if f.filePos != pos {
+		if _, err := f.ParquetFileReader.Seek(pos, io.SeekStart); err != nil {
+			f.filePos = -1
+			return 0, err
+		}
+		f.filePos = pos
+	}
+	start := time.Now()
+	n, err := f.ParquetFileReader.Read(p)
+	f.counters.read(f.op, n, time.Since(start))
+	f.filePos += int64(n)
+	return n, err
+}
+
+// Open opens another file counting its reads for the same operation
+func (f *ioFile) Open(name string) (source.ParquetFileReader, error) {
+	opened, err := f.ParquetFileReader.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return newIOFile(opened, f.op, f.counters, f.prefetch), nil
+}
+
+// Clone opens another handle of the file counting its reads for the same
+// operation
+func (f *ioFile) Clone() (source.ParquetFileReader, error) {
+	cloned, err := f.ParquetFileReader.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return newIOFile(cloned, f.op, f.counters, f.prefetch), nil
+}
+
+// WithPrefetch returns a reader over the same file whose reads prefetch as
+// opts says. It shares the file handles, display options, decoders and cache
+// of pr.
+func (pr *ParquetReader) WithPrefetch(opts PrefetchOptions) *ParquetReader {
+	copied := *pr
+	copied.prefetch = opts
+	return &copied
+}
+
+// IOStats counts the reads of the file since it was opened
+func (pr *ParquetReader) IOStats() IOStats {
+	if pr == nil || pr.pool == nil {
+		return IOStats{}
+	}
+	stats := pr.pool.io.stats()
+	stats.Prefetch = pr.prefetch
+	return stats
+}
@@ -0,0 +1,217 @@
+package model
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hangxie/parquet-go/v3/source"
+	"github.com/stretchr/testify/require"
+)
+
+// memFile is a file handle over bytes in memory
+type memFile struct {
+	*bytes.Reader
+	data []byte
+}
+
+func newMemFile(data []byte) *memFile {
+	return &memFile{Reader: bytes.NewReader(data), data: data}
+}
+
+func (f *memFile) Close() error { return nil }
+
+func (f *memFile) Open(string) (source.ParquetFileReader, error) { return newMemFile(f.data), nil }
+
+func (f *memFile) Clone() (source.ParquetFileReader, error) { return newMemFile(f.data), nil }
+
+func Test_ioFile_Read(t *testing.T) {
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	tests := []struct {
+		name     string
+		prefetch PrefetchOptions
+		requests int64
+	}{
+		{"No prefetch", PrefetchOptions{}, 4},
+		{"Prefetch", PrefetchOptions{MinBlock: 64, MaxBlock: 256}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counters := newIOCounters()
+			f := newIOFile(newMemFile(data), IOPageHeaders, counters, tt.prefetch)
+
+			buf := make([]byte, 10)
+			for _, offset := range []int64{100, 120, 150, 170} {
+				pos, err := f.Seek(offset, io.SeekStart)
+				require.NoError(t, err)
+				require.Equal(t, offset, pos)
+				_, err = io.ReadFull(f, buf)
+				require.NoError(t, err)
+				require.Equal(t, data[offset:offset+10], buf)
+			}
+
+			stats := counters.stats()
+			require.Len(t, stats.Operations, 1)
+			require.Equal(t, IOPageHeaders, stats.Operations[0].Operation)
+			require.Equal(t, tt.requests, stats.Total.Reads)
+			require.Equal(t, stats.Operations[0].Bytes, stats.Total.Bytes)
+		})
+	}
+}
+
+func Test_ioFile_Read_EndOfFile(t *testing.T) {
+	data := []byte("0123456789")
+	f := newIOFile(newMemFile(data), IOLayout, newIOCounters(), PrefetchOptions{MinBlock: 64, MaxBlock: 64})
+
+	end, err := f.Seek(-4, io.SeekEnd)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), end)
+	got, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, []byte("6789"), got)
+
+	_, err = f.Seek(-1, io.SeekStart)
+	require.Error(t, err)
+}
+
+func Test_ioFile_adapt(t *testing.T) {
+	f := newIOFile(newMemFile(make([]byte, 1<<20)), IOPageHeaders, newIOCounters(), PrefetchOptions{MinBlock: 64, MaxBlock: 256})
+	buf := make([]byte, 8)
+
+	// Reads served from the bytes read ahead grow the next read-ahead
+	for _, offset := range []int64{0, 16, 64, 80, 192, 200} {
+		_, err := f.Seek(offset, io.SeekStart)
+		require.NoError(t, err)
+		_, err = f.Read(buf)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 256, f.block)
+
+	// Reads far apart shrink it back
+	for _, offset := range []int64{10000, 20000, 30000} {
+		_, err := f.Seek(offset, io.SeekStart)
+		require.NoError(t, err)
+		_, err = f.Read(buf)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 64, f.block)
+}
+
+func Test_ioFile_Clone(t *testing.T) {
+	counters := newIOCounters()
+	f := newIOFile(newMemFile([]byte("0123456789")), IODictionary, counters, PrefetchOptions{})
+
+	cloned, err := f.Clone()
+	require.NoError(t, err)
+	buf := make([]byte, 3)
+	_, err = io.ReadFull(cloned, buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte("012"), buf)
+
+	opened, err := f.Open("")
+	require.NoError(t, err)
+	_, err = io.ReadFull(opened, buf)
+	require.NoError(t, err)
+
+	stats := counters.stats()
+	require.Equal(t, int64(2), stats.Total.Reads)
+	require.Equal(t, int64(6), stats.Total.Bytes)
+	require.Equal(t, IODictionary, stats.Operations[0].Operation)
+}
+
+func Test_OperationIOStats_Summary(t *testing.T) {
+	stats := OperationIOStats{Reads: 12, Bytes: 1536 << 10, Latency: 40*time.Millisecond + 300*time.Microsecond}
+	require.Equal(t, "12 reads, 1.5 MB in 40ms", stats.Summary())
+
+	stats.Prefetched = 30
+	require.Equal(t, "12 reads, 1.5 MB in 40ms, 30 prefetched", stats.Summary())
+
+	require.Equal(t, "0 reads, 0 B in 0s", OperationIOStats{}.Summary())
+	require.Equal(t, "250µs", FormatLatency(250*time.Microsecond+400*time.Nanosecond))
+}
+
+func Test_ParquetReader_IOStats(t *testing.T) {
+	var nilReader *ParquetReader
+	require.Equal(t, IOStats{}, nilReader.IOStats())
+
+	pr := openTestParquetReader(t)
+	require.Empty(t, pr.IOStats().Operations)
+
+	_, err := pr.GetPageMetadataList(0, 0)
+	require.NoError(t, err)
+	_, err = pr.GetPageContent(0, 0, 0)
+	require.NoError(t, err)
+
+	stats := pr.IOStats()
+	ops := map[IOOperation]OperationIOStats{}
+	for _, op := range stats.Operations {
+		ops[op.Operation] = op
+	}
+	require.Positive(t, ops[IOPageHeaders].Reads)
+	require.Positive(t, ops[IOPageHeaders].Bytes)
+	require.Positive(t, stats.Total.Reads)
+	require.GreaterOrEqual(t, stats.Total.Latency, stats.Total.MaxLatency)
+	require.Zero(t, stats.Total.Prefetched)
+}
+
+func Test_ParquetReader_WithPrefetch(t *testing.T) {
+	var pages int64
+	headerReads := func(pr *ParquetReader) OperationIOStats {
+		pages = 0
+		for colIndex := range pr.metadata.RowGroups[0].Columns {
+			list, err := pr.GetPageMetadataList(0, colIndex)
+			require.NoError(t, err)
+			pages += int64(len(list))
+		}
+		for _, op := range pr.IOStats().Operations {
+			if op.Operation == IOPageHeaders {
+				return op
+			}
+		}
+		return OperationIOStats{}
+	}
+
+	plain := headerReads(openTestParquetReader(t))
+	opts := PrefetchOptions{MinBlock: 16 << 10, MaxBlock: 1 << 20}
+	prefetching := openTestParquetReader(t).WithPrefetch(opts)
+	prefetched := headerReads(prefetching)
+
+	// Headers are decoded a few bytes at a time, read ahead they take a small
+	// part of the reads of the handle
+	require.Greater(t, plain.Reads, pages)
+	require.Less(t, prefetched.Reads*10, plain.Reads)
+	require.Less(t, prefetched.Reads, pages)
+	require.Positive(t, prefetched.Prefetched)
+	require.Equal(t, opts, prefetching.IOStats().Prefetch)
+}
+
+func Test_ParquetReader_WithPrefetch_PageValues(t *testing.T) {
+	opts := PrefetchOptions{MinBlock: 16, MaxBlock: 32}
+	plain := openTestParquetReader(t)
+	prefetching := openTestParquetReader(t).WithPrefetch(opts)
+
+	// Pages larger than the read-ahead cross the end of the bytes read ahead
+	// and decode to the same values
+	var largePages int
+	for colIndex := range plain.metadata.RowGroups[0].Columns {
+		pages, err := plain.GetPageMetadataList(0, colIndex)
+		require.NoError(t, err)
+		for _, page := range pages {
+			if int(page.CompressedSize) > opts.MaxBlock {
+				largePages++
+			}
+			want, err := plain.GetPageContent(0, colIndex, page.Index)
+			require.NoError(t, err)
+			got, err := prefetching.GetPageContent(0, colIndex, page.Index)
+			require.NoError(t, err)
+			require.Equal(t, want, got, "column %d page %d", colIndex, page.Index)
+		}
+	}
+	require.Positive(t, largePages)
+	require.Positive(t, prefetching.IOStats().Total.Prefetched)
+}
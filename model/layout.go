@@ -0,0 +1,382 @@
+package model
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/reader"
+)
+
+// Layout region kinds, pages use their page type (DATA_PAGE, DICTIONARY_PAGE, ...)
+const (
+	LayoutMagic        = "MAGIC"
+	LayoutPageHeader   = "PAGE_HEADER"
+	LayoutColumnChunk  = "COLUMN_CHUNK"
+	LayoutColumnIndex  = "COLUMN_INDEX"
+	LayoutOffsetIndex  = "OFFSET_INDEX"
+	LayoutBloomFilter  = "BLOOM_FILTER"
+	LayoutFooter       = "FOOTER"
+	LayoutFooterLength = "FOOTER_LENGTH"
+	LayoutGap          = "GAP"
+)
+
+// Layout issue kinds
+const (
+	LayoutIssueGap          = "GAP"
+	LayoutIssueOverlap      = "OVERLAP"
+	LayoutIssueOutOfOrder   = "OUT_OF_ORDER"
+	LayoutIssueOutOfBounds  = "OUT_OF_BOUNDS"
+	LayoutIssueSizeMismatch = "SIZE_MISMATCH"
+	LayoutIssueBadMagic     = "BAD_MAGIC"
+	LayoutIssueUnreadable   = "UNREADABLE"
+)
+
+// LayoutRegion is one structure placed at its byte range in the file.
+// RowGroup, Column and Page are -1 when they do not apply.
+type LayoutRegion struct {
+	Kind     string
+	Offset   int64
+	Length   int64
+	RowGroup int
+	Column   int
+	Page     int
+	Path     string
+}
+
+// LayoutIssue is a problem found while placing structures in the file
+type LayoutIssue struct {
+	Kind    string
+	Offset  int64
+	Length  int64
+	Message string
+}
+
+// FileLayout maps every structure of the file to its byte range. Regions
+// are sorted by offset and unreferenced bytes appear as GAP regions.
+type FileLayout struct {
+	FileSize     int64
+	Regions      []LayoutRegion
+	Issues       []LayoutIssue
+	CoveredBytes int64
+	GapBytes     int64
+	OverlapBytes int64
+}
+
+// GetFileLayout places the magic bytes, page headers and bodies, indexes,
+// bloom filters and footer at their byte offsets and reports gaps, overlaps
+// and chunks that are out of order or do not match their recorded sizes
+func (pr *ParquetReader) GetFileLayout() (FileLayout, error) {
+	if pr == nil || pr.metadata == nil || pr.Reader == nil {
+		return FileLayout{}, errors.New("no parquet file loaded")
+	}
+
+	var fileSize int64
+	err := pr.withReader(IOLayout, func(r *reader.ParquetReader) error {
+		var err error
+		fileSize, err = r.PFile.Seek(0, io.SeekEnd)
+		return err
+	})
+	if err != nil {
+		return FileLayout{}, fmt.Errorf("failed to get file size: %w", err)
+	}
+
+	layout := FileLayout{FileSize: fileSize}
+	var regions []LayoutRegion
+	addIssue := func(kind string, offset, length int64, format string, args ...interface{}) {
+		layout.Issues = append(layout.Issues, LayoutIssue{Kind: kind, Offset: offset, Length: length, Message: fmt.Sprintf(format, args...)})
+	}
+
+	regions = append(regions, pr.trailerRegions(fileSize, addIssue)...)
+
+	var prevEnd int64
+	var prevName string
+	for rgIndex, rg := range pr.metadata.RowGroups {
+		for colIndex, chunk := range rg.Columns {
+			meta := chunk.MetaData
+			if meta == nil {
+				addIssue(LayoutIssueUnreadable, 0, 0, "row group %d column %d has no readable metadata", rgIndex, colIndex)
+				continue
+			}
+			path := pr.columnName(colIndex)
+			name := fmt.Sprintf("row group %d column %s", rgIndex, path)
+
+			start := meta.DataPageOffset
+			if meta.DictionaryPageOffset != nil && *meta.DictionaryPageOffset > 0 && *meta.DictionaryPageOffset < start {
+				start = *meta.DictionaryPageOffset
+			}
+			if start < prevEnd {
+				addIssue(LayoutIssueOutOfOrder, start, meta.TotalCompressedSize,
+					"%s starts at %d, before the end of %s at %d", name, start, prevName, prevEnd)
+			}
+			prevEnd, prevName = start+meta.TotalCompressedSize, name
+
+			chunkRegions, err := pr.pageRegions(rgIndex, colIndex, chunk.CryptoMetadata != nil)
+			if err != nil {
+				addIssue(LayoutIssueUnreadable, start, meta.TotalCompressedSize, "%s: %v", name, err)
+				chunkRegions = []LayoutRegion{{Kind: LayoutColumnChunk, Offset: start, Length: meta.TotalCompressedSize, Page: -1}}
+			} else {
+				var pagesSize int64
+				for _, region := range chunkRegions {
+					pagesSize += region.Length
+				}
+				if pagesSize != meta.TotalCompressedSize {
+					addIssue(LayoutIssueSizeMismatch, start, pagesSize,
+						"%s pages take %d bytes but the chunk records %d", name, pagesSize, meta.TotalCompressedSize)
+				}
+			}
+
+			if chunk.ColumnIndexOffset != nil && chunk.ColumnIndexLength != nil {
+				chunkRegions = append(chunkRegions, LayoutRegion{Kind: LayoutColumnIndex, Offset: *chunk.ColumnIndexOffset, Length: int64(*chunk.ColumnIndexLength), Page: -1})
+			}
+			if chunk.OffsetIndexOffset != nil && chunk.OffsetIndexLength != nil {
+				chunkRegions = append(chunkRegions, LayoutRegion{Kind: LayoutOffsetIndex, Offset: *chunk.OffsetIndexOffset, Length: int64(*chunk.OffsetIndexLength), Page: -1})
+			}
+			if meta.BloomFilterOffset != nil {
+				length, err := pr.bloomFilterLength(meta, chunk.CryptoMetadata != nil)
+				if err != nil {
+					addIssue(LayoutIssueUnreadable, *meta.BloomFilterOffset, 0, "%s bloom filter: %v", name, err)
+				}
+				chunkRegions = append(chunkRegions, LayoutRegion{Kind: LayoutBloomFilter, Offset: *meta.BloomFilterOffset, Length: length, Page: -1})
+			}
+
+			for _, region := range chunkRegions {
+				region.RowGroup, region.Column, region.Path = rgIndex, colIndex, path
+				regions = append(regions, region)
+			}
+		}
+	}
+
+	sort.SliceStable(regions, func(i, j int) bool {
+		if regions[i].Offset != regions[j].Offset {
+			return regions[i].Offset < regions[j].Offset
+		}
+		return regions[i].Length > regions[j].Length
+	})
+
+	// Sweep in offset order, filling unreferenced bytes with gaps and
+	// flagging anything that starts before the previous region ended
+	var cursor int64
+	var last LayoutRegion
+	for _, region := range regions {
+		end := region.Offset + region.Length
+		if region.Offset < 0 || end > fileSize {
+			addIssue(LayoutIssueOutOfBounds, region.Offset, region.Length,
+				"%s at %d (%d bytes) lies outside the %d-byte file", describeLayoutRegion(region), region.Offset, region.Length, fileSize)
+		}
+		switch {
+		case region.Offset > cursor:
+			gap := LayoutRegion{Kind: LayoutGap, Offset: cursor, Length: region.Offset - cursor, RowGroup: -1, Column: -1, Page: -1}
+			layout.Regions = append(layout.Regions, gap)
+			layout.GapBytes += gap.Length
+			addIssue(LayoutIssueGap, gap.Offset, gap.Length, "%d unreferenced bytes before %s", gap.Length, describeLayoutRegion(region))
+		case region.Offset < cursor:
+			overlap := min(cursor, end) - region.Offset
+			layout.OverlapBytes += overlap
+			addIssue(LayoutIssueOverlap, region.Offset, overlap,
+				"%s overlaps %s by %d bytes", describeLayoutRegion(region), describeLayoutRegion(last), overlap)
+		}
+		layout.Regions = append(layout.Regions, region)
+		if end > cursor {
+			layout.CoveredBytes += end - max(cursor, region.Offset)
+			cursor, last = end, region
+		}
+	}
+
+	return layout, nil
+}
+
+// trailerRegions places the leading magic and the footer, footer length and
+// trailing magic read from the last 8 bytes of the file
+func (pr *ParquetReader) trailerRegions(fileSize int64, addIssue func(string, int64, int64, string, ...interface{})) []LayoutRegion {
+	fileRegion := func(kind string, offset, length int64) LayoutRegion {
+		return LayoutRegion{Kind: kind, Offset: offset, Length: length, RowGroup: -1, Column: -1, Page: -1}
+	}
+
+	if fileSize < 12 {
+		addIssue(LayoutIssueOutOfBounds, 0, fileSize, "file is %d bytes, too small to hold magic and footer", fileSize)
+		return nil
+	}
+
+	head := make([]byte, 4)
+	tail := make([]byte, 8)
+	if err := pr.readAt(head, 0); err != nil {
+		addIssue(LayoutIssueUnreadable, 0, 4, "failed to read leading magic: %v", err)
+		return nil
+	}
+	if err := pr.readAt(tail, fileSize-8); err != nil {
+		addIssue(LayoutIssueUnreadable, fileSize-8, 8, "failed to read trailing magic: %v", err)
+		return nil
+	}
+
+	if !isParquetMagic(head) {
+		addIssue(LayoutIssueBadMagic, 0, 4, "leading magic is %q", head)
+	}
+	if !isParquetMagic(tail[4:]) {
+		addIssue(LayoutIssueBadMagic, fileSize-4, 4, "trailing magic is %q", tail[4:])
+	}
+
+	regions := []LayoutRegion{
+		fileRegion(LayoutMagic, 0, 4),
+		fileRegion(LayoutFooterLength, fileSize-8, 4),
+		fileRegion(LayoutMagic, fileSize-4, 4),
+	}
+
+	footerLength := int64(binary.LittleEndian.Uint32(tail[:4]))
+	if footerLength > fileSize-12 {
+		addIssue(LayoutIssueOutOfBounds, fileSize-8, 4, "footer length %d does not fit in the %d-byte file", footerLength, fileSize)
+		return regions
+	}
+	return append(regions, fileRegion(LayoutFooter, fileSize-8-footerLength, footerLength))
+}
+
+// pageRegions walks the pages of a column chunk and returns a header and a
+// body region for each of them
+func (pr *ParquetReader) pageRegions(rgIndex, colIndex int, encrypted bool) ([]LayoutRegion, error) {
+	headers, err := pr.pageHeaders(rgIndex, colIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	regions := make([]LayoutRegion, 0, 2*len(headers))
+	for i, header := range headers {
+		headerSize, err := pr.pageHeaderSize(header.Offset, encrypted)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", i, err)
+		}
+		bodySize := int64(header.CompressedSize)
+		if encrypted {
+			if bodySize, err = pr.moduleSize(header.Offset + headerSize); err != nil {
+				return nil, fmt.Errorf("page %d: %w", i, err)
+			}
+		}
+		regions = append(regions,
+			LayoutRegion{Kind: LayoutPageHeader, Offset: header.Offset, Length: headerSize, Page: i},
+			LayoutRegion{Kind: header.PageType.String(), Offset: header.Offset + headerSize, Length: bodySize, Page: i},
+		)
+	}
+	return regions, nil
+}
+
+// pageHeaderSize returns the number of bytes the page header at offset
+// takes on disk. Encrypted headers are length-prefixed modules.
+func (pr *ParquetReader) pageHeaderSize(offset int64, encrypted bool) (int64, error) {
+	if encrypted {
+		return pr.moduleSize(offset)
+	}
+	return pr.thriftSize(offset, parquet.NewPageHeader())
+}
+
+// bloomFilterLength returns the on-disk size of a column's bloom filter,
+// reading its header when the metadata does not record the length
+func (pr *ParquetReader) bloomFilterLength(meta *parquet.ColumnMetaData, encrypted bool) (int64, error) {
+	if meta.BloomFilterLength != nil {
+		return int64(*meta.BloomFilterLength), nil
+	}
+
+	offset := *meta.BloomFilterOffset
+	if encrypted {
+		headerSize, err := pr.moduleSize(offset)
+		if err != nil {
+			return 0, err
+		}
+		bodySize, err := pr.moduleSize(offset + headerSize)
+		if err != nil {
+			return 0, err
+		}
+		return headerSize + bodySize, nil
+	}
+
+	header := parquet.NewBloomFilterHeader()
+	headerSize, err := pr.thriftSize(offset, header)
+	if err != nil {
+		return 0, err
+	}
+	return headerSize + int64(header.NumBytes), nil
+}
+
+// thriftSize decodes a thrift compact struct at offset and returns the
+// number of bytes it took
+func (pr *ParquetReader) thriftSize(offset int64, value thrift.TStruct) (int64, error) {
+	var size int64
+	err := pr.withReader(IOLayout, func(r *reader.ParquetReader) error {
+		if _, err := r.PFile.Seek(offset, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to seek to %d: %w", offset, err)
+		}
+		transport := &countingTransport{r: r.PFile}
+		if err := value.Read(context.Background(), thrift.NewTCompactProtocolConf(transport, nil)); err != nil {
+			return fmt.Errorf("failed to decode header at %d: %w", offset, err)
+		}
+		size = transport.n
+		return nil
+	})
+	return size, err
+}
+
+// moduleSize returns the size of the length-prefixed encrypted module at offset
+func (pr *ParquetReader) moduleSize(offset int64) (int64, error) {
+	prefix := make([]byte, 4)
+	if err := pr.readAt(prefix, offset); err != nil {
+		return 0, err
+	}
+	return 4 + int64(binary.LittleEndian.Uint32(prefix)), nil
+}
+
+// readAt fills buf from the file at offset
+func (pr *ParquetReader) readAt(buf []byte, offset int64) error {
+	return pr.withReader(IOLayout, func(r *reader.ParquetReader) error {
+		if _, err := r.PFile.Seek(offset, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to seek to %d: %w", offset, err)
+		}
+		if _, err := io.ReadFull(r.PFile, buf); err != nil {
+			return fmt.Errorf("failed to read %d bytes at %d: %w", len(buf), offset, err)
+		}
+		return nil
+	})
+}
+
+// isParquetMagic reports whether b is the plain or encrypted footer magic
+func isParquetMagic(b []byte) bool {
+	return bytes.Equal(b, []byte("PAR1")) || bytes.Equal(b, []byte("PARE"))
+}
+
+// describeLayoutRegion names a region for issue messages
+func describeLayoutRegion(region LayoutRegion) string {
+	switch {
+	case region.Page >= 0:
+		return fmt.Sprintf("%s of row group %d column %s page %d", region.Kind, region.RowGroup, region.Path, region.Page)
+	case region.RowGroup >= 0:
+		return fmt.Sprintf("%s of row group %d column %s", region.Kind, region.RowGroup, region.Path)
+	default:
+		return region.Kind
+	}
+}
+
+// countingTransport is a read-only thrift transport that counts the bytes
+// consumed by the protocol
+type countingTransport struct {
+	r io.Reader
+	n int64
+}
+
+func (t *countingTransport) Read(buf []byte) (int, error) {
+	n, err := t.r.Read(buf)
+	t.n += int64(n)
+	return n, err
+}
+
+func (t *countingTransport) Write(buf []byte) (int, error) {
+	return 0, errors.New("write not supported")
+}
+
+func (t *countingTransport) Close() error                    { return nil }
+func (t *countingTransport) Flush(ctx context.Context) error { return nil }
+func (t *countingTransport) RemainingBytes() uint64          { return ^uint64(0) }
+func (t *countingTransport) IsOpen() bool                    { return true }
+func (t *countingTransport) Open() error                     { return nil }
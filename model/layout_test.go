@@ -0,0 +1,154 @@
+package model
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+)
+
+func layoutKinds(layout FileLayout) map[string]int {
+	kinds := map[string]int{}
+	for _, region := range layout.Regions {
+		kinds[region.Kind]++
+	}
+	return kinds
+}
+
+func layoutIssueKinds(layout FileLayout) map[string]int {
+	kinds := map[string]int{}
+	for _, issue := range layout.Issues {
+		kinds[issue.Kind]++
+	}
+	return kinds
+}
+
+func Test_GetFileLayout(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	layout, err := pr.GetFileLayout()
+	require.NoError(t, err)
+	require.Empty(t, layout.Issues)
+	require.Equal(t, layout.FileSize, layout.CoveredBytes)
+	require.Zero(t, layout.GapBytes)
+
+	kinds := layoutKinds(layout)
+	require.Equal(t, 2, kinds[LayoutMagic])
+	require.Equal(t, 1, kinds[LayoutFooter])
+	require.Equal(t, 1, kinds[LayoutFooterLength])
+	require.Equal(t, kinds[LayoutPageHeader], kinds["DATA_PAGE"]+kinds["DICTIONARY_PAGE"])
+	require.Equal(t, len(pr.metadata.RowGroups[0].Columns), kinds[LayoutColumnIndex])
+	require.Equal(t, len(pr.metadata.RowGroups[0].Columns), kinds[LayoutOffsetIndex])
+
+	// Regions tile the file in order
+	var cursor int64
+	for _, region := range layout.Regions {
+		require.Equal(t, cursor, region.Offset)
+		cursor += region.Length
+	}
+	require.Equal(t, layout.FileSize, cursor)
+
+	first := layout.Regions[0]
+	require.Equal(t, LayoutRegion{Kind: LayoutMagic, Offset: 0, Length: 4, RowGroup: -1, Column: -1, Page: -1}, first)
+	require.Equal(t, LayoutPageHeader, layout.Regions[1].Kind)
+	require.Equal(t, 0, layout.Regions[1].Page)
+	require.Equal(t, "Bool", layout.Regions[1].Path)
+}
+
+func Test_GetFileLayout_Gap(t *testing.T) {
+	data, err := os.ReadFile(getTestParquetFilePath())
+	require.NoError(t, err)
+
+	// Splice junk between the last structure and the footer, offsets stay valid
+	footerLength := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerStart := len(data) - 8 - footerLength
+	spliced := append(append(append([]byte{}, data[:footerStart]...), make([]byte, 100)...), data[footerStart:]...)
+	path := filepath.Join(t.TempDir(), "gap.parquet")
+	require.NoError(t, os.WriteFile(path, spliced, 0o644))
+
+	r, err := pio.NewParquetFileReader(path, pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.ReadStop() })
+
+	layout, err := NewParquetReader(r).GetFileLayout()
+	require.NoError(t, err)
+	require.Equal(t, int64(100), layout.GapBytes)
+	require.Equal(t, map[string]int{LayoutIssueGap: 1}, layoutIssueKinds(layout))
+	require.Equal(t, int64(footerStart), layout.Issues[0].Offset)
+	require.Contains(t, layout.Issues[0].Message, "before FOOTER")
+	require.Equal(t, 1, layoutKinds(layout)[LayoutGap])
+}
+
+func Test_GetFileLayout_Issues(t *testing.T) {
+	t.Run("Overlap", func(t *testing.T) {
+		pr := openTestParquetReader(t)
+		offset := int64(4)
+		pr.metadata.RowGroups[0].Columns[1].ColumnIndexOffset = &offset
+
+		layout, err := pr.GetFileLayout()
+		require.NoError(t, err)
+		// The moved index covers the first pages and leaves a gap behind
+		kinds := layoutIssueKinds(layout)
+		require.Positive(t, kinds[LayoutIssueOverlap])
+		require.Equal(t, 1, kinds[LayoutIssueGap])
+		require.Positive(t, layout.OverlapBytes)
+	})
+
+	t.Run("Out of order", func(t *testing.T) {
+		pr := openTestParquetReader(t)
+		columns := pr.metadata.RowGroups[0].Columns
+		columns[0], columns[1] = columns[1], columns[0]
+
+		layout, err := pr.GetFileLayout()
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{LayoutIssueOutOfOrder: 1}, layoutIssueKinds(layout))
+	})
+
+	t.Run("Size mismatch and out of bounds", func(t *testing.T) {
+		pr := openTestParquetReader(t)
+		pr.metadata.RowGroups[0].Columns[0].MetaData.TotalCompressedSize += 10
+		length := int32(1 << 20)
+		pr.metadata.RowGroups[0].Columns[0].OffsetIndexLength = &length
+
+		layout, err := pr.GetFileLayout()
+		require.NoError(t, err)
+		kinds := layoutIssueKinds(layout)
+		require.Equal(t, 1, kinds[LayoutIssueSizeMismatch])
+		require.Equal(t, 1, kinds[LayoutIssueOutOfBounds])
+	})
+
+	t.Run("Unreadable chunk", func(t *testing.T) {
+		pr := openTestParquetReader(t)
+		meta := pr.metadata.RowGroups[0].Columns[2].MetaData
+		meta.DictionaryPageOffset = nil
+		meta.DataPageOffset *= 1000
+
+		layout, err := pr.GetFileLayout()
+		require.NoError(t, err)
+		require.Positive(t, layoutIssueKinds(layout)[LayoutIssueUnreadable])
+		require.Equal(t, 1, layoutKinds(layout)[LayoutColumnChunk])
+	})
+
+	t.Run("No file", func(t *testing.T) {
+		var pr *ParquetReader
+		_, err := pr.GetFileLayout()
+		require.Error(t, err)
+	})
+}
+
+func Test_isParquetMagic(t *testing.T) {
+	require.True(t, isParquetMagic([]byte("PAR1")))
+	require.True(t, isParquetMagic([]byte("PARE")))
+	require.False(t, isParquetMagic([]byte("PAR2")))
+}
+
+func Test_describeLayoutRegion(t *testing.T) {
+	require.Equal(t, "FOOTER", describeLayoutRegion(LayoutRegion{Kind: LayoutFooter, RowGroup: -1, Column: -1, Page: -1}))
+	require.Equal(t, "COLUMN_INDEX of row group 1 column a.b",
+		describeLayoutRegion(LayoutRegion{Kind: LayoutColumnIndex, RowGroup: 1, Path: "a.b", Page: -1}))
+	require.Equal(t, "DATA_PAGE of row group 0 column a page 2",
+		describeLayoutRegion(LayoutRegion{Kind: "DATA_PAGE", Path: "a", Page: 2}))
+}
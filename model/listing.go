@@ -0,0 +1,271 @@
+package model
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MaxListLimit caps the row groups or column chunks listed at once
+const MaxListLimit = 1000
+
+// DefaultListLimit is the number of items listed at once when a window of a
+// listing is asked for without a limit
+const DefaultListLimit = 100
+
+// ErrInvalidListOptions is returned for an offset, limit or sort key a
+// listing does not support
+var ErrInvalidListOptions = errors.New("invalid list options")
+
+// RowGroupSortKeys lists the keys row groups can be sorted by
+var RowGroupSortKeys = []string{"index", "rows", "size", "uncompressed", "ratio"}
+
+// ColumnChunkSortKeys lists the keys column chunks can be sorted by
+var ColumnChunkSortKeys = []string{"index", "path", "type", "codec", "values", "nulls", "size", "uncompressed", "ratio"}
+
+// ListOptions selects a window of a listing, after filtering and sorting
+type ListOptions struct {
+	Offset int    // first item of the window
+	Limit  int    // items at most, 0 for all the items from Offset
+	Sort   string // sort key, "" for index order
+	Desc   bool   // sort in descending order
+	Filter string // case-insensitive text the items must contain, "" for all
+}
+
+// ParseListOptions parses the offset, limit, sort key and filter of a window
+// of a listing. An empty limit means DefaultListLimit. The sort key is one of
+// sortKeys, prefixed with "-" for descending order.
+func ParseListOptions(offset, limit, sort, filter string, sortKeys []string) (ListOptions, error) {
+	opts := ListOptions{Limit: DefaultListLimit, Filter: strings.TrimSpace(filter)}
+	if offset != "" {
+		n, err := strconv.Atoi(strings.TrimSpace(offset))
+		if err != nil || n < 0 {
+			return ListOptions{}, fmt.Errorf("%w: offset %q", ErrInvalidListOptions, offset)
+		}
+		opts.Offset = n
+	}
+	if limit != "" {
+		n, err := strconv.Atoi(strings.TrimSpace(limit))
+		if err != nil || n < 1 || n > MaxListLimit {
+			return ListOptions{}, fmt.Errorf("%w: limit %q (expected 1 to %d)", ErrInvalidListOptions, limit, MaxListLimit)
+		}
+		opts.Limit = n
+	}
+	key := strings.TrimSpace(sort)
+	opts.Desc = strings.HasPrefix(key, "-")
+	opts.Sort = strings.TrimPrefix(key, "-")
+	if opts.Sort != "" && !slices.Contains(sortKeys, opts.Sort) {
+		return ListOptions{}, fmt.Errorf("%w: sort %q (expected one of %s)", ErrInvalidListOptions, sort, strings.Join(sortKeys, ", "))
+	}
+	if opts.Sort == "index" {
+		opts.Sort = ""
+	}
+	return opts, nil
+}
+
+// window returns the first and past-the-end item of the window in a listing
+// of total items. An offset past the end selects no items.
+func (o ListOptions) window(total int) (int, int) {
+	start := min(o.Offset, total)
+	end := total
+	if o.Limit > 0 {
+		end = min(total, start+o.Limit)
+	}
+	return start, end
+}
+
+// RowGroupList is a window of the row groups of a file
+type RowGroupList struct {
+	RowGroups []RowGroupInfo
+	Offset    int // position of the first row group in the filtered, sorted listing
+	Total     int // row groups matching the filter
+}
+
+// ColumnChunkList is a window of the column chunks of a row group, with the
+// totals of all the chunks matching the filter
+type ColumnChunkList struct {
+	ColumnChunks     []ColumnChunkInfo
+	Offset           int // position of the first chunk in the filtered, sorted listing
+	Total            int // column chunks matching the filter
+	NumValues        int64
+	NullCount        int64
+	CompressedSize   int64
+	UncompressedSize int64
+}
+
+// ListRowGroups returns the window of the row groups selected by opts. The
+// filter matches the row group index as text. Only the row groups of the
+// window are returned, so a UI can page through files with many of them.
+func (pr *ParquetReader) ListRowGroups(opts ListOptions) (RowGroupList, error) {
+	if pr == nil || pr.metadata == nil {
+		return RowGroupList{}, ErrInvalidRowGroupIndex
+	}
+
+	numRowGroups := len(pr.metadata.RowGroups)
+	if opts.Sort == "" && !opts.Desc && opts.Filter == "" {
+		// Index order, only the window is needed
+		start, end := opts.window(numRowGroups)
+		list := RowGroupList{RowGroups: make([]RowGroupInfo, 0, end-start), Offset: start, Total: numRowGroups}
+		for i := start; i < end; i++ {
+			info, _ := pr.GetRowGroupInfo(i)
+			list.RowGroups = append(list.RowGroups, info)
+		}
+		return list, nil
+	}
+
+	var infos []RowGroupInfo
+	for i := range numRowGroups {
+		if opts.Filter != "" && !strings.Contains(strconv.Itoa(i), opts.Filter) {
+			continue
+		}
+		info, _ := pr.GetRowGroupInfo(i)
+		infos = append(infos, info)
+	}
+	sortListing(infos, opts, func(a, b RowGroupInfo) int {
+		switch opts.Sort {
+		case "rows":
+			return cmp.Compare(a.NumRows, b.NumRows)
+		case "size":
+			return cmp.Compare(a.CompressedSize, b.CompressedSize)
+		case "uncompressed":
+			return cmp.Compare(a.UncompressedSize, b.UncompressedSize)
+		case "ratio":
+			return cmp.Compare(a.CompressionRatio, b.CompressionRatio)
+		}
+		return 0
+	}, func(info RowGroupInfo) int { return info.Index })
+
+	start, end := opts.window(len(infos))
+	return RowGroupList{RowGroups: infos[start:end], Offset: start, Total: len(infos)}, nil
+}
+
+// columnChunkKey holds what column chunks are filtered and sorted by, read
+// from the footer without formatting statistics
+type columnChunkKey struct {
+	index            int
+	path             string
+	physicalType     string
+	logicalType      string
+	codec            string
+	numValues        int64
+	nullCount        int64
+	compressedSize   int64
+	uncompressedSize int64
+}
+
+// ratio returns the compression ratio of the chunk
+func (k columnChunkKey) ratio() float64 {
+	if k.compressedSize <= 0 {
+		return 0
+	}
+	return float64(k.uncompressedSize) / float64(k.compressedSize)
+}
+
+// matches reports whether the path, physical type, logical type or codec of
+// the chunk contains the lowercase filter
+func (k columnChunkKey) matches(filter string) bool {
+	for _, field := range []string{k.path, k.physicalType, k.logicalType, k.codec} {
+		if strings.Contains(strings.ToLower(field), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListColumnChunks returns the window of the column chunks of a row group
+// selected by opts. The filter matches the column path, physical type,
+// logical type or codec, case-insensitively. Statistics are only formatted
+// for the chunks of the window.
+func (pr *ParquetReader) ListColumnChunks(rgIndex int, opts ListOptions) (ColumnChunkList, error) {
+	if pr == nil || pr.metadata == nil {
+		return ColumnChunkList{}, ErrInvalidRowGroupIndex
+	}
+
+	numRowGroups := len(pr.metadata.RowGroups)
+	if rgIndex < 0 || rgIndex >= numRowGroups {
+		return ColumnChunkList{}, fmt.Errorf("row group index %d out of range [0, %d): %w",
+			rgIndex, numRowGroups, ErrInvalidRowGroupIndex)
+	}
+
+	filter := strings.ToLower(opts.Filter)
+	var list ColumnChunkList
+	var keys []columnChunkKey
+	for i, col := range pr.metadata.RowGroups[rgIndex].Columns {
+		meta := col.MetaData
+		key := columnChunkKey{
+			index:            i,
+			path:             pr.columnName(i),
+			physicalType:     meta.Type.String(),
+			codec:            meta.Codec.String(),
+			numValues:        meta.NumValues,
+			compressedSize:   meta.TotalCompressedSize,
+			uncompressedSize: meta.TotalUncompressedSize,
+		}
+		if schemaElem := pr.schemaElement(i); schemaElem != nil {
+			key.logicalType = formatLogicalType(schemaElem.LogicalType)
+		}
+		if meta.Statistics != nil && meta.Statistics.NullCount != nil {
+			key.nullCount = *meta.Statistics.NullCount
+		}
+		if filter != "" && !key.matches(filter) {
+			continue
+		}
+		keys = append(keys, key)
+		list.NumValues += key.numValues
+		list.NullCount += key.nullCount
+		list.CompressedSize += key.compressedSize
+		list.UncompressedSize += key.uncompressedSize
+	}
+
+	sortListing(keys, opts, func(a, b columnChunkKey) int {
+		switch opts.Sort {
+		case "path":
+			return cmp.Compare(a.path, b.path)
+		case "type":
+			return cmp.Compare(a.physicalType, b.physicalType)
+		case "codec":
+			return cmp.Compare(a.codec, b.codec)
+		case "values":
+			return cmp.Compare(a.numValues, b.numValues)
+		case "nulls":
+			return cmp.Compare(a.nullCount, b.nullCount)
+		case "size":
+			return cmp.Compare(a.compressedSize, b.compressedSize)
+		case "uncompressed":
+			return cmp.Compare(a.uncompressedSize, b.uncompressedSize)
+		case "ratio":
+			return cmp.Compare(a.ratio(), b.ratio())
+		}
+		return 0
+	}, func(key columnChunkKey) int { return key.index })
+
+	start, end := opts.window(len(keys))
+	list.Offset, list.Total = start, len(keys)
+	list.ColumnChunks = make([]ColumnChunkInfo, 0, end-start)
+	for _, key := range keys[start:end] {
+		info, err := pr.GetColumnChunkInfo(rgIndex, key.index)
+		if err != nil {
+			return ColumnChunkList{}, err
+		}
+		list.ColumnChunks = append(list.ColumnChunks, info)
+	}
+	return list, nil
+}
+
+// sortListing sorts items by the sort key of opts with compare, ties and
+// the default order by index
+func sortListing[T any](items []T, opts ListOptions, compare func(a, b T) int, index func(T) int) {
+	if opts.Sort == "" && !opts.Desc {
+		return
+	}
+	slices.SortStableFunc(items, func(a, b T) int {
+		c := cmp.Or(compare(a, b), cmp.Compare(index(a), index(b)))
+		if opts.Desc {
+			return -c
+		}
+		return c
+	})
+}
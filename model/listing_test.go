@@ -0,0 +1,129 @@
+package model
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseListOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset string
+		limit  string
+		sort   string
+		filter string
+		want   ListOptions
+		errMsg string
+	}{
+		{"Defaults", "", "", "", "", ListOptions{Limit: DefaultListLimit}, ""},
+		{"Window", "20", " 10 ", "", "", ListOptions{Offset: 20, Limit: 10}, ""},
+		{"Descending", "", "", "-rows", " 12 ", ListOptions{Limit: DefaultListLimit, Sort: "rows", Desc: true, Filter: "12"}, ""},
+		{"Index order", "", "", "-index", "", ListOptions{Limit: DefaultListLimit, Desc: true}, ""},
+		{"Negative offset", "-1", "", "", "", ListOptions{}, `offset "-1"`},
+		{"Limit too large", "", "1001", "", "", ListOptions{}, "expected 1 to 1000"},
+		{"Unknown sort key", "", "", "path", "", ListOptions{}, `sort "path"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseListOptions(tt.offset, tt.limit, tt.sort, tt.filter, RowGroupSortKeys)
+			if tt.errMsg != "" {
+				require.ErrorIs(t, err, ErrInvalidListOptions)
+				require.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_ParquetReader_ListRowGroups(t *testing.T) {
+	file, err := pio.NewParquetFileReader(filepath.Join("..", "build", "testdata", "row-group.parquet"), pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.ReadStop() })
+	pr := NewParquetReader(file)
+	all := pr.GetAllRowGroupsInfo()
+
+	list, err := pr.ListRowGroups(ListOptions{Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, RowGroupList{RowGroups: all[:1], Total: 2}, list)
+
+	list, err = pr.ListRowGroups(ListOptions{Offset: 1, Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, all[1:], list.RowGroups)
+
+	list, err = pr.ListRowGroups(ListOptions{Sort: "rows"})
+	require.NoError(t, err)
+	require.Equal(t, []RowGroupInfo{all[1], all[0]}, list.RowGroups)
+
+	list, err = pr.ListRowGroups(ListOptions{Desc: true, Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, all[1:], list.RowGroups)
+	require.Equal(t, 2, list.Total)
+
+	list, err = pr.ListRowGroups(ListOptions{Filter: "1"})
+	require.NoError(t, err)
+	require.Equal(t, RowGroupList{RowGroups: all[1:], Total: 1}, list)
+
+	list, err = pr.ListRowGroups(ListOptions{Offset: 5, Limit: 1})
+	require.NoError(t, err)
+	require.Empty(t, list.RowGroups)
+	require.Equal(t, 2, list.Total)
+
+	var nilReader *ParquetReader
+	_, err = nilReader.ListRowGroups(ListOptions{})
+	require.ErrorIs(t, err, ErrInvalidRowGroupIndex)
+}
+
+func Test_ParquetReader_ListColumnChunks(t *testing.T) {
+	pr := openTestParquetReader(t)
+	all, err := pr.GetAllColumnChunksInfo(0)
+	require.NoError(t, err)
+
+	list, err := pr.ListColumnChunks(0, ListOptions{})
+	require.NoError(t, err)
+	require.Equal(t, all, list.ColumnChunks)
+	require.Equal(t, len(all), list.Total)
+	var compressed int64
+	for _, col := range all {
+		compressed += col.CompressedSize
+	}
+	require.Equal(t, compressed, list.CompressedSize)
+
+	list, err = pr.ListColumnChunks(0, ListOptions{Offset: 10, Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, all[10:15], list.ColumnChunks)
+	require.Equal(t, 10, list.Offset)
+
+	list, err = pr.ListColumnChunks(0, ListOptions{Filter: "map.KEY"})
+	require.NoError(t, err)
+	require.NotEmpty(t, list.ColumnChunks)
+	require.Equal(t, len(list.ColumnChunks), list.Total)
+	for _, col := range list.ColumnChunks {
+		require.Contains(t, strings.ToLower(col.Path), "map.key")
+	}
+
+	list, err = pr.ListColumnChunks(0, ListOptions{Filter: "geometry"})
+	require.NoError(t, err)
+	for _, col := range list.ColumnChunks {
+		require.Equal(t, "GEOMETRY", col.LogicalType)
+	}
+
+	list, err = pr.ListColumnChunks(0, ListOptions{Sort: "size", Desc: true})
+	require.NoError(t, err)
+	for i := 1; i < len(list.ColumnChunks); i++ {
+		require.GreaterOrEqual(t, list.ColumnChunks[i-1].CompressedSize, list.ColumnChunks[i].CompressedSize)
+	}
+
+	list, err = pr.ListColumnChunks(0, ListOptions{Sort: "path", Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, list.ColumnChunks, 3)
+	require.LessOrEqual(t, list.ColumnChunks[0].Path, list.ColumnChunks[1].Path)
+
+	_, err = pr.ListColumnChunks(1, ListOptions{})
+	require.ErrorIs(t, err, ErrInvalidRowGroupIndex)
+}
@@ -0,0 +1,325 @@
+package model
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/reader"
+)
+
+// errTruncatedPage is returned when a page section ends before the data its
+// headers announce
+var errTruncatedPage = errors.New("page data truncated")
+
+// pageSections holds the raw level and value sections of an uncompressed data page
+type pageSections struct {
+	repLevels []byte
+	defLevels []byte
+	values    []byte
+	// levelsPrefixed is true when each level section starts with a 4-byte
+	// length (DATA_PAGE v1 with RLE levels)
+	levelsPrefixed bool
+}
+
+// hybridRun is one run of the RLE/bit-packed hybrid encoding
+type hybridRun struct {
+	bitPacked bool
+	offset    int // byte offset of the run header within the encoded data
+	byteLen   int // bytes used by the run including its header
+	count     int // number of values in the run, excluding bit-packed padding
+	value     uint32
+}
+
+// readPageData reads and decompresses the body of a page described by header
+func (pr *ParquetReader) readPageData(meta *parquet.ColumnMetaData, header reader.PageHeaderInfo) ([]byte, error) {
+	pageHeader := parquet.NewPageHeader()
+	pageHeader.Type = header.PageType
+	pageHeader.CompressedPageSize = header.CompressedSize
+	pageHeader.UncompressedPageSize = header.UncompressedSize
+	if header.HasCRC {
+		crc := header.CRC
+		pageHeader.Crc = &crc
+	}
+	if header.PageType == parquet.PageType_DATA_PAGE_V2 {
+		pageHeader.DataPageHeaderV2 = &parquet.DataPageHeaderV2{
+			NumValues:                  header.NumValues,
+			NumNulls:                   header.NumNulls,
+			NumRows:                    header.NumRows,
+			Encoding:                   header.Encoding,
+			DefinitionLevelsByteLength: header.DefLevelBytes,
+			RepetitionLevelsByteLength: header.RepLevelBytes,
+			IsCompressed:               header.IsCompressed == nil || *header.IsCompressed,
+		}
+	}
+
+	var data []byte
+	err := pr.withReader(IOPageData, func(r *reader.ParquetReader) error {
+		var err error
+		data, err = reader.ReadPageData(r.PFile, header.Offset, pageHeader, meta.Codec, nil)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page at offset %d: %w", header.Offset, err)
+	}
+	return data, nil
+}
+
+// splitDataPage locates the repetition level, definition level and value
+// sections of an uncompressed data page
+func splitDataPage(data []byte, header reader.PageHeaderInfo, maxDef, maxRep int32) (pageSections, error) {
+	if header.PageType == parquet.PageType_DATA_PAGE_V2 {
+		rl, dl := int(header.RepLevelBytes), int(header.DefLevelBytes)
+		if rl < 0 || dl < 0 || rl+dl > len(data) {
+			return pageSections{}, fmt.Errorf("level lengths %d+%d exceed page size %d: %w", rl, dl, len(data), errTruncatedPage)
+		}
+		return pageSections{
+			repLevels: data[:rl],
+			defLevels: data[rl : rl+dl],
+			values:    data[rl+dl:],
+		}, nil
+	}
+
+	sections := pageSections{levelsPrefixed: true}
+	pos := 0
+	var err error
+	if maxRep > 0 {
+		if sections.repLevels, pos, err = levelSection(data, pos, header.RepLevelEncoding, maxRep, int(header.NumValues)); err != nil {
+			return pageSections{}, fmt.Errorf("repetition levels: %w", err)
+		}
+	}
+	if maxDef > 0 {
+		if sections.defLevels, pos, err = levelSection(data, pos, header.DefLevelEncoding, maxDef, int(header.NumValues)); err != nil {
+			return pageSections{}, fmt.Errorf("definition levels: %w", err)
+		}
+	}
+	sections.values = data[pos:]
+	return sections, nil
+}
+
+// levelSection returns one v1 level section starting at pos and the position
+// right after it. RLE sections carry a 4-byte length prefix which is kept in
+// the returned slice; deprecated BIT_PACKED sections have a fixed size.
+func levelSection(data []byte, pos int, encoding parquet.Encoding, maxLevel int32, numValues int) ([]byte, int, error) {
+	if encoding == parquet.Encoding_BIT_PACKED {
+		size := (numValues*levelBitWidth(maxLevel) + 7) / 8
+		if pos+size > len(data) {
+			return nil, 0, errTruncatedPage
+		}
+		return data[pos : pos+size], pos + size, nil
+	}
+
+	if pos+4 > len(data) {
+		return nil, 0, errTruncatedPage
+	}
+	size := int(binary.LittleEndian.Uint32(data[pos:]))
+	end := pos + 4 + size
+	if size < 0 || end > len(data) {
+		return nil, 0, errTruncatedPage
+	}
+	return data[pos:end], end, nil
+}
+
+// decodeLevels decodes a level section into numValues levels
+func decodeLevels(section []byte, prefixed bool, encoding parquet.Encoding, maxLevel int32, numValues int) ([]uint32, error) {
+	width := levelBitWidth(maxLevel)
+	if encoding == parquet.Encoding_BIT_PACKED {
+		return unpackBitsMSB(section, width, numValues)
+	}
+	if prefixed {
+		if len(section) < 4 {
+			return nil, errTruncatedPage
+		}
+		section = section[4:]
+	}
+	values, _, err := decodeHybrid(section, width, numValues)
+	return values, err
+}
+
+// countNonNull returns how many of the page's values are defined, i.e. how
+// many entries the value section actually holds
+func countNonNull(sections pageSections, header reader.PageHeaderInfo, maxDef int32) (int, error) {
+	numValues := int(header.NumValues)
+	if maxDef == 0 {
+		return numValues, nil
+	}
+	if header.PageType == parquet.PageType_DATA_PAGE_V2 {
+		return numValues - int(header.NumNulls), nil
+	}
+
+	levels, err := decodeLevels(sections.defLevels, sections.levelsPrefixed, header.DefLevelEncoding, maxDef, numValues)
+	if err != nil {
+		return 0, fmt.Errorf("definition levels: %w", err)
+	}
+	count := 0
+	for _, level := range levels {
+		if int32(level) == maxDef {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// decodeHybrid decodes numValues values of the RLE/bit-packed hybrid encoding
+// and also returns the runs they came from
+func decodeHybrid(data []byte, bitWidth, numValues int) ([]uint32, []hybridRun, error) {
+	if bitWidth < 0 || bitWidth > 32 {
+		return nil, nil, fmt.Errorf("invalid bit width %d", bitWidth)
+	}
+
+	values := make([]uint32, 0, numValues)
+	var runs []hybridRun
+	pos := 0
+	for len(values) < numValues {
+		if pos >= len(data) {
+			return values, runs, fmt.Errorf("decoded %d of %d values: %w", len(values), numValues, errTruncatedPage)
+		}
+		header, n := binary.Uvarint(data[pos:])
+		if n <= 0 {
+			return values, runs, fmt.Errorf("invalid run header at byte %d", pos)
+		}
+		run := hybridRun{offset: pos}
+		pos += n
+
+		if header&1 == 0 {
+			// RLE run: repeated count times, value stored in ceil(bitWidth/8) bytes
+			count := int(header >> 1)
+			valueBytes := (bitWidth + 7) / 8
+			if pos+valueBytes > len(data) {
+				return values, runs, errTruncatedPage
+			}
+			var value uint32
+			for i := 0; i < valueBytes; i++ {
+				value |= uint32(data[pos+i]) << (8 * i)
+			}
+			pos += valueBytes
+
+			count = min(count, numValues-len(values))
+			for i := 0; i < count; i++ {
+				values = append(values, value)
+			}
+			run.count, run.value = count, value
+		} else {
+			// Bit-packed run: groups of 8 values, bitWidth bytes per group
+			groups := int(header >> 1)
+			size := groups * bitWidth
+			if pos+size > len(data) {
+				return values, runs, errTruncatedPage
+			}
+			unpacked, err := unpackBitsLSB(data[pos:pos+size], bitWidth, groups*8)
+			if err != nil {
+				return values, runs, err
+			}
+			pos += size
+
+			count := min(groups*8, numValues-len(values))
+			values = append(values, unpacked[:count]...)
+			run.bitPacked, run.count = true, count
+		}
+
+		run.byteLen = pos - run.offset
+		runs = append(runs, run)
+	}
+	return values, runs, nil
+}
+
+// unpackBitsLSB unpacks count values of bitWidth bits packed from the least
+// significant bit of each byte, as used by the hybrid encoding
+func unpackBitsLSB(data []byte, bitWidth, count int) ([]uint32, error) {
+	if (count*bitWidth+7)/8 > len(data) {
+		return nil, errTruncatedPage
+	}
+	values := make([]uint32, count)
+	if bitWidth == 0 {
+		return values, nil
+	}
+	bitPos := 0
+	for i := range values {
+		var v uint64
+		for b := 0; b < bitWidth; b++ {
+			if data[bitPos/8]&(1<<(bitPos%8)) != 0 {
+				v |= 1 << b
+			}
+			bitPos++
+		}
+		values[i] = uint32(v)
+	}
+	return values, nil
+}
+
+// unpackBitsMSB unpacks count values of bitWidth bits packed from the most
+// significant bit of each byte, as used by the deprecated BIT_PACKED encoding
+func unpackBitsMSB(data []byte, bitWidth, count int) ([]uint32, error) {
+	if (count*bitWidth+7)/8 > len(data) {
+		return nil, errTruncatedPage
+	}
+	values := make([]uint32, count)
+	bitPos := 0
+	for i := range values {
+		var v uint32
+		for b := 0; b < bitWidth; b++ {
+			v <<= 1
+			if data[bitPos/8]&(0x80>>(bitPos%8)) != 0 {
+				v |= 1
+			}
+			bitPos++
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// levelBitWidth returns the number of bits needed to store levels up to maxLevel
+func levelBitWidth(maxLevel int32) int {
+	return bits.Len32(uint32(maxLevel))
+}
+
+// columnLevels returns the maximum definition and repetition levels of the
+// leaf column at pathInSchema
+func columnLevels(schema []*parquet.SchemaElement, pathInSchema []string) (maxDef, maxRep int32) {
+	if len(schema) == 0 {
+		return 0, 0
+	}
+
+	// Walk the flattened depth-first schema, skipping subtrees off the path
+	pos := 1
+	for depth, name := range pathInSchema {
+		found := false
+		numChildren := int(schema[0].GetNumChildren())
+		if depth > 0 {
+			numChildren = int(schema[pos-1].GetNumChildren())
+		}
+		for i := 0; i < numChildren && pos < len(schema); i++ {
+			elem := schema[pos]
+			if elem.Name == name {
+				switch elem.GetRepetitionType() {
+				case parquet.FieldRepetitionType_OPTIONAL:
+					maxDef++
+				case parquet.FieldRepetitionType_REPEATED:
+					maxDef++
+					maxRep++
+				}
+				pos++
+				found = true
+				break
+			}
+			pos = skipSchemaSubtree(schema, pos)
+		}
+		if !found {
+			return maxDef, maxRep
+		}
+	}
+	return maxDef, maxRep
+}
+
+// skipSchemaSubtree returns the position right after the subtree rooted at pos
+func skipSchemaSubtree(schema []*parquet.SchemaElement, pos int) int {
+	remaining := 1
+	for remaining > 0 && pos < len(schema) {
+		remaining += int(schema[pos].GetNumChildren()) - 1
+		pos++
+	}
+	return pos
+}
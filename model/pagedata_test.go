@@ -0,0 +1,196 @@
+package model
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/reader"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_decodeHybrid(t *testing.T) {
+	t.Run("RLE and bit-packed runs", func(t *testing.T) {
+		// RLE run of 5 x 3, then one bit-packed group of 8 values with width 2
+		data := []byte{
+			0x0a, 0x03, // header 5<<1, value 3
+			0x03,       // header 1<<1|1: one group
+			0xe4, 0xe4, // 0,1,2,3,0,1,2,3 packed LSB first
+		}
+		values, runs, err := decodeHybrid(data, 2, 11)
+		require.NoError(t, err)
+		require.Equal(t, []uint32{3, 3, 3, 3, 3, 0, 1, 2, 3, 0, 1}, values)
+		require.Len(t, runs, 2)
+		require.Equal(t, hybridRun{offset: 0, byteLen: 2, count: 5, value: 3}, runs[0])
+		require.Equal(t, hybridRun{bitPacked: true, offset: 2, byteLen: 3, count: 6}, runs[1])
+	})
+
+	t.Run("Zero bit width", func(t *testing.T) {
+		values, runs, err := decodeHybrid([]byte{0x08}, 0, 4)
+		require.NoError(t, err)
+		require.Equal(t, []uint32{0, 0, 0, 0}, values)
+		require.Len(t, runs, 1)
+	})
+
+	t.Run("Multi-byte RLE value", func(t *testing.T) {
+		values, _, err := decodeHybrid([]byte{0x04, 0x34, 0x12}, 13, 2)
+		require.NoError(t, err)
+		require.Equal(t, []uint32{0x1234, 0x1234}, values)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		_, _, err := decodeHybrid([]byte{0x04, 0x01}, 1, 5)
+		require.ErrorIs(t, err, errTruncatedPage)
+
+		_, _, err = decodeHybrid([]byte{0x05, 0x01}, 2, 8)
+		require.ErrorIs(t, err, errTruncatedPage)
+
+		_, _, err = decodeHybrid([]byte{0x02}, 8, 1)
+		require.ErrorIs(t, err, errTruncatedPage)
+	})
+
+	t.Run("Invalid bit width", func(t *testing.T) {
+		_, _, err := decodeHybrid([]byte{0x02, 0x00}, 33, 1)
+		require.Error(t, err)
+	})
+}
+
+func Test_unpackBits(t *testing.T) {
+	values, err := unpackBitsLSB([]byte{0x88, 0xc6, 0xfa}, 3, 8)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0, 1, 2, 3, 4, 5, 6, 7}, values)
+
+	// Deprecated BIT_PACKED: values packed from the most significant bit
+	values, err = unpackBitsMSB([]byte{0x05, 0x39, 0x77}, 3, 8)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0, 1, 2, 3, 4, 5, 6, 7}, values)
+
+	_, err = unpackBitsLSB([]byte{0x01}, 3, 8)
+	require.ErrorIs(t, err, errTruncatedPage)
+	_, err = unpackBitsMSB([]byte{0x01}, 3, 8)
+	require.ErrorIs(t, err, errTruncatedPage)
+}
+
+func Test_levelBitWidth(t *testing.T) {
+	require.Equal(t, 0, levelBitWidth(0))
+	require.Equal(t, 1, levelBitWidth(1))
+	require.Equal(t, 2, levelBitWidth(2))
+	require.Equal(t, 2, levelBitWidth(3))
+	require.Equal(t, 3, levelBitWidth(4))
+}
+
+func Test_columnLevels(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	tests := []struct {
+		path   []string
+		maxDef int32
+		maxRep int32
+	}{
+		{[]string{"Int32"}, 0, 0},
+		{[]string{"DecimalPointer"}, 1, 0},
+		{[]string{"Map", "Key_value", "Key"}, 1, 1},
+		{[]string{"NestedList", "List", "Element", "List", "List", "Element"}, 2, 2},
+		{[]string{"NoSuchColumn"}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(formatColumnName(tt.path), func(t *testing.T) {
+			maxDef, maxRep := columnLevels(pr.metadata.Schema, tt.path)
+			require.Equal(t, tt.maxDef, maxDef)
+			require.Equal(t, tt.maxRep, maxRep)
+		})
+	}
+
+	maxDef, maxRep := columnLevels(nil, []string{"a"})
+	require.Zero(t, maxDef)
+	require.Zero(t, maxRep)
+}
+
+func Test_splitDataPage(t *testing.T) {
+	t.Run("V1 with RLE levels", func(t *testing.T) {
+		rep := []byte{0x08, 0x00}             // 4 x 0
+		def := []byte{0x04, 0x01, 0x04, 0x00} // 2 x 1, 2 x 0
+		var data []byte
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(rep)))
+		data = append(data, rep...)
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(def)))
+		data = append(data, def...)
+		data = append(data, 0xaa, 0xbb)
+
+		header := reader.PageHeaderInfo{PageType: parquet.PageType_DATA_PAGE, NumValues: 4}
+		sections, err := splitDataPage(data, header, 1, 1)
+		require.NoError(t, err)
+		require.True(t, sections.levelsPrefixed)
+		require.Len(t, sections.repLevels, 6)
+		require.Len(t, sections.defLevels, 8)
+		require.Equal(t, []byte{0xaa, 0xbb}, sections.values)
+
+		nonNull, err := countNonNull(sections, header, 1)
+		require.NoError(t, err)
+		require.Equal(t, 2, nonNull)
+	})
+
+	t.Run("V1 with BIT_PACKED levels", func(t *testing.T) {
+		header := reader.PageHeaderInfo{
+			PageType:         parquet.PageType_DATA_PAGE,
+			NumValues:        8,
+			DefLevelEncoding: parquet.Encoding_BIT_PACKED,
+		}
+		sections, err := splitDataPage([]byte{0xf0, 0x01}, header, 1, 0)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xf0}, sections.defLevels)
+		require.Equal(t, []byte{0x01}, sections.values)
+
+		nonNull, err := countNonNull(sections, header, 1)
+		require.NoError(t, err)
+		require.Equal(t, 4, nonNull)
+	})
+
+	t.Run("V2", func(t *testing.T) {
+		header := reader.PageHeaderInfo{
+			PageType:      parquet.PageType_DATA_PAGE_V2,
+			NumValues:     5,
+			NumNulls:      2,
+			RepLevelBytes: 1,
+			DefLevelBytes: 2,
+		}
+		sections, err := splitDataPage([]byte{1, 2, 3, 4, 5}, header, 1, 1)
+		require.NoError(t, err)
+		require.False(t, sections.levelsPrefixed)
+		require.Equal(t, []byte{1}, sections.repLevels)
+		require.Equal(t, []byte{2, 3}, sections.defLevels)
+		require.Equal(t, []byte{4, 5}, sections.values)
+
+		nonNull, err := countNonNull(sections, header, 1)
+		require.NoError(t, err)
+		require.Equal(t, 3, nonNull)
+
+		_, err = splitDataPage([]byte{1, 2}, header, 1, 1)
+		require.ErrorIs(t, err, errTruncatedPage)
+	})
+
+	t.Run("Required column", func(t *testing.T) {
+		header := reader.PageHeaderInfo{PageType: parquet.PageType_DATA_PAGE, NumValues: 3}
+		sections, err := splitDataPage([]byte{7, 8}, header, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, []byte{7, 8}, sections.values)
+
+		nonNull, err := countNonNull(sections, header, 0)
+		require.NoError(t, err)
+		require.Equal(t, 3, nonNull)
+	})
+
+	t.Run("Truncated levels", func(t *testing.T) {
+		header := reader.PageHeaderInfo{PageType: parquet.PageType_DATA_PAGE, NumValues: 3}
+		_, err := splitDataPage([]byte{1, 0}, header, 1, 0)
+		require.ErrorIs(t, err, errTruncatedPage)
+
+		_, err = splitDataPage([]byte{9, 0, 0, 0, 1}, header, 0, 1)
+		require.ErrorIs(t, err, errTruncatedPage)
+
+		header.DefLevelEncoding = parquet.Encoding_BIT_PACKED
+		header.NumValues = 16
+		_, err = splitDataPage([]byte{0xff}, header, 1, 0)
+		require.ErrorIs(t, err, errTruncatedPage)
+	})
+}
@@ -0,0 +1,273 @@
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxPageContentLimit caps the values of a page returned at once
+const MaxPageContentLimit = 10000
+
+// DefaultPageContentLimit is the number of values returned at once when a
+// range of a page is asked for without a limit
+const DefaultPageContentLimit = 1000
+
+var (
+	// ErrInvalidPageRange is returned for an offset or limit that selects no
+	// values of a page
+	ErrInvalidPageRange = errors.New("invalid page range")
+
+	// ErrInvalidPageCursor is returned for a cursor that does not parse or
+	// belongs to another page
+	ErrInvalidPageCursor = errors.New("invalid page cursor")
+)
+
+// PageRange selects values of a page by position
+type PageRange struct {
+	Offset int // first value
+	Limit  int // values at most, 0 for all the values from Offset
+}
+
+// ParsePageRange parses the offset and limit of a range of page values. An
+// empty offset starts at the first value and an empty limit means
+// DefaultPageContentLimit.
+func ParsePageRange(offset, limit string) (PageRange, error) {
+	var r PageRange
+	if offset != "" {
+		n, err := strconv.Atoi(strings.TrimSpace(offset))
+		if err != nil || n < 0 {
+			return PageRange{}, fmt.Errorf("%w: offset %q", ErrInvalidPageRange, offset)
+		}
+		r.Offset = n
+	}
+	r.Limit = DefaultPageContentLimit
+	if limit != "" {
+		n, err := strconv.Atoi(strings.TrimSpace(limit))
+		if err != nil || n < 1 || n > MaxPageContentLimit {
+			return PageRange{}, fmt.Errorf("%w: limit %q (expected 1 to %d)", ErrInvalidPageRange, limit, MaxPageContentLimit)
+		}
+		r.Limit = n
+	}
+	return r, nil
+}
+
+// bounds returns the first and past-the-end value of the range in a page of
+// numValues values. An offset right at the end selects no values.
+func (r PageRange) bounds(numValues int) (int, int, error) {
+	if r.Offset < 0 || r.Offset > numValues || r.Limit < 0 {
+		return 0, 0, fmt.Errorf("%w: offset %d out of range [0, %d]", ErrInvalidPageRange, r.Offset, numValues)
+	}
+	end := numValues
+	if r.Limit > 0 {
+		end = min(numValues, r.Offset+r.Limit)
+	}
+	return r.Offset, end, nil
+}
+
+// Next returns the range of the values following r, or false when r reaches
+// the end of a page of numValues values
+func (r PageRange) Next(numValues int) (PageRange, bool) {
+	if r.Limit <= 0 || r.Offset+r.Limit >= numValues {
+		return PageRange{}, false
+	}
+	return PageRange{Offset: r.Offset + r.Limit, Limit: r.Limit}, true
+}
+
+// PageCursor points at a range of the values of a page, for clients to read
+// a page in parts without keeping track of offsets
+type PageCursor struct {
+	RowGroup int
+	Column   int
+	Page     int
+	Range    PageRange
+}
+
+// String encodes the cursor as an opaque URL-safe token
+func (c PageCursor) String() string {
+	plain := fmt.Sprintf("%d.%d.%d.%d.%d", c.RowGroup, c.Column, c.Page, c.Range.Offset, c.Range.Limit)
+	return base64.RawURLEncoding.EncodeToString([]byte(plain))
+}
+
+// ParsePageCursor decodes a cursor made by PageCursor.String
+func ParsePageCursor(s string) (PageCursor, error) {
+	plain, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return PageCursor{}, fmt.Errorf("%w: %q", ErrInvalidPageCursor, s)
+	}
+	fields := strings.Split(string(plain), ".")
+	if len(fields) != 5 {
+		return PageCursor{}, fmt.Errorf("%w: %q", ErrInvalidPageCursor, s)
+	}
+	numbers := make([]int, len(fields))
+	for i, field := range fields {
+		if numbers[i], err = strconv.Atoi(field); err != nil || numbers[i] < 0 {
+			return PageCursor{}, fmt.Errorf("%w: %q", ErrInvalidPageCursor, s)
+		}
+	}
+	if numbers[4] < 1 || numbers[4] > MaxPageContentLimit {
+		return PageCursor{}, fmt.Errorf("%w: %q", ErrInvalidPageCursor, s)
+	}
+	return PageCursor{
+		RowGroup: numbers[0],
+		Column:   numbers[1],
+		Page:     numbers[2],
+		Range:    PageRange{Offset: numbers[3], Limit: numbers[4]},
+	}, nil
+}
+
+// PageValues reads the values of a page a range at a time. A data page not
+// already in the cache is decoded as its ranges are read, only as far as
+// they reach, and without keeping the values of the ranges read before.
+type PageValues struct {
+	pr        *ParquetReader
+	rgIndex   int
+	colIndex  int
+	pageIndex int
+	numValues int
+	values    []interface{} // all the values, when decoded at once
+	rls, dls  []int32
+	decoder   *pageDecoder // decodes the ranges otherwise
+	start     int          // index in the column chunk of the first value, -1 for a dictionary page
+}
+
+// ReadPageValues reads a page for reading its values in ranges
+func (pr *ParquetReader) ReadPageValues(rgIndex, colIndex, pageIndex int) (*PageValues, error) {
+	meta, err := pr.GetPageMetadata(rgIndex, colIndex, pageIndex)
+	if err != nil {
+		return nil, err
+	}
+	page := &PageValues{pr: pr, rgIndex: rgIndex, colIndex: colIndex, pageIndex: pageIndex, start: -1}
+	if meta.PageType == "DATA_PAGE" || meta.PageType == "DATA_PAGE_V2" {
+		pages, err := pr.GetPageMetadataList(rgIndex, colIndex)
+		if err != nil {
+			return nil, err
+		}
+		page.start = int(pageValueStart(pages, pageIndex))
+		key := cacheKey{kind: cachePageValues, rgIndex: rgIndex, colIndex: colIndex, pageIndex: pageIndex}
+		if _, ok := pr.fromCache(key); !ok {
+			decoder, err := pr.newPageDecoder(rgIndex, colIndex, pageIndex)
+			if err == nil {
+				page.decoder, page.numValues = decoder, decoder.numValues
+				return page, nil
+			}
+			if !errors.Is(err, errUnsupportedEncoding) {
+				return nil, err
+			}
+		}
+	}
+
+	values, rls, dls, err := pr.readPageValues(rgIndex, colIndex, pageIndex)
+	if err != nil {
+		return nil, err
+	}
+	page.values, page.rls, page.dls, page.numValues = values, rls, dls, len(values)
+	return page, nil
+}
+
+// Len returns the number of values in the page
+func (p *PageValues) Len() int {
+	return p.numValues
+}
+
+// read returns the values of [start, end) with their levels, dictionary
+// pages have none
+func (p *PageValues) read(start, end int) ([]interface{}, []int32, []int32, error) {
+	if p.decoder == nil {
+		return p.values[start:end], levelsRange(p.rls, start, end), levelsRange(p.dls, start, end), nil
+	}
+	if err := p.decoder.seek(start); err != nil {
+		return nil, nil, nil, err
+	}
+	values, rls, dls, err := p.decoder.read(end - start)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// The whole page was decoded anyway, it goes to the cache as a page
+	// decoded at once does
+	if start == 0 && end == p.numValues {
+		key := cacheKey{kind: cachePageValues, rgIndex: p.rgIndex, colIndex: p.colIndex, pageIndex: p.pageIndex}
+		p.pr.toCache(key, pageValues{values, rls, dls}, valuesSize(values)+int64(len(rls)+len(dls))*4)
+		p.values, p.rls, p.dls, p.decoder = values, rls, dls, nil
+	}
+	return values, rls, dls, nil
+}
+
+// Formatted returns a range of the values as display strings, only those
+// are formatted
+func (p *PageValues) Formatted(geoFormat GeoFormat, r PageRange) ([]string, error) {
+	start, end, err := r.bounds(p.numValues)
+	if err != nil {
+		return nil, err
+	}
+
+	// VARIANT values are rebuilt from all the columns of the group
+	pr := p.pr
+	if p.start >= 0 {
+		if formatted, ok, err := pr.formatVariantPage(p.rgIndex, p.colIndex, p.start+start, end-start); ok {
+			for i := range formatted {
+				formatted[i] = truncateDisplayValue(formatted[i])
+			}
+			return formatted, err
+		}
+	}
+
+	values, _, _, err := p.read(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return pr.formatValues(p.colIndex, values, geoFormat), nil
+}
+
+// Typed returns a range of the values as JSON-typed data
+func (p *PageValues) Typed(geoFormat GeoFormat, r PageRange) ([]TypedValue, error) {
+	start, end, err := r.bounds(p.numValues)
+	if err != nil {
+		return nil, err
+	}
+	chunkStart := -1
+	if p.start >= 0 {
+		chunkStart = p.start + start
+	}
+	values, rls, dls, err := p.read(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return p.pr.typedValues(p.rgIndex, p.colIndex, chunkStart, values, rls, dls, geoFormat)
+}
+
+// GetPageContentFormattedRange is GetPageContentFormattedGeo for a range of
+// the values of a page, only those are formatted. It also returns the number
+// of values in the page. Callers reading a page in several ranges read it
+// once with ReadPageValues instead.
+func (pr *ParquetReader) GetPageContentFormattedRange(rgIndex, colIndex, pageIndex int, geoFormat GeoFormat, r PageRange) ([]string, int, error) {
+	page, err := pr.ReadPageValues(rgIndex, colIndex, pageIndex)
+	if err != nil {
+		return nil, 0, err
+	}
+	values, err := page.Formatted(geoFormat, r)
+	return values, page.Len(), err
+}
+
+// GetPageContentTypedRange is GetPageContentTyped for a range of the values
+// of a page. It also returns the number of values in the page.
+func (pr *ParquetReader) GetPageContentTypedRange(rgIndex, colIndex, pageIndex int, geoFormat GeoFormat, r PageRange) ([]TypedValue, int, error) {
+	page, err := pr.ReadPageValues(rgIndex, colIndex, pageIndex)
+	if err != nil {
+		return nil, 0, err
+	}
+	values, err := page.Typed(geoFormat, r)
+	return values, page.Len(), err
+}
+
+// levelsRange returns the levels of values [start, end), dictionary pages
+// have none
+func levelsRange(levels []int32, start, end int) []int32 {
+	if len(levels) < end {
+		return nil
+	}
+	return levels[start:end]
+}
@@ -0,0 +1,175 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParsePageRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset string
+		limit  string
+		want   PageRange
+		errMsg string
+	}{
+		{"Defaults", "", "", PageRange{Limit: DefaultPageContentLimit}, ""},
+		{"Offset and limit", "20", " 10 ", PageRange{Offset: 20, Limit: 10}, ""},
+		{"Largest limit", "0", "10000", PageRange{Limit: MaxPageContentLimit}, ""},
+		{"Negative offset", "-1", "", PageRange{}, `offset "-1"`},
+		{"Bad offset", "one", "", PageRange{}, `offset "one"`},
+		{"Zero limit", "", "0", PageRange{}, `limit "0"`},
+		{"Limit too large", "", "10001", PageRange{}, "expected 1 to 10000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePageRange(tt.offset, tt.limit)
+			if tt.errMsg != "" {
+				require.ErrorIs(t, err, ErrInvalidPageRange)
+				require.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_PageRange_Next(t *testing.T) {
+	next, ok := PageRange{Offset: 0, Limit: 10}.Next(25)
+	require.True(t, ok)
+	require.Equal(t, PageRange{Offset: 10, Limit: 10}, next)
+
+	next, ok = next.Next(25)
+	require.True(t, ok)
+	_, ok = next.Next(25)
+	require.False(t, ok)
+
+	_, ok = PageRange{}.Next(25)
+	require.False(t, ok)
+}
+
+func Test_PageCursor(t *testing.T) {
+	cursor := PageCursor{RowGroup: 1, Column: 2, Page: 3, Range: PageRange{Offset: 400, Limit: 100}}
+	parsed, err := ParsePageCursor(cursor.String())
+	require.NoError(t, err)
+	require.Equal(t, cursor, parsed)
+
+	for _, bad := range []string{"", "!!", "MS4y", PageCursor{Range: PageRange{Limit: 0}}.String(), PageCursor{Range: PageRange{Offset: -1, Limit: 1}}.String()} {
+		_, err := ParsePageCursor(bad)
+		require.ErrorIs(t, err, ErrInvalidPageCursor, bad)
+	}
+}
+
+func Test_ParquetReader_GetPageContentRange(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	// A plain column, the value of a VARIANT group and a column with a
+	// dictionary page
+	for _, colIndex := range []int{1, 15, 46} {
+		pages, err := pr.GetPageMetadataList(0, colIndex)
+		require.NoError(t, err)
+		for pageIndex := range pages {
+			all, err := pr.GetPageContentFormattedGeo(0, colIndex, pageIndex, GeoFormatGeoJSON)
+			require.NoError(t, err)
+			allTyped, err := pr.GetPageContentTyped(0, colIndex, pageIndex, GeoFormatGeoJSON)
+			require.NoError(t, err)
+
+			var formatted []string
+			var typed []TypedValue
+			for r, ok := (PageRange{Limit: 1}), true; ok; r, ok = r.Next(len(all)) {
+				values, total, err := pr.GetPageContentFormattedRange(0, colIndex, pageIndex, GeoFormatGeoJSON, r)
+				require.NoError(t, err)
+				require.Equal(t, len(all), total)
+				require.LessOrEqual(t, len(values), 1)
+				formatted = append(formatted, values...)
+
+				typedValues, total, err := pr.GetPageContentTypedRange(0, colIndex, pageIndex, GeoFormatGeoJSON, r)
+				require.NoError(t, err)
+				require.Equal(t, len(all), total)
+				typed = append(typed, typedValues...)
+			}
+			if len(all) == 0 {
+				continue
+			}
+			require.Equal(t, all, formatted, "column %d page %d", colIndex, pageIndex)
+			require.Equal(t, allTyped, typed, "column %d page %d", colIndex, pageIndex)
+		}
+	}
+
+	values, total, err := pr.GetPageContentFormattedRange(0, 1, 1, GeoFormatGeoJSON, PageRange{Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, values, total-1)
+
+	values, _, err = pr.GetPageContentFormattedRange(0, 1, 1, GeoFormatGeoJSON, PageRange{Offset: total, Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, values)
+
+	_, _, err = pr.GetPageContentFormattedRange(0, 1, 1, GeoFormatGeoJSON, PageRange{Offset: total + 1})
+	require.ErrorIs(t, err, ErrInvalidPageRange)
+	_, _, err = pr.GetPageContentTypedRange(0, 1, 1, GeoFormatGeoJSON, PageRange{Offset: total + 1})
+	require.ErrorIs(t, err, ErrInvalidPageRange)
+	_, _, err = pr.GetPageContentTypedRange(0, 1, 99, GeoFormatGeoJSON, PageRange{})
+	require.ErrorIs(t, err, ErrInvalidPageIndex)
+}
+
+func Test_ParquetReader_ReadPageValues(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	// Only the page is read, not its column chunk
+	page, err := pr.ReadPageValues(0, 1, 1)
+	require.NoError(t, err)
+	for _, op := range pr.IOStats().Operations {
+		require.NotEqual(t, IOColumnChunk, op.Operation)
+	}
+
+	// Its ranges are formatted without reading the file again
+	requests := pr.IOStats().Total.Reads
+	var formatted []string
+	for r, ok := (PageRange{Limit: 2}), true; ok; r, ok = r.Next(page.Len()) {
+		values, err := page.Formatted(GeoFormatGeoJSON, r)
+		require.NoError(t, err)
+		formatted = append(formatted, values...)
+		_, err = page.Typed(GeoFormatGeoJSON, r)
+		require.NoError(t, err)
+	}
+	require.Equal(t, requests, pr.IOStats().Total.Reads)
+
+	all, err := pr.GetPageContentFormatted(0, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, all, formatted)
+
+	_, err = page.Formatted(GeoFormatGeoJSON, PageRange{Offset: page.Len() + 1})
+	require.ErrorIs(t, err, ErrInvalidPageRange)
+	_, err = pr.ReadPageValues(0, 1, 99)
+	require.ErrorIs(t, err, ErrInvalidPageIndex)
+}
+
+func Test_PageValues_Cache(t *testing.T) {
+	pr := openEncodingTestReader(t).WithCache(CacheOptions{MaxBytes: DefaultCacheSize})
+	key := cacheKey{kind: cachePageValues, rgIndex: 0, colIndex: 1, pageIndex: 0}
+
+	// Part of a page is decoded without going to the cache
+	page, err := pr.ReadPageValues(0, 1, 0)
+	require.NoError(t, err)
+	values, err := page.Formatted(GeoFormatGeoJSON, PageRange{Offset: 10, Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, values, 5)
+	_, ok := pr.fromCache(key)
+	require.False(t, ok)
+
+	// The whole page does, and is read from there next time
+	all, err := page.Formatted(GeoFormatGeoJSON, PageRange{})
+	require.NoError(t, err)
+	require.Equal(t, values, all[10:15])
+	_, ok = pr.fromCache(key)
+	require.True(t, ok)
+
+	page, err = pr.ReadPageValues(0, 1, 0)
+	require.NoError(t, err)
+	require.Nil(t, page.decoder)
+	values, err = page.Formatted(GeoFormatGeoJSON, PageRange{Offset: 10, Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, all[10:15], values)
+}
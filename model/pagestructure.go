@@ -0,0 +1,544 @@
+package model
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/reader"
+)
+
+// HybridRun is one run of the RLE/bit-packed hybrid encoding
+type HybridRun struct {
+	Type       string // RLE or BIT_PACKED
+	Offset     int    // byte offset within the uncompressed page
+	ByteLength int    // bytes used by the run including its header
+	Count      int
+	Value      uint32 // repeated value of an RLE run
+}
+
+// DeltaMiniblock is one miniblock of a DELTA_BINARY_PACKED block
+type DeltaMiniblock struct {
+	Offset     int
+	ByteLength int
+	BitWidth   int
+	Count      int // deltas stored in the miniblock, excluding padding
+}
+
+// DeltaBlock is one block of a DELTA_BINARY_PACKED stream
+type DeltaBlock struct {
+	Offset     int
+	ByteLength int
+	MinDelta   int64
+	Miniblocks []DeltaMiniblock
+}
+
+// DeltaBinaryPacked describes a DELTA_BINARY_PACKED stream
+type DeltaBinaryPacked struct {
+	Offset             int
+	ByteLength         int
+	HeaderLength       int
+	BlockSize          int
+	MiniblocksPerBlock int
+	TotalValues        int
+	FirstValue         int64
+	Blocks             []DeltaBlock
+}
+
+// DeltaLengthByteArray describes a DELTA_LENGTH_BYTE_ARRAY section: the
+// delta-encoded lengths followed by the concatenated values
+type DeltaLengthByteArray struct {
+	LengthStream   DeltaBinaryPacked
+	Lengths        []int64
+	DataOffset     int
+	DataByteLength int
+}
+
+// DeltaByteArray describes a DELTA_BYTE_ARRAY section: delta-encoded prefix
+// lengths, then suffixes stored as DELTA_LENGTH_BYTE_ARRAY
+type DeltaByteArray struct {
+	PrefixStream   DeltaBinaryPacked
+	SuffixStream   DeltaBinaryPacked
+	PrefixLengths  []int64
+	SuffixLengths  []int64
+	DataOffset     int
+	DataByteLength int
+}
+
+// ByteStream is one byte stream of a BYTE_STREAM_SPLIT section
+type ByteStream struct {
+	Index      int
+	Offset     int
+	ByteLength int
+	// DistinctBytes counts the distinct byte values in the stream; streams
+	// with few distinct bytes are the ones that compress well
+	DistinctBytes int
+}
+
+// ByteStreamSplit describes the layout of a BYTE_STREAM_SPLIT section
+type ByteStreamSplit struct {
+	TypeSize  int
+	NumValues int
+	Streams   []ByteStream
+}
+
+// EncodedSection is one section of a page (levels or values) and its
+// encoding structure. Offsets are relative to the uncompressed page data.
+type EncodedSection struct {
+	Encoding        string
+	Offset          int
+	ByteLength      int
+	NumValues       int
+	MaxLevel        int32 // level sections only
+	BitWidth        int   // RLE/bit-packed hybrid and BIT_PACKED sections
+	Runs            []HybridRun
+	DeltaBinary     *DeltaBinaryPacked
+	DeltaLength     *DeltaLengthByteArray
+	DeltaByteArray  *DeltaByteArray
+	ByteStreamSplit *ByteStreamSplit
+	Error           string // set when the section could not be fully decoded
+}
+
+// PageStructure breaks a page down into its encoded sections
+type PageStructure struct {
+	RowGroup         int
+	ColumnIndex      int
+	PageIndex        int
+	Path             string
+	PhysicalType     string
+	PageType         string
+	UncompressedSize int
+	NumValues        int
+	NumNonNull       int
+	RepetitionLevels *EncodedSection
+	DefinitionLevels *EncodedSection
+	Values           EncodedSection
+}
+
+// GetPageStructure decodes the encoding structure of a page: the runs of
+// RLE/bit-packed hybrid levels and dictionary indices, the blocks and
+// miniblocks of delta encodings, and the stream layout of BYTE_STREAM_SPLIT
+func (pr *ParquetReader) GetPageStructure(rgIndex, colIndex, pageIndex int) (PageStructure, error) {
+	if _, err := pr.GetPageMetadata(rgIndex, colIndex, pageIndex); err != nil {
+		return PageStructure{}, err
+	}
+
+	meta := pr.metadata.RowGroups[rgIndex].Columns[colIndex].MetaData
+	headers, err := pr.pageHeaders(rgIndex, colIndex)
+	if err != nil {
+		return PageStructure{}, err
+	}
+	header := headers[pageIndex]
+
+	switch header.PageType {
+	case parquet.PageType_DATA_PAGE, parquet.PageType_DATA_PAGE_V2, parquet.PageType_DICTIONARY_PAGE:
+	default:
+		return PageStructure{}, fmt.Errorf("page %d is a %s: %w", pageIndex, header.PageType, ErrInvalidPageType)
+	}
+
+	data, err := pr.readPageData(meta, header)
+	if err != nil {
+		return PageStructure{}, err
+	}
+
+	structure := PageStructure{
+		RowGroup:         rgIndex,
+		ColumnIndex:      colIndex,
+		PageIndex:        pageIndex,
+		Path:             pr.columnName(colIndex),
+		PhysicalType:     meta.Type.String(),
+		PageType:         header.PageType.String(),
+		UncompressedSize: len(data),
+		NumValues:        int(header.NumValues),
+		NumNonNull:       int(header.NumValues),
+	}
+
+	if header.PageType == parquet.PageType_DICTIONARY_PAGE {
+		structure.Values = EncodedSection{
+			Encoding:   parquet.Encoding_PLAIN.String(),
+			ByteLength: len(data),
+			NumValues:  int(header.NumValues),
+		}
+		return structure, nil
+	}
+
+	maxDef, maxRep := columnLevels(pr.metadata.Schema, meta.PathInSchema)
+	sections, err := splitDataPage(data, header, maxDef, maxRep)
+	if err != nil {
+		return PageStructure{}, err
+	}
+
+	if maxRep > 0 {
+		rep, _ := levelStructure(sections.repLevels, 0, sections.levelsPrefixed, levelEncoding(header, header.RepLevelEncoding), maxRep, structure.NumValues)
+		structure.RepetitionLevels = &rep
+	}
+	if maxDef > 0 {
+		def, levels := levelStructure(sections.defLevels, len(sections.repLevels), sections.levelsPrefixed, levelEncoding(header, header.DefLevelEncoding), maxDef, structure.NumValues)
+		structure.DefinitionLevels = &def
+		switch {
+		case header.PageType == parquet.PageType_DATA_PAGE_V2:
+			structure.NumNonNull = int(header.NumValues - header.NumNulls)
+		case def.Error == "":
+			structure.NumNonNull = 0
+			for _, level := range levels {
+				if int32(level) == maxDef {
+					structure.NumNonNull++
+				}
+			}
+		}
+	}
+
+	schemaElem := pr.schemaElement(colIndex)
+	structure.Values = valueStructure(sections.values, len(sections.repLevels)+len(sections.defLevels),
+		header.Encoding, meta.Type, schemaElem, structure.NumNonNull)
+	return structure, nil
+}
+
+// levelEncoding returns the encoding of a level section; DATA_PAGE_V2 levels
+// are always RLE/bit-packed hybrid regardless of what the header says
+func levelEncoding(header reader.PageHeaderInfo, encoding parquet.Encoding) parquet.Encoding {
+	if header.PageType == parquet.PageType_DATA_PAGE_V2 {
+		return parquet.Encoding_RLE
+	}
+	return encoding
+}
+
+// levelStructure describes a level section starting at offset and returns
+// the decoded levels
+func levelStructure(section []byte, offset int, prefixed bool, encoding parquet.Encoding, maxLevel int32, numValues int) (EncodedSection, []uint32) {
+	result := EncodedSection{
+		Encoding:   encoding.String(),
+		Offset:     offset,
+		ByteLength: len(section),
+		NumValues:  numValues,
+		MaxLevel:   maxLevel,
+		BitWidth:   levelBitWidth(maxLevel),
+	}
+
+	if encoding == parquet.Encoding_BIT_PACKED {
+		levels, err := unpackBitsMSB(section, result.BitWidth, numValues)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		return result, levels
+	}
+
+	if prefixed {
+		if len(section) < 4 {
+			result.Error = errTruncatedPage.Error()
+			return result, nil
+		}
+		section, offset = section[4:], offset+4
+	}
+	levels, runs, err := decodeHybrid(section, result.BitWidth, numValues)
+	result.Runs = exportHybridRuns(runs, offset)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result, levels
+}
+
+// valueStructure describes the value section of a data page starting at offset
+func valueStructure(data []byte, offset int, encoding parquet.Encoding, physicalType parquet.Type, schemaElem *parquet.SchemaElement, numValues int) EncodedSection {
+	result := EncodedSection{
+		Encoding:   encoding.String(),
+		Offset:     offset,
+		ByteLength: len(data),
+		NumValues:  numValues,
+	}
+
+	var err error
+	switch encoding {
+	case parquet.Encoding_PLAIN_DICTIONARY, parquet.Encoding_RLE_DICTIONARY:
+		// One byte of index bit width, then the hybrid-encoded indices
+		if numValues == 0 {
+			break
+		}
+		if len(data) == 0 {
+			err = errTruncatedPage
+			break
+		}
+		result.BitWidth = int(data[0])
+		var runs []hybridRun
+		_, runs, err = decodeHybrid(data[1:], result.BitWidth, numValues)
+		result.Runs = exportHybridRuns(runs, offset+1)
+
+	case parquet.Encoding_RLE:
+		// Booleans: a 4-byte length prefix, then the hybrid-encoded bits
+		result.BitWidth = 1
+		if len(data) < 4 {
+			err = errTruncatedPage
+			break
+		}
+		var runs []hybridRun
+		_, runs, err = decodeHybrid(data[4:], 1, numValues)
+		result.Runs = exportHybridRuns(runs, offset+4)
+
+	case parquet.Encoding_DELTA_BINARY_PACKED:
+		var stream DeltaBinaryPacked
+		_, stream, err = decodeDeltaBinaryPacked(data, offset)
+		result.DeltaBinary = &stream
+
+	case parquet.Encoding_DELTA_LENGTH_BYTE_ARRAY:
+		var layout DeltaLengthByteArray
+		layout, err = decodeDeltaLengthByteArray(data, offset)
+		result.DeltaLength = &layout
+
+	case parquet.Encoding_DELTA_BYTE_ARRAY:
+		var layout DeltaByteArray
+		layout, err = decodeDeltaByteArray(data, offset)
+		result.DeltaByteArray = &layout
+
+	case parquet.Encoding_BYTE_STREAM_SPLIT:
+		var layout ByteStreamSplit
+		layout, err = byteStreamSplitLayout(data, offset, physicalType, schemaElem)
+		result.ByteStreamSplit = &layout
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// exportHybridRuns converts decoded hybrid runs to their exported form with
+// offsets shifted by base
+func exportHybridRuns(runs []hybridRun, base int) []HybridRun {
+	if len(runs) == 0 {
+		return nil
+	}
+	result := make([]HybridRun, len(runs))
+	for i, run := range runs {
+		result[i] = HybridRun{
+			Type:       "RLE",
+			Offset:     base + run.offset,
+			ByteLength: run.byteLen,
+			Count:      run.count,
+			Value:      run.value,
+		}
+		if run.bitPacked {
+			result[i].Type = "BIT_PACKED"
+		}
+	}
+	return result
+}
+
+// decodeDeltaBinaryPacked decodes a DELTA_BINARY_PACKED stream at the start of
+// data, returning its values and block structure. base is the offset of data
+// within the page.
+func decodeDeltaBinaryPacked(data []byte, base int) ([]int64, DeltaBinaryPacked, error) {
+	stream := DeltaBinaryPacked{Offset: base}
+	pos := 0
+
+	readUvarint := func(name string) (uint64, error) {
+		v, n := binary.Uvarint(data[pos:])
+		if n <= 0 {
+			return 0, fmt.Errorf("invalid %s at byte %d: %w", name, base+pos, errTruncatedPage)
+		}
+		pos += n
+		return v, nil
+	}
+	readVarint := func(name string) (int64, error) {
+		v, n := binary.Varint(data[pos:])
+		if n <= 0 {
+			return 0, fmt.Errorf("invalid %s at byte %d: %w", name, base+pos, errTruncatedPage)
+		}
+		pos += n
+		return v, nil
+	}
+
+	blockSize, err := readUvarint("block size")
+	if err != nil {
+		return nil, stream, err
+	}
+	miniblocks, err := readUvarint("miniblock count")
+	if err != nil {
+		return nil, stream, err
+	}
+	total, err := readUvarint("value count")
+	if err != nil {
+		return nil, stream, err
+	}
+	first, err := readVarint("first value")
+	if err != nil {
+		return nil, stream, err
+	}
+	stream.BlockSize, stream.MiniblocksPerBlock = int(blockSize), int(miniblocks)
+	stream.TotalValues, stream.FirstValue = int(total), first
+	stream.HeaderLength = pos
+	stream.ByteLength = pos
+
+	if miniblocks == 0 || blockSize%miniblocks != 0 || (blockSize/miniblocks)%8 != 0 {
+		return nil, stream, fmt.Errorf("invalid block size %d with %d miniblocks", blockSize, miniblocks)
+	}
+	valuesPerMiniblock := int(blockSize / miniblocks)
+
+	values := make([]int64, 0, stream.TotalValues)
+	if stream.TotalValues > 0 {
+		values = append(values, first)
+	}
+	last := first
+	for len(values) < stream.TotalValues {
+		block := DeltaBlock{Offset: base + pos}
+		if block.MinDelta, err = readVarint("min delta"); err != nil {
+			return values, stream, err
+		}
+		if pos+stream.MiniblocksPerBlock > len(data) {
+			return values, stream, fmt.Errorf("miniblock bit widths at byte %d: %w", base+pos, errTruncatedPage)
+		}
+		bitWidths := data[pos : pos+stream.MiniblocksPerBlock]
+		pos += stream.MiniblocksPerBlock
+
+		// Miniblocks past the last value are omitted, their bit widths are not
+		for _, width := range bitWidths {
+			if len(values) >= stream.TotalValues {
+				break
+			}
+			size := valuesPerMiniblock * int(width) / 8
+			if pos+size > len(data) {
+				stream.Blocks = append(stream.Blocks, block)
+				return values, stream, fmt.Errorf("miniblock at byte %d: %w", base+pos, errTruncatedPage)
+			}
+			deltas, err := unpackBitsLSB64(data[pos:pos+size], int(width), valuesPerMiniblock)
+			if err != nil {
+				return values, stream, err
+			}
+			count := min(valuesPerMiniblock, stream.TotalValues-len(values))
+			for _, delta := range deltas[:count] {
+				last += block.MinDelta + int64(delta)
+				values = append(values, last)
+			}
+			block.Miniblocks = append(block.Miniblocks, DeltaMiniblock{
+				Offset:     base + pos,
+				ByteLength: size,
+				BitWidth:   int(width),
+				Count:      count,
+			})
+			pos += size
+		}
+		block.ByteLength = base + pos - block.Offset
+		stream.Blocks = append(stream.Blocks, block)
+	}
+	stream.ByteLength = pos
+	return values, stream, nil
+}
+
+// decodeDeltaLengthByteArray describes a DELTA_LENGTH_BYTE_ARRAY section
+func decodeDeltaLengthByteArray(data []byte, base int) (DeltaLengthByteArray, error) {
+	lengths, stream, err := decodeDeltaBinaryPacked(data, base)
+	layout := DeltaLengthByteArray{LengthStream: stream, Lengths: lengths}
+	if err != nil {
+		return layout, fmt.Errorf("lengths: %w", err)
+	}
+	layout.DataOffset = base + stream.ByteLength
+	layout.DataByteLength = len(data) - stream.ByteLength
+	return layout, checkByteArrayLengths(lengths, layout.DataByteLength)
+}
+
+// decodeDeltaByteArray describes a DELTA_BYTE_ARRAY section
+func decodeDeltaByteArray(data []byte, base int) (DeltaByteArray, error) {
+	var layout DeltaByteArray
+	prefixes, prefixStream, err := decodeDeltaBinaryPacked(data, base)
+	layout.PrefixStream, layout.PrefixLengths = prefixStream, prefixes
+	if err != nil {
+		return layout, fmt.Errorf("prefix lengths: %w", err)
+	}
+
+	pos := prefixStream.ByteLength
+	suffixes, suffixStream, err := decodeDeltaBinaryPacked(data[pos:], base+pos)
+	layout.SuffixStream, layout.SuffixLengths = suffixStream, suffixes
+	if err != nil {
+		return layout, fmt.Errorf("suffix lengths: %w", err)
+	}
+	if len(prefixes) != len(suffixes) {
+		return layout, fmt.Errorf("%d prefix lengths but %d suffix lengths", len(prefixes), len(suffixes))
+	}
+
+	pos += suffixStream.ByteLength
+	layout.DataOffset = base + pos
+	layout.DataByteLength = len(data) - pos
+	return layout, checkByteArrayLengths(suffixes, layout.DataByteLength)
+}
+
+// checkByteArrayLengths verifies that decoded lengths add up to the bytes
+// actually present
+func checkByteArrayLengths(lengths []int64, available int) error {
+	var total int64
+	for _, length := range lengths {
+		if length < 0 {
+			return fmt.Errorf("negative length %d", length)
+		}
+		total += length
+	}
+	if total > int64(available) {
+		return fmt.Errorf("lengths add up to %d bytes but %d are present: %w", total, available, errTruncatedPage)
+	}
+	return nil
+}
+
+// byteStreamSplitLayout describes a BYTE_STREAM_SPLIT section: one stream per
+// byte of the value type, each holding that byte of every value
+func byteStreamSplitLayout(data []byte, base int, physicalType parquet.Type, schemaElem *parquet.SchemaElement) (ByteStreamSplit, error) {
+	var layout ByteStreamSplit
+	switch physicalType {
+	case parquet.Type_FLOAT, parquet.Type_INT32:
+		layout.TypeSize = 4
+	case parquet.Type_DOUBLE, parquet.Type_INT64:
+		layout.TypeSize = 8
+	case parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		if schemaElem != nil {
+			layout.TypeSize = int(schemaElem.GetTypeLength())
+		}
+	}
+	if layout.TypeSize <= 0 {
+		return layout, fmt.Errorf("unsupported type %s", physicalType)
+	}
+	if len(data)%layout.TypeSize != 0 {
+		return layout, fmt.Errorf("%d bytes is not a multiple of the %d-byte type size", len(data), layout.TypeSize)
+	}
+
+	layout.NumValues = len(data) / layout.TypeSize
+	layout.Streams = make([]ByteStream, layout.TypeSize)
+	for i := range layout.Streams {
+		stream := data[i*layout.NumValues : (i+1)*layout.NumValues]
+		var seen [256]bool
+		distinct := 0
+		for _, b := range stream {
+			if !seen[b] {
+				seen[b] = true
+				distinct++
+			}
+		}
+		layout.Streams[i] = ByteStream{
+			Index:         i,
+			Offset:        base + i*layout.NumValues,
+			ByteLength:    layout.NumValues,
+			DistinctBytes: distinct,
+		}
+	}
+	return layout, nil
+}
+
+// unpackBitsLSB64 is unpackBitsLSB for bit widths up to 64, as used by
+// DELTA_BINARY_PACKED miniblocks
+func unpackBitsLSB64(data []byte, bitWidth, count int) ([]uint64, error) {
+	if bitWidth < 0 || bitWidth > 64 {
+		return nil, fmt.Errorf("invalid bit width %d", bitWidth)
+	}
+	if (count*bitWidth+7)/8 > len(data) {
+		return nil, errTruncatedPage
+	}
+	values := make([]uint64, count)
+	bitPos := 0
+	for i := range values {
+		var v uint64
+		for b := 0; b < bitWidth; b++ {
+			if data[bitPos/8]&(1<<(bitPos%8)) != 0 {
+				v |= 1 << b
+			}
+			bitPos++
+		}
+		values[i] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,244 @@
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/source/local"
+	"github.com/hangxie/parquet-go/v3/writer"
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+)
+
+// encodingTestRow exercises the encodings missing from the shared test files
+type encodingTestRow struct {
+	ID    int64   `parquet:"name=id, type=INT64, encoding=DELTA_BINARY_PACKED"`
+	Name  string  `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=DELTA_BYTE_ARRAY"`
+	Tag   string  `parquet:"name=tag, type=BYTE_ARRAY, convertedtype=UTF8, encoding=DELTA_LENGTH_BYTE_ARRAY"`
+	Score float64 `parquet:"name=score, type=DOUBLE, encoding=BYTE_STREAM_SPLIT"`
+	Opt   *int32  `parquet:"name=opt, type=INT32, encoding=DELTA_BINARY_PACKED"`
+}
+
+// openEncodingTestReader writes a small DATA_PAGE_V2 file using delta and
+// byte stream split encodings and opens it
+func openEncodingTestReader(t *testing.T) *ParquetReader {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "encodings.parquet")
+	fw, err := local.NewLocalFileWriter(path)
+	require.NoError(t, err)
+	pw, err := writer.NewParquetWriter(fw, new(encodingTestRow), writer.WithDataPageVersion(2))
+	require.NoError(t, err)
+	for i := range 100 {
+		var opt *int32
+		if i%3 != 0 {
+			v := int32(i * 7)
+			opt = &v
+		}
+		require.NoError(t, pw.Write(encodingTestRow{
+			ID:    int64(1000 + i*i),
+			Name:  fmt.Sprintf("name-%04d", i),
+			Tag:   fmt.Sprintf("t%d", i%7),
+			Score: float64(i) / 4,
+			Opt:   opt,
+		}))
+	}
+	require.NoError(t, pw.WriteStop())
+	require.NoError(t, fw.Close())
+
+	r, err := pio.NewParquetFileReader(path, pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.ReadStop() })
+	return NewParquetReader(r)
+}
+
+func Test_GetPageStructure(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	t.Run("Dictionary indices", func(t *testing.T) {
+		structure, err := pr.GetPageStructure(0, 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, "DATA_PAGE", structure.PageType)
+		require.Nil(t, structure.RepetitionLevels)
+		require.Nil(t, structure.DefinitionLevels)
+		require.Equal(t, "RLE_DICTIONARY", structure.Values.Encoding)
+		require.Equal(t, 32, structure.Values.BitWidth)
+		require.Equal(t, []HybridRun{
+			{Type: "RLE", Offset: 1, ByteLength: 5, Count: 1, Value: 3},
+			{Type: "RLE", Offset: 6, ByteLength: 5, Count: 1, Value: 4},
+		}, structure.Values.Runs)
+	})
+
+	t.Run("Repeated column levels", func(t *testing.T) {
+		structure, err := pr.GetPageStructure(0, 46, 1)
+		require.NoError(t, err)
+		require.NotNil(t, structure.RepetitionLevels)
+		require.NotNil(t, structure.DefinitionLevels)
+		require.Equal(t, int32(1), structure.RepetitionLevels.MaxLevel)
+		require.Equal(t, 6, structure.DefinitionLevels.Offset)
+		require.Equal(t, 10, structure.DefinitionLevels.Runs[0].Offset)
+		require.Equal(t, 1, structure.NumNonNull)
+		require.Equal(t, 14, structure.Values.Offset)
+	})
+
+	t.Run("Boolean RLE values", func(t *testing.T) {
+		structure, err := pr.GetPageStructure(0, 0, 1)
+		require.NoError(t, err)
+		require.Equal(t, "RLE", structure.Values.Encoding)
+		require.Equal(t, 1, structure.Values.BitWidth)
+		require.Len(t, structure.Values.Runs, 2)
+	})
+
+	t.Run("Dictionary page", func(t *testing.T) {
+		structure, err := pr.GetPageStructure(0, 1, 0)
+		require.NoError(t, err)
+		require.Equal(t, "DICTIONARY_PAGE", structure.PageType)
+		require.Equal(t, "PLAIN", structure.Values.Encoding)
+		require.Equal(t, structure.UncompressedSize, structure.Values.ByteLength)
+	})
+
+	t.Run("Invalid page", func(t *testing.T) {
+		_, err := pr.GetPageStructure(0, 1, 99)
+		require.ErrorIs(t, err, ErrInvalidPageIndex)
+		_, err = pr.GetPageStructure(0, 999, 0)
+		require.ErrorIs(t, err, ErrInvalidColumnIndex)
+	})
+}
+
+func Test_GetPageStructure_Encodings(t *testing.T) {
+	pr := openEncodingTestReader(t)
+
+	t.Run("DELTA_BINARY_PACKED", func(t *testing.T) {
+		structure, err := pr.GetPageStructure(0, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, "DATA_PAGE_V2", structure.PageType)
+		delta := structure.Values.DeltaBinary
+		require.NotNil(t, delta)
+		require.Empty(t, structure.Values.Error)
+		require.Equal(t, int64(1000), delta.FirstValue)
+		require.Equal(t, structure.NumValues, delta.TotalValues)
+		require.Equal(t, structure.Values.ByteLength, delta.ByteLength)
+		require.Equal(t, int64(1), delta.Blocks[0].MinDelta)
+
+		count := 1
+		for _, block := range delta.Blocks {
+			for _, mb := range block.Miniblocks {
+				count += mb.Count
+			}
+		}
+		require.Equal(t, delta.TotalValues, count)
+	})
+
+	t.Run("DELTA_BYTE_ARRAY", func(t *testing.T) {
+		structure, err := pr.GetPageStructure(0, 1, 0)
+		require.NoError(t, err)
+		layout := structure.Values.DeltaByteArray
+		require.NotNil(t, layout)
+		require.Empty(t, structure.Values.Error)
+		require.Len(t, layout.PrefixLengths, structure.NumValues)
+		require.Equal(t, int64(0), layout.PrefixLengths[0])
+		require.Equal(t, int64(9), layout.SuffixLengths[0])
+		require.Equal(t, int64(8), layout.PrefixLengths[1]) // name-0000 -> name-0001
+		require.Equal(t, int64(1), layout.SuffixLengths[1])
+		require.Equal(t, layout.PrefixStream.ByteLength, layout.SuffixStream.Offset)
+	})
+
+	t.Run("DELTA_LENGTH_BYTE_ARRAY", func(t *testing.T) {
+		structure, err := pr.GetPageStructure(0, 2, 0)
+		require.NoError(t, err)
+		layout := structure.Values.DeltaLength
+		require.NotNil(t, layout)
+		require.Len(t, layout.Lengths, structure.NumValues)
+		require.Equal(t, 2*structure.NumValues, layout.DataByteLength)
+	})
+
+	t.Run("BYTE_STREAM_SPLIT", func(t *testing.T) {
+		structure, err := pr.GetPageStructure(0, 3, 0)
+		require.NoError(t, err)
+		layout := structure.Values.ByteStreamSplit
+		require.NotNil(t, layout)
+		require.Equal(t, 8, layout.TypeSize)
+		require.Len(t, layout.Streams, 8)
+		require.Equal(t, structure.NumValues, layout.NumValues)
+		require.Equal(t, layout.NumValues, layout.Streams[1].Offset)
+	})
+
+	t.Run("Optional DATA_PAGE_V2 levels", func(t *testing.T) {
+		structure, err := pr.GetPageStructure(0, 4, 0)
+		require.NoError(t, err)
+		require.NotNil(t, structure.DefinitionLevels)
+		require.Equal(t, "RLE", structure.DefinitionLevels.Encoding)
+		require.Less(t, structure.NumNonNull, structure.NumValues)
+		require.Equal(t, structure.NumNonNull, structure.Values.DeltaBinary.TotalValues)
+	})
+}
+
+func Test_decodeDeltaBinaryPacked(t *testing.T) {
+	// Block size 128 with 4 miniblocks, 3 values, first value 5; one block
+	// with min delta 1 and a single 1-bit miniblock holding deltas 0 and 1
+	data := []byte{
+		0x80, 0x01, 0x04, 0x03, 0x0a, // header
+		0x02,                   // min delta 1 (zigzag)
+		0x01, 0x00, 0x00, 0x00, // miniblock bit widths
+		0x02, 0x00, 0x00, 0x00, // 32 x 1 bit
+	}
+
+	values, stream, err := decodeDeltaBinaryPacked(data, 100)
+	require.NoError(t, err)
+	require.Equal(t, []int64{5, 6, 8}, values)
+	require.Equal(t, 100, stream.Offset)
+	require.Equal(t, 5, stream.HeaderLength)
+	require.Equal(t, len(data), stream.ByteLength)
+	require.Equal(t, []DeltaBlock{{
+		Offset:     105,
+		ByteLength: 9,
+		MinDelta:   1,
+		Miniblocks: []DeltaMiniblock{{Offset: 110, ByteLength: 4, BitWidth: 1, Count: 2}},
+	}}, stream.Blocks)
+
+	_, _, err = decodeDeltaBinaryPacked(data[:12], 0)
+	require.ErrorIs(t, err, errTruncatedPage)
+
+	_, _, err = decodeDeltaBinaryPacked([]byte{0x80, 0x01, 0x03, 0x01, 0x00}, 0)
+	require.ErrorContains(t, err, "invalid block size")
+
+	_, _, err = decodeDeltaBinaryPacked(nil, 0)
+	require.ErrorIs(t, err, errTruncatedPage)
+}
+
+func Test_byteStreamSplitLayout(t *testing.T) {
+	layout, err := byteStreamSplitLayout([]byte{1, 2, 3, 0, 0, 0, 9, 9, 9, 0, 0, 0}, 4, parquet.Type_INT32, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, layout.NumValues)
+	require.Equal(t, ByteStream{Index: 0, Offset: 4, ByteLength: 3, DistinctBytes: 3}, layout.Streams[0])
+	require.Equal(t, ByteStream{Index: 2, Offset: 10, ByteLength: 3, DistinctBytes: 1}, layout.Streams[2])
+
+	typeLength := int32(2)
+	layout, err = byteStreamSplitLayout([]byte{1, 2, 3, 4}, 0, parquet.Type_FIXED_LEN_BYTE_ARRAY, &parquet.SchemaElement{TypeLength: &typeLength})
+	require.NoError(t, err)
+	require.Len(t, layout.Streams, 2)
+
+	_, err = byteStreamSplitLayout([]byte{1, 2, 3}, 0, parquet.Type_FLOAT, nil)
+	require.ErrorContains(t, err, "not a multiple")
+
+	_, err = byteStreamSplitLayout(nil, 0, parquet.Type_BYTE_ARRAY, nil)
+	require.ErrorContains(t, err, "unsupported type")
+}
+
+func Test_checkByteArrayLengths(t *testing.T) {
+	require.NoError(t, checkByteArrayLengths([]int64{1, 2}, 3))
+	require.ErrorIs(t, checkByteArrayLengths([]int64{1, 2}, 2), errTruncatedPage)
+	require.ErrorContains(t, checkByteArrayLengths([]int64{-1}, 2), "negative length")
+}
+
+func Test_unpackBitsLSB64(t *testing.T) {
+	values, err := unpackBitsLSB64([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, 33, 2)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1<<33 - 1, 0x7fffffff | 1<<31}, values)
+
+	_, err = unpackBitsLSB64([]byte{0x00}, 65, 1)
+	require.ErrorContains(t, err, "invalid bit width")
+	_, err = unpackBitsLSB64([]byte{0x00}, 16, 1)
+	require.ErrorIs(t, err, errTruncatedPage)
+}
@@ -0,0 +1,637 @@
+package model
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/reader"
+)
+
+// errUnsupportedEncoding is returned for pages whose values decodePageValues
+// does not decode, which are left to the column reader
+var errUnsupportedEncoding = errors.New("unsupported encoding")
+
+// decodePageValues reads a single data page and decodes its values with
+// their repetition and definition levels, in the form the column reader
+// returns them: one value per level, nil where the value is missing. Only
+// the page is read, and the dictionary page of the chunk when the page is
+// dictionary encoded.
+func (pr *ParquetReader) decodePageValues(rgIndex, colIndex, pageIndex int) ([]interface{}, []int32, []int32, error) {
+	decoder, err := pr.newPageDecoder(rgIndex, colIndex, pageIndex)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return decoder.read(decoder.numValues)
+}
+
+// pageDecoder decodes the values of a data page in order, a range at a time.
+// Levels and values are decoded only as far as the ranges read reach, and
+// only the values of a range are kept, so reading part of a large page costs
+// the values up to the end of the part rather than the whole page.
+type pageDecoder struct {
+	numValues int
+	maxDef    int32
+	encoding  parquet.Encoding
+
+	// levels decodes the first n repetition and definition levels
+	levels   func(n int) ([]int32, []int32, error)
+	rls, dls []int32 // the levels decoded so far
+
+	// newValues starts decoding the values of the page over
+	newValues func() (valueDecoder, error)
+	values    valueDecoder // nil until a value is read
+
+	next int // index of the next value read
+}
+
+// newPageDecoder reads a data page for decoding its values. Encodings
+// decodePageValues does not decode return errUnsupportedEncoding.
+func (pr *ParquetReader) newPageDecoder(rgIndex, colIndex, pageIndex int) (*pageDecoder, error) {
+	meta := pr.metadata.RowGroups[rgIndex].Columns[colIndex].MetaData
+	headers, err := pr.pageHeaders(rgIndex, colIndex)
+	if err != nil {
+		return nil, err
+	}
+	if pageIndex < 0 || pageIndex >= len(headers) {
+		return nil, fmt.Errorf("page index %d out of range [0, %d): %w",
+			pageIndex, len(headers), ErrInvalidPageIndex)
+	}
+	header := headers[pageIndex]
+	if header.PageType != parquet.PageType_DATA_PAGE && header.PageType != parquet.PageType_DATA_PAGE_V2 {
+		return nil, fmt.Errorf("page %d is a %s: %w", pageIndex, header.PageType, ErrInvalidPageType)
+	}
+	dictionaryEncoded := isDictionaryEncoding(header.Encoding)
+	if !dictionaryEncoded {
+		if err := checkValueEncoding(header.Encoding, meta.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := pr.readPageData(meta, header)
+	if err != nil {
+		return nil, err
+	}
+	maxDef, maxRep := columnLevels(pr.metadata.Schema, meta.PathInSchema)
+	sections, err := splitDataPage(data, header, maxDef, maxRep)
+	if err != nil {
+		return nil, err
+	}
+
+	numValues := int(header.NumValues)
+	d := &pageDecoder{numValues: numValues, maxDef: maxDef, encoding: header.Encoding}
+	d.levels = func(n int) ([]int32, []int32, error) {
+		rls, err := pageLevels(sections.repLevels, sections.levelsPrefixed, levelEncoding(header, header.RepLevelEncoding), maxRep, n)
+		if err != nil {
+			return nil, nil, fmt.Errorf("repetition levels: %w", err)
+		}
+		dls, err := pageLevels(sections.defLevels, sections.levelsPrefixed, levelEncoding(header, header.DefLevelEncoding), maxDef, n)
+		if err != nil {
+			return nil, nil, fmt.Errorf("definition levels: %w", err)
+		}
+		return rls, dls, nil
+	}
+
+	schemaElem := pr.schemaElement(colIndex)
+	if dictionaryEncoded {
+		dictionary, err := pr.chunkDictionary(meta, headers, schemaElem)
+		if err != nil {
+			return nil, err
+		}
+		d.newValues = func() (valueDecoder, error) {
+			return newDictionaryDecoder(sections.values, dictionary, numValues)
+		}
+	} else {
+		d.newValues = func() (valueDecoder, error) {
+			return newValueDecoder(sections.values, header.Encoding, meta.Type, schemaElem, numValues)
+		}
+	}
+	return d, nil
+}
+
+// seek moves to the value at index, starting over for an index already
+// passed
+func (d *pageDecoder) seek(index int) error {
+	if index < d.next {
+		d.values, d.next = nil, 0
+	}
+	_, _, err := d.advance(index-d.next, false)
+	return err
+}
+
+// read returns the next n values, nil where the value is missing, with their
+// levels
+func (d *pageDecoder) read(n int) ([]interface{}, []int32, []int32, error) {
+	values, defined, err := d.advance(n, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	start := d.next - n
+	rls, dls := d.rls[start:d.next], d.dls[start:d.next]
+	j := 0
+	for i, level := range dls {
+		if level == d.maxDef {
+			values[i] = defined[j]
+			j++
+		}
+	}
+	return values, rls, dls, nil
+}
+
+// advance passes over the next n values, decoding the defined ones when keep
+// is set. It returns a slot for each value and the defined values in order.
+func (d *pageDecoder) advance(n int, keep bool) ([]interface{}, []interface{}, error) {
+	end := d.next + n
+	if n < 0 || end > d.numValues {
+		return nil, nil, fmt.Errorf("%w: values [%d, %d) of %d", ErrInvalidPageRange, d.next, end, d.numValues)
+	}
+	if err := d.decodeLevels(end); err != nil {
+		return nil, nil, err
+	}
+	count := 0
+	for _, level := range d.dls[d.next:end] {
+		if level == d.maxDef {
+			count++
+		}
+	}
+
+	var defined []interface{}
+	if count > 0 {
+		if d.values == nil {
+			values, err := d.newValues()
+			if err != nil {
+				return nil, nil, d.valuesError(err)
+			}
+			d.values = values
+		}
+		var err error
+		if keep {
+			defined, err = d.values.decode(count)
+		} else {
+			err = d.values.skip(count)
+		}
+		if err != nil {
+			return nil, nil, d.valuesError(err)
+		}
+	}
+	d.next = end
+
+	var values []interface{}
+	if keep {
+		values = make([]interface{}, n)
+	}
+	return values, defined, nil
+}
+
+// decodeLevels decodes the levels up to end at least. Levels are decoded
+// from the start of their sections, twice as many as before each time so
+// that reading a page in ranges decodes each level a few times at most.
+func (d *pageDecoder) decodeLevels(end int) error {
+	if end <= len(d.dls) {
+		return nil
+	}
+	rls, dls, err := d.levels(min(d.numValues, max(end, 2*len(d.dls))))
+	if err != nil {
+		return err
+	}
+	d.rls, d.dls = rls, dls
+	return nil
+}
+
+// valuesError adds the encoding of the page to an error decoding its values
+func (d *pageDecoder) valuesError(err error) error {
+	if isDictionaryEncoding(d.encoding) {
+		return err
+	}
+	return fmt.Errorf("%s values: %w", d.encoding, err)
+}
+
+// pageLevels decodes a level section to numValues levels, all zero for a
+// column without such levels
+func pageLevels(section []byte, prefixed bool, encoding parquet.Encoding, maxLevel int32, numValues int) ([]int32, error) {
+	levels := make([]int32, numValues)
+	if maxLevel == 0 {
+		return levels, nil
+	}
+	decoded, err := decodeLevels(section, prefixed, encoding, maxLevel, numValues)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) < numValues {
+		return nil, fmt.Errorf("decoded %d of %d levels: %w", len(decoded), numValues, errTruncatedPage)
+	}
+	for i := range levels {
+		levels[i] = int32(decoded[i])
+	}
+	return levels, nil
+}
+
+// chunkDictionary returns the values of the dictionary page among the
+// headers of a column chunk
+func (pr *ParquetReader) chunkDictionary(meta *parquet.ColumnMetaData, headers []reader.PageHeaderInfo, schemaElem *parquet.SchemaElement) ([]interface{}, error) {
+	for _, header := range headers {
+		if header.PageType == parquet.PageType_DICTIONARY_PAGE {
+			return pr.readDictionaryValues(meta, header, schemaElem)
+		}
+	}
+	return nil, fmt.Errorf("dictionary encoded page without a dictionary page: %w", ErrInvalidPageType)
+}
+
+// valueDecoder decodes the value section of a data page in order, as the
+// types the column reader returns: bool, int32, int64, float32, float64, and
+// string for INT96 and byte arrays
+type valueDecoder interface {
+	// skip passes over the next n values
+	skip(n int) error
+	// decode returns the next n values
+	decode(n int) ([]interface{}, error)
+}
+
+// checkValueEncoding returns errUnsupportedEncoding for an encoding of a
+// physical type newValueDecoder does not decode
+func checkValueEncoding(encoding parquet.Encoding, physicalType parquet.Type) error {
+	switch encoding {
+	case parquet.Encoding_PLAIN, parquet.Encoding_DELTA_LENGTH_BYTE_ARRAY,
+		parquet.Encoding_DELTA_BYTE_ARRAY, parquet.Encoding_BYTE_STREAM_SPLIT:
+		return nil
+	case parquet.Encoding_RLE:
+		if physicalType == parquet.Type_BOOLEAN {
+			return nil
+		}
+	case parquet.Encoding_DELTA_BINARY_PACKED:
+		if physicalType == parquet.Type_INT32 || physicalType == parquet.Type_INT64 {
+			return nil
+		}
+	default:
+		return errUnsupportedEncoding
+	}
+	return fmt.Errorf("%w for %s", errUnsupportedEncoding, physicalType)
+}
+
+// newValueDecoder starts decoding a value section of at most numValues
+// values. The length streams of the delta encodings are decoded whole, the
+// values themselves only as they are read.
+func newValueDecoder(data []byte, encoding parquet.Encoding, physicalType parquet.Type, schemaElem *parquet.SchemaElement, numValues int) (valueDecoder, error) {
+	if err := checkValueEncoding(encoding, physicalType); err != nil {
+		return nil, err
+	}
+	var typeLength int
+	if schemaElem != nil {
+		typeLength = int(schemaElem.GetTypeLength())
+	}
+	switch encoding {
+	case parquet.Encoding_PLAIN:
+		if physicalType == parquet.Type_BYTE_ARRAY {
+			return &byteArrayDecoder{data: data, plain: true}, nil
+		}
+		return newPlainDecoder(data, physicalType, typeLength)
+
+	case parquet.Encoding_RLE:
+		// Booleans: a 4-byte length prefix, then the hybrid-encoded bits
+		if len(data) < 4 {
+			return nil, errTruncatedPage
+		}
+		return &hybridDecoder{data: data[4:], bitWidth: 1, limit: numValues, value: func(bit uint32) (interface{}, error) {
+			return bit > 0, nil
+		}}, nil
+
+	case parquet.Encoding_DELTA_BINARY_PACKED:
+		values, _, err := decodeDeltaBinaryPacked(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &deltaDecoder{values: values, int32: physicalType == parquet.Type_INT32}, nil
+
+	case parquet.Encoding_DELTA_LENGTH_BYTE_ARRAY:
+		layout, err := decodeDeltaLengthByteArray(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &byteArrayDecoder{data: data[layout.DataOffset:], lengths: layout.Lengths}, nil
+
+	case parquet.Encoding_DELTA_BYTE_ARRAY:
+		layout, err := decodeDeltaByteArray(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &byteArrayDecoder{data: data[layout.DataOffset:], prefixes: layout.PrefixLengths, lengths: layout.SuffixLengths}, nil
+
+	case parquet.Encoding_BYTE_STREAM_SPLIT:
+		layout, err := byteStreamSplitLayout(data, 0, physicalType, schemaElem)
+		if err != nil {
+			return nil, err
+		}
+		return &byteStreamSplitDecoder{
+			data:         data,
+			physicalType: physicalType,
+			value:        make([]byte, layout.TypeSize),
+			numValues:    layout.NumValues,
+		}, nil
+	}
+	return nil, errUnsupportedEncoding
+}
+
+// plainDecoder decodes PLAIN values of a fixed size, or booleans packed a
+// bit each
+type plainDecoder struct {
+	data         []byte
+	physicalType parquet.Type
+	size         int // bytes a value, 0 for booleans
+	index        int // index of the next value
+}
+
+// newPlainDecoder starts decoding PLAIN values of a physical type other than
+// BYTE_ARRAY
+func newPlainDecoder(data []byte, physicalType parquet.Type, typeLength int) (*plainDecoder, error) {
+	p := &plainDecoder{data: data, physicalType: physicalType}
+	switch physicalType {
+	case parquet.Type_BOOLEAN:
+	case parquet.Type_INT32, parquet.Type_FLOAT:
+		p.size = 4
+	case parquet.Type_INT64, parquet.Type_DOUBLE:
+		p.size = 8
+	case parquet.Type_INT96:
+		p.size = 12
+	case parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		if typeLength <= 0 {
+			return nil, fmt.Errorf("unknown type length of %s", physicalType)
+		}
+		p.size = typeLength
+	default:
+		return nil, fmt.Errorf("unsupported type %s", physicalType)
+	}
+	return p, nil
+}
+
+// check verifies that the next n values are in the data
+func (p *plainDecoder) check(n int) error {
+	if p.size == 0 {
+		if (p.index+n+7)/8 > len(p.data) {
+			return errTruncatedPage
+		}
+		return nil
+	}
+	if (p.index+n)*p.size > len(p.data) {
+		return fmt.Errorf("%d values of %d bytes: %w", p.index+n, p.size, errTruncatedPage)
+	}
+	return nil
+}
+
+func (p *plainDecoder) skip(n int) error {
+	if err := p.check(n); err != nil {
+		return err
+	}
+	p.index += n
+	return nil
+}
+
+func (p *plainDecoder) decode(n int) ([]interface{}, error) {
+	if err := p.check(n); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, n)
+	for i := range values {
+		index := p.index + i
+		if p.size == 0 {
+			values[i] = p.data[index/8]&(1<<(index%8)) != 0
+		} else {
+			values[i] = fixedValue(p.physicalType, p.data[index*p.size:(index+1)*p.size])
+		}
+	}
+	p.index += n
+	return values, nil
+}
+
+// fixedValue converts the bytes of a fixed size value of a physical type
+func fixedValue(physicalType parquet.Type, data []byte) interface{} {
+	switch physicalType {
+	case parquet.Type_INT32:
+		return int32(binary.LittleEndian.Uint32(data))
+	case parquet.Type_INT64:
+		return int64(binary.LittleEndian.Uint64(data))
+	case parquet.Type_FLOAT:
+		return math.Float32frombits(binary.LittleEndian.Uint32(data))
+	case parquet.Type_DOUBLE:
+		return math.Float64frombits(binary.LittleEndian.Uint64(data))
+	}
+	return string(data)
+}
+
+// byteStreamSplitDecoder gathers the bytes of each BYTE_STREAM_SPLIT value
+// from the streams back in order
+type byteStreamSplitDecoder struct {
+	data         []byte
+	physicalType parquet.Type
+	value        []byte // the bytes of the value gathered last
+	numValues    int
+	index        int
+}
+
+// check verifies that the next n values are in the streams
+func (b *byteStreamSplitDecoder) check(n int) error {
+	if b.index+n > b.numValues {
+		return fmt.Errorf("%d of %d values: %w", b.numValues, b.index+n, errTruncatedPage)
+	}
+	return nil
+}
+
+func (b *byteStreamSplitDecoder) skip(n int) error {
+	if err := b.check(n); err != nil {
+		return err
+	}
+	b.index += n
+	return nil
+}
+
+func (b *byteStreamSplitDecoder) decode(n int) ([]interface{}, error) {
+	if err := b.check(n); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, n)
+	for i := range values {
+		for j := range b.value {
+			b.value[j] = b.data[j*b.numValues+b.index]
+		}
+		values[i] = fixedValue(b.physicalType, b.value)
+		b.index++
+	}
+	return values, nil
+}
+
+// byteArrayDecoder decodes byte arrays, PLAIN ones with a 4-byte length
+// before each, or cut out of data by lengths. With prefix lengths, as in
+// DELTA_BYTE_ARRAY, each array starts with that many bytes of the one before
+// it and data holds the rest.
+type byteArrayDecoder struct {
+	data              []byte
+	plain             bool
+	prefixes, lengths []int64
+	previous          []byte // the array before, for the prefix of the next
+	index, pos        int
+}
+
+// next returns the next array, valid until the one after is read
+func (b *byteArrayDecoder) next() ([]byte, error) {
+	i := b.index
+	var size int
+	if b.plain {
+		if b.pos+4 > len(b.data) {
+			return nil, fmt.Errorf("length of value %d: %w", i, errTruncatedPage)
+		}
+		size = int(binary.LittleEndian.Uint32(b.data[b.pos:]))
+		b.pos += 4
+	} else {
+		if i >= len(b.lengths) {
+			return nil, fmt.Errorf("%d lengths for value %d: %w", len(b.lengths), i, errTruncatedPage)
+		}
+		size = int(b.lengths[i])
+	}
+	if size < 0 || b.pos+size > len(b.data) {
+		return nil, fmt.Errorf("value %d of %d bytes: %w", i, size, errTruncatedPage)
+	}
+	value := b.data[b.pos : b.pos+size]
+	b.pos += size
+	b.index++
+	if b.prefixes == nil {
+		return value, nil
+	}
+	prefix := b.prefixes[i]
+	if prefix < 0 || prefix > int64(len(b.previous)) {
+		return nil, fmt.Errorf("prefix of %d bytes of a %d-byte value", prefix, len(b.previous))
+	}
+	b.previous = append(b.previous[:prefix], value...)
+	return b.previous, nil
+}
+
+func (b *byteArrayDecoder) skip(n int) error {
+	for range n {
+		if _, err := b.next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *byteArrayDecoder) decode(n int) ([]interface{}, error) {
+	values := make([]interface{}, n)
+	for i := range values {
+		value, err := b.next()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = string(value)
+	}
+	return values, nil
+}
+
+// deltaDecoder returns the values of a decoded DELTA_BINARY_PACKED stream
+type deltaDecoder struct {
+	values []int64
+	int32  bool
+	index  int
+}
+
+// check verifies that the stream holds the next n values
+func (d *deltaDecoder) check(n int) error {
+	if d.index+n > len(d.values) {
+		return fmt.Errorf("decoded %d of %d values: %w", len(d.values), d.index+n, errTruncatedPage)
+	}
+	return nil
+}
+
+func (d *deltaDecoder) skip(n int) error {
+	if err := d.check(n); err != nil {
+		return err
+	}
+	d.index += n
+	return nil
+}
+
+func (d *deltaDecoder) decode(n int) ([]interface{}, error) {
+	if err := d.check(n); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, n)
+	for i, v := range d.values[d.index : d.index+n] {
+		if d.int32 {
+			values[i] = int32(v)
+		} else {
+			values[i] = v
+		}
+	}
+	d.index += n
+	return values, nil
+}
+
+// newDictionaryDecoder starts looking up dictionary indexes, stored as a byte
+// of bit width followed by at most numValues hybrid-encoded indexes
+func newDictionaryDecoder(data []byte, dictionary []interface{}, numValues int) (valueDecoder, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("dictionary indexes: %w", errTruncatedPage)
+	}
+	return &hybridDecoder{data: data[1:], bitWidth: int(data[0]), limit: numValues, name: "dictionary indexes",
+		value: func(index uint32) (interface{}, error) {
+			if int(index) >= len(dictionary) {
+				return nil, fmt.Errorf("dictionary index %d out of range [0, %d): %w", index, len(dictionary), ErrInvalidPageType)
+			}
+			return dictionary[index], nil
+		}}, nil
+}
+
+// hybridDecoder looks values up by hybrid-encoded keys, the indexes of a
+// dictionary or the bits of RLE booleans. Keys are decoded from the start of
+// the section, twice as many as before each time they run out.
+type hybridDecoder struct {
+	data     []byte
+	bitWidth int
+	limit    int    // keys in the section at most
+	name     string // what the keys are, for errors
+	keys     []uint32
+	index    int
+	value    func(key uint32) (interface{}, error)
+}
+
+// decodeKeys decodes the keys up to end at least
+func (h *hybridDecoder) decodeKeys(end int) error {
+	if end <= len(h.keys) {
+		return nil
+	}
+	keys, _, err := decodeHybrid(h.data, h.bitWidth, min(h.limit, max(end, 2*len(h.keys))))
+	if len(keys) < end {
+		if err == nil {
+			err = fmt.Errorf("decoded %d of %d values: %w", len(keys), end, errTruncatedPage)
+		}
+		if h.name != "" {
+			err = fmt.Errorf("%s: %w", h.name, err)
+		}
+		return err
+	}
+	h.keys = keys
+	return nil
+}
+
+func (h *hybridDecoder) skip(n int) error {
+	if err := h.decodeKeys(h.index + n); err != nil {
+		return err
+	}
+	h.index += n
+	return nil
+}
+
+func (h *hybridDecoder) decode(n int) ([]interface{}, error) {
+	if err := h.decodeKeys(h.index + n); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, n)
+	for i, key := range h.keys[h.index : h.index+n] {
+		value, err := h.value(key)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	h.index += n
+	return values, nil
+}
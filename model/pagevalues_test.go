@@ -0,0 +1,153 @@
+package model
+
+import (
+	"path/filepath"
+	"testing"
+
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+)
+
+// requirePagesDecodeAlone checks that every data page decoded alone holds
+// the values the column reader returns for it reading the whole file
+func requirePagesDecodeAlone(t *testing.T, pr *ParquetReader) {
+	t.Helper()
+	for colIndex := range pr.columns {
+		var numValues int64
+		for _, rg := range pr.metadata.RowGroups {
+			numValues += rg.Columns[colIndex].MetaData.NumValues
+		}
+		r, err := pr.newColumnReader(IOColumnChunk, 1)
+		require.NoError(t, err)
+		want, wantRLs, wantDLs, err := r.ReadColumnByIndex(int64(colIndex), numValues)
+		pr.closeColumnReader(r)
+		require.NoError(t, err)
+
+		var start int
+		for rgIndex := range pr.metadata.RowGroups {
+			pages, err := pr.GetPageMetadataList(rgIndex, colIndex)
+			require.NoError(t, err)
+			for _, page := range pages {
+				if page.PageType != "DATA_PAGE" && page.PageType != "DATA_PAGE_V2" {
+					continue
+				}
+				end := start + int(page.NumValues)
+				got, rls, dls, err := pr.decodePageValues(rgIndex, colIndex, page.Index)
+				require.NoError(t, err, "row group %d column %d page %d", rgIndex, colIndex, page.Index)
+				require.Equal(t, want[start:end], got, "row group %d column %d page %d", rgIndex, colIndex, page.Index)
+				require.Equal(t, wantRLs[start:end], rls)
+				require.Equal(t, wantDLs[start:end], dls)
+				start = end
+			}
+		}
+	}
+}
+
+func Test_decodePageValues(t *testing.T) {
+	files, err := filepath.Glob("../build/testdata/*.parquet")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			r, err := pio.NewParquetFileReader(file, pio.ReadOption{})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = r.ReadStop() })
+			requirePagesDecodeAlone(t, NewParquetReader(r))
+		})
+	}
+
+	t.Run("Delta and byte stream split encodings", func(t *testing.T) {
+		requirePagesDecodeAlone(t, openEncodingTestReader(t))
+	})
+
+	t.Run("Invalid pages", func(t *testing.T) {
+		pr := openTestParquetReader(t)
+		_, _, _, err := pr.decodePageValues(0, 0, 1000)
+		require.ErrorIs(t, err, ErrInvalidPageIndex)
+		_, _, _, err = pr.decodePageValues(0, 1, 0)
+		require.ErrorIs(t, err, ErrInvalidPageType)
+	})
+}
+
+// requirePagesDecodeInRanges checks that every data page read in ranges, in
+// order and then from its middle again, holds the values decoded at once
+func requirePagesDecodeInRanges(t *testing.T, pr *ParquetReader) {
+	t.Helper()
+	for rgIndex := range pr.metadata.RowGroups {
+		for colIndex := range pr.columns {
+			pages, err := pr.GetPageMetadataList(rgIndex, colIndex)
+			require.NoError(t, err)
+			for _, page := range pages {
+				if page.PageType != "DATA_PAGE" && page.PageType != "DATA_PAGE_V2" {
+					continue
+				}
+				want, wantRLs, wantDLs, err := pr.decodePageValues(rgIndex, colIndex, page.Index)
+				require.NoError(t, err)
+
+				decoder, err := pr.newPageDecoder(rgIndex, colIndex, page.Index)
+				require.NoError(t, err)
+				for start := 0; start < decoder.numValues; start += 3 {
+					n := min(3, decoder.numValues-start)
+					got, rls, dls, err := decoder.read(n)
+					require.NoError(t, err, "row group %d column %d page %d", rgIndex, colIndex, page.Index)
+					require.Equal(t, want[start:start+n], got, "row group %d column %d page %d", rgIndex, colIndex, page.Index)
+					require.Equal(t, wantRLs[start:start+n], rls)
+					require.Equal(t, wantDLs[start:start+n], dls)
+				}
+
+				middle := decoder.numValues / 2
+				require.NoError(t, decoder.seek(middle))
+				got, _, _, err := decoder.read(decoder.numValues - middle)
+				require.NoError(t, err)
+				require.Equal(t, want[middle:], got)
+			}
+		}
+	}
+}
+
+func Test_pageDecoder(t *testing.T) {
+	t.Run("Ranges", func(t *testing.T) {
+		requirePagesDecodeInRanges(t, openTestParquetReader(t))
+	})
+
+	t.Run("Ranges of delta and byte stream split encodings", func(t *testing.T) {
+		requirePagesDecodeInRanges(t, openEncodingTestReader(t))
+	})
+
+	t.Run("Decodes only up to the end of a range", func(t *testing.T) {
+		pr := openEncodingTestReader(t)
+		decoder, err := pr.newPageDecoder(0, 1, 0)
+		require.NoError(t, err)
+		require.Equal(t, 25, decoder.numValues)
+
+		values, _, _, err := decoder.read(2)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"name-0000", "name-0001"}, values)
+		require.Len(t, decoder.dls, 2)
+		require.Equal(t, 2, decoder.values.(*byteArrayDecoder).index)
+
+		require.NoError(t, decoder.seek(20))
+		require.Len(t, decoder.dls, 20)
+		require.Equal(t, 20, decoder.values.(*byteArrayDecoder).index)
+		values, _, _, err = decoder.read(1)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{"name-0020"}, values)
+
+		// Missing values are not decoded
+		decoder, err = pr.newPageDecoder(0, 4, 0)
+		require.NoError(t, err)
+		values, _, dls, err := decoder.read(2)
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{nil, int32(7)}, values)
+		require.Equal(t, []int32{0, 1}, dls)
+		require.Equal(t, 1, decoder.values.(*deltaDecoder).index)
+	})
+
+	t.Run("Out of range", func(t *testing.T) {
+		pr := openTestParquetReader(t)
+		decoder, err := pr.newPageDecoder(0, 1, 1)
+		require.NoError(t, err)
+		_, _, _, err = decoder.read(decoder.numValues + 1)
+		require.ErrorIs(t, err, ErrInvalidPageRange)
+	})
+}
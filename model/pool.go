@@ -0,0 +1,462 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/reader"
+	"github.com/hangxie/parquet-go/v3/source"
+)
+
+// OpenFunc opens another reader of the same file with a file handle of its
+// own, configured like the first one (decryption keys included)
+type OpenFunc func() (*reader.ParquetReader, error)
+
+// ErrReaderClosed is returned for reads after the reader was closed
+var ErrReaderClosed = errors.New("parquet reader closed")
+
+// DefaultReaderPoolSize is the number of readers a pool holds at most unless
+// set otherwise. Each reader holds a file handle per column, so the pool does
+// not grow with the CPUs.
+const DefaultReaderPoolSize = 4
+
+// MaxReaderPoolSize caps the size of a reader pool
+const MaxReaderPoolSize = 64
+
+// HandleStats counts the readers of a file and the file handles they hold
+type HandleStats struct {
+	PoolSize      int   // pooled readers open at most
+	Readers       int   // pooled readers open
+	IdleReaders   int   // pooled readers waiting for a caller
+	ColumnReaders int   // readers opened for a single read and not closed yet, with a handle per column read
+	FileHandles   int   // handles held by the pooled readers, one of their own and one per column
+	Opened        int64 // readers opened since the start, column readers included
+	Closed        int64 // readers closed since the start, column readers included
+}
+
+// readerPool lends the readers of a file to one caller at a time. A file
+// handle keeps a single seek position, so two requests reading through the
+// same handle would read each other's bytes. The reader the pool starts with
+// is always there, more are opened when all are busy until the pool holds
+// size readers.
+type readerPool struct {
+	open OpenFunc
+	idle chan *reader.ParquetReader
+	done chan struct{} // closed by close, wakes up the callers waiting for a reader
+
+	mu      sync.Mutex
+	closed  bool
+	changed bool                    // the file changed on disk, its readers no longer match it
+	size    int                     // readers the pool may hold, 1 without open
+	opening int                     // readers being opened
+	readers []*reader.ParquetReader // every reader open, the first included
+	leases  sync.WaitGroup          // readers lent out and column readers not closed yet
+	stats   HandleStats
+	io      *ioCounters // reads of every handle of the file
+}
+
+// newReaderPool creates a pool holding first, which may grow to size readers
+// opened with open. Without open the pool never grows and callers take turns.
+func newReaderPool(first *reader.ParquetReader, open OpenFunc, size int) *readerPool {
+	p := &readerPool{
+		open:    open,
+		idle:    make(chan *reader.ParquetReader, MaxReaderPoolSize),
+		done:    make(chan struct{}),
+		readers: []*reader.ParquetReader{first},
+		io:      newIOCounters(),
+	}
+	p.setSize(size)
+	p.stats.Opened = 1
+	p.stats.FileHandles = fileHandles(first)
+	p.idle <- first
+	return p
+}
+
+// setSize changes the number of readers the pool may hold, within [1,
+// MaxReaderPoolSize]. Readers over a smaller size are closed as they are
+// released.
+func (p *readerPool) setSize(size int) {
+	if p.open == nil {
+		size = 1
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.size = min(max(size, 1), MaxReaderPoolSize)
+}
+
+// reserve claims the opening of a reader when the pool may still grow
+func (p *readerPool) reserve() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed || len(p.readers)+p.opening >= p.size {
+		return false
+	}
+	p.opening++
+	return true
+}
+
+// lease counts a reader lent out or a column reader opened, which close
+// waits for. It fails once the file changed or the pool is closed.
+func (p *readerPool) lease() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.changed {
+		return ErrFileChanged
+	}
+	if p.closed {
+		return ErrReaderClosed
+	}
+	p.leases.Add(1)
+	return nil
+}
+
+// acquire takes an idle reader, opens a new one when none is idle and the
+// pool may still grow, and otherwise waits for one to be released. A caller
+// whose reader fails to open waits instead. The wait ends with ctx.Err()
+// when ctx is cancelled.
+func (p *readerPool) acquire(ctx context.Context) (*reader.ParquetReader, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := p.lease(); err != nil {
+		return nil, err
+	}
+
+	select {
+	case r := <-p.idle:
+		return r, nil
+	default:
+	}
+
+	if p.reserve() {
+		r, err := p.open()
+		p.mu.Lock()
+		p.opening--
+		if err == nil {
+			p.readers = append(p.readers, r)
+			p.stats.Opened++
+			p.stats.FileHandles += fileHandles(r)
+			p.mu.Unlock()
+			return r, nil
+		}
+		p.mu.Unlock()
+	}
+
+	err := ErrReaderClosed
+	select {
+	case r := <-p.idle:
+		return r, nil
+	case <-p.done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err == ErrReaderClosed && p.isChanged() {
+		err = ErrFileChanged
+	}
+	p.leases.Done()
+	return nil, err
+}
+
+// release hands a reader back for the next caller, or closes it when the
+// pool holds more readers than its size. The first reader is always kept.
+func (p *readerPool) release(r *reader.ParquetReader) {
+	defer p.leases.Done()
+	p.mu.Lock()
+	if len(p.readers) <= p.size || r == p.readers[0] {
+		p.mu.Unlock()
+		p.idle <- r
+		return
+	}
+	for i, opened := range p.readers {
+		if opened == r {
+			p.readers = append(p.readers[:i], p.readers[i+1:]...)
+			break
+		}
+	}
+	p.stats.Closed++
+	p.stats.FileHandles -= fileHandles(r)
+	p.mu.Unlock()
+	_ = closeReader(r, true)
+}
+
+// handleStats counts the readers of the pool and their file handles
+func (p *readerPool) handleStats() HandleStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := p.stats
+	stats.PoolSize = p.size
+	stats.Readers = len(p.readers)
+	if !p.closed {
+		stats.IdleReaders = len(p.idle)
+	}
+	return stats
+}
+
+// fileChanged stops lending readers of a file that changed on disk
+func (p *readerPool) fileChanged() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changed = true
+}
+
+// isChanged reports whether the file of the pool changed on disk
+func (p *readerPool) isChanged() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.changed
+}
+
+// close stops lending readers, waits for the reads in progress and closes
+// every reader of the pool. Callers waiting for a reader get ErrReaderClosed.
+func (p *readerPool) close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.done)
+	p.mu.Unlock()
+
+	p.leases.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var errs []error
+	for _, r := range p.readers {
+		errs = append(errs, closeReader(r, true))
+		p.stats.Closed++
+	}
+	p.readers = nil
+	p.stats.FileHandles = 0
+	return errors.Join(errs...)
+}
+
+// fileHandles counts the handles of a reader when it is opened: its own and
+// one per column buffer
+func fileHandles(r *reader.ParquetReader) int {
+	if r == nil {
+		return 0
+	}
+	handles := len(r.ColumnBuffers)
+	if r.PFile != nil {
+		handles++
+	}
+	return handles
+}
+
+// closeReader closes the column buffers of a reader, and its own file handle
+// when it owns it
+func closeReader(r *reader.ParquetReader, ownsFile bool) error {
+	if r == nil {
+		return nil
+	}
+	err := r.ReadStop()
+	if ownsFile && r.PFile != nil {
+		err = errors.Join(err, r.PFile.Close())
+	}
+	return err
+}
+
+// WithReaderPool returns a reader over the same file that serves concurrent
+// callers with up to size readers, each with its own file handle, opening
+// more with open as needed
+func (pr *ParquetReader) WithReaderPool(open OpenFunc, size int) *ParquetReader {
+	copied := *pr
+	copied.pool = newReaderPool(pr.Reader, open, size)
+	return &copied
+}
+
+// SetReaderPoolSize changes the number of readers serving concurrent callers
+// at most, for this reader and every copy sharing its pool. Readers over a
+// smaller size are closed once their callers are done with them.
+func (pr *ParquetReader) SetReaderPoolSize(size int) {
+	if pr != nil && pr.pool != nil {
+		pr.pool.setSize(size)
+	}
+}
+
+// Reopen returns a reader over the file as it is now, its footer and readers
+// opened again with the OpenFunc of the pool. The display options, decoders,
+// cache and I/O counters carry over, the cache emptied. The readers of pr are
+// closed once the reads that borrowed them are done, reads through pr fail
+// with ErrFileChanged meanwhile.
+func (pr *ParquetReader) Reopen() (*ParquetReader, error) {
+	if pr == nil || pr.pool == nil || pr.pool.open == nil {
+		return nil, ErrFileChanged
+	}
+	r, err := pr.pool.open()
+	if err != nil {
+		return nil, fmt.Errorf("reopen: %w", err)
+	}
+
+	reopened := NewParquetReader(r)
+	reopened.display = pr.display
+	reopened.prefetch = pr.prefetch
+	reopened.ctx = pr.ctx
+	reopened.pool = newReaderPool(r, pr.pool.open, pr.HandleStats().PoolSize)
+	reopened.pool.io = pr.pool.io
+	if pr.decoders != nil {
+		if reopened, err = reopened.WithDecoders(pr.decoders); err != nil {
+			_ = closeReader(r, true)
+			return nil, fmt.Errorf("reopen: %w", err)
+		}
+	}
+	// Reads of the old file that were still going may have filled the cache
+	reopened.cache = pr.cache
+	if c := reopened.cache; c != nil {
+		c.mu.Lock()
+		c.clear()
+		c.mu.Unlock()
+	}
+
+	pr.pool.fileChanged()
+	go func() { _ = pr.pool.close() }()
+	return reopened, nil
+}
+
+// withReader runs fn with a reader of the file that no other caller uses
+// meanwhile, so fn may seek and read its file handle freely. The footer and
+// schema are the same in every reader of the pool. Reads through the file
+// handle are counted for op and fail once the context of pr is cancelled,
+// and so do the reads of the handles cloned from it meanwhile.
+func (pr *ParquetReader) withReader(op IOOperation, fn func(r *reader.ParquetReader) error) error {
+	ctx := pr.context()
+	r, err := pr.pool.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer pr.pool.release(r)
+
+	pFile := r.PFile
+	if pFile != nil {
+		r.PFile = newIOFile(pFile, op, pr.pool.io, pr.prefetch)
+		if ctx.Done() != nil {
+			r.PFile = &ctxFile{ParquetFileReader: r.PFile, ctx: ctx}
+		}
+		defer func() { r.PFile = pFile }()
+	}
+	return contextErr(ctx, fn(r))
+}
+
+// Close waits for the reads in progress and closes every file handle of the
+// reader, the one it was created with included. The copies made with other
+// display options, decoders or caches share the handles and are closed too.
+func (pr *ParquetReader) Close() error {
+	if pr == nil || pr.pool == nil {
+		return nil
+	}
+	return pr.pool.close()
+}
+
+// HandleStats counts the readers of the file open and their file handles
+func (pr *ParquetReader) HandleStats() HandleStats {
+	if pr == nil || pr.pool == nil {
+		return HandleStats{}
+	}
+	return pr.pool.handleStats()
+}
+
+// pageHeaders reads the page headers of a column chunk, or takes them from
+// the page cache
+func (pr *ParquetReader) pageHeaders(rgIndex, colIndex int) ([]reader.PageHeaderInfo, error) {
+	key := cacheKey{kind: cachePageHeaders, rgIndex: rgIndex, colIndex: colIndex}
+	if cached, ok := pr.fromCache(key); ok {
+		return cached.([]reader.PageHeaderInfo), nil
+	}
+
+	var headers []reader.PageHeaderInfo
+	err := pr.withReader(IOPageHeaders, func(r *reader.ParquetReader) error {
+		var err error
+		headers, err = r.GetAllPageHeaders(rgIndex, colIndex)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	pr.toCache(key, headers, headersSize(headers))
+	return headers, nil
+}
+
+// offsetIndex reads the offset index of a column chunk, nil when it has none
+func (pr *ParquetReader) offsetIndex(rgIndex, colIndex int) (*parquet.OffsetIndex, error) {
+	var index *parquet.OffsetIndex
+	err := pr.withReader(IOOffsetIndex, func(r *reader.ParquetReader) error {
+		var err error
+		index, err = r.ReadOffsetIndex(rgIndex, colIndex)
+		return err
+	})
+	return index, err
+}
+
+// columnIndex reads the column index of a column chunk, nil when it has none
+func (pr *ParquetReader) columnIndex(rgIndex, colIndex int) (*parquet.ColumnIndex, error) {
+	var index *parquet.ColumnIndex
+	err := pr.withReader(IOColumnIndex, func(r *reader.ParquetReader) error {
+		var err error
+		index, err = r.ReadColumnIndex(rgIndex, colIndex)
+		return err
+	})
+	return index, err
+}
+
+// newColumnReader creates a column reader of the file counting its reads
+// for op. Only the footer is read through the lent file handle, the column
+// buffers clone their own, so the reader stays usable after the handle goes
+// back to the pool. It must be closed with closeColumnReader.
+func (pr *ParquetReader) newColumnReader(op IOOperation, np int64) (*reader.ParquetReader, error) {
+	return pr.openColumnReader(op, func(pFile source.ParquetFileReader) (*reader.ParquetReader, error) {
+		return reader.NewParquetColumnReader(pFile, reader.WithNP(np))
+	})
+}
+
+// newRowReader creates a reader of whole rows of the file, which like
+// newColumnReader only holds a lent file handle while reading the footer
+func (pr *ParquetReader) newRowReader(op IOOperation, np int64) (*reader.ParquetReader, error) {
+	return pr.openColumnReader(op, func(pFile source.ParquetFileReader) (*reader.ParquetReader, error) {
+		return reader.NewParquetReader(pFile, nil, reader.WithNP(np))
+	})
+}
+
+// openColumnReader opens a reader for a single read through a lent file
+// handle and counts it open until closeColumnReader, so that Close waits
+// for it
+func (pr *ParquetReader) openColumnReader(op IOOperation, open func(source.ParquetFileReader) (*reader.ParquetReader, error)) (*reader.ParquetReader, error) {
+	if err := pr.pool.lease(); err != nil {
+		return nil, err
+	}
+	var opened *reader.ParquetReader
+	err := pr.withReader(op, func(r *reader.ParquetReader) error {
+		var err error
+		opened, err = open(r.PFile)
+		return err
+	})
+	if err != nil {
+		// A reader that failed halfway may have cloned some handles already
+		_ = closeReader(opened, false)
+		pr.pool.leases.Done()
+		return nil, err
+	}
+
+	pr.pool.mu.Lock()
+	defer pr.pool.mu.Unlock()
+	pr.pool.stats.ColumnReaders++
+	pr.pool.stats.Opened++
+	return opened, nil
+}
+
+// closeColumnReader closes a reader opened by newColumnReader or
+// newRowReader. The file handle it read the footer through belongs to the
+// pool and stays open.
+func (pr *ParquetReader) closeColumnReader(r *reader.ParquetReader) {
+	_ = closeReader(r, false)
+
+	pr.pool.mu.Lock()
+	pr.pool.stats.ColumnReaders--
+	pr.pool.stats.Closed++
+	pr.pool.mu.Unlock()
+	pr.pool.leases.Done()
+}
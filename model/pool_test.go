@@ -0,0 +1,244 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hangxie/parquet-go/v3/reader"
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTestReaderPool opens the test file behind a pool of up to size
+// readers, all of them closed when the test ends
+func openTestReaderPool(t *testing.T, size int) *ParquetReader {
+	t.Helper()
+	open := func() (*reader.ParquetReader, error) {
+		return pio.NewParquetFileReader(getTestParquetFilePath(), pio.ReadOption{})
+	}
+	first, err := open()
+	require.NoError(t, err)
+	pr := NewParquetReader(first).WithReaderPool(open, size)
+	t.Cleanup(func() { _ = pr.Close() })
+	return pr
+}
+
+func Test_readerPool(t *testing.T) {
+	first := &reader.ParquetReader{}
+	acquire := func(t *testing.T, pool *readerPool) *reader.ParquetReader {
+		t.Helper()
+		r, err := pool.acquire(context.Background())
+		require.NoError(t, err)
+		return r
+	}
+	acquireAsync := func(pool *readerPool) chan error {
+		done := make(chan error, 1)
+		go func() {
+			r, err := pool.acquire(context.Background())
+			if err == nil {
+				pool.release(r)
+			}
+			done <- err
+		}()
+		return done
+	}
+
+	t.Run("Grows up to its size", func(t *testing.T) {
+		var opens atomic.Int32
+		pool := newReaderPool(first, func() (*reader.ParquetReader, error) {
+			opens.Add(1)
+			return &reader.ParquetReader{}, nil
+		}, 3)
+
+		a, b, c := acquire(t, pool), acquire(t, pool), acquire(t, pool)
+		require.Same(t, first, a)
+		require.NotSame(t, a, b)
+		require.NotSame(t, b, c)
+		require.Equal(t, int32(2), opens.Load())
+		require.Equal(t, 3, pool.handleStats().Readers)
+		require.Zero(t, pool.handleStats().IdleReaders)
+
+		done := make(chan *reader.ParquetReader)
+		go func() {
+			r, _ := pool.acquire(context.Background())
+			done <- r
+		}()
+		pool.release(b)
+		require.Same(t, b, <-done)
+		require.Equal(t, int32(2), opens.Load())
+	})
+
+	t.Run("Waits when opening fails", func(t *testing.T) {
+		pool := newReaderPool(first, func() (*reader.ParquetReader, error) {
+			return nil, errors.New("no more handles")
+		}, 2)
+
+		a := acquire(t, pool)
+		done := acquireAsync(pool)
+		pool.release(a)
+		require.NoError(t, <-done)
+	})
+
+	t.Run("Without open callers take turns", func(t *testing.T) {
+		pool := newReaderPool(first, nil, 8)
+		require.Equal(t, 1, pool.handleStats().PoolSize)
+		pool.setSize(4)
+		require.Equal(t, 1, pool.handleStats().PoolSize)
+	})
+
+	t.Run("Resizes", func(t *testing.T) {
+		pool := newReaderPool(first, func() (*reader.ParquetReader, error) {
+			return &reader.ParquetReader{}, nil
+		}, 1000)
+		require.Equal(t, MaxReaderPoolSize, pool.handleStats().PoolSize)
+
+		pool.setSize(1)
+		a := acquire(t, pool)
+		require.Same(t, first, a)
+		pool.setSize(2)
+		b := acquire(t, pool)
+		require.NotSame(t, a, b)
+		require.Equal(t, 2, pool.handleStats().Readers)
+
+		// Readers over a smaller size are closed on release, the first kept
+		pool.setSize(0)
+		require.Equal(t, 1, pool.handleStats().PoolSize)
+		pool.release(a)
+		pool.release(b)
+		stats := pool.handleStats()
+		require.Equal(t, 1, stats.Readers)
+		require.Equal(t, 1, stats.IdleReaders)
+		require.Equal(t, int64(1), stats.Closed)
+	})
+
+	t.Run("Close waits for readers lent out", func(t *testing.T) {
+		pool := newReaderPool(first, nil, 1)
+		a := acquire(t, pool)
+		waiting := acquireAsync(pool)
+
+		closed := make(chan error, 1)
+		go func() { closed <- pool.close() }()
+		require.ErrorIs(t, <-waiting, ErrReaderClosed)
+		select {
+		case <-closed:
+			t.Fatal("closed while a reader was lent out")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		pool.release(a)
+		require.NoError(t, <-closed)
+		_, err := pool.acquire(context.Background())
+		require.ErrorIs(t, err, ErrReaderClosed)
+		require.NoError(t, pool.close())
+		require.Equal(t, HandleStats{PoolSize: 1, Opened: 1, Closed: 1}, pool.handleStats())
+	})
+}
+
+func Test_ParquetReader_Close(t *testing.T) {
+	pr := openTestReaderPool(t, 2)
+	numColumns := countLeafColumns(pr.metadata.Schema)
+
+	stats := pr.HandleStats()
+	require.Equal(t, 1, stats.Readers)
+	require.Equal(t, 1, stats.IdleReaders)
+	require.Equal(t, 1+numColumns, stats.FileHandles)
+
+	columnReader, err := pr.newColumnReader(IOColumnChunk, 1)
+	require.NoError(t, err)
+	stats = pr.HandleStats()
+	require.Equal(t, 1, stats.ColumnReaders)
+	require.Equal(t, int64(2), stats.Opened)
+
+	pr.closeColumnReader(columnReader)
+	_, err = pr.GetRecord(1)
+	require.NoError(t, err)
+	stats = pr.HandleStats()
+	require.Zero(t, stats.ColumnReaders)
+	require.Equal(t, 1+numColumns, stats.FileHandles)
+	require.Equal(t, stats.Opened-1, stats.Closed)
+
+	require.NoError(t, pr.Close())
+	stats = pr.HandleStats()
+	require.Zero(t, stats.Readers)
+	require.Zero(t, stats.FileHandles)
+	require.Equal(t, stats.Opened, stats.Closed)
+
+	_, err = pr.GetPageMetadataList(0, 1)
+	require.ErrorIs(t, err, ErrReaderClosed)
+	_, err = pr.GetRecord(1)
+	require.ErrorIs(t, err, ErrReaderClosed)
+	require.NoError(t, pr.Close())
+
+	var nilReader *ParquetReader
+	require.NoError(t, nilReader.Close())
+	require.Equal(t, HandleStats{}, nilReader.HandleStats())
+}
+
+// Test_ParquetReader_Concurrent reads the same pages, records and layout from
+// many goroutines at once, run with -race to check the file handles are
+// never shared. The small cache checked often keeps evicting and checking
+// the file meanwhile.
+func Test_ParquetReader_Concurrent(t *testing.T) {
+	pr := openTestReaderPool(t, 4).WithCache(CacheOptions{MaxBytes: 64 << 10, CheckInterval: time.Millisecond})
+	ctx := context.Background()
+
+	checks := map[string]func() (any, error){
+		"page content": func() (any, error) {
+			return pr.GetPageContentFormatted(0, 1, 1)
+		},
+		"repeated page content": func() (any, error) {
+			return pr.GetPageContentFormatted(0, 46, 1)
+		},
+		"page headers": func() (any, error) {
+			return pr.GetPageMetadataList(0, 3)
+		},
+		"page structure": func() (any, error) {
+			return pr.GetPageStructure(0, 1, 1)
+		},
+		"dictionary": func() (any, error) {
+			return pr.AnalyzeDictionary(ctx, 1)
+		},
+		"file layout": func() (any, error) {
+			return pr.GetFileLayout()
+		},
+		"record": func() (any, error) {
+			return pr.GetRecord(2)
+		},
+		"row location": func() (any, error) {
+			return pr.GetRowLocation(ctx, 3, nil)
+		},
+		"sample": func() (any, error) {
+			return pr.SampleRows(ctx, SampleOptions{N: 2, Seed: 5, Columns: []int{1, 46}})
+		},
+		"profile": func() (any, error) {
+			return pr.ProfileColumn(ctx, 1, ProfileOptions{})
+		},
+	}
+
+	want := map[string]any{}
+	for name, check := range checks {
+		got, err := check()
+		require.NoError(t, err, name)
+		want[name] = got
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 3 {
+				for name, check := range checks {
+					got, err := check()
+					if assert.NoError(t, err, name) {
+						assert.Equal(t, want[name], got, name)
+					}
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,549 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/types"
+)
+
+// Profile value kinds
+const (
+	ProfileKindNumeric   = "NUMERIC"
+	ProfileKindString    = "STRING"
+	ProfileKindBinary    = "BINARY"
+	ProfileKindTimestamp = "TIMESTAMP"
+	ProfileKindBoolean   = "BOOLEAN"
+	ProfileKindOther     = "OTHER"
+)
+
+const (
+	defaultProfileTopK             = 10
+	defaultProfileHistogramBuckets = 20
+	defaultProfileSampleSize       = 10000
+	profileBatchRows               = 10000
+)
+
+// profileQuantiles are the quantiles reported for numeric columns
+var profileQuantiles = []float64{0.01, 0.05, 0.25, 0.5, 0.75, 0.95, 0.99}
+
+// timestampGapBuckets are the upper bounds of the gap distribution buckets
+var timestampGapBuckets = []struct {
+	label string
+	upper time.Duration
+}{
+	{"0", 1},
+	{"< 1ms", time.Millisecond},
+	{"< 1s", time.Second},
+	{"< 1m", time.Minute},
+	{"< 1h", time.Hour},
+	{"< 1d", 24 * time.Hour},
+	{"< 30d", 30 * 24 * time.Hour},
+	{">= 30d", time.Duration(math.MaxInt64)},
+}
+
+// ValueCount is a value together with its number of occurrences
+type ValueCount struct {
+	Value string
+	Count int64
+}
+
+// HistogramBucket is one bucket of a histogram covering [Lower, Upper)
+type HistogramBucket struct {
+	Lower float64
+	Upper float64
+	Count int64
+}
+
+// QuantileValue is the value at a given quantile
+type QuantileValue struct {
+	Quantile float64
+	Value    float64
+}
+
+// LengthProfile describes the distribution of string or binary value lengths
+// in bytes; buckets are powers of two
+type LengthProfile struct {
+	Min     int
+	Max     int
+	Mean    float64
+	Buckets []HistogramBucket
+}
+
+// FormatLengthBucket labels a length bucket by the lengths it holds, from
+// Lower to Upper-1 bytes, or a single length when that is all it holds
+func FormatLengthBucket(b HistogramBucket) string {
+	lower, upper := int64(b.Lower), int64(b.Upper)-1
+	if lower >= upper {
+		return fmt.Sprintf("%d", lower)
+	}
+	return fmt.Sprintf("%d – %d", lower, upper)
+}
+
+// DurationBucket counts timestamp gaps that fall into a labelled range
+type DurationBucket struct {
+	Label string
+	Count int64
+}
+
+// TimestampProfile describes the range of a temporal column and the gaps
+// between consecutive non-null values in file order
+type TimestampProfile struct {
+	Min  string
+	Max  string
+	Span string
+	Gaps []DurationBucket
+}
+
+// ColumnProfile summarizes the values of one column across all row groups.
+// DistinctCount is a HyperLogLog estimate, TopValues are approximate for
+// high-cardinality columns, and Histogram/Quantiles are computed from a uniform
+// sample of SampleSize values.
+type ColumnProfile struct {
+	ColumnIndex   int
+	Path          string
+	PhysicalType  string
+	LogicalType   string
+	ConvertedType string
+	Kind          string
+	NumRowGroups  int
+	TotalValues   int64
+	NullCount     int64
+	NullRatio     float64
+	DistinctCount uint64
+	MinValue      string
+	MaxValue      string
+	TopValues     []ValueCount
+	SampleSize    int
+	Histogram     []HistogramBucket `json:",omitempty"`
+	Quantiles     []QuantileValue   `json:",omitempty"`
+	StringLengths *LengthProfile    `json:",omitempty"`
+	Timestamps    *TimestampProfile `json:",omitempty"`
+}
+
+// ProfileProgress reports how far a profile run has got
+type ProfileProgress struct {
+	RowGroup     int
+	NumRowGroups int
+	RowsRead     int64
+	TotalRows    int64
+}
+
+// ProfileOptions controls a profile run; zero values select defaults
+type ProfileOptions struct {
+	TopK             int
+	HistogramBuckets int
+	SampleSize       int
+	// Progress, if set, is called after every batch of rows is processed
+	Progress func(ProfileProgress)
+}
+
+// ProfileColumn reads every value of a column across all row groups and
+// computes its profile. The run stops with ctx.Err() when ctx is cancelled.
+func (pr *ParquetReader) ProfileColumn(ctx context.Context, colIndex int, opts ProfileOptions) (ColumnProfile, error) {
+	if pr == nil || pr.metadata == nil {
+		return ColumnProfile{}, ErrInvalidColumnIndex
+	}
+	if len(pr.metadata.RowGroups) == 0 {
+		return ColumnProfile{}, fmt.Errorf("column index %d out of range [0, 0): %w", colIndex, ErrInvalidColumnIndex)
+	}
+	pr = pr.WithContext(ctx)
+	numColumns := len(pr.metadata.RowGroups[0].Columns)
+	if colIndex < 0 || colIndex >= numColumns {
+		return ColumnProfile{}, fmt.Errorf("column index %d out of range [0, %d): %w",
+			colIndex, numColumns, ErrInvalidColumnIndex)
+	}
+
+	if opts.TopK <= 0 {
+		opts.TopK = defaultProfileTopK
+	}
+	if opts.HistogramBuckets <= 0 {
+		opts.HistogramBuckets = defaultProfileHistogramBuckets
+	}
+	if opts.SampleSize <= 0 {
+		opts.SampleSize = defaultProfileSampleSize
+	}
+
+	meta := pr.metadata.RowGroups[0].Columns[colIndex].MetaData
+	schemaElem := pr.schemaElement(colIndex)
+	p := newColumnProfiler(meta.Type, schemaElem, opts)
+	p.display = pr.display
+	p.decoder = pr.decoderFor(colIndex)
+
+	profile := ColumnProfile{
+		ColumnIndex:   colIndex,
+		Path:          pr.columnName(colIndex),
+		PhysicalType:  meta.Type.String(),
+		LogicalType:   "-",
+		ConvertedType: "-",
+		Kind:          p.kind,
+		NumRowGroups:  len(pr.metadata.RowGroups),
+	}
+	if schemaElem != nil {
+		profile.LogicalType = formatLogicalType(schemaElem.LogicalType)
+		if schemaElem.ConvertedType != nil {
+			profile.ConvertedType = schemaElem.ConvertedType.String()
+		}
+	}
+
+	columnReader, err := pr.newColumnReader(IOProfile, 1)
+	if err != nil {
+		return ColumnProfile{}, err
+	}
+	defer pr.closeColumnReader(columnReader)
+
+	progress := ProfileProgress{NumRowGroups: len(pr.metadata.RowGroups), TotalRows: pr.metadata.NumRows}
+	for rgIndex, rg := range pr.metadata.RowGroups {
+		progress.RowGroup = rgIndex
+		for remaining := rg.NumRows; remaining > 0; {
+			if err := ctx.Err(); err != nil {
+				return ColumnProfile{}, err
+			}
+
+			batch := min(remaining, int64(profileBatchRows))
+			values, _, _, err := columnReader.ReadColumnByIndex(int64(colIndex), batch)
+			if err != nil {
+				return ColumnProfile{}, fmt.Errorf("failed to read row group %d: %w", rgIndex, contextErr(ctx, err))
+			}
+			for _, v := range values {
+				p.add(v)
+			}
+
+			remaining -= batch
+			progress.RowsRead += batch
+			if opts.Progress != nil {
+				opts.Progress(progress)
+			}
+		}
+	}
+
+	p.finish(&profile)
+	return profile, nil
+}
+
+// columnProfiler accumulates statistics for one column
+type columnProfiler struct {
+	physicalType parquet.Type
+	schemaElem   *parquet.SchemaElement
+	kind         string
+	opts         ProfileOptions
+	display      DisplayOptions // how top values and min/max are shown
+	decoder      ValueDecoder   // custom decoder of the values, may be nil
+
+	total  int64
+	nulls  int64
+	hll    *hyperLogLog
+	topK   *topKCounter
+	sample *reservoirSample
+
+	// min/max tracking; numeric and timestamp kinds compare by value, the rest
+	// compare by their formatted representation
+	hasMinMax bool
+	minNum    float64
+	maxNum    float64
+	minTime   time.Time
+	maxTime   time.Time
+	minStr    string
+	maxStr    string
+
+	lengths     *LengthProfile
+	lengthTotal int64
+	lengthCount int64
+
+	lastTime time.Time
+	hasLast  bool
+	gaps     []DurationBucket
+}
+
+// newColumnProfiler creates a profiler for the given column type
+func newColumnProfiler(physicalType parquet.Type, schemaElem *parquet.SchemaElement, opts ProfileOptions) *columnProfiler {
+	p := &columnProfiler{
+		physicalType: physicalType,
+		schemaElem:   schemaElem,
+		kind:         profileKind(physicalType, schemaElem),
+		opts:         opts,
+		hll:          newHyperLogLog(),
+		topK:         newTopKCounter(opts.TopK * 10),
+		sample:       newReservoirSample(opts.SampleSize),
+	}
+	switch p.kind {
+	case ProfileKindString, ProfileKindBinary:
+		p.lengths = &LengthProfile{Min: math.MaxInt}
+	case ProfileKindTimestamp:
+		p.gaps = make([]DurationBucket, len(timestampGapBuckets))
+		for i, b := range timestampGapBuckets {
+			p.gaps[i].Label = b.label
+		}
+	}
+	return p
+}
+
+// profileKind classifies a column for profiling purposes
+func profileKind(physicalType parquet.Type, se *parquet.SchemaElement) string {
+	if physicalType == parquet.Type_INT96 {
+		return ProfileKindTimestamp
+	}
+	if physicalType == parquet.Type_BOOLEAN {
+		return ProfileKindBoolean
+	}
+
+	if se != nil && se.LogicalType != nil {
+		lt := se.LogicalType
+		switch {
+		case lt.IsSetTIMESTAMP(), lt.IsSetDATE():
+			return ProfileKindTimestamp
+		case lt.IsSetSTRING(), lt.IsSetENUM(), lt.IsSetJSON():
+			return ProfileKindString
+		case lt.IsSetDECIMAL(), lt.IsSetINTEGER(), lt.IsSetFLOAT16():
+			return ProfileKindNumeric
+		case lt.IsSetTIME(), lt.IsSetUUID(), lt.IsSetBSON(), lt.IsSetVARIANT(),
+			lt.IsSetGEOMETRY(), lt.IsSetGEOGRAPHY(), lt.IsSetMAP(), lt.IsSetLIST():
+			return ProfileKindOther
+		}
+	}
+	if se != nil && se.ConvertedType != nil {
+		switch *se.ConvertedType {
+		case parquet.ConvertedType_TIMESTAMP_MILLIS, parquet.ConvertedType_TIMESTAMP_MICROS, parquet.ConvertedType_DATE:
+			return ProfileKindTimestamp
+		case parquet.ConvertedType_UTF8, parquet.ConvertedType_ENUM, parquet.ConvertedType_JSON:
+			return ProfileKindString
+		case parquet.ConvertedType_DECIMAL:
+			return ProfileKindNumeric
+		case parquet.ConvertedType_INTERVAL, parquet.ConvertedType_BSON,
+			parquet.ConvertedType_TIME_MILLIS, parquet.ConvertedType_TIME_MICROS:
+			return ProfileKindOther
+		}
+	}
+
+	switch physicalType {
+	case parquet.Type_INT32, parquet.Type_INT64, parquet.Type_FLOAT, parquet.Type_DOUBLE:
+		return ProfileKindNumeric
+	case parquet.Type_BYTE_ARRAY, parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		return ProfileKindBinary
+	}
+	return ProfileKindOther
+}
+
+// add records one raw value as returned by the column reader
+func (p *columnProfiler) add(raw any) {
+	p.total++
+	if raw == nil {
+		p.nulls++
+		return
+	}
+
+	key := formatValue(p.display, p.decoder, raw, p.physicalType, p.schemaElem)
+	p.hll.Add(key)
+	p.topK.Add(key)
+
+	switch p.kind {
+	case ProfileKindNumeric:
+		if f, ok := toFloat64(types.ConvertToJSONType(raw, p.elem(), geospatialOpt)); ok && !math.IsNaN(f) {
+			p.addNumeric(f)
+			return
+		}
+	case ProfileKindTimestamp:
+		if t, ok := p.toTime(raw); ok {
+			p.addTime(t)
+			return
+		}
+	case ProfileKindString, ProfileKindBinary:
+		if s, ok := raw.(string); ok {
+			p.addLength(len(s))
+		}
+	}
+	p.addString(key)
+}
+
+// elem returns the schema element used for type conversion
+func (p *columnProfiler) elem() *parquet.SchemaElement {
+	if p.schemaElem != nil {
+		return p.schemaElem
+	}
+	return &parquet.SchemaElement{Type: &p.physicalType}
+}
+
+func (p *columnProfiler) addNumeric(f float64) {
+	p.sample.Add(f)
+	if !p.hasMinMax || f < p.minNum {
+		p.minNum = f
+	}
+	if !p.hasMinMax || f > p.maxNum {
+		p.maxNum = f
+	}
+	p.hasMinMax = true
+}
+
+func (p *columnProfiler) addTime(t time.Time) {
+	if !p.hasMinMax || t.Before(p.minTime) {
+		p.minTime = t
+	}
+	if !p.hasMinMax || t.After(p.maxTime) {
+		p.maxTime = t
+	}
+	p.hasMinMax = true
+
+	if p.hasLast {
+		gap := t.Sub(p.lastTime)
+		if gap < 0 {
+			gap = -gap
+		}
+		for i, b := range timestampGapBuckets {
+			if gap < b.upper || i == len(timestampGapBuckets)-1 {
+				p.gaps[i].Count++
+				break
+			}
+		}
+	}
+	p.lastTime = t
+	p.hasLast = true
+}
+
+func (p *columnProfiler) addString(s string) {
+	if !p.hasMinMax || s < p.minStr {
+		p.minStr = s
+	}
+	if !p.hasMinMax || s > p.maxStr {
+		p.maxStr = s
+	}
+	p.hasMinMax = true
+}
+
+func (p *columnProfiler) addLength(n int) {
+	p.lengths.Min = min(p.lengths.Min, n)
+	p.lengths.Max = max(p.lengths.Max, n)
+	p.lengthTotal += int64(n)
+	p.lengthCount++
+
+	// Bucket 0 holds empty values, bucket i holds lengths in [2^(i-1), 2^i)
+	idx := 0
+	for v := n; v > 0; v >>= 1 {
+		idx++
+	}
+	for len(p.lengths.Buckets) <= idx {
+		i := len(p.lengths.Buckets)
+		lower, upper := 0.0, 1.0
+		if i > 0 {
+			lower, upper = math.Ldexp(1, i-1), math.Ldexp(1, i)
+		}
+		p.lengths.Buckets = append(p.lengths.Buckets, HistogramBucket{Lower: lower, Upper: upper})
+	}
+	p.lengths.Buckets[idx].Count++
+}
+
+// toTime converts a raw temporal value into a time.Time
+func (p *columnProfiler) toTime(raw any) (time.Time, bool) {
+	if p.physicalType == parquet.Type_INT96 {
+		s, ok := raw.(string)
+		if !ok {
+			return time.Time{}, false
+		}
+		t, err := types.INT96ToTime(s)
+		return t, err == nil
+	}
+
+	se := p.schemaElem
+	switch v := raw.(type) {
+	case int32:
+		// DATE: days since the Unix epoch
+		return time.Unix(int64(v)*86400, 0).UTC(), true
+	case int64:
+		if se != nil && se.LogicalType != nil && se.LogicalType.IsSetTIMESTAMP() {
+			unit := se.LogicalType.TIMESTAMP.Unit
+			switch {
+			case unit != nil && unit.IsSetNANOS():
+				return time.Unix(0, v).UTC(), true
+			case unit != nil && unit.IsSetMICROS():
+				return time.UnixMicro(v).UTC(), true
+			}
+			return time.UnixMilli(v).UTC(), true
+		}
+		if se != nil && se.ConvertedType != nil && *se.ConvertedType == parquet.ConvertedType_TIMESTAMP_MICROS {
+			return time.UnixMicro(v).UTC(), true
+		}
+		return time.UnixMilli(v).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// finish fills the derived fields of the profile
+func (p *columnProfiler) finish(profile *ColumnProfile) {
+	profile.TotalValues = p.total
+	profile.NullCount = p.nulls
+	if p.total > 0 {
+		profile.NullRatio = float64(p.nulls) / float64(p.total)
+	}
+	profile.DistinctCount = p.hll.Estimate()
+	profile.TopValues = p.topK.Top(p.opts.TopK)
+	profile.MinValue, profile.MaxValue = "-", "-"
+
+	switch {
+	case p.kind == ProfileKindNumeric && p.sample.seen > 0:
+		profile.MinValue = formatProfileFloat(p.minNum)
+		profile.MaxValue = formatProfileFloat(p.maxNum)
+		sorted := p.sample.Sorted()
+		profile.SampleSize = len(sorted)
+		profile.Histogram = equiWidthHistogram(sorted, p.opts.HistogramBuckets)
+		profile.Quantiles = make([]QuantileValue, len(profileQuantiles))
+		for i, q := range profileQuantiles {
+			profile.Quantiles[i] = QuantileValue{Quantile: q, Value: quantile(sorted, q)}
+		}
+	case p.kind == ProfileKindTimestamp && p.hasLast:
+		profile.MinValue = p.minTime.Format(time.RFC3339Nano)
+		profile.MaxValue = p.maxTime.Format(time.RFC3339Nano)
+		profile.Timestamps = &TimestampProfile{
+			Min:  profile.MinValue,
+			Max:  profile.MaxValue,
+			Span: p.maxTime.Sub(p.minTime).String(),
+			Gaps: p.gaps,
+		}
+	case p.hasMinMax:
+		profile.MinValue = p.minStr
+		profile.MaxValue = p.maxStr
+	}
+
+	if p.lengths != nil && p.lengthCount > 0 {
+		p.lengths.Mean = float64(p.lengthTotal) / float64(p.lengthCount)
+		profile.StringLengths = p.lengths
+	}
+}
+
+// toFloat64 converts a JSON-typed numeric value to float64
+func toFloat64(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// formatProfileFloat formats a float without trailing zeros
+func formatProfileFloat(f float64) string {
+	s := fmt.Sprintf("%.6f", f)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
@@ -0,0 +1,195 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestParquetReader(t *testing.T) *ParquetReader {
+	t.Helper()
+	pr, err := pio.NewParquetFileReader(getTestParquetFilePath(), pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pr.ReadStop() })
+	return NewParquetReader(pr)
+}
+
+func Test_ColumnIndexByPath(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	idx, err := pr.ColumnIndexByPath("Int32")
+	require.NoError(t, err)
+	require.Equal(t, 1, idx)
+
+	idx, err = pr.ColumnIndexByPath("Map.Key_value.Key")
+	require.NoError(t, err)
+	require.Equal(t, 46, idx)
+
+	_, err = pr.ColumnIndexByPath("NoSuchColumn")
+	require.ErrorIs(t, err, ErrInvalidColumnPath)
+
+	// Paths match exactly, not by case or by leaf name alone
+	_, err = pr.ColumnIndexByPath("int32")
+	require.ErrorIs(t, err, ErrInvalidColumnPath)
+	_, err = pr.ColumnIndexByPath("Key")
+	require.ErrorIs(t, err, ErrInvalidColumnPath)
+
+	var nilReader *ParquetReader
+	_, err = nilReader.ColumnIndexByPath("Int32")
+	require.ErrorIs(t, err, ErrInvalidColumnPath)
+}
+
+func Test_ProfileColumn(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	testCases := map[string]struct {
+		path     string
+		kind     string
+		min, max string
+		check    func(t *testing.T, p ColumnProfile)
+	}{
+		"int32": {
+			path: "Int32", kind: ProfileKindNumeric, min: "0", max: "4",
+			check: func(t *testing.T, p ColumnProfile) {
+				require.Equal(t, 5, p.SampleSize)
+				require.NotEmpty(t, p.Histogram)
+				require.Len(t, p.Quantiles, len(profileQuantiles))
+				require.Equal(t, 2.0, p.Quantiles[3].Value)
+			},
+		},
+		"decimal": {path: "Decimal1", kind: ProfileKindNumeric, min: "0", max: "44.44"},
+		"string": {
+			path: "Utf8", kind: ProfileKindString, min: "UTF8-0", max: "UTF8-4",
+			check: func(t *testing.T, p ColumnProfile) {
+				require.NotNil(t, p.StringLengths)
+				require.Equal(t, 6, p.StringLengths.Min)
+				require.Equal(t, 6, p.StringLengths.Max)
+				require.Equal(t, 6.0, p.StringLengths.Mean)
+			},
+		},
+		"timestamp": {
+			path: "TimestampMillis", kind: ProfileKindTimestamp,
+			min: "2022-01-01T00:00:00Z", max: "2022-01-01T00:00:00.004Z",
+			check: func(t *testing.T, p ColumnProfile) {
+				require.NotNil(t, p.Timestamps)
+				require.Equal(t, "4ms", p.Timestamps.Span)
+				var gaps int64
+				for _, g := range p.Timestamps.Gaps {
+					gaps += g.Count
+				}
+				require.Equal(t, int64(4), gaps)
+			},
+		},
+		"int96":   {path: "Int96", kind: ProfileKindTimestamp, min: "2022-01-01T00:00:00Z"},
+		"boolean": {path: "Bool", kind: ProfileKindBoolean, min: "false", max: "true"},
+		"binary":  {path: "ByteArray", kind: ProfileKindBinary},
+		"nested":  {path: "Map.Key_value.Value", kind: ProfileKindNumeric, min: "0", max: "3"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			colIndex, err := pr.ColumnIndexByPath(tc.path)
+			require.NoError(t, err)
+
+			profile, err := pr.ProfileColumn(context.Background(), colIndex, ProfileOptions{})
+			require.NoError(t, err)
+			require.Equal(t, tc.path, profile.Path)
+			require.Equal(t, tc.kind, profile.Kind)
+			require.Equal(t, 1, profile.NumRowGroups)
+			require.Positive(t, profile.TotalValues)
+			require.Equal(t, float64(profile.NullCount)/float64(profile.TotalValues), profile.NullRatio)
+			require.Positive(t, profile.DistinctCount)
+			require.NotEmpty(t, profile.TopValues)
+			if tc.min != "" {
+				require.Equal(t, tc.min, profile.MinValue)
+			}
+			if tc.max != "" {
+				require.Equal(t, tc.max, profile.MaxValue)
+			}
+			if tc.check != nil {
+				tc.check(t, profile)
+			}
+		})
+	}
+}
+
+func Test_ProfileColumn_Progress(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	var updates []ProfileProgress
+	_, err := pr.ProfileColumn(context.Background(), 0, ProfileOptions{
+		Progress: func(p ProfileProgress) { updates = append(updates, p) },
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, updates)
+	last := updates[len(updates)-1]
+	require.Equal(t, last.TotalRows, last.RowsRead)
+}
+
+func Test_ProfileColumn_Cancelled(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := pr.ProfileColumn(ctx, 0, ProfileOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_ProfileColumn_InvalidIndex(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	_, err := pr.ProfileColumn(context.Background(), -1, ProfileOptions{})
+	require.ErrorIs(t, err, ErrInvalidColumnIndex)
+	_, err = pr.ProfileColumn(context.Background(), 999, ProfileOptions{})
+	require.ErrorIs(t, err, ErrInvalidColumnIndex)
+
+	var nilReader *ParquetReader
+	_, err = nilReader.ProfileColumn(context.Background(), 0, ProfileOptions{})
+	require.ErrorIs(t, err, ErrInvalidColumnIndex)
+}
+
+func Test_profileKind(t *testing.T) {
+	int32Type := parquet.Type_INT32
+	utf8 := parquet.ConvertedType_UTF8
+	interval := parquet.ConvertedType_INTERVAL
+
+	testCases := map[string]struct {
+		physicalType parquet.Type
+		se           *parquet.SchemaElement
+		expected     string
+	}{
+		"int96":          {parquet.Type_INT96, nil, ProfileKindTimestamp},
+		"bool":           {parquet.Type_BOOLEAN, nil, ProfileKindBoolean},
+		"plain-int":      {parquet.Type_INT32, nil, ProfileKindNumeric},
+		"plain-binary":   {parquet.Type_BYTE_ARRAY, nil, ProfileKindBinary},
+		"utf8":           {parquet.Type_BYTE_ARRAY, &parquet.SchemaElement{ConvertedType: &utf8}, ProfileKindString},
+		"interval":       {parquet.Type_FIXED_LEN_BYTE_ARRAY, &parquet.SchemaElement{ConvertedType: &interval}, ProfileKindOther},
+		"date-logical":   {parquet.Type_INT32, &parquet.SchemaElement{Type: &int32Type, LogicalType: &parquet.LogicalType{DATE: parquet.NewDateType()}}, ProfileKindTimestamp},
+		"uuid-logical":   {parquet.Type_FIXED_LEN_BYTE_ARRAY, &parquet.SchemaElement{LogicalType: &parquet.LogicalType{UUID: parquet.NewUUIDType()}}, ProfileKindOther},
+		"float16":        {parquet.Type_FIXED_LEN_BYTE_ARRAY, &parquet.SchemaElement{LogicalType: &parquet.LogicalType{FLOAT16: parquet.NewFloat16Type()}}, ProfileKindNumeric},
+		"string-logical": {parquet.Type_BYTE_ARRAY, &parquet.SchemaElement{LogicalType: &parquet.LogicalType{STRING: parquet.NewStringType()}}, ProfileKindString},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, profileKind(tc.physicalType, tc.se))
+		})
+	}
+}
+
+func Test_FormatLengthBucket(t *testing.T) {
+	require.Equal(t, "0", FormatLengthBucket(HistogramBucket{Lower: 0, Upper: 1}))
+	require.Equal(t, "1", FormatLengthBucket(HistogramBucket{Lower: 1, Upper: 2}))
+	require.Equal(t, "2 – 3", FormatLengthBucket(HistogramBucket{Lower: 2, Upper: 4}))
+	require.Equal(t, "8 – 15", FormatLengthBucket(HistogramBucket{Lower: 8, Upper: 16}))
+}
+
+func Test_formatProfileFloat(t *testing.T) {
+	require.Equal(t, "0", formatProfileFloat(0))
+	require.Equal(t, "44.44", formatProfileFloat(44.44))
+	require.Equal(t, "-3", formatProfileFloat(-3))
+	require.Equal(t, "0.000001", formatProfileFloat(0.000001))
+}
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -37,12 +39,15 @@ type RowGroupInfo struct {
 // ColumnChunkInfo contains metadata about a column chunk
 type ColumnChunkInfo struct {
 	Index            int
+	RowGroup         int
+	Path             string // dotted path of the column, stable across column reordering
 	PathInSchema     []string
 	Name             string
 	PhysicalType     string
 	LogicalType      string
 	ConvertedType    string
 	Codec            string
+	Encodings        []string
 	NumValues        int64
 	NullCount        *int64
 	CompressedSize   int64
@@ -50,6 +55,13 @@ type ColumnChunkInfo struct {
 	CompressionRatio float64
 	MinValue         string // Formatted for display
 	MaxValue         string // Formatted for display
+	HasStatistics    bool
+	HasDictionary    bool
+	SizeStatistics   *SizeStatistics
+	// Bounding box and geometry types, GEOMETRY and GEOGRAPHY columns only
+	GeospatialStatistics *GeospatialStatistics
+	// Enclosing VARIANT group and the role of this column in it, if any
+	Variant *VariantMember
 	// Formatted fields for display (kept for backward compatibility)
 	CompressedSizeFormatted   string `json:"compressedSizeFormatted,omitempty"`
 	UncompressedSizeFormatted string `json:"uncompressedSizeFormatted,omitempty"`
@@ -60,6 +72,7 @@ type ColumnChunkInfo struct {
 // PageMetadata contains metadata about a page
 type PageMetadata struct {
 	Index            int
+	Path             string // dotted path of the column
 	Offset           int64
 	PageType         string
 	CompressedSize   int32
@@ -73,6 +86,7 @@ type PageMetadata struct {
 	MinValue         string // Formatted for display
 	MaxValue         string // Formatted for display
 	NullCount        *int64
+	SizeStatistics   *SizeStatistics // From the column and offset indexes, data pages only
 	// Formatted fields for display (kept for backward compatibility)
 	CompressedSizeFormatted   string `json:"compressedSizeFormatted,omitempty"`
 	UncompressedSizeFormatted string `json:"uncompressedSizeFormatted,omitempty"`
@@ -84,14 +98,62 @@ type PageMetadata struct {
 type ParquetReader struct {
 	Reader   *reader.ParquetReader
 	metadata *parquet.FileMetaData
+	display  DisplayOptions   // how values are formatted
+	decoders *DecoderRegistry // custom decoders of binary columns, may be nil
+	// Leaf columns in column chunk order, their dotted paths and their
+	// indexes by path, built once from the footer schema. Every path a
+	// response shows and every lookup by path goes through these.
+	columns     []*schemaNode
+	columnNames []string
+	columnPaths map[string]int // -1 for a path shared by several columns
+	// Readers of the file lent to one caller at a time, shared by the
+	// copies made with other display options or decoders
+	pool *readerPool
+	// What was read from the file, nil when not cached
+	cache *PageCache
+	// How small reads of the file are read ahead
+	prefetch PrefetchOptions
+	// Context the reads run in, nil for reads that are never cancelled
+	ctx context.Context
 }
 
 // NewParquetReader creates a new ParquetReader
 func NewParquetReader(r *reader.ParquetReader) *ParquetReader {
-	return &ParquetReader{
+	pr := &ParquetReader{
 		Reader:   r,
 		metadata: r.Footer,
+		pool:     newReaderPool(r, nil, 1),
+	}
+	if r.Footer != nil {
+		if root := buildSchemaTree(r.Footer.Schema); root != nil {
+			pr.columns = root.leaves()
+		}
+	}
+	pr.columnNames = make([]string, len(pr.columns))
+	pr.columnPaths = make(map[string]int, len(pr.columns))
+	for i, column := range pr.columns {
+		name := formatColumnName(column.path)
+		pr.columnNames[i] = name
+		if _, ok := pr.columnPaths[name]; ok {
+			pr.columnPaths[name] = -1
+		} else {
+			pr.columnPaths[name] = i
+		}
 	}
+	return pr
+}
+
+// WithDisplayOptions returns a reader over the same file that formats values
+// with the given display options
+func (pr *ParquetReader) WithDisplayOptions(opts DisplayOptions) *ParquetReader {
+	copied := *pr
+	copied.display = opts
+	return &copied
+}
+
+// DisplayOptions returns the display options values are formatted with
+func (pr *ParquetReader) DisplayOptions() DisplayOptions {
+	return pr.display
 }
 
 // GetFileInfo extracts file-level information
@@ -248,14 +310,26 @@ func (pr *ParquetReader) GetColumnChunkInfo(rgIndex, colIndex int) (ColumnChunkI
 	meta := col.MetaData
 
 	info := ColumnChunkInfo{
-		Index:            colIndex,
-		PathInSchema:     meta.PathInSchema,
-		Name:             formatColumnName(meta.PathInSchema),
-		PhysicalType:     meta.Type.String(),
-		Codec:            meta.Codec.String(),
-		NumValues:        meta.NumValues,
-		CompressedSize:   meta.TotalCompressedSize,
-		UncompressedSize: meta.TotalUncompressedSize,
+		Index:                colIndex,
+		RowGroup:             rgIndex,
+		Path:                 pr.columnName(colIndex),
+		PathInSchema:         meta.PathInSchema,
+		Name:                 pr.columnName(colIndex),
+		PhysicalType:         meta.Type.String(),
+		Codec:                meta.Codec.String(),
+		NumValues:            meta.NumValues,
+		CompressedSize:       meta.TotalCompressedSize,
+		UncompressedSize:     meta.TotalUncompressedSize,
+		SizeStatistics:       convertSizeStatistics(meta.SizeStatistics),
+		GeospatialStatistics: convertGeospatialStatistics(meta.GeospatialStatistics),
+		Variant:              pr.variantMember(colIndex),
+	}
+
+	// Some writers only record the dictionary encoding, not the page offset
+	info.HasDictionary = meta.DictionaryPageOffset != nil
+	for _, encoding := range meta.Encodings {
+		info.Encodings = append(info.Encodings, encoding.String())
+		info.HasDictionary = info.HasDictionary || isDictionaryEncoding(encoding)
 	}
 
 	// Calculate compression ratio
@@ -264,7 +338,7 @@ func (pr *ParquetReader) GetColumnChunkInfo(rgIndex, colIndex int) (ColumnChunkI
 	}
 
 	// Get schema element for logical/converted types
-	schemaElem := findSchemaElement(pr.metadata.Schema, meta.PathInSchema)
+	schemaElem := pr.schemaElement(colIndex)
 	if schemaElem != nil {
 		info.LogicalType = formatLogicalType(schemaElem.LogicalType)
 		info.ConvertedType = "-"
@@ -276,6 +350,7 @@ func (pr *ParquetReader) GetColumnChunkInfo(rgIndex, colIndex int) (ColumnChunkI
 	// Get statistics if available
 	if meta.Statistics != nil {
 		stats := meta.Statistics
+		info.HasStatistics = true
 
 		// Null count
 		info.NullCount = stats.NullCount
@@ -291,8 +366,9 @@ func (pr *ParquetReader) GetColumnChunkInfo(rgIndex, colIndex int) (ColumnChunkI
 		}
 
 		// Format min/max values for display
-		info.MinValue = FormatStatValue(minValueBytes, meta, schemaElem)
-		info.MaxValue = FormatStatValue(maxValueBytes, meta, schemaElem)
+		decoder := pr.decoderFor(colIndex)
+		info.MinValue = formatStatValue(pr.display, decoder, minValueBytes, meta, schemaElem)
+		info.MaxValue = formatStatValue(pr.display, decoder, maxValueBytes, meta, schemaElem)
 		// Keep the formatted fields for backward compatibility
 		info.MinValueFormatted = info.MinValue
 		info.MaxValueFormatted = info.MaxValue
@@ -328,13 +404,75 @@ func (pr *ParquetReader) GetAllColumnChunksInfo(rgIndex int) ([]ColumnChunkInfo,
 	return infos, nil
 }
 
-// formatColumnName creates a display name from path in schema
+// ColumnIndexByPath returns the leaf column index for a dotted column path
+// such as "Map.Key_value.Key", as ColumnPath returns it. The path must match
+// exactly, a path shared by several columns matches none.
+func (pr *ParquetReader) ColumnIndexByPath(path string) (int, error) {
+	if pr == nil {
+		return -1, fmt.Errorf("column %q not found: %w", path, ErrInvalidColumnPath)
+	}
+	colIndex, ok := pr.columnPaths[path]
+	if !ok {
+		return -1, fmt.Errorf("column %q not found: %w", path, ErrInvalidColumnPath)
+	}
+	if colIndex < 0 {
+		return -1, fmt.Errorf("column %q is ambiguous, several columns have that path: %w", path, ErrInvalidColumnPath)
+	}
+	return colIndex, nil
+}
+
+// ColumnPath returns the canonical dotted path of a leaf column
+func (pr *ParquetReader) ColumnPath(colIndex int) (string, error) {
+	if pr == nil || colIndex < 0 || colIndex >= len(pr.columns) {
+		return "", fmt.Errorf("column index %d out of range [0, %d): %w",
+			colIndex, pr.numColumns(), ErrInvalidColumnIndex)
+	}
+	return pr.columnNames[colIndex], nil
+}
+
+// columnName returns the dotted path of a leaf column, empty when the index
+// is out of range
+func (pr *ParquetReader) columnName(colIndex int) string {
+	if colIndex < 0 || colIndex >= len(pr.columnNames) {
+		return ""
+	}
+	return pr.columnNames[colIndex]
+}
+
+// numColumns returns the number of leaf columns in the schema
+func (pr *ParquetReader) numColumns() int {
+	if pr == nil {
+		return 0
+	}
+	return len(pr.columns)
+}
+
+// schemaElement returns the schema element of a leaf column, nil when the
+// index is out of range
+func (pr *ParquetReader) schemaElement(colIndex int) *parquet.SchemaElement {
+	if colIndex < 0 || colIndex >= len(pr.columns) {
+		return nil
+	}
+	return pr.columns[colIndex].elem
+}
+
+// columnNameEscaper escapes the dots of a field name, and the backslashes
+// that escape them
+var columnNameEscaper = strings.NewReplacer(`\`, `\\`, `.`, `\.`)
+
+// formatColumnName creates a display name from path in schema. Dots within a
+// field name are escaped with a backslash, so that a field named "a.b" is
+// not taken for field b of group a.
 func formatColumnName(pathInSchema []string) string {
-	return strings.Join(pathInSchema, ".")
+	names := make([]string, len(pathInSchema))
+	for i, name := range pathInSchema {
+		names[i] = columnNameEscaper.Replace(name)
+	}
+	return strings.Join(names, ".")
 }
 
 // convertPageHeaderInfoToMetadata converts reader.PageHeaderInfo to PageMetadata
-func convertPageHeaderInfoToMetadata(headerInfo reader.PageHeaderInfo, columnMeta *parquet.ColumnMetaData, schemaElem *parquet.SchemaElement) PageMetadata {
+func convertPageHeaderInfoToMetadata(headerInfo reader.PageHeaderInfo, columnMeta *parquet.ColumnMetaData, schemaElem *parquet.SchemaElement, display DisplayOptions, decoder ValueDecoder) PageMetadata {
 	pageInfo := PageMetadata{
 		Index:            headerInfo.Index,
 		Offset:           headerInfo.Offset,
@@ -370,10 +508,10 @@ func convertPageHeaderInfoToMetadata(headerInfo reader.PageHeaderInfo, columnMet
 
 			// Format the values for display
 			if len(minValueBytes) > 0 && columnMeta != nil {
-				pageInfo.MinValue = FormatStatValue(minValueBytes, columnMeta, schemaElem)
+				pageInfo.MinValue = formatStatValue(display, decoder, minValueBytes, columnMeta, schemaElem)
 			}
 			if len(maxValueBytes) > 0 && columnMeta != nil {
-				pageInfo.MaxValue = FormatStatValue(maxValueBytes, columnMeta, schemaElem)
+				pageInfo.MaxValue = formatStatValue(display, decoder, maxValueBytes, columnMeta, schemaElem)
 			}
 		}
 	case parquet.PageType_DICTIONARY_PAGE:
@@ -413,17 +551,27 @@ func (pr *ParquetReader) GetPageMetadataList(rgIndex, colIndex int) ([]PageMetad
 	meta := rg.Columns[colIndex].MetaData
 
 	// Get schema element for formatting
-	schemaElem := findSchemaElement(pr.metadata.Schema, meta.PathInSchema)
+	schemaElem := pr.schemaElement(colIndex)
 
-	pageHeaders, err := pr.Reader.GetAllPageHeaders(rgIndex, colIndex)
+	pageHeaders, err := pr.pageHeaders(rgIndex, colIndex)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert PageHeaderInfo to PageMetadata
+	// Convert PageHeaderInfo to PageMetadata, attaching the indexed size
+	// statistics to data pages in order
+	sizeStats := pr.pageSizeStatistics(rgIndex, colIndex)
+	dataPage := 0
 	pages := make([]PageMetadata, len(pageHeaders))
 	for i, headerInfo := range pageHeaders {
-		pages[i] = convertPageHeaderInfoToMetadata(headerInfo, meta, schemaElem)
+		pages[i] = convertPageHeaderInfoToMetadata(headerInfo, meta, schemaElem, pr.display, pr.decoderFor(colIndex))
+		pages[i].Path = pr.columnName(colIndex)
+		if headerInfo.PageType == parquet.PageType_DATA_PAGE || headerInfo.PageType == parquet.PageType_DATA_PAGE_V2 {
+			if dataPage < len(sizeStats) {
+				pages[i].SizeStatistics = sizeStats[dataPage]
+			}
+			dataPage++
+		}
 	}
 
 	return pages, nil
@@ -447,34 +595,39 @@ func (pr *ParquetReader) GetPageMetadata(rgIndex, colIndex, pageIndex int) (Page
 
 // GetPageContent reads and decodes the values from a specific page
 func (pr *ParquetReader) GetPageContent(rgIndex, colIndex, pageIndex int) ([]interface{}, error) {
+	values, _, _, err := pr.readPageValues(rgIndex, colIndex, pageIndex)
+	return values, err
+}
+
+// readPageValues reads the values of a page with their repetition and
+// definition levels. Dictionary and index pages come without levels.
+func (pr *ParquetReader) readPageValues(rgIndex, colIndex, pageIndex int) ([]interface{}, []int32, []int32, error) {
 	if pr == nil || pr.metadata == nil {
-		return nil, ErrInvalidRowGroupIndex
+		return nil, nil, nil, ErrInvalidRowGroupIndex
 	}
 
 	numRowGroups := len(pr.metadata.RowGroups)
 	if rgIndex < 0 || rgIndex >= numRowGroups {
-		return nil, fmt.Errorf("row group index %d out of range [0, %d): %w",
+		return nil, nil, nil, fmt.Errorf("row group index %d out of range [0, %d): %w",
 			rgIndex, numRowGroups, ErrInvalidRowGroupIndex)
 	}
 
 	rg := pr.metadata.RowGroups[rgIndex]
 	numColumns := len(rg.Columns)
 	if colIndex < 0 || colIndex >= numColumns {
-		return nil, fmt.Errorf("column index %d out of range [0, %d): %w",
+		return nil, nil, nil, fmt.Errorf("column index %d out of range [0, %d): %w",
 			colIndex, numColumns, ErrInvalidColumnIndex)
 	}
 
-	meta := rg.Columns[colIndex].MetaData
-
 	// Get all page metadata to understand page boundaries
 	pages, err := pr.GetPageMetadataList(rgIndex, colIndex)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	numPages := len(pages)
 	if pageIndex < 0 || pageIndex >= numPages {
-		return nil, fmt.Errorf("page index %d out of range [0, %d): %w",
+		return nil, nil, nil, fmt.Errorf("page index %d out of range [0, %d): %w",
 			pageIndex, numPages, ErrInvalidPageIndex)
 	}
 
@@ -486,13 +639,79 @@ func (pr *ParquetReader) GetPageContent(rgIndex, colIndex, pageIndex int) ([]int
 		// Continue with normal data page reading
 	case "DICTIONARY_PAGE":
 		// For dictionary pages, we need to read and decode the dictionary
-		return pr.readDictionaryPageContent(rgIndex, colIndex, pageIndex, pages)
+		values, err := pr.readDictionaryPageContent(rgIndex, colIndex, pageIndex, pages)
+		return values, nil, nil, err
 	default:
 		// For other page types (INDEX_PAGE, etc.), return empty
 		// These pages don't contain user data
-		return []interface{}{}, nil
+		return []interface{}{}, nil, nil, nil
+	}
+
+	key := cacheKey{kind: cachePageValues, rgIndex: rgIndex, colIndex: colIndex, pageIndex: pageIndex}
+	if cached, ok := pr.fromCache(key); ok {
+		page := cached.(pageValues)
+		return page.values, page.rls, page.dls, nil
 	}
 
+	// Only the page is read and decoded, the column reader decodes the
+	// encodings decodePageValues does not
+	values, rls, dls, err := pr.decodePageValues(rgIndex, colIndex, pageIndex)
+	if errors.Is(err, errUnsupportedEncoding) {
+		return pr.readChunkPageValues(rgIndex, colIndex, pageIndex, pages)
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pr.toCache(key, pageValues{values, rls, dls}, valuesSize(values)+int64(len(rls)+len(dls))*4)
+	return values, rls, dls, nil
+}
+
+// readChunkPageValues reads the values of a data page by decoding its whole
+// column chunk. The other data pages of the chunk go to the cache since they
+// were decoded anyway.
+func (pr *ParquetReader) readChunkPageValues(rgIndex, colIndex, pageIndex int, pages []PageMetadata) ([]interface{}, []int32, []int32, error) {
+	allValues, rls, dls, err := pr.readColumnChunk(rgIndex, colIndex)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var requested pageValues
+	var startIdx int64
+	for i, page := range pages {
+		if page.PageType != "DATA_PAGE" && page.PageType != "DATA_PAGE_V2" {
+			continue
+		}
+		endIdx := min(startIdx+int64(page.NumValues), int64(len(allValues)))
+		startIdx = min(startIdx, endIdx)
+		// Each page gets its own copy so that evicting it frees its values
+		values := pageValues{
+			copyOf(allValues[startIdx:endIdx]),
+			copyOf(rls[startIdx:endIdx]),
+			copyOf(dls[startIdx:endIdx]),
+		}
+		if i == pageIndex {
+			requested = values
+		}
+		pr.toCache(cacheKey{kind: cachePageValues, rgIndex: rgIndex, colIndex: colIndex, pageIndex: i},
+			values, valuesSize(values.values)+int64(len(values.rls)+len(values.dls))*4)
+		startIdx = endIdx
+	}
+
+	return requested.values, requested.rls, requested.dls, nil
+}
+
+// copyOf returns a copy of s in an array of its own, sized to fit
+func copyOf[T any](s []T) []T {
+	copied := make([]T, len(s))
+	copy(copied, s)
+	return copied
+}
+
+// readColumnChunk reads all values of a column chunk with their repetition
+// and definition levels
+func (pr *ParquetReader) readColumnChunk(rgIndex, colIndex int) ([]interface{}, []int32, []int32, error) {
+	meta := pr.metadata.RowGroups[rgIndex].Columns[colIndex].MetaData
+
 	// Calculate rows before this row group
 	var rowsBeforeThisRG int64 = 0
 	for i := 0; i < rgIndex; i++ {
@@ -500,41 +719,34 @@ func (pr *ParquetReader) GetPageContent(rgIndex, colIndex, pageIndex int) ([]int
 	}
 
 	// Create a fresh column reader
-	freshReader, err := reader.NewParquetColumnReader(pr.Reader.PFile, reader.WithNP(4))
+	freshReader, err := pr.newColumnReader(IOColumnChunk, 4)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-	defer func() { _ = freshReader.ReadStop() }()
+	defer pr.closeColumnReader(freshReader)
 
 	// Skip to the beginning of the current row group
 	if rowsBeforeThisRG > 0 {
 		err = freshReader.SkipRows(rowsBeforeThisRG)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, contextErr(pr.context(), err)
 		}
 	}
 
-	// Read ALL values from this column chunk
-	allValues, _, _, err := freshReader.ReadColumnByIndex(int64(colIndex), meta.NumValues)
-	if err != nil {
-		return nil, err
-	}
+	values, rls, dls, err := freshReader.ReadColumnByIndex(int64(colIndex), meta.NumValues)
+	return values, rls, dls, contextErr(pr.context(), err)
+}
 
-	// Calculate the start index for this page
+// pageValueStart returns the index in the column chunk of the first value of
+// the page, counting the values of the data pages before it
+func pageValueStart(pages []PageMetadata, pageIndex int) int64 {
 	var startIdx int64 = 0
 	for i := 0; i < pageIndex; i++ {
 		if pages[i].PageType == "DATA_PAGE" || pages[i].PageType == "DATA_PAGE_V2" {
 			startIdx += int64(pages[i].NumValues)
 		}
 	}
-
-	// Extract values for just this page
-	endIdx := startIdx + int64(pageInfo.NumValues)
-	if endIdx > int64(len(allValues)) {
-		endIdx = int64(len(allValues))
-	}
-
-	return allValues[startIdx:endIdx], nil
+	return startIdx
 }
 
 // readDictionaryPageContent reads and decodes dictionary page values
@@ -542,40 +754,52 @@ func (pr *ParquetReader) readDictionaryPageContent(rgIndex, colIndex, pageIndex
 	rg := pr.metadata.RowGroups[rgIndex]
 	meta := rg.Columns[colIndex].MetaData
 	pageInfo := pages[pageIndex]
+	schemaElem := pr.schemaElement(colIndex)
 
-	values, err := pr.Reader.ReadDictionaryPageValues(pageInfo.Offset, meta.Codec, meta.Type)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read dictionary page: %w", err)
+	header := reader.PageHeaderInfo{
+		PageType:         parquet.PageType_DICTIONARY_PAGE,
+		Offset:           pageInfo.Offset,
+		CompressedSize:   pageInfo.CompressedSize,
+		UncompressedSize: pageInfo.UncompressedSize,
+		NumValues:        pageInfo.NumValues,
 	}
-
-	return values, nil
+	return pr.readDictionaryValues(meta, header, schemaElem)
 }
 
 // GetPageContentFormatted returns pre-formatted string values for display
 // This is the preferred method for frontends to use
 func (pr *ParquetReader) GetPageContentFormatted(rgIndex, colIndex, pageIndex int) ([]string, error) {
-	// Get raw values
-	rawValues, err := pr.GetPageContent(rgIndex, colIndex, pageIndex)
-	if err != nil {
-		return nil, err
-	}
+	return pr.GetPageContentFormattedGeo(rgIndex, colIndex, pageIndex, GeoFormatGeoJSON)
+}
+
+// GetPageContentFormattedGeo is GetPageContentFormatted with GEOMETRY and
+// GEOGRAPHY values rendered in the given format
+func (pr *ParquetReader) GetPageContentFormattedGeo(rgIndex, colIndex, pageIndex int, geoFormat GeoFormat) ([]string, error) {
+	values, _, err := pr.GetPageContentFormattedRange(rgIndex, colIndex, pageIndex, geoFormat, PageRange{})
+	return values, err
+}
 
+// formatValues formats values of a column for display
+func (pr *ParquetReader) formatValues(colIndex int, rawValues []interface{}, geoFormat GeoFormat) []string {
 	// Get column metadata and schema element for formatting
-	rg := pr.metadata.RowGroups[rgIndex]
-	meta := rg.Columns[colIndex].MetaData
-	schemaElem := findSchemaElement(pr.metadata.Schema, meta.PathInSchema)
+	meta := pr.metadata.RowGroups[0].Columns[colIndex].MetaData
+	schemaElem := pr.schemaElement(colIndex)
 
 	// Format each value
+	decoder := pr.decoderFor(colIndex)
 	formattedValues := make([]string, len(rawValues))
 	for i, rawVal := range rawValues {
+		if text, ok := formatDecodedValue(decoder, rawVal); ok {
+			formattedValues[i] = truncateDisplayValue(text)
+			continue
+		}
 		// Handle special case: nil values for STRING logical type should be treated as empty strings
 		// This is because parquet readers may return nil for zero-length BYTE_ARRAY values
 		if rawVal == nil && schemaElem != nil && schemaElem.LogicalType != nil && schemaElem.LogicalType.IsSetSTRING() {
 			formattedValues[i] = ""
 			continue
 		}
-		formattedValues[i] = FormatValue(rawVal, meta.Type, schemaElem)
+		formattedValues[i] = pr.display.FormatGeoValue(rawVal, meta.Type, schemaElem, geoFormat)
 	}
-
-	return formattedValues, nil
+	return formattedValues
 }
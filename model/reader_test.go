@@ -105,6 +105,16 @@ func Test_FormatColumnName(t *testing.T) {
 			path:     []string{},
 			expected: "",
 		},
+		{
+			name:     "Dot in a name",
+			path:     []string{"a.b", "c"},
+			expected: `a\.b.c`,
+		},
+		{
+			name:     "Backslash in a name",
+			path:     []string{`a\`, "b"},
+			expected: `a\\.b`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -115,6 +125,54 @@ func Test_FormatColumnName(t *testing.T) {
 	}
 }
 
+func Test_leafColumnMapping(t *testing.T) {
+	// Leaf names repeat across groups and differ only by case
+	schema := []*parquet.SchemaElement{
+		{Name: "Parquet_go_root", NumChildren: intPtr(3)},
+		{Name: "Key", Type: parquetTypePtr(parquet.Type_INT32)},
+		{Name: "A", NumChildren: intPtr(1)},
+		{Name: "Key", Type: parquetTypePtr(parquet.Type_BYTE_ARRAY)},
+		{Name: "B", NumChildren: intPtr(1)},
+		{Name: "key", Type: parquetTypePtr(parquet.Type_INT64)},
+	}
+	pr := NewParquetReader(&reader.ParquetReader{Footer: &parquet.FileMetaData{Schema: schema}})
+
+	tests := []struct {
+		path     string
+		colIndex int
+		elem     *parquet.SchemaElement
+	}{
+		{"Key", 0, schema[1]},
+		{"A.Key", 1, schema[3]},
+		{"B.key", 2, schema[5]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			colIndex, err := pr.ColumnIndexByPath(tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.colIndex, colIndex)
+			require.Same(t, tt.elem, pr.schemaElement(colIndex))
+			path, err := pr.ColumnPath(colIndex)
+			require.NoError(t, err)
+			require.Equal(t, tt.path, path)
+		})
+	}
+
+	_, err := pr.ColumnIndexByPath("B.Key")
+	require.ErrorIs(t, err, ErrInvalidColumnPath)
+	_, err = pr.ColumnPath(3)
+	require.ErrorIs(t, err, ErrInvalidColumnIndex)
+	require.Nil(t, pr.schemaElement(-1))
+
+	var nilReader *ParquetReader
+	_, err = nilReader.ColumnPath(0)
+	require.ErrorIs(t, err, ErrInvalidColumnIndex)
+
+	empty := NewParquetReader(&reader.ParquetReader{})
+	_, err = empty.ColumnIndexByPath("Key")
+	require.ErrorIs(t, err, ErrInvalidColumnPath)
+}
+
 func Test_ConvertPageHeaderInfoToMetadata(t *testing.T) {
 	t.Run("DATA_PAGE", func(t *testing.T) {
 		headerInfo := reader.PageHeaderInfo{
@@ -129,7 +187,7 @@ func Test_ConvertPageHeaderInfoToMetadata(t *testing.T) {
 			RepLevelEncoding: parquet.Encoding_RLE,
 		}
 
-		result := convertPageHeaderInfoToMetadata(headerInfo, nil, nil)
+		result := convertPageHeaderInfoToMetadata(headerInfo, nil, nil, DisplayOptions{}, nil)
 
 		require.Equal(t, 0, result.Index)
 		require.Equal(t, int64(1000), result.Offset)
@@ -151,7 +209,7 @@ func Test_ConvertPageHeaderInfoToMetadata(t *testing.T) {
 			Encoding:         parquet.Encoding_DELTA_BINARY_PACKED,
 		}
 
-		result := convertPageHeaderInfoToMetadata(headerInfo, nil, nil)
+		result := convertPageHeaderInfoToMetadata(headerInfo, nil, nil, DisplayOptions{}, nil)
 
 		require.Equal(t, 1, result.Index)
 		require.Equal(t, "DATA_PAGE_V2", result.PageType)
@@ -170,7 +228,7 @@ func Test_ConvertPageHeaderInfoToMetadata(t *testing.T) {
 			Encoding:         parquet.Encoding_PLAIN_DICTIONARY,
 		}
 
-		result := convertPageHeaderInfoToMetadata(headerInfo, nil, nil)
+		result := convertPageHeaderInfoToMetadata(headerInfo, nil, nil, DisplayOptions{}, nil)
 
 		require.Equal(t, "DICTIONARY_PAGE", result.PageType)
 		require.Equal(t, int32(50), result.NumValues)
@@ -186,7 +244,7 @@ func Test_ConvertPageHeaderInfoToMetadata(t *testing.T) {
 			UncompressedSize: 256,
 		}
 
-		result := convertPageHeaderInfoToMetadata(headerInfo, nil, nil)
+		result := convertPageHeaderInfoToMetadata(headerInfo, nil, nil, DisplayOptions{}, nil)
 
 		require.Equal(t, 2, result.Index)
 		require.Equal(t, "INDEX_PAGE", result.PageType)
@@ -220,7 +278,7 @@ func Test_ConvertPageHeaderInfoToMetadataWithStatistics(t *testing.T) {
 			Type: parquet.Type_INT32,
 		}
 
-		result := convertPageHeaderInfoToMetadata(headerInfo, columnMeta, nil)
+		result := convertPageHeaderInfoToMetadata(headerInfo, columnMeta, nil, DisplayOptions{}, nil)
 
 		require.Equal(t, int32(100), result.NumValues)
 		require.True(t, result.HasStatistics)
@@ -242,7 +300,7 @@ func Test_ConvertPageHeaderInfoToMetadataWithStatistics(t *testing.T) {
 			Statistics:    stats,
 		}
 
-		result := convertPageHeaderInfoToMetadata(headerInfo, nil, nil)
+		result := convertPageHeaderInfoToMetadata(headerInfo, nil, nil, DisplayOptions{}, nil)
 
 		require.NotNil(t, result.NullCount)
 		require.Equal(t, int64(10), *result.NullCount)
@@ -678,3 +736,47 @@ func Test_ReadDictionaryPageContent_Coverage(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 2, len(values))
 }
+
+func Test_leafColumnMapping_AmbiguousNames(t *testing.T) {
+	// A field named "a.b" next to field b of group a, and two fields named c
+	schema := []*parquet.SchemaElement{
+		{Name: "Parquet_go_root", NumChildren: intPtr(4)},
+		{Name: "a.b", Type: parquetTypePtr(parquet.Type_INT32)},
+		{Name: "a", NumChildren: intPtr(1)},
+		{Name: "b", Type: parquetTypePtr(parquet.Type_INT64)},
+		{Name: "c", Type: parquetTypePtr(parquet.Type_INT32)},
+		{Name: "c", Type: parquetTypePtr(parquet.Type_INT64)},
+	}
+	// The column chunks name their columns differently from the schema,
+	// responses follow the schema as lookups do
+	var columns []*parquet.ColumnChunk
+	for range 4 {
+		columns = append(columns, &parquet.ColumnChunk{MetaData: &parquet.ColumnMetaData{
+			Type:         parquet.Type_INT32,
+			PathInSchema: []string{"other"},
+		}})
+	}
+	pr := NewParquetReader(&reader.ParquetReader{Footer: &parquet.FileMetaData{
+		Schema:    schema,
+		RowGroups: []*parquet.RowGroup{{Columns: columns}},
+	}})
+
+	for colIndex, path := range []string{`a\.b`, "a.b"} {
+		got, err := pr.ColumnIndexByPath(path)
+		require.NoError(t, err)
+		require.Equal(t, colIndex, got)
+		name, err := pr.ColumnPath(colIndex)
+		require.NoError(t, err)
+		require.Equal(t, path, name)
+
+		info, err := pr.GetColumnChunkInfo(0, colIndex)
+		require.NoError(t, err)
+		require.Equal(t, path, info.Path)
+	}
+
+	_, err := pr.ColumnIndexByPath("c")
+	require.ErrorIs(t, err, ErrInvalidColumnPath)
+	require.ErrorContains(t, err, "ambiguous")
+	_, err = pr.ColumnIndexByPath("other")
+	require.ErrorIs(t, err, ErrInvalidColumnPath)
+}
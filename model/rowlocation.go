@@ -0,0 +1,166 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// RowColumnLocation is where one column stores the values of a row
+type RowColumnLocation struct {
+	ColumnIndex       int
+	Path              string
+	PageIndex         int   // index in the page list of the column chunk, dictionary page included
+	PageFirstRowIndex int64 // first row of the page within its row group
+	PageOffset        int64
+	ValueIndex        int // first value of the row in the page content
+	NumValues         int // values of the row, more than one for repeated columns
+}
+
+// RowLocation places a row of the file in its row group and in a page of
+// each requested column
+type RowLocation struct {
+	Row      int64 // within the file
+	RowGroup int
+	RowIndex int64 // within the row group
+	Columns  []RowColumnLocation
+	Record   string // the assembled row as indented JSON, empty unless asked for
+}
+
+// GetRowLocation finds the row group holding a row of the file and, for each
+// of the given columns, the page and the values within it that hold the row.
+// No columns means all columns.
+func (pr *ParquetReader) GetRowLocation(ctx context.Context, row int64, colIndexes []int) (RowLocation, error) {
+	rowPages, err := pr.GetRowPages(ctx, row, row, colIndexes)
+	if err != nil {
+		return RowLocation{}, err
+	}
+	pr = pr.WithContext(ctx)
+
+	location := RowLocation{Row: row, Columns: make([]RowColumnLocation, len(rowPages.Columns))}
+	for i, column := range rowPages.Columns {
+		if err := ctx.Err(); err != nil {
+			return RowLocation{}, err
+		}
+		if len(column.Pages) == 0 {
+			return RowLocation{}, fmt.Errorf("column %s has no page holding row %d: %w", column.Path, row, ErrInvalidRowIndex)
+		}
+
+		page := column.Pages[0]
+		location.RowGroup = page.RowGroup
+		location.RowIndex = row - page.FirstRow + page.FirstRowIndex
+
+		valueIndex, numValues, err := pr.rowValues(page, column.ColumnIndex, row-page.FirstRow)
+		if err != nil {
+			return RowLocation{}, fmt.Errorf("column %s: %w", column.Path, err)
+		}
+		location.Columns[i] = RowColumnLocation{
+			ColumnIndex:       column.ColumnIndex,
+			Path:              column.Path,
+			PageIndex:         page.PageIndex,
+			PageFirstRowIndex: page.FirstRowIndex,
+			PageOffset:        page.Offset,
+			ValueIndex:        valueIndex,
+			NumValues:         numValues,
+		}
+	}
+
+	// Without columns the row group still locates the row
+	if len(location.Columns) == 0 {
+		var rgStart int64
+		for rgIndex, rg := range pr.metadata.RowGroups {
+			if row < rgStart+rg.NumRows {
+				location.RowGroup, location.RowIndex = rgIndex, row-rgStart
+				break
+			}
+			rgStart += rg.NumRows
+		}
+	}
+	return location, nil
+}
+
+// rowValues finds the values of the rowInPage-th row of a data page. Each
+// row of a flat column is one value, rows of repeated columns start at each
+// repetition level of 0.
+func (pr *ParquetReader) rowValues(page RowPage, colIndex int, rowInPage int64) (int, int, error) {
+	meta := pr.metadata.RowGroups[page.RowGroup].Columns[colIndex].MetaData
+	_, maxRep := columnLevels(pr.metadata.Schema, meta.PathInSchema)
+	if maxRep == 0 {
+		return int(rowInPage), 1, nil
+	}
+
+	headers, err := pr.pageHeaders(page.RowGroup, colIndex)
+	if err != nil {
+		return 0, 0, err
+	}
+	if page.PageIndex < 0 || page.PageIndex >= len(headers) {
+		return 0, 0, fmt.Errorf("page index %d out of range [0, %d): %w", page.PageIndex, len(headers), ErrInvalidPageIndex)
+	}
+	levels, err := pr.pageRepetitionLevels(meta, headers[page.PageIndex], maxRep)
+	if err != nil {
+		return 0, 0, fmt.Errorf("page %d: %w", page.PageIndex, err)
+	}
+
+	start, count, ok := rowSpan(levels, rowInPage)
+	if !ok {
+		return 0, 0, fmt.Errorf("page %d has no row %d: %w", page.PageIndex, rowInPage, ErrInvalidRowIndex)
+	}
+	return start, count, nil
+}
+
+// rowSpan finds the first value and the number of values of the
+// rowInPage-th row in the repetition levels of a page
+func rowSpan[L int32 | uint32](levels []L, rowInPage int64) (int, int, bool) {
+	start, rows := -1, int64(-1)
+	for i, level := range levels {
+		if level != 0 {
+			continue
+		}
+		rows++
+		switch rows {
+		case rowInPage:
+			start = i
+		case rowInPage + 1:
+			return start, i - start, true
+		}
+	}
+	if start < 0 {
+		return 0, 0, false
+	}
+	return start, len(levels) - start, true
+}
+
+// GetRecord assembles a row of the file from all its columns and returns it
+// as indented JSON. Values are shown as stored, without display options.
+func (pr *ParquetReader) GetRecord(row int64) (string, error) {
+	if pr == nil || pr.metadata == nil {
+		return "", ErrInvalidRowIndex
+	}
+	if row < 0 || row >= pr.metadata.NumRows {
+		return "", fmt.Errorf("row %d out of range [0, %d): %w", row, pr.metadata.NumRows, ErrInvalidRowIndex)
+	}
+
+	// A fresh reader so that skipping rows leaves the shared one alone
+	rowReader, err := pr.newRowReader(IORows, 4)
+	if err != nil {
+		return "", err
+	}
+	defer pr.closeColumnReader(rowReader)
+
+	if err := rowReader.SkipRows(row); err != nil {
+		return "", contextErr(pr.context(), err)
+	}
+	rows, err := rowReader.ReadByNumber(1)
+	if err != nil {
+		return "", contextErr(pr.context(), err)
+	}
+	if len(rows) == 0 {
+		return "", fmt.Errorf("row %d not read: %w", row, ErrInvalidRowIndex)
+	}
+
+	record, err := json.MarshalIndent(rows[0], "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(record), nil
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRowLocation(t *testing.T) {
+	pr := openTestParquetReader(t)
+	numColumns := countLeafColumns(pr.metadata.Schema)
+
+	t.Run("Values match page content", func(t *testing.T) {
+		for row := range pr.metadata.NumRows {
+			location, err := pr.GetRowLocation(context.Background(), row, nil)
+			require.NoError(t, err)
+			require.Equal(t, row, location.Row)
+			require.Equal(t, 0, location.RowGroup)
+			require.Equal(t, row, location.RowIndex)
+			require.Len(t, location.Columns, numColumns)
+
+			for _, column := range location.Columns {
+				// The row starts at a repetition level of 0 and ends before the next one
+				_, rls, _, err := pr.readPageValues(location.RowGroup, column.ColumnIndex, column.PageIndex)
+				require.NoError(t, err)
+				require.Positive(t, column.NumValues, column.Path)
+				end := column.ValueIndex + column.NumValues
+				require.LessOrEqual(t, end, len(rls), column.Path)
+				require.Equal(t, int32(0), rls[column.ValueIndex], column.Path)
+				for _, rl := range rls[column.ValueIndex+1 : end] {
+					require.NotEqual(t, int32(0), rl, column.Path)
+				}
+				if end < len(rls) {
+					require.Equal(t, int32(0), rls[end], column.Path)
+				}
+			}
+		}
+	})
+
+	t.Run("Repeated column", func(t *testing.T) {
+		location, err := pr.GetRowLocation(context.Background(), 2, []int{46})
+		require.NoError(t, err)
+		require.Len(t, location.Columns, 1)
+		column := location.Columns[0]
+		require.Equal(t, "Map.Key_value.Key", column.Path)
+		require.Equal(t, int64(2), column.PageFirstRowIndex)
+		require.Equal(t, 0, column.ValueIndex)
+		require.Equal(t, 2, column.NumValues)
+	})
+
+	t.Run("Invalid row", func(t *testing.T) {
+		_, err := pr.GetRowLocation(context.Background(), pr.metadata.NumRows, nil)
+		require.ErrorIs(t, err, ErrInvalidRowIndex)
+	})
+
+	t.Run("Invalid column", func(t *testing.T) {
+		_, err := pr.GetRowLocation(context.Background(), 0, []int{-1})
+		require.ErrorIs(t, err, ErrInvalidColumnIndex)
+	})
+
+	t.Run("Nil reader", func(t *testing.T) {
+		var nilReader *ParquetReader
+		_, err := nilReader.GetRowLocation(context.Background(), 0, nil)
+		require.ErrorIs(t, err, ErrInvalidRowIndex)
+	})
+}
+
+func Test_GetRecord(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	record, err := pr.GetRecord(2)
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal([]byte(record), &fields))
+	require.Equal(t, 2.0, fields["Int32"])
+	require.Equal(t, "ByteArray-2", fields["ByteArray"])
+
+	_, err = pr.GetRecord(-1)
+	require.ErrorIs(t, err, ErrInvalidRowIndex)
+	_, err = pr.GetRecord(pr.metadata.NumRows)
+	require.ErrorIs(t, err, ErrInvalidRowIndex)
+
+	var nilReader *ParquetReader
+	_, err = nilReader.GetRecord(0)
+	require.ErrorIs(t, err, ErrInvalidRowIndex)
+}
@@ -0,0 +1,317 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/reader"
+)
+
+// Where the page boundaries of a column chunk were read from
+const (
+	RowPageSourceOffsetIndex = "OFFSET_INDEX"
+	RowPageSourcePageHeaders = "PAGE_HEADERS"
+)
+
+// RowPage is a data page holding some of the looked up rows
+type RowPage struct {
+	RowGroup      int
+	PageIndex     int   // index in the page list of the column chunk, dictionary page included
+	FirstRowIndex int64 // first row of the page within its row group
+	FirstRow      int64 // first row of the page within the file
+	NumRows       int64
+	Offset        int64 // of the page header
+	Size          int64 // page header and body as stored in the file
+}
+
+// RowColumnPages lists the pages of one column holding the looked up rows
+type RowColumnPages struct {
+	ColumnIndex int
+	Path        string
+	Source      string // RowPageSourceOffsetIndex unless some chunk had no offset index
+	Pages       []RowPage
+	// Dictionary pages of the row groups involved, which a reader needs
+	// before it can decode dictionary-encoded pages
+	DictionaryBytes int64
+	Bytes           int64 // pages plus dictionary pages
+}
+
+// RowPageMap locates a range of rows in the pages of each requested column
+type RowPageMap struct {
+	FirstRow   int64 // first row of the range within the file
+	LastRow    int64 // last row of the range within the file, inclusive
+	Columns    []RowColumnPages
+	TotalBytes int64 // bytes to read to fetch the rows of all requested columns
+}
+
+// ParseRowRange parses a row number or an inclusive range of row numbers
+// such as "10-20". Digit groups may be separated with commas or underscores.
+func ParseRowRange(s string) (int64, int64, error) {
+	first, last, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	firstRow, err := parseRowNumber(first)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidRowIndex, s)
+	}
+	if !isRange {
+		return firstRow, firstRow, nil
+	}
+	lastRow, err := parseRowNumber(last)
+	if err != nil || lastRow < firstRow {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidRowIndex, s)
+	}
+	return firstRow, lastRow, nil
+}
+
+// ParseRowNumber parses a row number, digit groups may be separated with
+// commas or underscores
+func ParseRowNumber(s string) (int64, error) {
+	row, err := parseRowNumber(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRowIndex, s)
+	}
+	return row, nil
+}
+
+// parseRowNumber parses a non-negative row number
+func parseRowNumber(s string) (int64, error) {
+	s = strings.NewReplacer(",", "", "_", "").Replace(strings.TrimSpace(s))
+	row, err := strconv.ParseInt(s, 10, 64)
+	if err == nil && row < 0 {
+		return 0, strconv.ErrRange
+	}
+	return row, err
+}
+
+// chunkPage is a data page of a column chunk with the rows it holds
+type chunkPage struct {
+	index         int
+	firstRowIndex int64
+	numRows       int64
+	offset        int64
+	size          int64
+}
+
+// GetRowPages finds, for each of the given columns, the data pages holding
+// rows firstRow to lastRow of the file and the bytes to read to fetch them.
+// Page boundaries come from the offset index when the chunk has one and
+// from scanning the page headers otherwise. No columns means all columns.
+func (pr *ParquetReader) GetRowPages(ctx context.Context, firstRow, lastRow int64, colIndexes []int) (RowPageMap, error) {
+	if pr == nil || pr.metadata == nil {
+		return RowPageMap{}, ErrInvalidRowIndex
+	}
+	pr = pr.WithContext(ctx)
+
+	numRows := pr.metadata.NumRows
+	if firstRow < 0 || lastRow < firstRow || lastRow >= numRows {
+		return RowPageMap{}, fmt.Errorf("row range %d-%d out of range [0, %d): %w", firstRow, lastRow, numRows, ErrInvalidRowIndex)
+	}
+
+	if len(colIndexes) == 0 {
+		colIndexes = make([]int, len(pr.columns))
+		for i := range colIndexes {
+			colIndexes[i] = i
+		}
+	}
+	for _, colIndex := range colIndexes {
+		if colIndex < 0 || colIndex >= len(pr.columns) {
+			return RowPageMap{}, fmt.Errorf("column index %d out of range [0, %d): %w",
+				colIndex, len(pr.columns), ErrInvalidColumnIndex)
+		}
+	}
+
+	result := RowPageMap{FirstRow: firstRow, LastRow: lastRow, Columns: make([]RowColumnPages, len(colIndexes))}
+	for i, colIndex := range colIndexes {
+		result.Columns[i] = RowColumnPages{
+			ColumnIndex: colIndex,
+			Path:        pr.columnName(colIndex),
+			Source:      RowPageSourceOffsetIndex,
+		}
+	}
+
+	var rgStart int64
+	for rgIndex, rg := range pr.metadata.RowGroups {
+		rgFirst, rgLast := rgStart, rgStart+rg.NumRows-1
+		rgStart += rg.NumRows
+		if rgLast < firstRow || rgFirst > lastRow {
+			continue
+		}
+
+		for i, colIndex := range colIndexes {
+			if err := ctx.Err(); err != nil {
+				return RowPageMap{}, err
+			}
+
+			pages, dictionarySize, source, err := pr.chunkPages(rgIndex, colIndex)
+			if err != nil {
+				return RowPageMap{}, fmt.Errorf("row group %d, column %s: %w", rgIndex, result.Columns[i].Path, err)
+			}
+
+			column := &result.Columns[i]
+			if source != RowPageSourceOffsetIndex {
+				column.Source = source
+			}
+			found := false
+			for _, page := range pages {
+				pageFirst, pageLast := rgFirst+page.firstRowIndex, rgFirst+page.firstRowIndex+page.numRows-1
+				if pageLast < firstRow || pageFirst > lastRow {
+					continue
+				}
+				found = true
+				column.Pages = append(column.Pages, RowPage{
+					RowGroup:      rgIndex,
+					PageIndex:     page.index,
+					FirstRowIndex: page.firstRowIndex,
+					FirstRow:      pageFirst,
+					NumRows:       page.numRows,
+					Offset:        page.offset,
+					Size:          page.size,
+				})
+				column.Bytes += page.size
+			}
+			if found {
+				column.DictionaryBytes += dictionarySize
+				column.Bytes += dictionarySize
+			}
+		}
+	}
+
+	for _, column := range result.Columns {
+		result.TotalBytes += column.Bytes
+	}
+	return result, nil
+}
+
+// chunkPages returns the data pages of a column chunk with the rows they
+// hold, the size of its dictionary page, and where the page boundaries came
+// from
+func (pr *ParquetReader) chunkPages(rgIndex, colIndex int) ([]chunkPage, int64, string, error) {
+	chunk := pr.metadata.RowGroups[rgIndex].Columns[colIndex]
+	meta := chunk.MetaData
+
+	// The offset index is optional, a failed read or one whose row indexes
+	// do not fit the row group falls back to the headers
+	numRows := pr.metadata.RowGroups[rgIndex].NumRows
+	offsetIndex, _ := pr.offsetIndex(rgIndex, colIndex)
+	if offsetIndex == nil || !validPageLocations(offsetIndex.PageLocations, numRows) {
+		return pr.scanChunkPages(rgIndex, colIndex)
+	}
+
+	// The offset index only lists data pages, the dictionary page comes
+	// before the first of them
+	var dictionarySize int64
+	firstPage := 0
+	if meta.DictionaryPageOffset != nil && *meta.DictionaryPageOffset > 0 && *meta.DictionaryPageOffset < offsetIndex.PageLocations[0].Offset {
+		dictionarySize = offsetIndex.PageLocations[0].Offset - *meta.DictionaryPageOffset
+		firstPage = 1
+	}
+
+	locations := offsetIndex.PageLocations
+	pages := make([]chunkPage, len(locations))
+	for i, location := range locations {
+		next := numRows
+		if i+1 < len(locations) {
+			next = locations[i+1].FirstRowIndex
+		}
+		pages[i] = chunkPage{
+			index:         firstPage + i,
+			firstRowIndex: location.FirstRowIndex,
+			numRows:       next - location.FirstRowIndex,
+			offset:        location.Offset,
+			size:          int64(location.CompressedPageSize),
+		}
+	}
+	return pages, dictionarySize, RowPageSourceOffsetIndex, nil
+}
+
+// validPageLocations reports whether the pages of an offset index start at
+// the first row and at strictly increasing rows within the row group. Some
+// writers record value counts instead of row indexes for repeated columns.
+func validPageLocations(locations []*parquet.PageLocation, numRows int64) bool {
+	if len(locations) == 0 || locations[0].FirstRowIndex != 0 {
+		return false
+	}
+	for i := 1; i < len(locations); i++ {
+		if locations[i].FirstRowIndex <= locations[i-1].FirstRowIndex || locations[i].FirstRowIndex >= numRows {
+			return false
+		}
+	}
+	return true
+}
+
+// scanChunkPages reads the page headers of a column chunk to find the rows
+// each data page holds. Pages of repeated columns without a row count in
+// their header have their repetition levels decoded.
+func (pr *ParquetReader) scanChunkPages(rgIndex, colIndex int) ([]chunkPage, int64, string, error) {
+	meta := pr.metadata.RowGroups[rgIndex].Columns[colIndex].MetaData
+	_, maxRep := columnLevels(pr.metadata.Schema, meta.PathInSchema)
+
+	headers, err := pr.pageHeaders(rgIndex, colIndex)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	// A page spans from its header to the next header, the last one to the
+	// end of the chunk
+	chunkEnd := meta.DataPageOffset + meta.TotalCompressedSize
+	if meta.DictionaryPageOffset != nil && *meta.DictionaryPageOffset > 0 && *meta.DictionaryPageOffset < meta.DataPageOffset {
+		chunkEnd = *meta.DictionaryPageOffset + meta.TotalCompressedSize
+	}
+
+	var pages []chunkPage
+	var dictionarySize, rowIndex int64
+	for i, header := range headers {
+		end := chunkEnd
+		if i+1 < len(headers) {
+			end = headers[i+1].Offset
+		}
+		size := end - header.Offset
+
+		var rows int64
+		switch {
+		case header.PageType == parquet.PageType_DICTIONARY_PAGE:
+			dictionarySize += size
+			continue
+		case header.PageType == parquet.PageType_DATA_PAGE_V2:
+			rows = int64(header.NumRows)
+		case header.PageType != parquet.PageType_DATA_PAGE:
+			continue
+		case maxRep == 0:
+			rows = int64(header.NumValues)
+		default:
+			levels, err := pr.pageRepetitionLevels(meta, header, maxRep)
+			if err != nil {
+				return nil, 0, "", fmt.Errorf("page %d: %w", i, err)
+			}
+			for _, level := range levels {
+				if level == 0 {
+					rows++
+				}
+			}
+		}
+
+		pages = append(pages, chunkPage{index: i, firstRowIndex: rowIndex, numRows: rows, offset: header.Offset, size: size})
+		rowIndex += rows
+	}
+	return pages, dictionarySize, RowPageSourcePageHeaders, nil
+}
+
+// pageRepetitionLevels decodes the repetition levels of a data page, one per
+// value. A level of 0 starts a new row.
+func (pr *ParquetReader) pageRepetitionLevels(meta *parquet.ColumnMetaData, header reader.PageHeaderInfo, maxRep int32) ([]uint32, error) {
+	data, err := pr.readPageData(meta, header)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := splitDataPage(data, header, 0, maxRep)
+	if err != nil {
+		return nil, err
+	}
+	levels, err := decodeLevels(sections.repLevels, sections.levelsPrefixed, header.RepLevelEncoding, maxRep, int(header.NumValues))
+	if err != nil {
+		return nil, fmt.Errorf("repetition levels: %w", err)
+	}
+	return levels, nil
+}
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRowPages(t *testing.T) {
+	pr := openTestParquetReader(t)
+	numColumns := countLeafColumns(pr.metadata.Schema)
+	numRows := pr.metadata.NumRows
+
+	t.Run("All columns", func(t *testing.T) {
+		rowPages, err := pr.GetRowPages(context.Background(), 0, numRows-1, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), rowPages.FirstRow)
+		require.Equal(t, numRows-1, rowPages.LastRow)
+		require.Len(t, rowPages.Columns, numColumns)
+
+		var total int64
+		for i, column := range rowPages.Columns {
+			require.Equal(t, i, column.ColumnIndex)
+			require.NotEmpty(t, column.Pages, column.Path)
+
+			// Every row of the file is in exactly one page
+			var rows, bytes int64
+			for _, page := range column.Pages {
+				require.Equal(t, rows, page.FirstRow, column.Path)
+				require.Positive(t, page.NumRows, column.Path)
+				require.Positive(t, page.Size, column.Path)
+				rows += page.NumRows
+				bytes += page.Size
+			}
+			require.Equal(t, numRows, rows, column.Path)
+			require.Equal(t, bytes+column.DictionaryBytes, column.Bytes)
+			total += column.Bytes
+		}
+		require.Equal(t, total, rowPages.TotalBytes)
+		require.Equal(t, "Map.Key_value.Key", rowPages.Columns[46].Path)
+	})
+
+	t.Run("Offset index agrees with page headers", func(t *testing.T) {
+		for colIndex := range numColumns {
+			indexed, indexedDictionary, _, err := pr.chunkPages(0, colIndex)
+			require.NoError(t, err)
+			scanned, scannedDictionary, source, err := pr.scanChunkPages(0, colIndex)
+			require.NoError(t, err)
+			require.Equal(t, RowPageSourcePageHeaders, source)
+			require.Equal(t, scanned, indexed, colIndex)
+			require.Equal(t, scannedDictionary, indexedDictionary, colIndex)
+		}
+	})
+
+	t.Run("Single row", func(t *testing.T) {
+		rowPages, err := pr.GetRowPages(context.Background(), 2, 2, []int{1, 46})
+		require.NoError(t, err)
+		require.Len(t, rowPages.Columns, 2)
+		require.Equal(t, 46, rowPages.Columns[1].ColumnIndex)
+		for _, column := range rowPages.Columns {
+			require.Len(t, column.Pages, 1, column.Path)
+			page := column.Pages[0]
+			require.LessOrEqual(t, page.FirstRow, int64(2))
+			require.Greater(t, page.FirstRow+page.NumRows, int64(2))
+		}
+	})
+
+	t.Run("Invalid rows", func(t *testing.T) {
+		for _, rows := range [][2]int64{{-1, 0}, {0, numRows}, {3, 2}} {
+			_, err := pr.GetRowPages(context.Background(), rows[0], rows[1], nil)
+			require.ErrorIs(t, err, ErrInvalidRowIndex)
+		}
+	})
+
+	t.Run("Invalid column", func(t *testing.T) {
+		_, err := pr.GetRowPages(context.Background(), 0, 0, []int{numColumns})
+		require.ErrorIs(t, err, ErrInvalidColumnIndex)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := pr.GetRowPages(ctx, 0, 0, nil)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Nil reader", func(t *testing.T) {
+		var nilReader *ParquetReader
+		_, err := nilReader.GetRowPages(context.Background(), 0, 0, nil)
+		require.ErrorIs(t, err, ErrInvalidRowIndex)
+	})
+}
+
+func Test_validPageLocations(t *testing.T) {
+	locations := func(rows ...int64) []*parquet.PageLocation {
+		result := make([]*parquet.PageLocation, len(rows))
+		for i, row := range rows {
+			result[i] = &parquet.PageLocation{FirstRowIndex: row}
+		}
+		return result
+	}
+
+	require.True(t, validPageLocations(locations(0), 5))
+	require.True(t, validPageLocations(locations(0, 2, 4), 5))
+	require.False(t, validPageLocations(nil, 5))
+	require.False(t, validPageLocations(locations(1, 2), 5))
+	require.False(t, validPageLocations(locations(0, 2, 2), 5))
+	require.False(t, validPageLocations(locations(0, 2, 7), 5))
+}
+
+func Test_ParseRowRange(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		first int64
+		last  int64
+	}{
+		"single":     {input: "7", first: 7, last: 7},
+		"range":      {input: "10-20", first: 10, last: 20},
+		"spaces":     {input: " 1 - 2 ", first: 1, last: 2},
+		"separators": {input: "73,402,118", first: 73402118, last: 73402118},
+		"underscore": {input: "1_000-2_000", first: 1000, last: 2000},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			first, last, err := ParseRowRange(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.first, first)
+			require.Equal(t, tc.last, last)
+		})
+	}
+
+	for _, input := range []string{"", "abc", "-1", "5-", "5-3", "1-2-3"} {
+		_, _, err := ParseRowRange(input)
+		require.ErrorIs(t, err, ErrInvalidRowIndex, input)
+	}
+}
+
+func Test_ParseRowNumber(t *testing.T) {
+	row, err := ParseRowNumber("73,402,118")
+	require.NoError(t, err)
+	require.Equal(t, int64(73402118), row)
+
+	for _, input := range []string{"", "x", "1-2", "-1"} {
+		_, err = ParseRowNumber(input)
+		require.ErrorIs(t, err, ErrInvalidRowIndex, input)
+	}
+}
@@ -0,0 +1,308 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SampleMode selects how sampled rows are spread over the file
+type SampleMode string
+
+const (
+	SampleRandom     SampleMode = "random"     // N rows anywhere in the file
+	SampleStratified SampleMode = "stratified" // N rows in every row group
+)
+
+// SampleModes lists the sample modes in display order
+var SampleModes = []SampleMode{SampleRandom, SampleStratified}
+
+// MaxSampleSize caps the rows of a sample, per row group when stratified
+const MaxSampleSize = 10000
+
+// DefaultSampleSize is the sample size when none is asked for
+const DefaultSampleSize = 10
+
+var (
+	// ErrInvalidSampleMode is returned for an unknown sample mode
+	ErrInvalidSampleMode = errors.New("invalid sample mode")
+
+	// ErrInvalidSampleSize is returned for a sample size outside [1, MaxSampleSize]
+	ErrInvalidSampleSize = errors.New("invalid sample size")
+)
+
+// ParseSampleMode parses a sample mode, empty means random
+func ParseSampleMode(s string) (SampleMode, error) {
+	if s == "" {
+		return SampleRandom, nil
+	}
+	for _, mode := range SampleModes {
+		if SampleMode(strings.ToLower(s)) == mode {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q (expected one of %v)", ErrInvalidSampleMode, s, SampleModes)
+}
+
+// ParseSampleSize parses the number of rows to sample, empty means
+// DefaultSampleSize
+func ParseSampleSize(s string) (int, error) {
+	if s == "" {
+		return DefaultSampleSize, nil
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 1 || n > MaxSampleSize {
+		return 0, fmt.Errorf("%w: %q (expected 1 to %d)", ErrInvalidSampleSize, s, MaxSampleSize)
+	}
+	return n, nil
+}
+
+// ParseSampleSeed parses the seed of a sample. Empty picks a random seed,
+// which the sample reports so that it can be drawn again.
+func ParseSampleSeed(s string) (uint64, error) {
+	if s == "" {
+		return rand.Uint64(), nil
+	}
+	seed, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample seed %q: %w", s, err)
+	}
+	return seed, nil
+}
+
+// SampleOptions describes the rows to sample and the columns to show
+type SampleOptions struct {
+	Mode    SampleMode
+	N       int    // rows in the file, or in every row group when stratified
+	Seed    uint64 // the same seed draws the same rows
+	Columns []int  // leaf column indexes, none means all columns
+}
+
+// SampleRow is one sampled row with its values in the sampled columns
+type SampleRow struct {
+	Row      int64 // within the file
+	RowGroup int
+	RowIndex int64    // within the row group
+	Values   []string // one per column, several values of a repeated column in brackets
+}
+
+// Sample is a set of rows drawn from the file, in file order
+type Sample struct {
+	Mode      SampleMode
+	N         int
+	Seed      uint64
+	Columns   []string // paths of the sampled columns
+	Rows      []SampleRow
+	PagesRead int   // data pages decoded to fetch the values
+	BytesRead int64 // stored size of those pages, the dictionary pages they need and the VARIANT column chunks read whole
+}
+
+// sampledPage caches a decoded page while its rows are being sampled
+type sampledPage struct {
+	values []string
+	levels []int32 // repetition levels
+}
+
+// SampleRows draws rows from the file with a seeded generator and reads
+// their values in the requested columns. Only the pages holding sampled rows
+// are decoded, their boundaries come from the offset index when the chunk
+// has one and from the page headers otherwise.
+func (pr *ParquetReader) SampleRows(ctx context.Context, opts SampleOptions) (Sample, error) {
+	if pr == nil || pr.metadata == nil {
+		return Sample{}, ErrInvalidRowIndex
+	}
+	pr = pr.WithContext(ctx)
+	mode, err := ParseSampleMode(string(opts.Mode))
+	if err != nil {
+		return Sample{}, err
+	}
+	if opts.N < 1 || opts.N > MaxSampleSize {
+		return Sample{}, fmt.Errorf("%w: %d (expected 1 to %d)", ErrInvalidSampleSize, opts.N, MaxSampleSize)
+	}
+
+	colIndexes := opts.Columns
+	if len(colIndexes) == 0 {
+		colIndexes = make([]int, len(pr.columns))
+		for i := range colIndexes {
+			colIndexes[i] = i
+		}
+	}
+	for _, colIndex := range colIndexes {
+		if colIndex < 0 || colIndex >= len(pr.columns) {
+			return Sample{}, fmt.Errorf("column index %d out of range [0, %d): %w",
+				colIndex, len(pr.columns), ErrInvalidColumnIndex)
+		}
+	}
+
+	sample := Sample{Mode: mode, N: opts.N, Seed: opts.Seed, Columns: make([]string, len(colIndexes))}
+	for i, colIndex := range colIndexes {
+		sample.Columns[i] = pr.columnName(colIndex)
+	}
+
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x5eed))
+	rows := make([][]int64, len(pr.metadata.RowGroups)) // row indexes within each row group
+	if mode == SampleStratified {
+		for rgIndex, rg := range pr.metadata.RowGroups {
+			rows[rgIndex] = sampleIndexes(rng, opts.N, rg.NumRows)
+		}
+	} else {
+		var rgIndex int
+		var rgStart int64
+		for _, row := range sampleIndexes(rng, opts.N, pr.metadata.NumRows) {
+			for row >= rgStart+pr.metadata.RowGroups[rgIndex].NumRows {
+				rgStart += pr.metadata.RowGroups[rgIndex].NumRows
+				rgIndex++
+			}
+			rows[rgIndex] = append(rows[rgIndex], row-rgStart)
+		}
+	}
+
+	var rgStart int64
+	for rgIndex, rgRows := range rows {
+		first := len(sample.Rows)
+		for _, rowIndex := range rgRows {
+			sample.Rows = append(sample.Rows, SampleRow{
+				Row:      rgStart + rowIndex,
+				RowGroup: rgIndex,
+				RowIndex: rowIndex,
+				Values:   make([]string, len(colIndexes)),
+			})
+		}
+		rgStart += pr.metadata.RowGroups[rgIndex].NumRows
+		if len(rgRows) == 0 {
+			continue
+		}
+
+		for i, colIndex := range colIndexes {
+			if err := ctx.Err(); err != nil {
+				return Sample{}, err
+			}
+			if err := pr.sampleChunk(rgIndex, colIndex, i, sample.Rows[first:], &sample); err != nil {
+				return Sample{}, fmt.Errorf("row group %d, column %s: %w", rgIndex, sample.Columns[i], err)
+			}
+		}
+	}
+	return sample, nil
+}
+
+// sampleChunk fills in the values of one column for the sampled rows of a
+// row group, which are in row order. Only the pages holding those rows are
+// read, with the dictionary page when they are dictionary encoded, except
+// for VARIANT columns whose values are rebuilt from their whole chunks.
+func (pr *ParquetReader) sampleChunk(rgIndex, colIndex, valueIndex int, rows []SampleRow, sample *Sample) error {
+	meta := pr.metadata.RowGroups[rgIndex].Columns[colIndex].MetaData
+	_, maxRep := columnLevels(pr.metadata.Schema, meta.PathInSchema)
+
+	pages, dictionarySize, _, err := pr.chunkPages(rgIndex, colIndex)
+	if err != nil {
+		return err
+	}
+	if len(pages) == 0 {
+		return fmt.Errorf("no data pages: %w", ErrInvalidPageIndex)
+	}
+	headers, err := pr.pageHeaders(rgIndex, colIndex)
+	if err != nil {
+		return err
+	}
+	variantSize := pr.variantChunkSize(rgIndex, colIndex)
+
+	decoded := map[int]sampledPage{}
+	readDictionary := false
+	p := 0
+	for r := range rows {
+		for p+1 < len(pages) && rows[r].RowIndex >= pages[p+1].firstRowIndex {
+			p++
+		}
+		page := pages[p]
+
+		cached, ok := decoded[page.index]
+		if !ok {
+			if cached, err = pr.samplePage(rgIndex, colIndex, page.index, variantSize > 0); err != nil {
+				return fmt.Errorf("page %d: %w", page.index, err)
+			}
+			decoded[page.index] = cached
+			sample.PagesRead++
+			sample.BytesRead += page.size
+			readDictionary = readDictionary || isDictionaryEncoding(headers[page.index].Encoding)
+		}
+
+		start, count := int(rows[r].RowIndex-page.firstRowIndex), 1
+		if maxRep > 0 {
+			if start, count, ok = rowSpan(cached.levels, rows[r].RowIndex-page.firstRowIndex); !ok {
+				return fmt.Errorf("page %d has no row %d: %w", page.index, rows[r].RowIndex, ErrInvalidRowIndex)
+			}
+		}
+		if start+count > len(cached.values) {
+			return fmt.Errorf("page %d has no row %d: %w", page.index, rows[r].RowIndex, ErrInvalidRowIndex)
+		}
+		if maxRep > 0 {
+			rows[r].Values[valueIndex] = "[" + strings.Join(cached.values[start:start+count], ", ") + "]"
+		} else {
+			rows[r].Values[valueIndex] = cached.values[start]
+		}
+	}
+	switch {
+	case variantSize > 0:
+		sample.BytesRead += variantSize
+	case readDictionary:
+		sample.BytesRead += dictionarySize
+	}
+	return nil
+}
+
+// samplePage decodes a data page alone and formats its values, VARIANT
+// values are rebuilt from the chunks of all the columns of their group
+func (pr *ParquetReader) samplePage(rgIndex, colIndex, pageIndex int, variant bool) (sampledPage, error) {
+	rawValues, rls, _, err := pr.decodePageValues(rgIndex, colIndex, pageIndex)
+	if err != nil {
+		return sampledPage{}, err
+	}
+	page := sampledPage{levels: rls}
+	if variant {
+		pages, err := pr.GetPageMetadataList(rgIndex, colIndex)
+		if err != nil {
+			return sampledPage{}, err
+		}
+		formatted, _, err := pr.formatVariantPage(rgIndex, colIndex, int(pageValueStart(pages, pageIndex)), len(rawValues))
+		if err != nil {
+			return sampledPage{}, err
+		}
+		for i := range formatted {
+			formatted[i] = truncateDisplayValue(formatted[i])
+		}
+		page.values = formatted
+		return page, nil
+	}
+	page.values = pr.formatValues(colIndex, rawValues, GeoFormatGeoJSON)
+	return page, nil
+}
+
+// sampleIndexes picks n distinct indexes out of [0, total) in ascending
+// order, all of them when n is not less than total. Floyd's algorithm draws
+// exactly n numbers however large total is.
+func sampleIndexes(rng *rand.Rand, n int, total int64) []int64 {
+	if int64(n) >= total {
+		indexes := make([]int64, total)
+		for i := range indexes {
+			indexes[i] = int64(i)
+		}
+		return indexes
+	}
+
+	picked := make(map[int64]bool, n)
+	indexes := make([]int64, 0, n)
+	for j := total - int64(n); j < total; j++ {
+		index := rng.Int64N(j + 1)
+		if picked[index] {
+			index = j
+		}
+		picked[index] = true
+		indexes = append(indexes, index)
+	}
+	slices.Sort(indexes)
+	return indexes
+}
@@ -0,0 +1,164 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseSampleMode(t *testing.T) {
+	mode, err := ParseSampleMode("")
+	require.NoError(t, err)
+	require.Equal(t, SampleRandom, mode)
+
+	mode, err = ParseSampleMode("Stratified")
+	require.NoError(t, err)
+	require.Equal(t, SampleStratified, mode)
+
+	_, err = ParseSampleMode("bogus")
+	require.ErrorIs(t, err, ErrInvalidSampleMode)
+}
+
+func Test_ParseSampleSize(t *testing.T) {
+	n, err := ParseSampleSize("")
+	require.NoError(t, err)
+	require.Equal(t, DefaultSampleSize, n)
+
+	n, err = ParseSampleSize("25")
+	require.NoError(t, err)
+	require.Equal(t, 25, n)
+
+	for _, s := range []string{"0", "-1", "x", "10001"} {
+		_, err = ParseSampleSize(s)
+		require.ErrorIs(t, err, ErrInvalidSampleSize, s)
+	}
+}
+
+func Test_ParseSampleSeed(t *testing.T) {
+	seed, err := ParseSampleSeed("42")
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), seed)
+
+	_, err = ParseSampleSeed("")
+	require.NoError(t, err)
+
+	_, err = ParseSampleSeed("-1")
+	require.Error(t, err)
+}
+
+func Test_sampleIndexes(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	indexes := sampleIndexes(rng, 10, 1_000_000_000)
+	require.Len(t, indexes, 10)
+	for i := 1; i < len(indexes); i++ {
+		require.Less(t, indexes[i-1], indexes[i])
+	}
+
+	require.Equal(t, []int64{0, 1, 2}, sampleIndexes(rng, 5, 3))
+	require.Empty(t, sampleIndexes(rng, 5, 0))
+}
+
+func Test_SampleRows(t *testing.T) {
+	pr := openTestParquetReader(t)
+	numColumns := countLeafColumns(pr.metadata.Schema)
+
+	t.Run("Values match row locations", func(t *testing.T) {
+		sample, err := pr.SampleRows(context.Background(), SampleOptions{Mode: SampleRandom, N: 3, Seed: 7})
+		require.NoError(t, err)
+		require.Len(t, sample.Columns, numColumns)
+		require.Len(t, sample.Rows, 3)
+		require.Positive(t, sample.PagesRead)
+		require.Positive(t, sample.BytesRead)
+
+		for _, row := range sample.Rows {
+			require.Equal(t, 0, row.RowGroup)
+			require.Equal(t, row.Row, row.RowIndex)
+			location, err := pr.GetRowLocation(context.Background(), row.Row, nil)
+			require.NoError(t, err)
+			for i, column := range location.Columns {
+				values, err := pr.GetPageContentFormatted(0, column.ColumnIndex, column.PageIndex)
+				require.NoError(t, err)
+				meta := pr.metadata.RowGroups[0].Columns[column.ColumnIndex].MetaData
+				want := values[column.ValueIndex]
+				if _, maxRep := columnLevels(pr.metadata.Schema, meta.PathInSchema); maxRep > 0 {
+					want = "[" + strings.Join(values[column.ValueIndex:column.ValueIndex+column.NumValues], ", ") + "]"
+				}
+				require.Equal(t, want, row.Values[i], column.Path)
+			}
+		}
+	})
+
+	t.Run("Same seed same rows", func(t *testing.T) {
+		opts := SampleOptions{N: 2, Seed: 99, Columns: []int{1}}
+		first, err := pr.SampleRows(context.Background(), opts)
+		require.NoError(t, err)
+		second, err := pr.SampleRows(context.Background(), opts)
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+		require.Equal(t, SampleRandom, first.Mode)
+		require.Equal(t, []string{"Int32"}, first.Columns)
+	})
+
+	t.Run("Stratified takes every row of small row groups", func(t *testing.T) {
+		sample, err := pr.SampleRows(context.Background(), SampleOptions{Mode: SampleStratified, N: 100, Columns: []int{1}})
+		require.NoError(t, err)
+		require.Len(t, sample.Rows, int(pr.metadata.NumRows))
+		for i, row := range sample.Rows {
+			require.Equal(t, int64(i), row.Row)
+			require.Equal(t, []string{row.Values[0]}, row.Values)
+		}
+	})
+
+	t.Run("Reads only the sampled pages", func(t *testing.T) {
+		r, err := pio.NewParquetFileReader("../build/testdata/row-group.parquet", pio.ReadOption{})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = r.ReadStop() })
+		pr := NewParquetReader(r)
+
+		sample, err := pr.SampleRows(context.Background(), SampleOptions{Mode: SampleStratified, N: 1, Seed: 3, Columns: []int{0}})
+		require.NoError(t, err)
+		require.Len(t, sample.Rows, 2)
+		require.Equal(t, 2, sample.PagesRead)
+
+		var chunkSizes int64
+		for rgIndex, row := range sample.Rows {
+			require.Equal(t, fmt.Sprintf("the brand is: %d", row.Row), row.Values[0])
+			chunkSizes += pr.metadata.RowGroups[rgIndex].Columns[0].MetaData.TotalCompressedSize
+		}
+		require.Less(t, sample.BytesRead, chunkSizes)
+
+		ops := map[IOOperation]OperationIOStats{}
+		for _, op := range pr.IOStats().Operations {
+			ops[op.Operation] = op
+		}
+		require.NotContains(t, ops, IOColumnChunk)
+		require.LessOrEqual(t, ops[IOPageData].Bytes, sample.BytesRead)
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		_, err := pr.SampleRows(context.Background(), SampleOptions{N: 0})
+		require.ErrorIs(t, err, ErrInvalidSampleSize)
+		_, err = pr.SampleRows(context.Background(), SampleOptions{N: 1, Mode: "bogus"})
+		require.ErrorIs(t, err, ErrInvalidSampleMode)
+		_, err = pr.SampleRows(context.Background(), SampleOptions{N: 1, Columns: []int{-1}})
+		require.ErrorIs(t, err, ErrInvalidColumnIndex)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := pr.SampleRows(ctx, SampleOptions{N: 1})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Nil reader", func(t *testing.T) {
+		var nilReader *ParquetReader
+		_, err := nilReader.SampleRows(context.Background(), SampleOptions{N: 1})
+		require.ErrorIs(t, err, ErrInvalidRowIndex)
+	})
+}
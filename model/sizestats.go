@@ -0,0 +1,88 @@
+package model
+
+import (
+	"github.com/hangxie/parquet-go/v3/parquet"
+)
+
+// SizeStatistics holds the optional size statistics newer writers record for
+// a column chunk, and per page in the column and offset indexes. Histogram
+// entry i counts the values whose repetition or definition level is i.
+type SizeStatistics struct {
+	UnencodedByteArrayDataBytes *int64
+	RepetitionLevelHistogram    []int64
+	DefinitionLevelHistogram    []int64
+}
+
+// convertSizeStatistics converts the thrift size statistics of a column
+// chunk, returning nil when nothing is recorded
+func convertSizeStatistics(stats *parquet.SizeStatistics) *SizeStatistics {
+	if stats == nil {
+		return nil
+	}
+	result := &SizeStatistics{
+		UnencodedByteArrayDataBytes: stats.UnencodedByteArrayDataBytes,
+		RepetitionLevelHistogram:    stats.RepetitionLevelHistogram,
+		DefinitionLevelHistogram:    stats.DefinitionLevelHistogram,
+	}
+	if result.isEmpty() {
+		return nil
+	}
+	return result
+}
+
+// isEmpty reports whether no size statistic is set
+func (s *SizeStatistics) isEmpty() bool {
+	return s.UnencodedByteArrayDataBytes == nil && len(s.RepetitionLevelHistogram) == 0 && len(s.DefinitionLevelHistogram) == 0
+}
+
+// pageSizeStatistics gathers the per-page size statistics of a column chunk
+// from its column index (level histograms) and offset index (unencoded byte
+// array sizes). Entry i belongs to the i-th data page, dictionary pages are
+// not indexed. It returns nil when the indexes are missing or hold none.
+func (pr *ParquetReader) pageSizeStatistics(rgIndex, colIndex int) []*SizeStatistics {
+	meta := pr.metadata.RowGroups[rgIndex].Columns[colIndex].MetaData
+	maxDef, maxRep := columnLevels(pr.metadata.Schema, meta.PathInSchema)
+
+	// Indexes are optional, so read failures just mean no per-page statistics
+	columnIndex, _ := pr.columnIndex(rgIndex, colIndex)
+	offsetIndex, _ := pr.offsetIndex(rgIndex, colIndex)
+
+	numPages := 0
+	if columnIndex != nil {
+		numPages = len(columnIndex.NullPages)
+	}
+	if offsetIndex != nil {
+		numPages = max(numPages, len(offsetIndex.PageLocations))
+	}
+
+	stats := make([]*SizeStatistics, numPages)
+	found := false
+	for i := range stats {
+		page := &SizeStatistics{}
+		if columnIndex != nil {
+			page.RepetitionLevelHistogram = splitLevelHistogram(columnIndex.RepetitionLevelHistograms, i, int(maxRep)+1, numPages)
+			page.DefinitionLevelHistogram = splitLevelHistogram(columnIndex.DefinitionLevelHistograms, i, int(maxDef)+1, numPages)
+		}
+		if offsetIndex != nil && len(offsetIndex.UnencodedByteArrayDataBytes) == numPages {
+			page.UnencodedByteArrayDataBytes = &offsetIndex.UnencodedByteArrayDataBytes[i]
+		}
+		if !page.isEmpty() {
+			stats[i] = page
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return stats
+}
+
+// splitLevelHistogram returns the histogram of page i from the concatenated
+// per-page histograms of a column index, or nil when their length does not
+// match numPages histograms of width buckets
+func splitLevelHistogram(histograms []int64, page, width, numPages int) []int64 {
+	if len(histograms) == 0 || len(histograms) != width*numPages {
+		return nil
+	}
+	return histograms[page*width : (page+1)*width]
+}
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/source/local"
+	"github.com/hangxie/parquet-go/v3/writer"
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+)
+
+// sizeStatsTestRow has an optional and a repeated column so that both level
+// histograms are written
+type sizeStatsTestRow struct {
+	Name *string  `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Tags []string `parquet:"name=tags, type=LIST, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+// openSizeStatsTestReader writes a small file with several pages per column
+// chunk, which the writer annotates with size statistics, and opens it
+func openSizeStatsTestReader(t *testing.T) *ParquetReader {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "sizestats.parquet")
+	fw, err := local.NewLocalFileWriter(path)
+	require.NoError(t, err)
+	pw, err := writer.NewParquetWriter(fw, new(sizeStatsTestRow), writer.WithPageSize(64))
+	require.NoError(t, err)
+	for i := range 20 {
+		var name *string
+		if i%4 != 0 {
+			v := fmt.Sprintf("n%d", i)
+			name = &v
+		}
+		tags := make([]string, i%3)
+		for j := range tags {
+			tags[j] = "t"
+		}
+		require.NoError(t, pw.Write(sizeStatsTestRow{Name: name, Tags: tags}))
+	}
+	require.NoError(t, pw.WriteStop())
+	require.NoError(t, fw.Close())
+
+	r, err := pio.NewParquetFileReader(path, pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.ReadStop() })
+	return NewParquetReader(r)
+}
+
+func Test_SizeStatistics_ColumnChunk(t *testing.T) {
+	pr := openSizeStatsTestReader(t)
+
+	info, err := pr.GetColumnChunkInfo(0, 0)
+	require.NoError(t, err)
+	require.NotNil(t, info.SizeStatistics)
+	require.Equal(t, []int64{5, 15}, info.SizeStatistics.DefinitionLevelHistogram)
+	require.Empty(t, info.SizeStatistics.RepetitionLevelHistogram)
+	require.NotNil(t, info.SizeStatistics.UnencodedByteArrayDataBytes)
+	require.Positive(t, *info.SizeStatistics.UnencodedByteArrayDataBytes)
+
+	info, err = pr.GetColumnChunkInfo(0, 1)
+	require.NoError(t, err)
+	require.Len(t, info.SizeStatistics.RepetitionLevelHistogram, 2)
+	require.Equal(t, int64(20), info.SizeStatistics.RepetitionLevelHistogram[0]) // one record start per row
+
+	// Files from older writers carry none
+	info, err = openTestParquetReader(t).GetColumnChunkInfo(0, 0)
+	require.NoError(t, err)
+	require.Nil(t, info.SizeStatistics)
+}
+
+func Test_SizeStatistics_Pages(t *testing.T) {
+	pr := openSizeStatsTestReader(t)
+
+	pages, err := pr.GetPageMetadataList(0, 1)
+	require.NoError(t, err)
+	require.Greater(t, len(pages), 1)
+
+	var repTotal, defTotal []int64
+	for _, page := range pages {
+		require.NotNil(t, page.SizeStatistics)
+		stats := page.SizeStatistics
+		require.Len(t, stats.DefinitionLevelHistogram, 2)
+		require.Len(t, stats.RepetitionLevelHistogram, 2)
+
+		var sum int64
+		for _, n := range stats.DefinitionLevelHistogram {
+			sum += n
+		}
+		require.Equal(t, int64(page.NumValues), sum)
+
+		repTotal = addHistograms(repTotal, stats.RepetitionLevelHistogram)
+		defTotal = addHistograms(defTotal, stats.DefinitionLevelHistogram)
+	}
+
+	info, err := pr.GetColumnChunkInfo(0, 1)
+	require.NoError(t, err)
+	require.Equal(t, info.SizeStatistics.RepetitionLevelHistogram, repTotal)
+	require.Equal(t, info.SizeStatistics.DefinitionLevelHistogram, defTotal)
+
+	pages, err = openTestParquetReader(t).GetPageMetadataList(0, 1)
+	require.NoError(t, err)
+	for _, page := range pages {
+		require.Nil(t, page.SizeStatistics)
+	}
+}
+
+func addHistograms(total, hist []int64) []int64 {
+	if total == nil {
+		total = make([]int64, len(hist))
+	}
+	for i, n := range hist {
+		total[i] += n
+	}
+	return total
+}
+
+func Test_convertSizeStatistics(t *testing.T) {
+	require.Nil(t, convertSizeStatistics(nil))
+	require.Nil(t, convertSizeStatistics(&parquet.SizeStatistics{}))
+
+	bytes := int64(42)
+	stats := convertSizeStatistics(&parquet.SizeStatistics{UnencodedByteArrayDataBytes: &bytes, DefinitionLevelHistogram: []int64{1, 2}})
+	require.Equal(t, &SizeStatistics{UnencodedByteArrayDataBytes: &bytes, DefinitionLevelHistogram: []int64{1, 2}}, stats)
+}
+
+func Test_splitLevelHistogram(t *testing.T) {
+	histograms := []int64{1, 2, 3, 4, 5, 6}
+	require.Equal(t, []int64{1, 2, 3}, splitLevelHistogram(histograms, 0, 3, 2))
+	require.Equal(t, []int64{4, 5, 6}, splitLevelHistogram(histograms, 1, 3, 2))
+	require.Equal(t, []int64{3, 4}, splitLevelHistogram(histograms, 1, 2, 3))
+	require.Nil(t, splitLevelHistogram(histograms, 0, 2, 2))
+	require.Nil(t, splitLevelHistogram(nil, 0, 1, 1))
+}
@@ -0,0 +1,207 @@
+package model
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"math/rand/v2"
+	"sort"
+)
+
+// hllPrecision is the number of index bits used by hyperLogLog (2^14 registers,
+// roughly 0.8% standard error)
+const hllPrecision = 14
+
+// hyperLogLog is a fixed-size approximate distinct counter
+type hyperLogLog struct {
+	registers []uint8
+}
+
+// newHyperLogLog creates an empty HyperLogLog sketch
+func newHyperLogLog() *hyperLogLog {
+	return &hyperLogLog{registers: make([]uint8, 1<<hllPrecision)}
+}
+
+// hashKey hashes a key with FNV-1a followed by a 64-bit finalizer so short,
+// similar keys still spread evenly over the registers
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// Add records one key
+func (h *hyperLogLog) Add(key string) {
+	x := hashKey(key)
+	idx := x >> (64 - hllPrecision)
+	rank := uint8(bits.LeadingZeros64(x<<hllPrecision|1<<(hllPrecision-1)) + 1)
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Estimate returns the approximate number of distinct keys added
+func (h *hyperLogLog) Estimate() uint64 {
+	m := float64(len(h.registers))
+	sum := 0.0
+	zeros := 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+
+	// Small range correction: linear counting is far more accurate when many
+	// registers are still empty
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
+
+// topKCounter tracks the most frequent keys with the Space-Saving algorithm,
+// using a bounded number of counters regardless of column cardinality
+type topKCounter struct {
+	capacity int
+	counts   map[string]int64
+}
+
+// newTopKCounter creates a counter that keeps at most capacity candidates
+func newTopKCounter(capacity int) *topKCounter {
+	return &topKCounter{
+		capacity: capacity,
+		counts:   make(map[string]int64, capacity),
+	}
+}
+
+// Add records one occurrence of key
+func (c *topKCounter) Add(key string) {
+	if _, ok := c.counts[key]; ok {
+		c.counts[key]++
+		return
+	}
+	if len(c.counts) < c.capacity {
+		c.counts[key] = 1
+		return
+	}
+
+	// Replace the smallest counter, inheriting its count as the error bound
+	minKey := ""
+	minCount := int64(math.MaxInt64)
+	for k, v := range c.counts {
+		if v < minCount || (v == minCount && k < minKey) {
+			minKey, minCount = k, v
+		}
+	}
+	delete(c.counts, minKey)
+	c.counts[key] = minCount + 1
+}
+
+// Top returns up to k keys ordered by descending count, ties broken by key
+func (c *topKCounter) Top(k int) []ValueCount {
+	result := make([]ValueCount, 0, len(c.counts))
+	for key, count := range c.counts {
+		result = append(result, ValueCount{Value: key, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Value < result[j].Value
+	})
+	if len(result) > k {
+		result = result[:k]
+	}
+	return result
+}
+
+// reservoirSample keeps a uniform random sample of float values with a fixed
+// seed so that repeated profiles of the same file produce the same output
+type reservoirSample struct {
+	size   int
+	seen   int64
+	values []float64
+	rng    *rand.Rand
+}
+
+// newReservoirSample creates a sample holding at most size values
+func newReservoirSample(size int) *reservoirSample {
+	return &reservoirSample{
+		size:   size,
+		values: make([]float64, 0, size),
+		rng:    rand.New(rand.NewPCG(0x5eed, 0xc0ffee)),
+	}
+}
+
+// Add offers one value to the sample
+func (s *reservoirSample) Add(v float64) {
+	s.seen++
+	if len(s.values) < s.size {
+		s.values = append(s.values, v)
+		return
+	}
+	if j := s.rng.Int64N(s.seen); j < int64(s.size) {
+		s.values[j] = v
+	}
+}
+
+// Sorted returns a sorted copy of the sampled values
+func (s *reservoirSample) Sorted() []float64 {
+	sorted := make([]float64, len(s.values))
+	copy(sorted, s.values)
+	sort.Float64s(sorted)
+	return sorted
+}
+
+// quantile returns the q-th quantile of sorted values using linear interpolation
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	if lower == upper {
+		return sorted[lower]
+	}
+	frac := pos - float64(lower)
+	return sorted[lower]*(1-frac) + sorted[upper]*frac
+}
+
+// equiWidthHistogram buckets sorted values into numBuckets equal-width buckets
+func equiWidthHistogram(sorted []float64, numBuckets int) []HistogramBucket {
+	if len(sorted) == 0 || numBuckets <= 0 {
+		return nil
+	}
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	if lo == hi {
+		return []HistogramBucket{{Lower: lo, Upper: hi, Count: int64(len(sorted))}}
+	}
+
+	width := (hi - lo) / float64(numBuckets)
+	buckets := make([]HistogramBucket, numBuckets)
+	for i := range buckets {
+		buckets[i].Lower = lo + float64(i)*width
+		buckets[i].Upper = lo + float64(i+1)*width
+	}
+	buckets[numBuckets-1].Upper = hi
+
+	for _, v := range sorted {
+		idx := int((v - lo) / width)
+		if idx >= numBuckets {
+			idx = numBuckets - 1
+		}
+		buckets[idx].Count++
+	}
+	return buckets
+}
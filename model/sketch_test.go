@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_hyperLogLog(t *testing.T) {
+	testCases := map[string]struct {
+		distinct int
+		repeat   int
+	}{
+		"empty":        {distinct: 0, repeat: 1},
+		"small":        {distinct: 10, repeat: 3},
+		"medium":       {distinct: 5000, repeat: 2},
+		"large":        {distinct: 100000, repeat: 1},
+		"single-value": {distinct: 1, repeat: 1000},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			hll := newHyperLogLog()
+			for r := 0; r < tc.repeat; r++ {
+				for i := 0; i < tc.distinct; i++ {
+					hll.Add(fmt.Sprintf("value-%d", i))
+				}
+			}
+			estimate := float64(hll.Estimate())
+			require.InDelta(t, float64(tc.distinct), estimate, float64(tc.distinct)*0.03+1)
+		})
+	}
+}
+
+func Test_topKCounter(t *testing.T) {
+	t.Run("exact when under capacity", func(t *testing.T) {
+		c := newTopKCounter(10)
+		for i := 0; i < 5; i++ {
+			for j := 0; j <= i; j++ {
+				c.Add(fmt.Sprintf("k%d", i))
+			}
+		}
+		top := c.Top(3)
+		require.Equal(t, []ValueCount{{"k4", 5}, {"k3", 4}, {"k2", 3}}, top)
+	})
+
+	t.Run("keeps heavy hitters over capacity", func(t *testing.T) {
+		c := newTopKCounter(5)
+		for i := 0; i < 1000; i++ {
+			c.Add("hot")
+			c.Add(fmt.Sprintf("cold-%d", i))
+		}
+		top := c.Top(1)
+		require.Len(t, top, 1)
+		require.Equal(t, "hot", top[0].Value)
+		require.GreaterOrEqual(t, top[0].Count, int64(1000))
+	})
+}
+
+func Test_reservoirSample(t *testing.T) {
+	t.Run("keeps everything under size", func(t *testing.T) {
+		s := newReservoirSample(10)
+		for i := 5; i > 0; i-- {
+			s.Add(float64(i))
+		}
+		require.Equal(t, []float64{1, 2, 3, 4, 5}, s.Sorted())
+	})
+
+	t.Run("bounded and reproducible", func(t *testing.T) {
+		a, b := newReservoirSample(100), newReservoirSample(100)
+		for i := 0; i < 10000; i++ {
+			a.Add(float64(i))
+			b.Add(float64(i))
+		}
+		require.Len(t, a.values, 100)
+		require.Equal(t, a.Sorted(), b.Sorted())
+	})
+}
+
+func Test_quantile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5}
+	require.Equal(t, 1.0, quantile(sorted, 0))
+	require.Equal(t, 3.0, quantile(sorted, 0.5))
+	require.Equal(t, 5.0, quantile(sorted, 1))
+	require.Equal(t, 1.5, quantile(sorted, 0.125))
+	require.Equal(t, 0.0, quantile(nil, 0.5))
+}
+
+func Test_equiWidthHistogram(t *testing.T) {
+	require.Nil(t, equiWidthHistogram(nil, 4))
+
+	single := equiWidthHistogram([]float64{7, 7, 7}, 4)
+	require.Equal(t, []HistogramBucket{{Lower: 7, Upper: 7, Count: 3}}, single)
+
+	buckets := equiWidthHistogram([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8}, 4)
+	require.Len(t, buckets, 4)
+	require.Equal(t, 0.0, buckets[0].Lower)
+	require.Equal(t, 8.0, buckets[3].Upper)
+	var total int64
+	for _, b := range buckets {
+		total += b.Count
+	}
+	require.Equal(t, int64(9), total)
+	require.Equal(t, int64(3), buckets[3].Count)
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+)
+
+// TypedValue is one value of a page as JSON-typed data, for API consumers
+// that need to tell NULL from "NULL" or 1 from "1"
+type TypedValue struct {
+	// Value is the value with its logical type applied: nil for NULL,
+	// numbers, booleans, strings, or objects and arrays for JSON, BSON,
+	// VARIANT and GeoJSON values and the output of custom decoders.
+	// Decimals are exact numbers.
+	Value any
+	// Raw is the physical value as stored, BYTE_ARRAY, FIXED_LEN_BYTE_ARRAY
+	// and INT96 values are base64 encoded
+	Raw             any
+	DefinitionLevel int32 // zero for dictionary page entries
+	RepetitionLevel int32 // zero for dictionary page entries
+}
+
+// GetPageContentTyped returns the values of a page as JSON-typed data along
+// with their physical values and levels
+func (pr *ParquetReader) GetPageContentTyped(rgIndex, colIndex, pageIndex int, geoFormat GeoFormat) ([]TypedValue, error) {
+	typed, _, err := pr.GetPageContentTypedRange(rgIndex, colIndex, pageIndex, geoFormat, PageRange{})
+	return typed, err
+}
+
+// typedValues converts values of a column chunk starting at value start to
+// JSON-typed data with their levels. start is -1 for dictionary values.
+func (pr *ParquetReader) typedValues(rgIndex, colIndex, start int, rawValues []interface{}, rls, dls []int32, geoFormat GeoFormat) ([]TypedValue, error) {
+	meta := pr.metadata.RowGroups[rgIndex].Columns[colIndex].MetaData
+	schemaElem := pr.schemaElement(colIndex)
+
+	decoder := pr.decoderFor(colIndex)
+	typed := make([]TypedValue, len(rawValues))
+	for i, rawVal := range rawValues {
+		typed[i] = TypedValue{
+			Value: typedValue(rawVal, meta.Type, schemaElem, geoFormat),
+			Raw:   rawJSONValue(rawVal),
+		}
+		if decoded, ok := decodeValue(decoder, rawVal); ok {
+			typed[i].Value = decoded
+		}
+		if i < len(dls) {
+			typed[i].DefinitionLevel = dls[i]
+		}
+		if i < len(rls) {
+			typed[i].RepetitionLevel = rls[i]
+		}
+	}
+
+	// VARIANT values are rebuilt from all the columns of the group
+	if start < 0 {
+		return typed, nil
+	}
+	values, present, ok, err := pr.readVariantPage(rgIndex, colIndex, start, len(rawValues))
+	if !ok {
+		return typed, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range typed {
+		typed[i].Value = nil
+		if present[i] {
+			typed[i].Value = values[i]
+		}
+	}
+	return typed, nil
+}
+
+// typedValue applies the logical type of a column to a physical value for
+// JSON output
+func typedValue(val any, parquetType parquet.Type, schemaElem *parquet.SchemaElement, geoFormat GeoFormat) any {
+	if val == nil {
+		// Zero-length strings may come back as nil, same as in page listings
+		if schemaElem != nil && schemaElem.LogicalType != nil && schemaElem.LogicalType.IsSetSTRING() {
+			return ""
+		}
+		return nil
+	}
+
+	kind := valueKind(parquetType, schemaElem)
+	if geoFormat == GeoFormatWKT && (kind == ValueKindGeometry || kind == ValueKindGeography) {
+		if wkt, err := WKBToWKT(physicalBytes(val)); err == nil {
+			return wkt
+		}
+	}
+
+	converted := convertValue(val, parquetType, schemaElem)
+	if str, ok := converted.(string); ok && schemaElem != nil && json.Valid([]byte(str)) {
+		// Exact decimals are kept as number literals
+		if _, isDecimal := decimalScale(schemaElem); isDecimal {
+			return json.Number(str)
+		}
+	}
+	if str, ok := converted.(string); ok && kind == ValueKindJSON && json.Valid([]byte(str)) {
+		return json.RawMessage(str)
+	}
+	return jsonFloat(converted)
+}
+
+// rawJSONValue renders a physical value for JSON output, binaries as base64
+func rawJSONValue(val any) any {
+	switch v := val.(type) {
+	case string:
+		return base64.StdEncoding.EncodeToString([]byte(v))
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	}
+	return jsonFloat(val)
+}
+
+// jsonFloat replaces NaN and infinities, which JSON cannot represent, with
+// their names
+func jsonFloat(val any) any {
+	var f float64
+	switch v := val.(type) {
+	case float32:
+		f = float64(v)
+	case float64:
+		f = v
+	default:
+		return val
+	}
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	return val
+}
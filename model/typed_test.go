@@ -0,0 +1,110 @@
+package model
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPageContentTyped(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	tests := []struct {
+		name     string
+		colIndex int
+		page     int
+		expected string
+	}{
+		{"int96", 3, 0, `{"Value":"2022-01-01T00:00:00.000000000Z","Raw":"AAAAAAAAAAC9hyUA","DefinitionLevel":0,"RepetitionLevel":0}`},
+		{"json object", 10, 0, `{"Value":{"2":2},"Raw":"eyIyIjoyfQ==","DefinitionLevel":0,"RepetitionLevel":0}`},
+		{"bson object", 11, 0, `{"Value":{"4":4},"Raw":"DAAAABA0AAQAAAAA","DefinitionLevel":0,"RepetitionLevel":0}`},
+		{"variant object", 15, 1, `{"Value":{"0":0},"Raw":"AgEAAAkYAAAAAAAAAAA=","DefinitionLevel":0,"RepetitionLevel":0}`},
+		{"exact decimal", 40, 0, `{"Value":0.22,"Raw":22,"DefinitionLevel":0,"RepetitionLevel":0}`},
+		{"string", 17, 0, `{"Value":"UTF8-2","Raw":"VVRGOC0y","DefinitionLevel":0,"RepetitionLevel":0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := pr.GetPageContentTyped(0, tt.colIndex, tt.page, GeoFormatGeoJSON)
+			require.NoError(t, err)
+			require.NotEmpty(t, values)
+			actual, err := json.Marshal(values[0])
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expected, string(actual))
+		})
+	}
+
+	t.Run("geospatial as WKT", func(t *testing.T) {
+		values, err := openGeoTestReader(t).GetPageContentTyped(0, 1, 0, GeoFormatWKT)
+		require.NoError(t, err)
+		require.Equal(t, "POINT (0 0)", values[0].Value)
+	})
+
+	t.Run("nulls and levels", func(t *testing.T) {
+		values, err := openShreddedVariantReader(t).GetPageContentTyped(0, 4, 0, GeoFormatGeoJSON)
+		require.NoError(t, err)
+		require.Equal(t, []TypedValue{
+			{Value: int64(3), Raw: int64(3), DefinitionLevel: 2},
+			{Value: nil, Raw: nil, DefinitionLevel: 0},
+		}, values)
+	})
+
+	t.Run("variant", func(t *testing.T) {
+		pr := openShreddedVariantReader(t)
+		pages, err := pr.GetPageMetadataList(0, 1)
+		require.NoError(t, err)
+		var values []TypedValue
+		for i, page := range pages {
+			if page.PageType == "DICTIONARY_PAGE" {
+				continue
+			}
+			typed, err := pr.GetPageContentTyped(0, 1, i, GeoFormatGeoJSON)
+			require.NoError(t, err)
+			values = append(values, typed...)
+		}
+		require.Len(t, values, 5)
+		require.Equal(t, map[string]any{"Age": int64(3), "Tags": []any{"t1", "x"}}, values[0].Value)
+		require.Nil(t, values[1].Value)
+		require.Equal(t, "x", values[2].Value)
+	})
+
+	t.Run("invalid index", func(t *testing.T) {
+		_, err := pr.GetPageContentTyped(0, 999, 0, GeoFormatGeoJSON)
+		require.ErrorIs(t, err, ErrInvalidColumnIndex)
+	})
+}
+
+func Test_typedValue(t *testing.T) {
+	jsonType := parquet.ConvertedType_JSON
+	require.Nil(t, typedValue(nil, parquet.Type_INT32, nil, GeoFormatGeoJSON))
+	require.Equal(t, "", typedValue(nil, parquet.Type_BYTE_ARRAY, &parquet.SchemaElement{
+		LogicalType: &parquet.LogicalType{STRING: parquet.NewStringType()},
+	}, GeoFormatGeoJSON))
+	require.Equal(t, int32(7), typedValue(int32(7), parquet.Type_INT32, nil, GeoFormatGeoJSON))
+	require.Equal(t, "NaN", typedValue(math.NaN(), parquet.Type_DOUBLE, nil, GeoFormatGeoJSON))
+
+	// Invalid JSON stays a string
+	require.Equal(t, "{", typedValue("{", parquet.Type_BYTE_ARRAY, &parquet.SchemaElement{ConvertedType: &jsonType}, GeoFormatGeoJSON))
+	require.Equal(t, json.RawMessage(`[1]`), typedValue("[1]", parquet.Type_BYTE_ARRAY, &parquet.SchemaElement{ConvertedType: &jsonType}, GeoFormatGeoJSON))
+
+	decimal := &parquet.SchemaElement{LogicalType: &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: 30, Scale: 2}}}
+	require.Equal(t, json.Number("-1.05"), typedValue(int64(-105), parquet.Type_INT64, decimal, GeoFormatGeoJSON))
+}
+
+func Test_rawJSONValue(t *testing.T) {
+	require.Equal(t, "YWI=", rawJSONValue("ab"))
+	require.Equal(t, "YWI=", rawJSONValue([]byte("ab")))
+	require.Equal(t, int64(1), rawJSONValue(int64(1)))
+	require.Equal(t, "+Inf", rawJSONValue(float32(math.Inf(1))))
+	require.Nil(t, rawJSONValue(nil))
+}
+
+func Test_jsonFloat(t *testing.T) {
+	require.Equal(t, "NaN", jsonFloat(float32(math.NaN())))
+	require.Equal(t, "+Inf", jsonFloat(math.Inf(1)))
+	require.Equal(t, "-Inf", jsonFloat(math.Inf(-1)))
+	require.Equal(t, 1.5, jsonFloat(1.5))
+	require.Equal(t, "x", jsonFloat("x"))
+}
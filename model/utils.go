@@ -2,7 +2,6 @@ package model
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hangxie/parquet-go/v3/parquet"
 )
@@ -19,93 +18,6 @@ func countLeafColumns(schema []*parquet.SchemaElement) int {
 	return count
 }
 
-// findSchemaElement finds the schema element for a given path
-//
-//nolint:gocognit // Complex path matching with stack-based tree traversal - inherent complexity
-func findSchemaElement(schema []*parquet.SchemaElement, pathInSchema []string) *parquet.SchemaElement {
-	if len(pathInSchema) == 0 || len(schema) == 0 {
-		return nil
-	}
-
-	// The schema is stored as a flat list in depth-first pre-order traversal
-	// We need to reconstruct paths to find the correct element
-
-	// Build a stack-based traversal to match the full path
-	type stackEntry struct {
-		path       []string
-		childCount int
-	}
-
-	var stack []stackEntry
-	var candidates []*parquet.SchemaElement
-
-	for _, elem := range schema {
-		// Skip root element
-		if elem.Name == "Parquet_go_root" || elem.Name == "" {
-			continue
-		}
-
-		// Pop completed parent nodes from stack
-		for len(stack) > 0 {
-			top := &stack[len(stack)-1]
-			if top.childCount > 0 {
-				top.childCount--
-				break
-			}
-			stack = stack[:len(stack)-1]
-		}
-
-		// Build current path
-		currentPath := make([]string, 0, len(stack)+1)
-		for _, entry := range stack {
-			currentPath = append(currentPath, entry.path[len(entry.path)-1])
-		}
-		currentPath = append(currentPath, elem.Name)
-
-		// Check if this matches our target path
-		if len(currentPath) == len(pathInSchema) {
-			match := true
-			for i := range pathInSchema {
-				// Case-insensitive match to handle Key_value vs key_value
-				if !strings.EqualFold(pathInSchema[i], currentPath[i]) {
-					match = false
-					break
-				}
-			}
-			if match {
-				candidates = append(candidates, elem)
-			}
-		}
-
-		// Push current element to stack if it has children
-		childCount := 0
-		if elem.NumChildren != nil {
-			childCount = int(*elem.NumChildren)
-		}
-		if childCount > 0 {
-			stack = append(stack, stackEntry{
-				path:       currentPath,
-				childCount: childCount,
-			})
-		}
-	}
-
-	// Return the first matching candidate
-	if len(candidates) > 0 {
-		return candidates[0]
-	}
-
-	// Fallback: match just the leaf name (for backward compatibility with simple schemas)
-	leafName := pathInSchema[len(pathInSchema)-1]
-	for _, elem := range schema {
-		if strings.EqualFold(elem.Name, leafName) {
-			return elem
-		}
-	}
-
-	return nil
-}
-
 // formatLogicalType formats the logical type for display
 func formatLogicalType(logicalType *parquet.LogicalType) string {
 	if logicalType == nil {
@@ -125,67 +125,6 @@ func Test_FormatLogicalType(t *testing.T) {
 	}
 }
 
-// Test findSchemaElement with various paths
-func Test_FindSchemaElement(t *testing.T) {
-	schema := []*parquet.SchemaElement{
-		{Name: "Parquet_go_root", NumChildren: intPtr(3)},
-		{Name: "field1", Type: parquetTypePtr(parquet.Type_INT32)},
-		{Name: "group1", NumChildren: intPtr(2)},
-		{Name: "nested1", Type: parquetTypePtr(parquet.Type_INT64)},
-		{Name: "nested2", Type: parquetTypePtr(parquet.Type_BOOLEAN)},
-		{Name: "field2", Type: parquetTypePtr(parquet.Type_BYTE_ARRAY)},
-	}
-
-	tests := []struct {
-		name     string
-		path     []string
-		expected string // expected element name or empty if nil
-	}{
-		{
-			name:     "Empty path",
-			path:     []string{},
-			expected: "",
-		},
-		{
-			name:     "Root level field",
-			path:     []string{"field1"},
-			expected: "field1",
-		},
-		{
-			name:     "Nested field",
-			path:     []string{"group1", "nested1"},
-			expected: "nested1",
-		},
-		{
-			name:     "Case insensitive match",
-			path:     []string{"FIELD1"},
-			expected: "field1",
-		},
-		{
-			name:     "Non-existent path",
-			path:     []string{"does_not_exist"},
-			expected: "",
-		},
-		{
-			name:     "Partial match only",
-			path:     []string{"group1"},
-			expected: "group1",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := findSchemaElement(schema, tt.path)
-			if tt.expected == "" {
-				require.Nil(t, result)
-			} else {
-				require.NotNil(t, result)
-				require.Equal(t, tt.expected, result.Name)
-			}
-		})
-	}
-}
-
 // Test formatLogicalType with time types
 func Test_FormatLogicalType_TimeTypes(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,236 @@
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+)
+
+// Statistics that can be fetched in full
+const (
+	StatisticMin = "min"
+	StatisticMax = "max"
+)
+
+// Kinds of value, they decide how a value is rendered in full
+const (
+	ValueKindJSON      = "json"
+	ValueKindBSON      = "bson"
+	ValueKindUUID      = "uuid"
+	ValueKindGeometry  = "geometry"
+	ValueKindGeography = "geography"
+	ValueKindVariant   = "variant"
+	ValueKindString    = "string"
+	ValueKindBinary    = "binary"
+	ValueKindScalar    = "scalar"
+)
+
+// ValueDetail is a single value shown in full, without the truncation of
+// page listings and statistics, along with its raw bytes
+type ValueDetail struct {
+	Path        string // dotted path of the column
+	Kind        string
+	Null        bool
+	Formatted   string // JSON, BSON, VARIANT, GeoJSON and decoded values are pretty-printed
+	WKT         string `json:",omitempty"` // GEOMETRY and GEOGRAPHY values only
+	Decoder     string `json:",omitempty"` // custom decoder of the column
+	DecodeError string `json:",omitempty"` // why the custom decoder failed, Formatted is the regular rendering then
+	Size        int    // length of the raw value in bytes
+	Hex         string
+	Base64      string
+}
+
+// GetPageValueDetail returns one value of a page in full
+func (pr *ParquetReader) GetPageValueDetail(rgIndex, colIndex, pageIndex, valueIndex int) (ValueDetail, error) {
+	values, err := pr.GetPageContent(rgIndex, colIndex, pageIndex)
+	if err != nil {
+		return ValueDetail{}, err
+	}
+	if valueIndex < 0 || valueIndex >= len(values) {
+		return ValueDetail{}, fmt.Errorf("value index %d out of range [0, %d): %w",
+			valueIndex, len(values), ErrInvalidValueIndex)
+	}
+
+	meta := pr.metadata.RowGroups[rgIndex].Columns[colIndex].MetaData
+	schemaElem := pr.schemaElement(colIndex)
+	val := values[valueIndex]
+	// Zero-length strings may come back as nil, same as in page listings
+	if val == nil && schemaElem != nil && schemaElem.LogicalType != nil && schemaElem.LogicalType.IsSetSTRING() {
+		val = ""
+	}
+	detail := pr.display.describeValue(val, physicalBytes(val), meta.Type, schemaElem)
+	decodeDetail(&detail, pr.decoderFor(colIndex), physicalBytes(val))
+	detail.Path = pr.columnName(colIndex)
+
+	// VARIANT values are rebuilt from all the columns of the group
+	pages, err := pr.GetPageMetadataList(rgIndex, colIndex)
+	if err == nil && pages[pageIndex].PageType != "DICTIONARY_PAGE" {
+		start := int(pageValueStart(pages, pageIndex)) + valueIndex
+		formatted, ok, err := pr.formatVariantPage(rgIndex, colIndex, start, 1)
+		if ok && err != nil {
+			return ValueDetail{}, err
+		}
+		if ok {
+			detail.Kind = ValueKindVariant
+			detail.Formatted = prettyJSON(formatted[0])
+		}
+	}
+
+	return detail, nil
+}
+
+// GetColumnChunkStatistic returns the min or max statistic of a column chunk in full
+func (pr *ParquetReader) GetColumnChunkStatistic(rgIndex, colIndex int, stat string) (ValueDetail, error) {
+	if _, err := pr.GetColumnChunkInfo(rgIndex, colIndex); err != nil {
+		return ValueDetail{}, err
+	}
+
+	meta := pr.metadata.RowGroups[rgIndex].Columns[colIndex].MetaData
+	return pr.describeStatistic(colIndex, meta, meta.Statistics, stat)
+}
+
+// GetPageStatistic returns the min or max statistic of a data page in full
+func (pr *ParquetReader) GetPageStatistic(rgIndex, colIndex, pageIndex int, stat string) (ValueDetail, error) {
+	if _, err := pr.GetPageMetadata(rgIndex, colIndex, pageIndex); err != nil {
+		return ValueDetail{}, err
+	}
+
+	meta := pr.metadata.RowGroups[rgIndex].Columns[colIndex].MetaData
+	headers, err := pr.pageHeaders(rgIndex, colIndex)
+	if err != nil {
+		return ValueDetail{}, err
+	}
+	return pr.describeStatistic(colIndex, meta, headers[pageIndex].Statistics, stat)
+}
+
+// describeStatistic decodes the min or max value of statistics
+func (pr *ParquetReader) describeStatistic(colIndex int, meta *parquet.ColumnMetaData, stats *parquet.Statistics, stat string) (ValueDetail, error) {
+	if stat != StatisticMin && stat != StatisticMax {
+		return ValueDetail{}, fmt.Errorf("%w: %q (expected %q or %q)", ErrInvalidStatistic, stat, StatisticMin, StatisticMax)
+	}
+
+	var raw []byte
+	if stats != nil {
+		// Prefer MinValue/MaxValue over deprecated Min/Max
+		if stat == StatisticMin {
+			raw = stats.MinValue
+			if len(raw) == 0 {
+				raw = stats.Min
+			}
+		} else {
+			raw = stats.MaxValue
+			if len(raw) == 0 {
+				raw = stats.Max
+			}
+		}
+	}
+	if len(raw) == 0 {
+		return ValueDetail{}, fmt.Errorf("%s value of %s: %w", stat, pr.columnName(colIndex), ErrNoStatistic)
+	}
+
+	schemaElem := pr.schemaElement(colIndex)
+	detail := pr.display.describeValue(retrieveStatValue(raw, meta.Type), raw, meta.Type, schemaElem)
+	decodeDetail(&detail, pr.decoderFor(colIndex), raw)
+	detail.Path = pr.columnName(colIndex)
+	return detail, nil
+}
+
+// describeValue renders a value in full along with its raw bytes
+func (o DisplayOptions) describeValue(val any, raw []byte, parquetType parquet.Type, schemaElem *parquet.SchemaElement) ValueDetail {
+	detail := ValueDetail{Kind: valueKind(parquetType, schemaElem)}
+	if val == nil {
+		detail.Null = true
+		detail.Formatted = "NULL"
+		return detail
+	}
+
+	detail.Size = len(raw)
+	detail.Hex = hex.EncodeToString(raw)
+	detail.Base64 = base64.StdEncoding.EncodeToString(raw)
+	detail.Formatted = o.formatFullValue(val, parquetType, schemaElem)
+
+	switch detail.Kind {
+	case ValueKindJSON, ValueKindBSON:
+		detail.Formatted = prettyJSON(detail.Formatted)
+	case ValueKindGeometry, ValueKindGeography:
+		detail.Formatted = prettyJSON(detail.Formatted)
+		if wkt, err := WKBToWKT(raw); err == nil {
+			detail.WKT = wkt
+		}
+	}
+	return detail
+}
+
+// valueKind classifies a column by how its values are rendered in full
+func valueKind(parquetType parquet.Type, se *parquet.SchemaElement) string {
+	if se != nil && se.LogicalType != nil {
+		switch lt := se.LogicalType; {
+		case lt.IsSetJSON():
+			return ValueKindJSON
+		case lt.IsSetBSON():
+			return ValueKindBSON
+		case lt.IsSetUUID():
+			return ValueKindUUID
+		case lt.IsSetGEOMETRY():
+			return ValueKindGeometry
+		case lt.IsSetGEOGRAPHY():
+			return ValueKindGeography
+		case lt.IsSetSTRING(), lt.IsSetENUM():
+			return ValueKindString
+		}
+	}
+	if se != nil && se.ConvertedType != nil {
+		switch *se.ConvertedType {
+		case parquet.ConvertedType_JSON:
+			return ValueKindJSON
+		case parquet.ConvertedType_BSON:
+			return ValueKindBSON
+		case parquet.ConvertedType_UTF8, parquet.ConvertedType_ENUM:
+			return ValueKindString
+		}
+	}
+	if (parquetType == parquet.Type_BYTE_ARRAY || parquetType == parquet.Type_FIXED_LEN_BYTE_ARRAY) &&
+		(se == nil || (se.LogicalType == nil && se.ConvertedType == nil)) {
+		return ValueKindBinary
+	}
+	return ValueKindScalar
+}
+
+// physicalBytes encodes a value read from a page back to its PLAIN bytes
+func physicalBytes(val any) []byte {
+	switch v := val.(type) {
+	case bool:
+		if v {
+			return []byte{1}
+		}
+		return []byte{0}
+	case int32:
+		return binary.LittleEndian.AppendUint32(nil, uint32(v))
+	case int64:
+		return binary.LittleEndian.AppendUint64(nil, uint64(v))
+	case float32:
+		return binary.LittleEndian.AppendUint32(nil, math.Float32bits(v))
+	case float64:
+		return binary.LittleEndian.AppendUint64(nil, math.Float64bits(v))
+	case string:
+		return []byte(v)
+	case []byte:
+		return v
+	}
+	return nil
+}
+
+// prettyJSON indents JSON text, anything else is returned unchanged
+func prettyJSON(text string) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, []byte(text), "", "  "); err != nil {
+		return text
+	}
+	return out.String()
+}
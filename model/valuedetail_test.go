@@ -0,0 +1,186 @@
+package model
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPageValueDetail(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	t.Run("scalar", func(t *testing.T) {
+		detail, err := pr.GetPageValueDetail(0, 3, 0, 1)
+		require.NoError(t, err)
+		require.Equal(t, ValueDetail{
+			Path:      "Int96",
+			Kind:      ValueKindScalar,
+			Formatted: "2022-01-01T01:01:01.001001000Z",
+			Size:      12,
+			Hex:       "2808aa6454030000bd872500",
+			Base64:    "KAiqZFQDAAC9hyUA",
+		}, detail)
+	})
+
+	t.Run("json pretty-printed", func(t *testing.T) {
+		detail, err := pr.GetPageValueDetail(0, 10, 0, 1)
+		require.NoError(t, err)
+		require.Equal(t, ValueKindJSON, detail.Kind)
+		require.Equal(t, "{\n  \"3\": 3\n}", detail.Formatted)
+		require.Equal(t, hex.EncodeToString([]byte(`{"3":3}`)), detail.Hex)
+	})
+
+	t.Run("bson pretty-printed", func(t *testing.T) {
+		detail, err := pr.GetPageValueDetail(0, 11, 0, 1)
+		require.NoError(t, err)
+		require.Equal(t, ValueKindBSON, detail.Kind)
+		require.Equal(t, "{\n  \"2\": 2\n}", detail.Formatted)
+	})
+
+	t.Run("uuid", func(t *testing.T) {
+		detail, err := pr.GetPageValueDetail(0, 9, 0, 1)
+		require.NoError(t, err)
+		require.Equal(t, ValueKindUUID, detail.Kind)
+		require.Equal(t, "01010101-0101-0101-0101-010101010101", detail.Formatted)
+		require.Equal(t, 16, detail.Size)
+	})
+
+	t.Run("variant", func(t *testing.T) {
+		detail, err := pr.GetPageValueDetail(0, 15, 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, ValueKindVariant, detail.Kind)
+		require.Equal(t, "{\n  \"1\": 1\n}", detail.Formatted)
+	})
+
+	t.Run("geography", func(t *testing.T) {
+		detail, err := openGeoTestReader(t).GetPageValueDetail(0, 1, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, ValueKindGeography, detail.Kind)
+		require.Equal(t, "POINT (0 0)", detail.WKT)
+		require.Contains(t, detail.Formatted, "\n  \"geometry\": {")
+	})
+
+	t.Run("invalid indexes", func(t *testing.T) {
+		_, err := pr.GetPageValueDetail(0, 3, 0, -1)
+		require.ErrorIs(t, err, ErrInvalidValueIndex)
+		_, err = pr.GetPageValueDetail(0, 3, 0, 1<<20)
+		require.ErrorIs(t, err, ErrInvalidValueIndex)
+		_, err = pr.GetPageValueDetail(0, 3, 99, 0)
+		require.ErrorIs(t, err, ErrInvalidPageIndex)
+		_, err = pr.GetPageValueDetail(99, 3, 0, 0)
+		require.ErrorIs(t, err, ErrInvalidRowGroupIndex)
+	})
+}
+
+func Test_GetColumnChunkStatistic(t *testing.T) {
+	pr := openTestParquetReader(t)
+
+	detail, err := pr.GetColumnChunkStatistic(0, 17, StatisticMax)
+	require.NoError(t, err)
+	require.Equal(t, ValueDetail{Path: "Utf8", Kind: ValueKindString, Formatted: "UTF8-4", Size: 6, Hex: "555446382d34", Base64: "VVRGOC00"}, detail)
+
+	detail, err = pr.GetColumnChunkStatistic(0, 43, StatisticMin)
+	require.NoError(t, err)
+	require.Equal(t, "0.00", detail.Formatted)
+
+	_, err = pr.GetColumnChunkStatistic(0, 17, "avg")
+	require.ErrorIs(t, err, ErrInvalidStatistic)
+
+	// INTERVAL has no defined sort order so writers leave it out
+	_, err = pr.GetColumnChunkStatistic(0, 39, StatisticMax)
+	require.ErrorIs(t, err, ErrNoStatistic)
+
+	_, err = pr.GetColumnChunkStatistic(0, 999, StatisticMin)
+	require.ErrorIs(t, err, ErrInvalidColumnIndex)
+}
+
+func Test_GetPageStatistic(t *testing.T) {
+	pr := openTestParquetReader(t)
+	pages, err := pr.GetPageMetadataList(0, 1)
+	require.NoError(t, err)
+
+	checked := 0
+	for i, page := range pages {
+		if page.MaxValue == "" {
+			continue
+		}
+		detail, err := pr.GetPageStatistic(0, 1, i, StatisticMax)
+		require.NoError(t, err)
+		require.Equal(t, page.MaxValue, detail.Formatted)
+		checked++
+	}
+	require.NotZero(t, checked)
+
+	_, err = pr.GetPageStatistic(0, 1, len(pages), StatisticMax)
+	require.ErrorIs(t, err, ErrInvalidPageIndex)
+}
+
+func Test_describeStatistic(t *testing.T) {
+	pr := openTestParquetReader(t)
+	int32Type := parquet.Type_INT32
+	meta := &parquet.ColumnMetaData{Type: int32Type, PathInSchema: []string{"Int32"}}
+
+	// Deprecated Min/Max are used when MinValue/MaxValue are missing
+	detail, err := pr.describeStatistic(1, meta, &parquet.Statistics{Min: []byte{7, 0, 0, 0}}, StatisticMin)
+	require.NoError(t, err)
+	require.Equal(t, "7", detail.Formatted)
+
+	_, err = pr.describeStatistic(1, meta, nil, StatisticMin)
+	require.ErrorIs(t, err, ErrNoStatistic)
+}
+
+func Test_describeValue(t *testing.T) {
+	detail := DisplayOptions{}.describeValue(nil, nil, parquet.Type_INT32, nil)
+	require.Equal(t, ValueDetail{Kind: ValueKindScalar, Null: true, Formatted: "NULL"}, detail)
+
+	// Long values are not truncated
+	long := strings.Repeat("x", 500)
+	detail = DisplayOptions{}.describeValue(long, []byte(long), parquet.Type_BYTE_ARRAY, &parquet.SchemaElement{
+		LogicalType: &parquet.LogicalType{STRING: parquet.NewStringType()},
+	})
+	require.Equal(t, long, detail.Formatted)
+	require.Equal(t, 500, detail.Size)
+}
+
+func Test_valueKind(t *testing.T) {
+	utf8, jsonType := parquet.ConvertedType_UTF8, parquet.ConvertedType_JSON
+	tests := []struct {
+		name     string
+		pT       parquet.Type
+		se       *parquet.SchemaElement
+		expected string
+	}{
+		{"no schema binary", parquet.Type_BYTE_ARRAY, nil, ValueKindBinary},
+		{"no schema scalar", parquet.Type_INT64, nil, ValueKindScalar},
+		{"converted UTF8", parquet.Type_BYTE_ARRAY, &parquet.SchemaElement{ConvertedType: &utf8}, ValueKindString},
+		{"converted JSON", parquet.Type_BYTE_ARRAY, &parquet.SchemaElement{ConvertedType: &jsonType}, ValueKindJSON},
+		{"logical UUID", parquet.Type_FIXED_LEN_BYTE_ARRAY, &parquet.SchemaElement{LogicalType: &parquet.LogicalType{UUID: parquet.NewUUIDType()}}, ValueKindUUID},
+		{"logical GEOMETRY", parquet.Type_BYTE_ARRAY, &parquet.SchemaElement{LogicalType: &parquet.LogicalType{GEOMETRY: parquet.NewGeometryType()}}, ValueKindGeometry},
+		{"decimal FLBA", parquet.Type_FIXED_LEN_BYTE_ARRAY, &parquet.SchemaElement{LogicalType: &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: 5}}}, ValueKindScalar},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, valueKind(tt.pT, tt.se))
+		})
+	}
+}
+
+func Test_physicalBytes(t *testing.T) {
+	require.Equal(t, []byte{1}, physicalBytes(true))
+	require.Equal(t, []byte{0}, physicalBytes(false))
+	require.Equal(t, []byte{0xff, 0xff, 0xff, 0xff}, physicalBytes(int32(-1)))
+	require.Equal(t, []byte{2, 0, 0, 0, 0, 0, 0, 0}, physicalBytes(int64(2)))
+	require.Equal(t, []byte{0, 0, 0x80, 0x3f}, physicalBytes(float32(1)))
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0xf0, 0x3f}, physicalBytes(float64(1)))
+	require.Equal(t, []byte("ab"), physicalBytes("ab"))
+	require.Equal(t, []byte("ab"), physicalBytes([]byte("ab")))
+	require.Nil(t, physicalBytes(struct{}{}))
+}
+
+func Test_prettyJSON(t *testing.T) {
+	require.Equal(t, "[\n  1,\n  2\n]", prettyJSON("[1,2]"))
+	require.Equal(t, "not json", prettyJSON("not json"))
+}
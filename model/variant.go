@@ -0,0 +1,464 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/types"
+)
+
+// Roles of the leaf columns a VARIANT group is stored in
+const (
+	VariantRoleMetadata = "metadata"
+	VariantRoleValue    = "value"
+	VariantRoleShredded = "shredded" // anything under typed_value
+)
+
+// VariantColumn describes a VARIANT group and the leaf columns holding it
+type VariantColumn struct {
+	Path     string
+	Shredded bool
+	Columns  []VariantLeaf
+}
+
+// VariantLeaf is one leaf column of a VARIANT group
+type VariantLeaf struct {
+	ColumnIndex int
+	Path        string
+	Role        string
+}
+
+// VariantMember tells which VARIANT group a leaf column belongs to
+type VariantMember struct {
+	Path     string
+	Role     string
+	Shredded bool
+}
+
+// schemaNode is an element of the schema tree with the maximum definition
+// and repetition levels of its position
+type schemaNode struct {
+	elem     *parquet.SchemaElement
+	path     []string
+	children []*schemaNode
+	maxDef   int32
+	maxRep   int32
+	leaf     int // leaf column index, -1 for groups
+}
+
+// leafColumn holds the values and levels of one leaf column chunk
+type leafColumn struct {
+	values []any
+	rls    []int32
+	dls    []int32
+}
+
+// variantSegment maps each leaf column under a node to the range of its
+// entries belonging to one instance of that node
+type variantSegment map[int][2]int
+
+// buildSchemaTree rebuilds the tree of the flattened depth-first schema
+func buildSchemaTree(schema []*parquet.SchemaElement) *schemaNode {
+	if len(schema) == 0 {
+		return nil
+	}
+
+	pos, leaf := 0, 0
+	var build func(path []string, maxDef, maxRep int32) *schemaNode
+	build = func(path []string, maxDef, maxRep int32) *schemaNode {
+		elem := schema[pos]
+		pos++
+		node := &schemaNode{elem: elem, path: path, maxDef: maxDef, maxRep: maxRep, leaf: -1}
+		numChildren := int(elem.GetNumChildren())
+		for i := 0; i < numChildren && pos < len(schema); i++ {
+			child := schema[pos]
+			def, rep := maxDef, maxRep
+			switch child.GetRepetitionType() {
+			case parquet.FieldRepetitionType_OPTIONAL:
+				def++
+			case parquet.FieldRepetitionType_REPEATED:
+				def++
+				rep++
+			}
+			node.children = append(node.children, build(append(slices.Clone(path), child.Name), def, rep))
+		}
+		if numChildren == 0 && elem.IsSetType() {
+			node.leaf = leaf
+			leaf++
+		}
+		return node
+	}
+	return build(nil, 0, 0)
+}
+
+// child returns the child with the given name, ignoring case like parquet-go
+// does for the VARIANT fields
+func (n *schemaNode) child(name string) *schemaNode {
+	for _, c := range n.children {
+		if strings.EqualFold(c.elem.Name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// leaves returns the leaf nodes under n in column order
+func (n *schemaNode) leaves() []*schemaNode {
+	if n.leaf >= 0 {
+		return []*schemaNode{n}
+	}
+	var result []*schemaNode
+	for _, c := range n.children {
+		result = append(result, c.leaves()...)
+	}
+	return result
+}
+
+// isVariant tells whether n is a VARIANT group with its metadata column
+func (n *schemaNode) isVariant() bool {
+	return n.elem.LogicalType != nil && n.elem.LogicalType.IsSetVARIANT() && n.child("metadata") != nil
+}
+
+// variantNodes returns the VARIANT groups under n
+func (n *schemaNode) variantNodes() []*schemaNode {
+	if n.isVariant() {
+		return []*schemaNode{n}
+	}
+	var result []*schemaNode
+	for _, c := range n.children {
+		result = append(result, c.variantNodes()...)
+	}
+	return result
+}
+
+// variantRole is the role of the leaf in the VARIANT group n
+func (n *schemaNode) variantRole(leaf *schemaNode) string {
+	if len(leaf.path) == len(n.path)+1 {
+		name := leaf.path[len(n.path)]
+		switch {
+		case strings.EqualFold(name, VariantRoleMetadata):
+			return VariantRoleMetadata
+		case strings.EqualFold(name, VariantRoleValue):
+			return VariantRoleValue
+		}
+	}
+	return VariantRoleShredded
+}
+
+// GetVariantColumns returns every VARIANT group of the schema with the role
+// of each of its leaf columns
+func (pr *ParquetReader) GetVariantColumns() []VariantColumn {
+	variants := []VariantColumn{}
+	if pr == nil || pr.metadata == nil {
+		return variants
+	}
+
+	root := buildSchemaTree(pr.metadata.Schema)
+	if root == nil {
+		return variants
+	}
+	for _, node := range root.variantNodes() {
+		variant := VariantColumn{
+			Path:     formatColumnName(node.path),
+			Shredded: node.child("typed_value") != nil,
+		}
+		for _, leaf := range node.leaves() {
+			variant.Columns = append(variant.Columns, VariantLeaf{
+				ColumnIndex: leaf.leaf,
+				Path:        pr.columnName(leaf.leaf),
+				Role:        node.variantRole(leaf),
+			})
+		}
+		variants = append(variants, variant)
+	}
+	return variants
+}
+
+// findVariant returns the VARIANT group holding leaf column colIndex and
+// the leaf itself, nil when the column is not part of a VARIANT
+func findVariant(root *schemaNode, colIndex int) (*schemaNode, *schemaNode) {
+	if root == nil {
+		return nil, nil
+	}
+	for _, node := range root.variantNodes() {
+		for _, leaf := range node.leaves() {
+			if leaf.leaf == colIndex {
+				return node, leaf
+			}
+		}
+	}
+	return nil, nil
+}
+
+// variantMember returns the VARIANT group leaf column colIndex belongs to, or
+// nil when there is none
+func (pr *ParquetReader) variantMember(colIndex int) *VariantMember {
+	node, leaf := findVariant(buildSchemaTree(pr.metadata.Schema), colIndex)
+	if node == nil {
+		return nil
+	}
+	return &VariantMember{
+		Path:     formatColumnName(node.path),
+		Role:     node.variantRole(leaf),
+		Shredded: node.child("typed_value") != nil,
+	}
+}
+
+// variantChunkSize returns the stored size of the column chunks a VARIANT
+// value of leaf column colIndex is rebuilt from, 0 when formatVariantPage
+// does not rebuild the values of that column
+func (pr *ParquetReader) variantChunkSize(rgIndex, colIndex int) int64 {
+	variant, leaf := findVariant(buildSchemaTree(pr.metadata.Schema), colIndex)
+	if variant == nil || variant.variantRole(leaf) == VariantRoleShredded {
+		return 0
+	}
+	if metadata := variant.child("metadata"); metadata == nil || metadata.leaf < 0 {
+		return 0
+	}
+	var size int64
+	for _, l := range variant.leaves() {
+		size += pr.metadata.RowGroups[rgIndex].Columns[l.leaf].MetaData.TotalCompressedSize
+	}
+	return size
+}
+
+// formatVariantPage decodes the count VARIANT values of a data page of the
+// metadata or value column starting at entry start of the column chunk and
+// renders them as JSON, NULL for missing values. ok is false when colIndex
+// holds no such column.
+func (pr *ParquetReader) formatVariantPage(rgIndex, colIndex, start, count int) (formatted []string, ok bool, err error) {
+	values, present, ok, err := pr.readVariantPage(rgIndex, colIndex, start, count)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+
+	formatted = make([]string, count)
+	for k, value := range values {
+		if !present[k] {
+			formatted[k] = "NULL"
+			continue
+		}
+		formatted[k] = formatVariantJSON(value)
+	}
+	return formatted, true, nil
+}
+
+// readVariantPage decodes the count VARIANT values of a data page of the
+// metadata or value column starting at entry start of the column chunk.
+// The values are rebuilt from the metadata, value and shredded typed_value
+// columns, present is false for missing values. ok is false when colIndex
+// holds no such column.
+func (pr *ParquetReader) readVariantPage(rgIndex, colIndex, start, count int) (values []any, present []bool, ok bool, err error) {
+	variant, leaf := findVariant(buildSchemaTree(pr.metadata.Schema), colIndex)
+	if variant == nil || variant.variantRole(leaf) == VariantRoleShredded {
+		return nil, nil, false, nil
+	}
+
+	metadataNode := variant.child("metadata")
+	if metadataNode.leaf < 0 {
+		return nil, nil, false, nil
+	}
+
+	columns := map[int]leafColumn{}
+	for _, l := range variant.leaves() {
+		values, rls, dls, err := pr.readColumnChunk(rgIndex, l.leaf)
+		if err != nil {
+			return nil, nil, true, err
+		}
+		columns[l.leaf] = leafColumn{values: values, rls: rls, dls: dls}
+	}
+
+	// Every leaf under the VARIANT starts a new instance of it when the
+	// repetition level drops to the level of the group
+	starts := map[int][]int{}
+	for idx, col := range columns {
+		for i, rl := range col.rls {
+			if i == 0 || rl <= variant.maxRep {
+				starts[idx] = append(starts[idx], i)
+			}
+		}
+	}
+
+	metadata := columns[metadataNode.leaf]
+	values, present = make([]any, count), make([]bool, count)
+	for k := range count {
+		entry := start + k
+		if entry >= len(metadata.values) {
+			return nil, nil, true, fmt.Errorf("variant entry %d out of range [0, %d)", entry, len(metadata.values))
+		}
+		if metadata.dls[entry] < metadataNode.maxDef {
+			continue
+		}
+
+		segment := variantSegment{}
+		for idx, s := range starts {
+			if entry >= len(s) {
+				return nil, nil, true, fmt.Errorf("column %d holds %d variant entries, want more than %d", idx, len(s), entry)
+			}
+			end := len(columns[idx].values)
+			if entry+1 < len(s) {
+				end = s[entry+1]
+			}
+			segment[idx] = [2]int{s[entry], end}
+		}
+
+		rebuilder := variantRebuilder{columns: columns, metadata: variantBytes(metadata.values[entry])}
+		values[k], _ = rebuilder.value(variant, segment)
+		present[k] = true
+	}
+	return values, present, true, nil
+}
+
+// variantRebuilder rebuilds one VARIANT value from its leaf columns
+type variantRebuilder struct {
+	columns  map[int]leafColumn
+	metadata []byte
+}
+
+// value rebuilds a group holding value and typed_value fields: the VARIANT
+// itself, a shredded object field or a shredded array element. ok is false
+// when neither is set, meaning the field is missing.
+func (r variantRebuilder) value(n *schemaNode, segment variantSegment) (value any, ok bool) {
+	var typed any
+	typedOK := false
+	if t := n.child("typed_value"); t != nil && r.present(t, segment) {
+		typed, typedOK = r.typed(t, segment), true
+	}
+
+	var encoded any
+	encodedOK := false
+	if v := n.child("value"); v != nil && v.leaf >= 0 {
+		col, rng := r.columns[v.leaf], segment[v.leaf]
+		if rng[0] < rng[1] && col.dls[rng[0]] == v.maxDef {
+			encoded, encodedOK = decodeVariant(r.metadata, variantBytes(col.values[rng[0]])), true
+		}
+	}
+
+	switch {
+	case typedOK && encodedOK:
+		// A partially shredded object keeps its remaining fields in value
+		if object, isObject := typed.(map[string]any); isObject {
+			if rest, isRest := encoded.(map[string]any); isRest {
+				for k, v := range rest {
+					object[k] = v
+				}
+			}
+		}
+		return typed, true
+	case typedOK:
+		return typed, true
+	case encodedOK:
+		return encoded, true
+	}
+	return nil, false
+}
+
+// typed rebuilds a typed_value node: a primitive, an array or an object
+func (r variantRebuilder) typed(n *schemaNode, segment variantSegment) any {
+	if n.leaf >= 0 {
+		col, rng := r.columns[n.leaf], segment[n.leaf]
+		return types.ConvertToJSONType(col.values[rng[0]], n.elem, geospatialOpt)
+	}
+
+	if isListNode(n) {
+		repeated := n.children[0]
+		element := repeated
+		if len(repeated.children) == 1 {
+			element = repeated.children[0]
+		}
+		elements := []any{}
+		for _, sub := range r.split(repeated, segment) {
+			value, _ := r.value(element, sub)
+			elements = append(elements, value)
+		}
+		return elements
+	}
+
+	object := map[string]any{}
+	for _, field := range n.children {
+		if value, ok := r.value(field, segment); ok {
+			object[field.elem.Name] = value
+		}
+	}
+	return object
+}
+
+// present tells whether the optional node n is defined in segment
+func (r variantRebuilder) present(n *schemaNode, segment variantSegment) bool {
+	leaves := n.leaves()
+	if len(leaves) == 0 {
+		return false
+	}
+	col, rng := r.columns[leaves[0].leaf], segment[leaves[0].leaf]
+	return rng[0] < rng[1] && col.dls[rng[0]] >= n.maxDef
+}
+
+// split cuts segment into one segment per occurrence of the repeated node
+func (r variantRebuilder) split(repeated *schemaNode, segment variantSegment) []variantSegment {
+	if !r.present(repeated, segment) {
+		return nil
+	}
+
+	var subs []variantSegment
+	for _, leaf := range repeated.leaves() {
+		col, rng := r.columns[leaf.leaf], segment[leaf.leaf]
+		n := 0
+		for i := rng[0]; i < rng[1]; i++ {
+			if i > rng[0] && col.rls[i] > repeated.maxRep {
+				continue
+			}
+			end := i + 1
+			for end < rng[1] && col.rls[end] > repeated.maxRep {
+				end++
+			}
+			if n == len(subs) {
+				subs = append(subs, variantSegment{})
+			}
+			subs[n][leaf.leaf] = [2]int{i, end}
+			n++
+		}
+	}
+	return subs
+}
+
+// isListNode tells whether n is a LIST annotated group
+func isListNode(n *schemaNode) bool {
+	if len(n.children) != 1 || n.children[0].elem.GetRepetitionType() != parquet.FieldRepetitionType_REPEATED {
+		return false
+	}
+	return (n.elem.LogicalType != nil && n.elem.LogicalType.IsSetLIST()) ||
+		n.elem.GetConvertedType() == parquet.ConvertedType_LIST
+}
+
+// decodeVariant decodes a VARIANT value, falling back to the base64 encoded
+// binaries when they cannot be decoded
+func decodeVariant(metadata, value []byte) any {
+	decoded, err := types.ConvertVariantValue(types.Variant{Metadata: metadata, Value: value})
+	if err != nil {
+		return err.Error()
+	}
+	return decoded
+}
+
+// variantBytes returns the bytes of a BYTE_ARRAY value
+func variantBytes(val any) []byte {
+	switch v := val.(type) {
+	case string:
+		return []byte(v)
+	case []byte:
+		return v
+	}
+	return nil
+}
+
+// formatVariantJSON renders a rebuilt VARIANT value as compact JSON
+func formatVariantJSON(value any) string {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(jsonBytes)
+}
@@ -0,0 +1,250 @@
+package model
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/source/local"
+	"github.com/hangxie/parquet-go/v3/types"
+	"github.com/hangxie/parquet-go/v3/writer"
+	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/require"
+)
+
+type shreddedInt struct {
+	Value      *string `parquet:"name=value, type=BYTE_ARRAY, repetitiontype=OPTIONAL"`
+	TypedValue *int64  `parquet:"name=typed_value, type=INT64, repetitiontype=OPTIONAL"`
+}
+
+type shreddedString struct {
+	Value      *string `parquet:"name=value, type=BYTE_ARRAY, repetitiontype=OPTIONAL"`
+	TypedValue *string `parquet:"name=typed_value, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+}
+
+type shreddedList struct {
+	Value      *string           `parquet:"name=value, type=BYTE_ARRAY, repetitiontype=OPTIONAL"`
+	TypedValue *[]shreddedString `parquet:"name=typed_value, type=LIST, repetitiontype=OPTIONAL"`
+}
+
+type shreddedObject struct {
+	Age  shreddedInt  `parquet:"name=age"`
+	Tags shreddedList `parquet:"name=tags"`
+}
+
+type shreddedVariant struct {
+	Metadata   string          `parquet:"name=metadata, type=BYTE_ARRAY"`
+	Value      *string         `parquet:"name=value, type=BYTE_ARRAY, repetitiontype=OPTIONAL"`
+	TypedValue *shreddedObject `parquet:"name=typed_value, repetitiontype=OPTIONAL"`
+}
+
+type shreddedRow struct {
+	ID  int64           `parquet:"name=id, type=INT64"`
+	Var shreddedVariant `parquet:"name=var"`
+}
+
+// openShreddedVariantReader writes and opens a file with a shredded VARIANT
+// column holding a fully shredded object, a missing value, an unshredded
+// string, a partially shredded object and an empty array, one row per page
+func openShreddedVariantReader(t *testing.T) *ParquetReader {
+	t.Helper()
+
+	metadata := string(types.EncodeVariantMetadata([]string{"age", "name"}))
+	str := string(types.EncodeVariantString("x"))
+	rest := string(types.EncodeVariantObject([]int{1}, [][]byte{types.EncodeVariantString("bob")}))
+	age, tag := int64(3), "t1"
+	tags, empty := []shreddedString{{TypedValue: &tag}, {Value: &str}}, []shreddedString{}
+	rows := []shreddedRow{
+		{ID: 1, Var: shreddedVariant{Metadata: metadata, TypedValue: &shreddedObject{
+			Age:  shreddedInt{TypedValue: &age},
+			Tags: shreddedList{TypedValue: &tags},
+		}}},
+		{ID: 2, Var: shreddedVariant{Metadata: metadata}},
+		{ID: 3, Var: shreddedVariant{Metadata: metadata, Value: &str}},
+		{ID: 4, Var: shreddedVariant{Metadata: metadata, Value: &rest, TypedValue: &shreddedObject{
+			Age: shreddedInt{TypedValue: &age},
+		}}},
+		{ID: 5, Var: shreddedVariant{Metadata: metadata, TypedValue: &shreddedObject{
+			Tags: shreddedList{TypedValue: &empty},
+		}}},
+	}
+
+	path := filepath.Join(t.TempDir(), "variant.parquet")
+	fw, err := local.NewLocalFileWriter(path)
+	require.NoError(t, err)
+	pw, err := writer.NewParquetWriter(fw, new(shreddedRow))
+	require.NoError(t, err)
+	// Annotate the group in the footer only, the writer would otherwise
+	// encode the struct as an unshredded VARIANT
+	group := *pw.Footer.Schema[2]
+	group.LogicalType = &parquet.LogicalType{VARIANT: parquet.NewVariantType()}
+	pw.Footer.Schema[2] = &group
+	for _, row := range rows {
+		require.NoError(t, pw.Write(row))
+	}
+	require.NoError(t, pw.WriteStop())
+	require.NoError(t, fw.Close())
+
+	pr, err := pio.NewParquetFileReader(path, pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = pr.ReadStop() })
+	return NewParquetReader(pr)
+}
+
+func Test_buildSchemaTree(t *testing.T) {
+	pr := openTestParquetReader(t)
+	root := buildSchemaTree(pr.metadata.Schema)
+	require.NotNil(t, root)
+
+	leaves := root.leaves()
+	require.Len(t, leaves, countLeafColumns(pr.metadata.Schema))
+	for i, leaf := range leaves {
+		path := pr.metadata.RowGroups[0].Columns[i].MetaData.PathInSchema
+		require.Equal(t, i, leaf.leaf)
+		require.Equal(t, path, leaf.path)
+		maxDef, maxRep := columnLevels(pr.metadata.Schema, path)
+		require.Equal(t, maxDef, leaf.maxDef, formatColumnName(path))
+		require.Equal(t, maxRep, leaf.maxRep, formatColumnName(path))
+	}
+
+	require.Nil(t, buildSchemaTree(nil))
+}
+
+func Test_GetVariantColumns(t *testing.T) {
+	t.Run("unshredded", func(t *testing.T) {
+		variants := openTestParquetReader(t).GetVariantColumns()
+		require.Len(t, variants, 1)
+		require.Equal(t, "Variant", variants[0].Path)
+		require.False(t, variants[0].Shredded)
+		require.Equal(t, []VariantLeaf{
+			{ColumnIndex: 14, Path: "Variant.Metadata", Role: VariantRoleMetadata},
+			{ColumnIndex: 15, Path: "Variant.Value", Role: VariantRoleValue},
+		}, variants[0].Columns)
+	})
+
+	t.Run("shredded", func(t *testing.T) {
+		variants := openShreddedVariantReader(t).GetVariantColumns()
+		require.Len(t, variants, 1)
+		require.Equal(t, "Var", variants[0].Path)
+		require.True(t, variants[0].Shredded)
+		require.Equal(t, []VariantLeaf{
+			{ColumnIndex: 1, Path: "Var.Metadata", Role: VariantRoleMetadata},
+			{ColumnIndex: 2, Path: "Var.Value", Role: VariantRoleValue},
+			{ColumnIndex: 3, Path: "Var.Typed_value.Age.Value", Role: VariantRoleShredded},
+			{ColumnIndex: 4, Path: "Var.Typed_value.Age.Typed_value", Role: VariantRoleShredded},
+			{ColumnIndex: 5, Path: "Var.Typed_value.Tags.Value", Role: VariantRoleShredded},
+			{ColumnIndex: 6, Path: "Var.Typed_value.Tags.Typed_value.List.Element.Value", Role: VariantRoleShredded},
+			{ColumnIndex: 7, Path: "Var.Typed_value.Tags.Typed_value.List.Element.Typed_value", Role: VariantRoleShredded},
+		}, variants[0].Columns)
+	})
+
+	t.Run("no variant", func(t *testing.T) {
+		require.Empty(t, openGeoTestReader(t).GetVariantColumns())
+		require.Empty(t, (*ParquetReader)(nil).GetVariantColumns())
+	})
+}
+
+func Test_GetColumnChunkInfo_Variant(t *testing.T) {
+	pr := openShreddedVariantReader(t)
+
+	info, err := pr.GetColumnChunkInfo(0, 0)
+	require.NoError(t, err)
+	require.Nil(t, info.Variant)
+
+	info, err = pr.GetColumnChunkInfo(0, 2)
+	require.NoError(t, err)
+	require.Equal(t, &VariantMember{Path: "Var", Role: VariantRoleValue, Shredded: true}, info.Variant)
+
+	info, err = pr.GetColumnChunkInfo(0, 4)
+	require.NoError(t, err)
+	require.Equal(t, &VariantMember{Path: "Var", Role: VariantRoleShredded, Shredded: true}, info.Variant)
+}
+
+// readFormattedColumn returns the formatted values of all data pages of a
+// column chunk
+func readFormattedColumn(t *testing.T, pr *ParquetReader, colIndex int) []string {
+	t.Helper()
+	pages, err := pr.GetPageMetadataList(0, colIndex)
+	require.NoError(t, err)
+
+	var values []string
+	for i, page := range pages {
+		if page.PageType == "DICTIONARY_PAGE" {
+			continue
+		}
+		formatted, err := pr.GetPageContentFormatted(0, colIndex, i)
+		require.NoError(t, err)
+		values = append(values, formatted...)
+	}
+	return values
+}
+
+func Test_GetPageContentFormatted_Variant(t *testing.T) {
+	t.Run("unshredded", func(t *testing.T) {
+		pr := openTestParquetReader(t)
+		for _, col := range []int{14, 15} {
+			values := readFormattedColumn(t, pr, col)
+			require.NotEmpty(t, values)
+			for _, v := range values {
+				require.True(t, json.Valid([]byte(v)), v)
+			}
+		}
+	})
+
+	t.Run("shredded", func(t *testing.T) {
+		pr := openShreddedVariantReader(t)
+		expected := []string{
+			`{"Age":3,"Tags":["t1","x"]}`,
+			`null`,
+			`"x"`,
+			`{"Age":3,"name":"bob"}`,
+			`{"Tags":[]}`,
+		}
+		require.Equal(t, expected, readFormattedColumn(t, pr, 1))
+		require.Equal(t, expected, readFormattedColumn(t, pr, 2))
+
+		// Shredded columns keep their own values
+		require.Equal(t, []string{"3", "NULL", "NULL", "3", "NULL"}, readFormattedColumn(t, pr, 4))
+	})
+}
+
+func Test_isListNode(t *testing.T) {
+	repeated := parquet.FieldRepetitionType_REPEATED
+	list := parquet.ConvertedType_LIST
+	element := &schemaNode{elem: &parquet.SchemaElement{Name: "list", RepetitionType: &repeated}}
+
+	require.True(t, isListNode(&schemaNode{elem: &parquet.SchemaElement{ConvertedType: &list}, children: []*schemaNode{element}}))
+	require.True(t, isListNode(&schemaNode{
+		elem:     &parquet.SchemaElement{LogicalType: &parquet.LogicalType{LIST: parquet.NewListType()}},
+		children: []*schemaNode{element},
+	}))
+	require.False(t, isListNode(&schemaNode{elem: &parquet.SchemaElement{}, children: []*schemaNode{element}}))
+	require.False(t, isListNode(&schemaNode{elem: &parquet.SchemaElement{ConvertedType: &list}}))
+}
+
+func Test_decodeVariant(t *testing.T) {
+	metadata := types.EncodeVariantMetadata(nil)
+	require.Equal(t, "x", decodeVariant(metadata, types.EncodeVariantString("x")))
+	require.Equal(t, true, decodeVariant(metadata, types.EncodeVariantBool(true)))
+
+	// Undecodable binaries fall back to base64
+	fallback, ok := decodeVariant([]byte{0x07}, []byte{0x01}).(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "Bw==", fallback["metadata"])
+}
+
+func Test_variantBytes(t *testing.T) {
+	require.Equal(t, []byte("ab"), variantBytes("ab"))
+	require.Equal(t, []byte("ab"), variantBytes([]byte("ab")))
+	require.Nil(t, variantBytes(42))
+}
+
+func Test_formatVariantJSON(t *testing.T) {
+	require.Equal(t, `{"a":[1,null]}`, formatVariantJSON(map[string]any{"a": []any{1, nil}}))
+	require.Equal(t, "null", formatVariantJSON(nil))
+
+	// Values are kept whole, the page view truncates them
+	require.Len(t, formatVariantJSON(strings.Repeat("a", 300)), 302)
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+// displayCookieName is the cookie the web UI keeps its display settings in,
+// as a URL-encoded query string
+const displayCookieName = "pb_display"
+
+// readerKey is the request context key of the reader with the display
+// options of a request applied
+type readerKey struct{}
+
+// SetDisplayOptions sets the display options used when a request does not ask
+// for others
+func (s *ParquetService) SetDisplayOptions(opts model.DisplayOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reader = s.reader.WithDisplayOptions(opts)
+	return nil
+}
+
+// SetDecoders sets the custom decoders values of binary columns are shown with
+func (s *ParquetService) SetDecoders(registry *model.DecoderRegistry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reader, err := s.reader.WithDecoders(registry)
+	if err != nil {
+		return err
+	}
+	s.reader = reader
+	return nil
+}
+
+// parseDisplayOptions reads display options from query parameters: timezone,
+// temporal, binary, decimal and float_precision. An explicit
+// float_precision=shortest (or 0) asks for the shortest form over any
+// precision set by the server.
+func parseDisplayOptions(values url.Values) (model.DisplayOptions, error) {
+	opts := model.DisplayOptions{
+		TimeZone: values.Get("timezone"),
+		Temporal: values.Get("temporal"),
+		Binary:   values.Get("binary"),
+		Decimal:  values.Get("decimal"),
+	}
+	if precision := values.Get("float_precision"); precision == "shortest" {
+		opts.FloatPrecision = model.FloatShortest
+	} else if precision != "" {
+		n, err := strconv.Atoi(precision)
+		if err != nil || n < 0 {
+			return opts, fmt.Errorf("%w: float precision %q", model.ErrInvalidDisplayOption, precision)
+		}
+		if n == 0 {
+			n = model.FloatShortest
+		}
+		opts.FloatPrecision = n
+	}
+	return opts, opts.Validate()
+}
+
+// encodeDisplayOptions is the reverse of parseDisplayOptions, unset options
+// are left out
+func encodeDisplayOptions(opts model.DisplayOptions) url.Values {
+	values := url.Values{}
+	for name, value := range map[string]string{
+		"timezone": opts.TimeZone,
+		"temporal": opts.Temporal,
+		"binary":   opts.Binary,
+		"decimal":  opts.Decimal,
+	} {
+		if value != "" {
+			values.Set(name, value)
+		}
+	}
+	if opts.FloatPrecision != 0 {
+		values.Set("float_precision", strconv.Itoa(max(opts.FloatPrecision, 0)))
+	}
+	return values
+}
+
+// savedDisplayOptions returns the display options in the web UI cookie, a
+// cookie that no longer parses is ignored
+func savedDisplayOptions(r *http.Request) model.DisplayOptions {
+	cookie, err := r.Cookie(displayCookieName)
+	if err != nil {
+		return model.DisplayOptions{}
+	}
+	values, err := url.ParseQuery(cookie.Value)
+	if err != nil {
+		return model.DisplayOptions{}
+	}
+	opts, err := parseDisplayOptions(values)
+	if err != nil {
+		return model.DisplayOptions{}
+	}
+	return opts
+}
+
+// displayMiddleware applies the display options of a request on top of the
+// server ones, web UI settings first and then query parameters. Invalid query
+// parameters are rejected with 400. The reader of the request stops reading
+// when the client goes away or the request context ends.
+func (s *ParquetService) displayMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Routes of the API and the web UI share a router, apply once
+		if _, ok := r.Context().Value(readerKey{}).(*model.ParquetReader); ok || s.defaultReader() == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		requested, err := parseDisplayOptions(r.URL.Query())
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		reader := s.currentReader()
+		opts := reader.DisplayOptions().Merge(savedDisplayOptions(r)).Merge(requested)
+		if opts != reader.DisplayOptions() {
+			reader = reader.WithDisplayOptions(opts)
+		}
+		reader = reader.WithContext(r.Context())
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), readerKey{}, reader)))
+	})
+}
+
+// readerFor returns the reader with the display options of a request applied
+func (s *ParquetService) readerFor(r *http.Request) *model.ParquetReader {
+	if reader, ok := r.Context().Value(readerKey{}).(*model.ParquetReader); ok {
+		return reader
+	}
+	return s.defaultReader()
+}
+
+// currentReader returns the reader with the server display options and
+// decoders, first reopening the file when it changed since it was opened. A
+// file that cannot be reopened is served by the old reader, whose reads fail
+// with model.ErrFileChanged.
+func (s *ParquetService) currentReader() *model.ParquetReader {
+	reader := s.defaultReader()
+	if !reader.FileChanged() {
+		return reader
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Another request may have reopened it meanwhile
+	if !s.reader.FileChanged() {
+		return s.reader
+	}
+	reopened, err := s.reader.Reopen()
+	if err != nil {
+		return s.reader
+	}
+	s.reader = reopened
+	return reopened
+}
+
+// defaultReader returns the reader with the server display options and
+// decoders, which settings may swap while requests are served
+func (s *ParquetService) defaultReader() *model.ParquetReader {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.reader
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
+)
+
+func Test_parseDisplayOptions(t *testing.T) {
+	testCases := map[string]struct {
+		query    string
+		expected model.DisplayOptions
+		errMsg   string
+	}{
+		"empty": {query: ""},
+		"all": {
+			query: "timezone=Asia/Tokyo&temporal=epoch&binary=hex&decimal=unscaled&float_precision=3",
+			expected: model.DisplayOptions{
+				TimeZone: "Asia/Tokyo", Temporal: "epoch", Binary: "hex", Decimal: "unscaled", FloatPrecision: 3,
+			},
+		},
+		"shortest-precision": {
+			query:    "float_precision=0",
+			expected: model.DisplayOptions{FloatPrecision: model.FloatShortest},
+		},
+		"shortest-by-name": {
+			query:    "float_precision=shortest",
+			expected: model.DisplayOptions{FloatPrecision: model.FloatShortest},
+		},
+		"bad-precision":  {query: "float_precision=abc", errMsg: "float precision"},
+		"negative":       {query: "float_precision=-1", errMsg: "float precision"},
+		"out-of-range":   {query: "float_precision=99", errMsg: "out of range"},
+		"bad-choice":     {query: "binary=octal", errMsg: "binary"},
+		"bad-time-zone":  {query: "timezone=Nowhere/Nothing", errMsg: "time zone"},
+		"unrelated-only": {query: "typed=true&geo=wkt"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			values, err := url.ParseQuery(tc.query)
+			require.NoError(t, err)
+			opts, err := parseDisplayOptions(values)
+			if tc.errMsg != "" {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, model.ErrInvalidDisplayOption))
+				require.Contains(t, err.Error(), tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, opts)
+		})
+	}
+}
+
+func Test_encodeDisplayOptions(t *testing.T) {
+	require.Empty(t, encodeDisplayOptions(model.DisplayOptions{}))
+
+	opts := model.DisplayOptions{TimeZone: "Europe/Paris", Binary: "utf8", FloatPrecision: 2}
+	values := encodeDisplayOptions(opts)
+	require.Equal(t, "binary=utf8&float_precision=2&timezone=Europe%2FParis", values.Encode())
+
+	parsed, err := parseDisplayOptions(values)
+	require.NoError(t, err)
+	require.Equal(t, opts, parsed)
+
+	shortest := model.DisplayOptions{FloatPrecision: model.FloatShortest}
+	values = encodeDisplayOptions(shortest)
+	require.Equal(t, "float_precision=0", values.Encode())
+	parsed, err = parseDisplayOptions(values)
+	require.NoError(t, err)
+	require.Equal(t, shortest, parsed)
+}
+
+func Test_SetDisplayOptions(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+
+	err := svc.SetDisplayOptions(model.DisplayOptions{Temporal: "never"})
+	require.Error(t, err)
+	require.Empty(t, svc.reader.DisplayOptions())
+
+	require.NoError(t, svc.SetDisplayOptions(model.DisplayOptions{Temporal: model.TemporalEpoch}))
+	require.Equal(t, model.TemporalEpoch, svc.reader.DisplayOptions().Temporal)
+}
+
+func Test_displayMiddleware(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	require.NoError(t, svc.SetDisplayOptions(model.DisplayOptions{Binary: model.BinaryHex}))
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+	svc.SetupWebUIRoutes(router)
+
+	content := func(path string, cookie string) (int, []string) {
+		req := httptest.NewRequest("GET", path, nil)
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: displayCookieName, Value: cookie})
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var result struct {
+			Values []string `json:"values"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &result)
+		return w.Code, result.Values
+	}
+
+	t.Run("server-defaults", func(t *testing.T) {
+		code, values := content("/rowgroups/0/columnchunks/7/pages/0/content", "")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "4279746541727261792d32", values[0])
+	})
+
+	t.Run("query-over-server", func(t *testing.T) {
+		code, values := content("/rowgroups/0/columnchunks/7/pages/0/content?binary=utf8", "")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "ByteArray-2", values[0])
+	})
+
+	t.Run("cookie-over-server", func(t *testing.T) {
+		code, values := content("/rowgroups/0/columnchunks/35/pages/0/content", "timezone=Asia%2FTokyo")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "2022-01-01T09:00:00.002+09:00", values[0])
+	})
+
+	t.Run("query-over-cookie", func(t *testing.T) {
+		code, values := content("/rowgroups/0/columnchunks/35/pages/0/content?timezone=UTC", "timezone=Asia%2FTokyo")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "2022-01-01T00:00:00.002Z", values[0])
+	})
+
+	t.Run("stale-cookie-ignored", func(t *testing.T) {
+		code, values := content("/rowgroups/0/columnchunks/34/pages/0/content", "temporal=never")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "2022-01-01T00:00:00.004Z", values[0])
+	})
+
+	t.Run("epoch", func(t *testing.T) {
+		code, values := content("/rowgroups/0/columnchunks/34/pages/0/content?temporal=epoch", "")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "1640995200004", values[0])
+	})
+
+	t.Run("query-back-to-shortest", func(t *testing.T) {
+		require.NoError(t, svc.SetDisplayOptions(model.DisplayOptions{Binary: model.BinaryHex, FloatPrecision: 3}))
+		t.Cleanup(func() { _ = svc.SetDisplayOptions(model.DisplayOptions{Binary: model.BinaryHex}) })
+
+		code, values := content("/rowgroups/0/columnchunks/6/pages/0/content", "")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "0.500", values[1])
+
+		code, values = content("/rowgroups/0/columnchunks/6/pages/0/content?float_precision=0", "")
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "0.5", values[1])
+	})
+
+	t.Run("invalid-query", func(t *testing.T) {
+		code, _ := content("/rowgroups/0/columnchunks/34/pages/0/content?temporal=never", "")
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("statistics", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/rowgroups/0/columnchunks/34?temporal=epoch", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		var info model.ColumnChunkInfo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+		require.Equal(t, "1640995200000", info.MinValue)
+	})
+}
+
+func Test_readerFor(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+
+	req := httptest.NewRequest("GET", "/info", nil)
+	require.Same(t, svc.reader, svc.readerFor(req))
+
+	var seen *model.ParquetReader
+	handler := svc.displayMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = svc.readerFor(r)
+	}))
+
+	// Every request reads with its own context, through the same handles
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/info", nil))
+	require.NotSame(t, svc.reader, seen)
+	require.Equal(t, svc.reader.DisplayOptions(), seen.DisplayOptions())
+	require.Equal(t, svc.reader.HandleStats(), seen.HandleStats())
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/info?decimal=unscaled", nil))
+	require.NotSame(t, svc.reader, seen)
+	require.Equal(t, model.DecimalUnscaled, seen.DisplayOptions().Decimal)
+	require.Empty(t, svc.reader.DisplayOptions())
+}
+
+// reverseDecoder decodes a value to its bytes reversed, wrapped in an object
+type reverseDecoder struct{}
+
+func (reverseDecoder) Name() string { return "reverse" }
+
+func (reverseDecoder) Decode(raw []byte) (any, error) {
+	reversed := make([]byte, len(raw))
+	for i, b := range raw {
+		reversed[len(raw)-1-i] = b
+	}
+	return map[string]any{"reversed": string(reversed)}, nil
+}
+
+func Test_SetDecoders(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+
+	numeric := model.NewDecoderRegistry()
+	numeric.Register("Int32", reverseDecoder{})
+	require.ErrorIs(t, svc.SetDecoders(numeric), model.ErrInvalidDecoderConfig)
+	require.Nil(t, svc.reader.Decoders())
+
+	registry := model.NewDecoderRegistry()
+	registry.Register("ByteArray", reverseDecoder{})
+	require.NoError(t, svc.SetDecoders(registry))
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	// Display options from the request keep the decoders
+	req := httptest.NewRequest("GET", "/rowgroups/0/columnchunks/7/pages/0/content?binary=hex", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var content struct {
+		Values []string `json:"values"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &content))
+	require.Equal(t, `{"reversed":"2-yarrAetyB"}`, content.Values[0])
+
+	req = httptest.NewRequest("GET", "/rowgroups/0/columnchunks/7/pages/0/content/0", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var detail model.ValueDetail
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+	require.Equal(t, "reverse", detail.Decoder)
+	require.Equal(t, "{\n  \"reversed\": \"2-yarrAetyB\"\n}", detail.Formatted)
+}
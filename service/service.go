@@ -1,10 +1,15 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/hangxie/parquet-go/v3/reader"
@@ -16,9 +21,10 @@ import (
 
 // ParquetService manages the Parquet file and provides HTTP endpoints
 type ParquetService struct {
-	reader        *model.ParquetReader
-	parquetReader *reader.ParquetReader // Raw reader for schema generation
-	uri           string
+	mu             sync.RWMutex         // guards reader, which settings may swap while serving
+	reader         *model.ParquetReader // reads through a pool of file handles, one per concurrent request
+	uri            string
+	requestTimeout time.Duration // how long a request may read the file, 0 for no limit
 }
 
 // NewParquetService creates a new service instance
@@ -28,19 +34,60 @@ func NewParquetService(uri string, readOpts pio.ReadOption) (*ParquetService, er
 		return nil, fmt.Errorf("failed to open parquet file: %w", err)
 	}
 
+	// Requests seek and read file handles, so each one in flight borrows
+	// its own, opened with the same options as the first
+	open := func() (*reader.ParquetReader, error) {
+		return pio.NewParquetFileReader(uri, readOpts)
+	}
+	cache := model.CacheOptions{MaxBytes: model.DefaultCacheSize, CheckInterval: model.DefaultCacheCheckInterval}
 	return &ParquetService{
-		reader:        model.NewParquetReader(parquetReader),
-		parquetReader: parquetReader,
-		uri:           uri,
+		reader: model.NewParquetReader(parquetReader).WithReaderPool(open, model.DefaultReaderPoolSize).WithCache(cache),
+		uri:    uri,
 	}, nil
 }
 
-// Close closes the underlying parquet file
+// Close waits for the requests reading the file and closes every file
+// handle the service opened. Later requests fail.
 func (s *ParquetService) Close() error {
-	if s.reader != nil {
-		return nil // The reader doesn't expose Close, handle at caller level
-	}
-	return nil
+	return s.defaultReader().Close()
+}
+
+// HandleStats counts the readers of the file open and their file handles
+func (s *ParquetService) HandleStats() model.HandleStats {
+	return s.defaultReader().HandleStats()
+}
+
+// SetReaderPoolSize changes how many readers of the file serve requests in
+// parallel at most, each with a file handle per column
+func (s *ParquetService) SetReaderPoolSize(size int) {
+	s.defaultReader().SetReaderPoolSize(size)
+}
+
+// SetCacheOptions replaces the cache of page headers, dictionaries and decoded
+// pages with an empty one of the given options
+func (s *ParquetService) SetCacheOptions(opts model.CacheOptions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reader = s.reader.WithCache(opts)
+}
+
+// CacheStats returns the use of the cache of page headers, dictionaries and
+// decoded pages
+func (s *ParquetService) CacheStats() model.CacheStats {
+	return s.defaultReader().CacheStats()
+}
+
+// SetPrefetchOptions configures how small reads of the file are read ahead
+func (s *ParquetService) SetPrefetchOptions(opts model.PrefetchOptions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reader = s.reader.WithPrefetch(opts)
+}
+
+// IOStats counts the reads of the file with their bytes and latency, by
+// operation
+func (s *ParquetService) IOStats() model.IOStats {
+	return s.defaultReader().IOStats()
 }
 
 // CreateRouter creates a new router with all routes configured
@@ -57,14 +104,26 @@ func CreateRouter(s *ParquetService, quiet bool) *mux.Router {
 
 // SetupRoutes configures all HTTP routes
 func (s *ParquetService) SetupRoutes(r *mux.Router) {
+	r.Use(s.withRequestTimeout)
+	r.Use(s.displayMiddleware)
+
 	// Schema endpoints
 	r.HandleFunc("/schema/go", s.handleSchemaGo).Methods("GET")
 	r.HandleFunc("/schema/json", s.handleSchemaJSON).Methods("GET")
 	r.HandleFunc("/schema/raw", s.handleSchemaRaw).Methods("GET")
 	r.HandleFunc("/schema/csv", s.handleSchemaCSV).Methods("GET")
+	r.HandleFunc("/schema/variants", s.handleSchemaVariants).Methods("GET")
 
 	// File info endpoint
 	r.HandleFunc("/info", s.handleFileInfo).Methods("GET")
+	r.HandleFunc("/layout", s.handleFileLayout).Methods("GET")
+	r.HandleFunc("/heatmap", s.handleHeatmap).Methods("GET")
+	r.HandleFunc("/rows/{rows}/pages", s.handleRowPages).Methods("GET")
+	r.HandleFunc("/rows/{row}/location", s.handleRowLocation).Methods("GET")
+	r.HandleFunc("/sample", s.handleSample).Methods("GET")
+	r.HandleFunc("/cache", s.handleCacheStats).Methods("GET")
+	r.HandleFunc("/handles", s.handleHandleStats).Methods("GET")
+	r.HandleFunc("/debug/io", s.handleIOStats).Methods("GET")
 
 	// Row groups endpoints
 	r.HandleFunc("/rowgroups", s.handleRowGroups).Methods("GET")
@@ -73,16 +132,38 @@ func (s *ParquetService) SetupRoutes(r *mux.Router) {
 	// Column chunks endpoints
 	r.HandleFunc("/rowgroups/{rgIndex}/columnchunks", s.handleColumnChunks).Methods("GET")
 	r.HandleFunc("/rowgroups/{rgIndex}/columnchunks/{colIndex}", s.handleColumnChunkInfo).Methods("GET")
+	r.HandleFunc("/rowgroups/{rgIndex}/columnchunks/{colIndex}/statistics/{stat}", s.handleColumnChunkStatistic).Methods("GET")
 
 	// Page endpoints
 	r.HandleFunc("/rowgroups/{rgIndex}/columnchunks/{colIndex}/pages", s.handlePages).Methods("GET")
 	r.HandleFunc("/rowgroups/{rgIndex}/columnchunks/{colIndex}/pages/{pageIndex}", s.handlePageInfo).Methods("GET")
 	r.HandleFunc("/rowgroups/{rgIndex}/columnchunks/{colIndex}/pages/{pageIndex}/content", s.handlePageContent).Methods("GET")
+	r.HandleFunc("/rowgroups/{rgIndex}/columnchunks/{colIndex}/pages/{pageIndex}/content/{valueIndex}", s.handlePageValue).Methods("GET")
+	r.HandleFunc("/rowgroups/{rgIndex}/columnchunks/{colIndex}/pages/{pageIndex}/statistics/{stat}", s.handlePageStatistic).Methods("GET")
+	r.HandleFunc("/rowgroups/{rgIndex}/columnchunks/{colIndex}/pages/{pageIndex}/structure", s.handlePageStructure).Methods("GET")
+
+	// The column chunk and page endpoints with the column addressed by its
+	// dotted schema path, links stay valid when columns are reordered
+	r.HandleFunc("/rowgroups/{rgIndex}/columns/{path}", s.handleColumnChunkInfo).Methods("GET")
+	r.HandleFunc("/rowgroups/{rgIndex}/columns/{path}/statistics/{stat}", s.handleColumnChunkStatistic).Methods("GET")
+	r.HandleFunc("/rowgroups/{rgIndex}/columns/{path}/pages", s.handlePages).Methods("GET")
+	r.HandleFunc("/rowgroups/{rgIndex}/columns/{path}/pages/{pageIndex}", s.handlePageInfo).Methods("GET")
+	r.HandleFunc("/rowgroups/{rgIndex}/columns/{path}/pages/{pageIndex}/content", s.handlePageContent).Methods("GET")
+	r.HandleFunc("/rowgroups/{rgIndex}/columns/{path}/pages/{pageIndex}/content/{valueIndex}", s.handlePageValue).Methods("GET")
+	r.HandleFunc("/rowgroups/{rgIndex}/columns/{path}/pages/{pageIndex}/statistics/{stat}", s.handlePageStatistic).Methods("GET")
+	r.HandleFunc("/rowgroups/{rgIndex}/columns/{path}/pages/{pageIndex}/structure", s.handlePageStructure).Methods("GET")
+
+	// Column endpoints
+	r.HandleFunc("/columns", s.handleLeafColumns).Methods("GET")
+	r.HandleFunc("/columns/{path}", s.handleColumnOverview).Methods("GET")
+	r.HandleFunc("/columns/{path}/profile", s.handleColumnProfile).Methods("GET")
+	r.HandleFunc("/columns/{path}/dictionary", s.handleColumnDictionary).Methods("GET")
+	r.HandleFunc("/columns/{path}/geo", s.handleColumnGeo).Methods("GET")
 }
 
 // handleSchemaGo returns schema in Go struct format
 func (s *ParquetService) handleSchemaGo(w http.ResponseWriter, r *http.Request) {
-	schemaRoot, err := pschema.NewSchemaTree(s.parquetReader, pschema.SchemaOption{FailOnInt96: false})
+	schemaRoot, err := pschema.NewSchemaTree(s.readerFor(r).Reader, pschema.SchemaOption{FailOnInt96: false})
 	if err != nil {
 		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to generate schema: %v", err))
 		return
@@ -108,7 +189,7 @@ func (s *ParquetService) handleSchemaGo(w http.ResponseWriter, r *http.Request)
 
 // handleSchemaJSON returns schema in JSON format
 func (s *ParquetService) handleSchemaJSON(w http.ResponseWriter, r *http.Request) {
-	schemaRoot, err := pschema.NewSchemaTree(s.parquetReader, pschema.SchemaOption{FailOnInt96: false})
+	schemaRoot, err := pschema.NewSchemaTree(s.readerFor(r).Reader, pschema.SchemaOption{FailOnInt96: false})
 	if err != nil {
 		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to generate schema: %v", err))
 		return
@@ -123,7 +204,7 @@ func (s *ParquetService) handleSchemaJSON(w http.ResponseWriter, r *http.Request
 
 // handleSchemaRaw returns the raw schema tree structure as JSON
 func (s *ParquetService) handleSchemaRaw(w http.ResponseWriter, r *http.Request) {
-	schemaRoot, err := pschema.NewSchemaTree(s.parquetReader, pschema.SchemaOption{FailOnInt96: false})
+	schemaRoot, err := pschema.NewSchemaTree(s.readerFor(r).Reader, pschema.SchemaOption{FailOnInt96: false})
 	if err != nil {
 		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to generate schema: %v", err))
 		return
@@ -143,7 +224,7 @@ func (s *ParquetService) handleSchemaRaw(w http.ResponseWriter, r *http.Request)
 
 // handleSchemaCSV returns schema in CSV format
 func (s *ParquetService) handleSchemaCSV(w http.ResponseWriter, r *http.Request) {
-	schemaRoot, err := pschema.NewSchemaTree(s.parquetReader, pschema.SchemaOption{FailOnInt96: false})
+	schemaRoot, err := pschema.NewSchemaTree(s.readerFor(r).Reader, pschema.SchemaOption{FailOnInt96: false})
 	if err != nil {
 		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to generate schema: %v", err))
 		return
@@ -160,18 +241,222 @@ func (s *ParquetService) handleSchemaCSV(w http.ResponseWriter, r *http.Request)
 	_, _ = w.Write([]byte(schemaText))
 }
 
+// handleSchemaVariants returns the VARIANT columns with the role of each of
+// their leaf columns, telling shredded columns apart
+func (s *ParquetService) handleSchemaVariants(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, s.readerFor(r).GetVariantColumns())
+}
+
 // handleFileInfo returns file-level metadata
 func (s *ParquetService) handleFileInfo(w http.ResponseWriter, r *http.Request) {
-	info := s.reader.GetFileInfo()
+	info := s.readerFor(r).GetFileInfo()
 	WriteJSON(w, http.StatusOK, info)
 }
 
+// handleCacheStats returns the use of the cache of page headers,
+// dictionaries and decoded pages
+func (s *ParquetService) handleCacheStats(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, s.CacheStats())
+}
+
+// handleHandleStats returns the readers of the file open and their file
+// handles
+func (s *ParquetService) handleHandleStats(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, s.HandleStats())
+}
+
+// handleIOStats returns the reads of the file by operation
+func (s *ParquetService) handleIOStats(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, s.IOStats())
+}
+
+// handleFileLayout returns the byte-level layout of the file
+func (s *ParquetService) handleFileLayout(w http.ResponseWriter, r *http.Request) {
+	layout, err := s.readerFor(r).GetFileLayout()
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to read file layout: %v", err))
+		return
+	}
+	WriteJSON(w, http.StatusOK, layout)
+}
+
+// handleHeatmap returns a metric of every column chunk as a row group ×
+// column matrix
+func (s *ParquetService) handleHeatmap(w http.ResponseWriter, r *http.Request) {
+	metric, err := model.ParseHeatmapMetric(r.URL.Query().Get("metric"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	heatmap, err := s.readerFor(r).GetHeatmap(r.Context(), metric)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to build heatmap: %v", err))
+		return
+	}
+	WriteJSON(w, http.StatusOK, heatmap)
+}
+
+// handleRowPages finds the pages holding a row or row range in each column,
+// all columns unless some are picked with repeated column=path parameters
+func (s *ParquetService) handleRowPages(w http.ResponseWriter, r *http.Request) {
+	firstRow, lastRow, err := model.ParseRowRange(mux.Vars(r)["rows"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	colIndexes, err := s.requestColumnIndexes(r)
+	if err != nil {
+		WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	rowPages, err := s.readerFor(r).GetRowPages(r.Context(), firstRow, lastRow, colIndexes)
+	switch {
+	case errors.Is(err, model.ErrInvalidRowIndex):
+		WriteError(w, http.StatusNotFound, err.Error())
+	case err != nil:
+		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to locate rows: %v", err))
+	default:
+		WriteJSON(w, http.StatusOK, rowPages)
+	}
+}
+
+// handleRowLocation finds the row group of a row and the page and values
+// holding it in each column, all columns unless some are picked with repeated
+// column=path parameters. With record=true the assembled row is included.
+func (s *ParquetService) handleRowLocation(w http.ResponseWriter, r *http.Request) {
+	row, err := model.ParseRowNumber(mux.Vars(r)["row"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	colIndexes, err := s.requestColumnIndexes(r)
+	if err != nil {
+		WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	location, err := s.readerFor(r).GetRowLocation(r.Context(), row, colIndexes)
+	if err == nil && r.URL.Query().Get("record") == "true" {
+		location.Record, err = s.readerFor(r).GetRecord(row)
+	}
+	switch {
+	case errors.Is(err, model.ErrInvalidRowIndex):
+		WriteError(w, http.StatusNotFound, err.Error())
+	case err != nil:
+		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to locate row: %v", err))
+	default:
+		WriteJSON(w, http.StatusOK, location)
+	}
+}
+
+// handleSample returns n random rows of the file, or n rows of every row
+// group with mode=stratified, in all columns unless some are picked with
+// repeated column=path parameters. The same seed draws the same rows, without
+// one a random seed is picked and returned.
+func (s *ParquetService) handleSample(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseSampleOptions(r.URL.Query())
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if opts.Columns, err = s.requestColumnIndexes(r); err != nil {
+		WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	sample, err := s.readerFor(r).SampleRows(r.Context(), opts)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to sample rows: %v", err))
+		return
+	}
+	WriteJSON(w, http.StatusOK, sample)
+}
+
+// parseSampleOptions reads the n, mode and seed query parameters of a sample
+func parseSampleOptions(values url.Values) (model.SampleOptions, error) {
+	var opts model.SampleOptions
+	var err error
+	if opts.Mode, err = model.ParseSampleMode(values.Get("mode")); err != nil {
+		return opts, err
+	}
+	if opts.N, err = model.ParseSampleSize(values.Get("n")); err != nil {
+		return opts, err
+	}
+	opts.Seed, err = model.ParseSampleSeed(values.Get("seed"))
+	return opts, err
+}
+
+// Sample draws rows from the file with the server display options, in the
+// columns with the given paths or in all columns when there are none
+func (s *ParquetService) Sample(ctx context.Context, opts model.SampleOptions, paths []string) (model.Sample, error) {
+	reader := s.defaultReader()
+	colIndexes, err := columnIndexes(reader, paths)
+	if err != nil {
+		return model.Sample{}, err
+	}
+	opts.Columns = colIndexes
+	return reader.SampleRows(ctx, opts)
+}
+
+// requestColumnIndexes resolves the column=path query parameters of a request
+func (s *ParquetService) requestColumnIndexes(r *http.Request) ([]int, error) {
+	return columnIndexes(s.readerFor(r), r.URL.Query()["column"])
+}
+
+// columnIndexes resolves leaf column paths to column indexes
+func columnIndexes(reader *model.ParquetReader, paths []string) ([]int, error) {
+	var colIndexes []int
+	for _, path := range paths {
+		colIndex, err := reader.ColumnIndexByPath(path)
+		if err != nil {
+			return nil, err
+		}
+		colIndexes = append(colIndexes, colIndex)
+	}
+	return colIndexes, nil
+}
+
 // handleRowGroups returns all row groups
 func (s *ParquetService) handleRowGroups(w http.ResponseWriter, r *http.Request) {
-	rowGroups := s.reader.GetAllRowGroupsInfo()
+	// With offset, limit, sort or filter only a window of the row groups is
+	// returned, along with the number of matching row groups
+	if isListRequest(r) {
+		opts, err := parseListOptions(r, model.RowGroupSortKeys)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		list, err := s.readerFor(r).ListRowGroups(opts)
+		if err != nil {
+			WriteError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		WriteJSON(w, http.StatusOK, list)
+		return
+	}
+
+	rowGroups := s.readerFor(r).GetAllRowGroupsInfo()
 	WriteJSON(w, http.StatusOK, rowGroups)
 }
 
+// isListRequest reports whether a listing request asks for a window with
+// offset, limit, sort or filter
+func isListRequest(r *http.Request) bool {
+	query := r.URL.Query()
+	return query.Has("offset") || query.Has("limit") || query.Has("sort") || query.Has("filter")
+}
+
+// parseListOptions reads the window of a listing from the request query
+func parseListOptions(r *http.Request, sortKeys []string) (model.ListOptions, error) {
+	query := r.URL.Query()
+	return model.ParseListOptions(query.Get("offset"), query.Get("limit"), query.Get("sort"), query.Get("filter"), sortKeys)
+}
+
 // handleRowGroupInfo returns info for a specific row group
 func (s *ParquetService) handleRowGroupInfo(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -181,7 +466,7 @@ func (s *ParquetService) handleRowGroupInfo(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	info, err := s.reader.GetRowGroupInfo(rgIndex)
+	info, err := s.readerFor(r).GetRowGroupInfo(rgIndex)
 	if err != nil {
 		WriteError(w, http.StatusNotFound, err.Error())
 		return
@@ -199,7 +484,22 @@ func (s *ParquetService) handleColumnChunks(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	columns, err := s.reader.GetAllColumnChunksInfo(rgIndex)
+	if isListRequest(r) {
+		opts, err := parseListOptions(r, model.ColumnChunkSortKeys)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		list, err := s.readerFor(r).ListColumnChunks(rgIndex, opts)
+		if err != nil {
+			WriteError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		WriteJSON(w, http.StatusOK, list)
+		return
+	}
+
+	columns, err := s.readerFor(r).GetAllColumnChunksInfo(rgIndex)
 	if err != nil {
 		WriteError(w, http.StatusNotFound, err.Error())
 		return
@@ -217,13 +517,12 @@ func (s *ParquetService) handleColumnChunkInfo(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	colIndex, err := strconv.Atoi(vars["colIndex"])
-	if err != nil {
-		WriteError(w, http.StatusBadRequest, "Invalid column index")
+	colIndex, ok := s.requestColumnIndex(w, r)
+	if !ok {
 		return
 	}
 
-	info, err := s.reader.GetColumnChunkInfo(rgIndex, colIndex)
+	info, err := s.readerFor(r).GetColumnChunkInfo(rgIndex, colIndex)
 	if err != nil {
 		WriteError(w, http.StatusNotFound, err.Error())
 		return
@@ -232,6 +531,24 @@ func (s *ParquetService) handleColumnChunkInfo(w http.ResponseWriter, r *http.Re
 	WriteJSON(w, http.StatusOK, info)
 }
 
+// handleColumnChunkStatistic returns the full min or max statistic of a column chunk
+func (s *ParquetService) handleColumnChunkStatistic(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	rgIndex, err := strconv.Atoi(vars["rgIndex"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Invalid row group index")
+		return
+	}
+
+	colIndex, ok := s.requestColumnIndex(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := s.readerFor(r).GetColumnChunkStatistic(rgIndex, colIndex, vars["stat"])
+	writeValueDetail(w, detail, err)
+}
+
 // handlePages returns page metadata for a column chunk
 func (s *ParquetService) handlePages(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -241,13 +558,12 @@ func (s *ParquetService) handlePages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	colIndex, err := strconv.Atoi(vars["colIndex"])
-	if err != nil {
-		WriteError(w, http.StatusBadRequest, "Invalid column index")
+	colIndex, ok := s.requestColumnIndex(w, r)
+	if !ok {
 		return
 	}
 
-	pages, err := s.reader.GetPageMetadataList(rgIndex, colIndex)
+	pages, err := s.readerFor(r).GetPageMetadataList(rgIndex, colIndex)
 	if err != nil {
 		WriteError(w, http.StatusNotFound, err.Error())
 		return
@@ -265,9 +581,8 @@ func (s *ParquetService) handlePageInfo(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	colIndex, err := strconv.Atoi(vars["colIndex"])
-	if err != nil {
-		WriteError(w, http.StatusBadRequest, "Invalid column index")
+	colIndex, ok := s.requestColumnIndex(w, r)
+	if !ok {
 		return
 	}
 
@@ -277,7 +592,7 @@ func (s *ParquetService) handlePageInfo(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	pageInfo, err := s.reader.GetPageMetadata(rgIndex, colIndex, pageIndex)
+	pageInfo, err := s.readerFor(r).GetPageMetadata(rgIndex, colIndex, pageIndex)
 	if err != nil {
 		WriteError(w, http.StatusNotFound, err.Error())
 		return
@@ -295,9 +610,8 @@ func (s *ParquetService) handlePageContent(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	colIndex, err := strconv.Atoi(vars["colIndex"])
-	if err != nil {
-		WriteError(w, http.StatusBadRequest, "Invalid column index")
+	colIndex, ok := s.requestColumnIndex(w, r)
+	if !ok {
 		return
 	}
 
@@ -307,21 +621,402 @@ func (s *ParquetService) handlePageContent(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Get pre-formatted values ready for display
-	values, err := s.reader.GetPageContentFormatted(rgIndex, colIndex, pageIndex)
+	geoFormat, err := model.ParseGeoFormat(r.URL.Query().Get("geo"))
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	query := r.URL.Query()
+	pageRange, paginated, err := parsePageContentRange(query, rgIndex, colIndex, pageIndex)
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	path, err := s.readerFor(r).ColumnPath(colIndex)
+	if err != nil {
+		WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// With typed=true values keep their JSON types instead of display strings
+	readRange := s.pageContentReader(r, rgIndex, colIndex, pageIndex, geoFormat, query.Get("typed") == "true")
+	if query.Get("stream") == "true" {
+		streamPageContent(w, r, readRange, pageRange)
+		return
+	}
+
+	values, total, err := readRange(pageRange)
 	if err != nil {
 		WriteError(w, http.StatusNotFound, err.Error())
 		return
 	}
 
-	// Return the formatted values as JSON
 	response := map[string]interface{}{
+		"path":   path,
 		"values": values,
 		"count":  len(values),
 	}
+	if paginated {
+		response["offset"] = pageRange.Offset
+		response["total"] = total
+		if next, ok := pageRange.Next(total); ok {
+			response["next"] = model.PageCursor{RowGroup: rgIndex, Column: colIndex, Page: pageIndex, Range: next}.String()
+		}
+	}
 	WriteJSON(w, http.StatusOK, response)
 }
 
+// parsePageContentRange reads the values of a page a request asks for: the
+// range of a cursor, or offset and limit, or the whole page when there are
+// none. It reports whether the response is paginated.
+func parsePageContentRange(values url.Values, rgIndex, colIndex, pageIndex int) (model.PageRange, bool, error) {
+	if token := values.Get("cursor"); token != "" {
+		cursor, err := model.ParsePageCursor(token)
+		if err != nil {
+			return model.PageRange{}, false, err
+		}
+		if cursor.RowGroup != rgIndex || cursor.Column != colIndex || cursor.Page != pageIndex {
+			return model.PageRange{}, false, fmt.Errorf("%w: cursor of row group %d, column %d, page %d",
+				model.ErrInvalidPageCursor, cursor.RowGroup, cursor.Column, cursor.Page)
+		}
+		return cursor.Range, true, nil
+	}
+	if values.Get("offset") == "" && values.Get("limit") == "" {
+		return model.PageRange{}, false, nil
+	}
+	pageRange, err := model.ParsePageRange(values.Get("offset"), values.Get("limit"))
+	return pageRange, true, err
+}
+
+// pageContentReader returns a function reading a range of the values of a
+// page as display strings or, when typed, as JSON-typed values, along with
+// the number of values in the page. The page is read on the first call and
+// each call decodes only as far as its range, so reading ranges in order, as
+// a stream does, decodes the page once.
+func (s *ParquetService) pageContentReader(r *http.Request, rgIndex, colIndex, pageIndex int, geoFormat model.GeoFormat, typed bool) func(model.PageRange) ([]any, int, error) {
+	reader := s.readerFor(r)
+	var page *model.PageValues
+	return func(pageRange model.PageRange) ([]any, int, error) {
+		if page == nil {
+			var err error
+			if page, err = reader.ReadPageValues(rgIndex, colIndex, pageIndex); err != nil {
+				return nil, 0, err
+			}
+		}
+		if typed {
+			values, err := page.Typed(geoFormat, pageRange)
+			return anySlice(values), page.Len(), err
+		}
+		values, err := page.Formatted(geoFormat, pageRange)
+		return anySlice(values), page.Len(), err
+	}
+}
+
+// anySlice converts a slice to a slice of interfaces
+func anySlice[T any](values []T) []any {
+	converted := make([]any, len(values))
+	for i, v := range values {
+		converted[i] = v
+	}
+	return converted
+}
+
+// pageStreamBatch is the number of values formatted at a time while
+// streaming a page
+const pageStreamBatch = 1000
+
+// pageValueEvent is one line of the streamed page content response, a value
+// with its index in the page. A read failing midway ends the stream with an
+// {"error": message} line instead.
+type pageValueEvent struct {
+	Index int `json:"index"`
+	Value any `json:"value"`
+}
+
+// streamPageContent writes the values of a page range as NDJSON, one value
+// per line, formatting them a batch at a time so that the text of a large page
+// is never in memory all at once. Closing the connection stops the stream.
+func streamPageContent(w http.ResponseWriter, r *http.Request, readRange func(model.PageRange) ([]any, int, error), pageRange model.PageRange) {
+	batch := model.PageRange{Offset: pageRange.Offset, Limit: pageStreamBatch}
+	if pageRange.Limit > 0 {
+		batch.Limit = min(pageStreamBatch, pageRange.Limit)
+	}
+	values, total, err := readRange(batch)
+	if err != nil {
+		WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	end := total
+	if pageRange.Limit > 0 {
+		end = min(total, pageRange.Offset+pageRange.Limit)
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	for {
+		for i, value := range values {
+			_ = encoder.Encode(pageValueEvent{Index: batch.Offset + i, Value: value})
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		batch.Offset += len(values)
+		if batch.Offset >= end || len(values) == 0 {
+			return
+		}
+		if err := r.Context().Err(); err != nil {
+			return
+		}
+		batch.Limit = min(pageStreamBatch, end-batch.Offset)
+		if values, _, err = readRange(batch); err != nil {
+			_ = encoder.Encode(map[string]string{"error": err.Error()})
+			return
+		}
+	}
+}
+
+// handlePageValue returns one value of a page in full
+func (s *ParquetService) handlePageValue(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	rgIndex, err := strconv.Atoi(vars["rgIndex"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Invalid row group index")
+		return
+	}
+
+	colIndex, ok := s.requestColumnIndex(w, r)
+	if !ok {
+		return
+	}
+
+	pageIndex, err := strconv.Atoi(vars["pageIndex"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Invalid page index")
+		return
+	}
+
+	valueIndex, err := strconv.Atoi(vars["valueIndex"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Invalid value index")
+		return
+	}
+
+	detail, err := s.readerFor(r).GetPageValueDetail(rgIndex, colIndex, pageIndex, valueIndex)
+	writeValueDetail(w, detail, err)
+}
+
+// handlePageStatistic returns the full min or max statistic of a page
+func (s *ParquetService) handlePageStatistic(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	rgIndex, err := strconv.Atoi(vars["rgIndex"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Invalid row group index")
+		return
+	}
+
+	colIndex, ok := s.requestColumnIndex(w, r)
+	if !ok {
+		return
+	}
+
+	pageIndex, err := strconv.Atoi(vars["pageIndex"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Invalid page index")
+		return
+	}
+
+	detail, err := s.readerFor(r).GetPageStatistic(rgIndex, colIndex, pageIndex, vars["stat"])
+	writeValueDetail(w, detail, err)
+}
+
+// requestColumnIndex resolves the column of a request, addressed by its
+// dotted schema path or by its position. It writes the error response and
+// returns false when there is no such column.
+func (s *ParquetService) requestColumnIndex(w http.ResponseWriter, r *http.Request) (int, bool) {
+	vars := mux.Vars(r)
+	if path, ok := vars["path"]; ok {
+		colIndex, err := s.readerFor(r).ColumnIndexByPath(path)
+		if err != nil {
+			WriteError(w, http.StatusNotFound, err.Error())
+			return -1, false
+		}
+		return colIndex, true
+	}
+
+	colIndex, err := strconv.Atoi(vars["colIndex"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Invalid column index")
+		return -1, false
+	}
+	return colIndex, true
+}
+
+// writeValueDetail writes a value detail or maps its lookup error to a status
+func writeValueDetail(w http.ResponseWriter, detail model.ValueDetail, err error) {
+	switch {
+	case errors.Is(err, model.ErrInvalidStatistic):
+		WriteError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, model.ErrInvalidRowGroupIndex), errors.Is(err, model.ErrInvalidColumnIndex),
+		errors.Is(err, model.ErrInvalidPageIndex), errors.Is(err, model.ErrInvalidValueIndex),
+		errors.Is(err, model.ErrNoStatistic):
+		WriteError(w, http.StatusNotFound, err.Error())
+	case err != nil:
+		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to read value: %v", err))
+	default:
+		WriteJSON(w, http.StatusOK, detail)
+	}
+}
+
+// handlePageStructure returns the encoding structure of a specific page
+func (s *ParquetService) handlePageStructure(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	rgIndex, err := strconv.Atoi(vars["rgIndex"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Invalid row group index")
+		return
+	}
+
+	colIndex, ok := s.requestColumnIndex(w, r)
+	if !ok {
+		return
+	}
+
+	pageIndex, err := strconv.Atoi(vars["pageIndex"])
+	if err != nil {
+		WriteError(w, http.StatusBadRequest, "Invalid page index")
+		return
+	}
+
+	structure, err := s.readerFor(r).GetPageStructure(rgIndex, colIndex, pageIndex)
+	switch {
+	case errors.Is(err, model.ErrInvalidPageType):
+		WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, model.ErrInvalidRowGroupIndex), errors.Is(err, model.ErrInvalidColumnIndex), errors.Is(err, model.ErrInvalidPageIndex):
+		WriteError(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to read page structure: %v", err))
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, structure)
+}
+
+// handleLeafColumns returns the leaf columns of the schema
+func (s *ParquetService) handleLeafColumns(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, s.readerFor(r).GetLeafColumns())
+}
+
+// handleColumnOverview returns the chunks of a column in every row group with
+// file-wide totals
+func (s *ParquetService) handleColumnOverview(w http.ResponseWriter, r *http.Request) {
+	colIndex, ok := s.requestColumnIndex(w, r)
+	if !ok {
+		return
+	}
+
+	overview, err := s.readerFor(r).GetColumnOverview(colIndex)
+	if err != nil {
+		WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, overview)
+}
+
+// profileEvent is one line of the streamed column profile response. Exactly
+// one of the fields is set.
+type profileEvent struct {
+	Progress *model.ProfileProgress `json:"progress,omitempty"`
+	Profile  *model.ColumnProfile   `json:"profile,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// handleColumnProfile computes a data profile for a column across all row
+// groups. With stream=true the response is NDJSON: progress events followed by
+// a final profile (or error) event. Closing the connection cancels the run.
+func (s *ParquetService) handleColumnProfile(w http.ResponseWriter, r *http.Request) {
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(mux.Vars(r)["path"])
+	if err != nil {
+		WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if r.URL.Query().Get("stream") != "true" {
+		profile, err := s.readerFor(r).ProfileColumn(r.Context(), colIndex, model.ProfileOptions{})
+		if err != nil {
+			WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to profile column: %v", err))
+			return
+		}
+		WriteJSON(w, http.StatusOK, profile)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	writeEvent := func(event profileEvent) {
+		_ = encoder.Encode(event)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	profile, err := s.readerFor(r).ProfileColumn(r.Context(), colIndex, model.ProfileOptions{
+		Progress: func(p model.ProfileProgress) { writeEvent(profileEvent{Progress: &p}) },
+	})
+	if err != nil {
+		writeEvent(profileEvent{Error: fmt.Sprintf("Failed to profile column: %v", err)})
+		return
+	}
+	writeEvent(profileEvent{Profile: &profile})
+}
+
+// handleColumnDictionary returns the dictionary analysis of a column across all row groups
+func (s *ParquetService) handleColumnDictionary(w http.ResponseWriter, r *http.Request) {
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(mux.Vars(r)["path"])
+	if err != nil {
+		WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	analysis, err := s.readerFor(r).AnalyzeDictionary(r.Context(), colIndex)
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to analyze dictionary: %v", err))
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, analysis)
+}
+
+// handleColumnGeo returns the bounding box of a geospatial column per row group
+func (s *ParquetService) handleColumnGeo(w http.ResponseWriter, r *http.Request) {
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(mux.Vars(r)["path"])
+	if err != nil {
+		WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	bounds, err := s.readerFor(r).GetGeospatialBounds(r.Context(), colIndex)
+	if errors.Is(err, model.ErrNotGeospatial) {
+		WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		WriteError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to read geospatial bounds: %v", err))
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, bounds)
+}
+
 // StartServer starts the HTTP server with verbose output
 func StartServer(service *ParquetService, addr string) error {
 	r := CreateRouter(service, false) // verbose mode (not quiet)
@@ -329,17 +1024,36 @@ func StartServer(service *ParquetService, addr string) error {
 	fmt.Printf("Starting Parquet Browser API server on %s\n", addr)
 	fmt.Printf("Available endpoints:\n")
 	fmt.Printf("  GET /info                                                    - File metadata\n")
+	fmt.Printf("  GET /layout                                                  - Byte-level file layout\n")
+	fmt.Printf("  GET /heatmap?metric=size|ratio|nulls|pages|stats|dictionary  - Row group × column chunk heatmap\n")
+	fmt.Printf("  GET /rows/{row|first-last}/pages?column=path                 - Pages holding the rows in each column\n")
+	fmt.Printf("  GET /rows/{row}/location?column=path&record=true             - Row group, page and values of a row\n")
+	fmt.Printf("  GET /sample?n=10&mode=random|stratified&seed=N&column=path   - Random or per row group sample of rows\n")
+	fmt.Printf("  GET /cache                                                   - Page cache size, hits and misses\n")
+	fmt.Printf("  GET /handles                                                 - Open readers and file handles\n")
+	fmt.Printf("  GET /debug/io                                                - Reads, bytes and latency of file reads by operation\n")
 	fmt.Printf("  GET /schema/go                                               - Schema (Go format)\n")
 	fmt.Printf("  GET /schema/json?pretty=true                                 - Schema (JSON format)\n")
 	fmt.Printf("  GET /schema/raw?pretty=true                                  - Schema (Raw format)\n")
 	fmt.Printf("  GET /schema/csv                                              - Schema (CSV format)\n")
-	fmt.Printf("  GET /rowgroups                                               - All row groups\n")
+	fmt.Printf("  GET /schema/variants                                         - VARIANT columns and shredded paths\n")
+	fmt.Printf("  GET /rowgroups                                               - All row groups (?offset=N&limit=N&sort=key&filter=text for a window)\n")
 	fmt.Printf("  GET /rowgroups/{rgIndex}                                     - Row group info\n")
-	fmt.Printf("  GET /rowgroups/{rgIndex}/columnchunks                        - All column chunks\n")
+	fmt.Printf("  GET /rowgroups/{rgIndex}/columnchunks                        - All column chunks (?offset=N&limit=N&sort=key&filter=text for a window)\n")
 	fmt.Printf("  GET /rowgroups/{rgIndex}/columnchunks/{colIndex}             - Column chunk info\n")
+	fmt.Printf("  GET /rowgroups/{rgIndex}/columnchunks/{colIndex}/statistics/{min|max} - Full column chunk statistic\n")
 	fmt.Printf("  GET /rowgroups/{rgIndex}/columnchunks/{colIndex}/pages       - All pages\n")
 	fmt.Printf("  GET /rowgroups/{rgIndex}/columnchunks/{colIndex}/pages/{pageIndex} - Page info\n")
-	fmt.Printf("  GET /rowgroups/{rgIndex}/columnchunks/{colIndex}/pages/{pageIndex}/content - Page content\n")
+	fmt.Printf("  GET /rowgroups/{rgIndex}/columnchunks/{colIndex}/pages/{pageIndex}/content - Page content (?typed=true for JSON-typed values, ?offset=N&limit=N or ?cursor=next for a part, ?stream=true for NDJSON)\n")
+	fmt.Printf("  GET /rowgroups/{rgIndex}/columnchunks/{colIndex}/pages/{pageIndex}/content/{valueIndex} - Full value\n")
+	fmt.Printf("  GET /rowgroups/{rgIndex}/columnchunks/{colIndex}/pages/{pageIndex}/statistics/{min|max} - Full page statistic\n")
+	fmt.Printf("  GET /rowgroups/{rgIndex}/columnchunks/{colIndex}/pages/{pageIndex}/structure - Page encoding structure\n")
+	fmt.Printf("  GET /rowgroups/{rgIndex}/columns/{path}/...                  - Same as columnchunks/{colIndex}/..., by dotted column path\n")
+	fmt.Printf("  GET /columns                                                 - Leaf columns\n")
+	fmt.Printf("  GET /columns/{path}                                          - Column chunks across all row groups\n")
+	fmt.Printf("  GET /columns/{path}/profile?stream=true                      - Column data profile\n")
+	fmt.Printf("  GET /columns/{path}/dictionary                               - Column dictionary analysis\n")
+	fmt.Printf("  GET /columns/{path}/geo                                      - Geospatial bounds per row group\n")
 	fmt.Println()
 
 	return http.ListenAndServe(addr, r)
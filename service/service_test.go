@@ -2,6 +2,7 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,12 +13,20 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/hangxie/parquet-go/v3/parquet"
+	"github.com/hangxie/parquet-go/v3/source/local"
+	"github.com/hangxie/parquet-go/v3/types"
+	"github.com/hangxie/parquet-go/v3/writer"
 	pio "github.com/hangxie/parquet-tools/io"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/hangxie/parquet-browser/model"
 )
 
 // Helper function to create a test service
@@ -25,9 +34,8 @@ func createTestService() *ParquetService {
 	// Note: This would normally require a real parquet file
 	// For unit tests, we're testing the HTTP layer without file I/O
 	return &ParquetService{
-		reader:        nil, // Would be populated with mock data in real tests
-		parquetReader: nil,
-		uri:           "test://file.parquet",
+		reader: nil, // Would be populated with mock data in real tests
+		uri:    "test://file.parquet",
 	}
 }
 
@@ -725,9 +733,8 @@ func Test_JSON_Roundtrip(t *testing.T) {
 func Test_Close_NilReaderBranch(t *testing.T) {
 	// Create service with explicitly nil reader
 	service := &ParquetService{
-		reader:        nil,
-		parquetReader: nil,
-		uri:           "",
+		reader: nil,
+		uri:    "",
 	}
 
 	err := service.Close()
@@ -795,7 +802,6 @@ func Test_NewParquetService_Success_Path(t *testing.T) {
 
 	require.NotNil(t, service)
 	require.NotNil(t, service.reader)
-	require.NotNil(t, service.parquetReader)
 	require.Equal(t, parquetFile, service.uri)
 }
 
@@ -1386,6 +1392,47 @@ func Test_HandleRowGroups_Success(t *testing.T) {
 	require.NotEmpty(t, rowGroups)
 }
 
+func Test_HandleListings_Window(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w
+	}
+
+	w := get("/rowgroups?limit=1")
+	require.Equal(t, http.StatusOK, w.Code)
+	var rowGroups model.RowGroupList
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rowGroups))
+	require.Len(t, rowGroups.RowGroups, 1)
+	require.Equal(t, 1, rowGroups.Total)
+
+	w = get("/rowgroups/0/columnchunks?offset=2&limit=3&sort=-size&filter=int")
+	require.Equal(t, http.StatusOK, w.Code)
+	var columns model.ColumnChunkList
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &columns))
+	require.Len(t, columns.ColumnChunks, 3)
+	require.Equal(t, 2, columns.Offset)
+	require.Greater(t, columns.Total, 3)
+	require.GreaterOrEqual(t, columns.ColumnChunks[0].CompressedSize, columns.ColumnChunks[1].CompressedSize)
+
+	w = get("/rowgroups/0/columns/Int32/pages?limit=1")
+	require.Equal(t, http.StatusOK, w.Code, "other listings ignore the window")
+
+	require.Equal(t, http.StatusBadRequest, get("/rowgroups?sort=path").Code)
+	require.Equal(t, http.StatusBadRequest, get("/rowgroups/0/columnchunks?limit=0").Code)
+	require.Equal(t, http.StatusNotFound, get("/rowgroups/9/columnchunks?limit=1").Code)
+}
+
 // Test all schema handlers together
 func Test_AllSchemaHandlers_Integration(t *testing.T) {
 	service, err := NewParquetService(getTestParquetFile(), pio.ReadOption{})
@@ -1997,3 +2044,1277 @@ func Test_HandleSchemaCSV_DifferentFileTypes(t *testing.T) {
 		})
 	}
 }
+
+func Test_HandleColumnProfile(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	t.Run("JSON", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/columns/Int32/profile", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var profile map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &profile))
+		require.Equal(t, "Int32", profile["Path"])
+		require.Equal(t, "NUMERIC", profile["Kind"])
+		require.Equal(t, float64(5), profile["TotalValues"])
+		require.NotEmpty(t, profile["Histogram"])
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/columns/Utf8/profile?stream=true", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.GreaterOrEqual(t, len(lines), 2)
+
+		var first, last map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		require.Contains(t, first, "progress")
+		require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &last))
+		require.Contains(t, last, "profile")
+	})
+
+	t.Run("Unknown column", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/columns/NoSuchColumn/profile", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func Test_HandleColumnDictionary(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	t.Run("Dictionary encoded", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/columns/Map.Key_value.Key/dictionary", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var analysis model.DictionaryAnalysis
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &analysis))
+		require.Equal(t, "Map.Key_value.Key", analysis.Path)
+		require.Equal(t, 4, analysis.DistinctValues)
+		require.Equal(t, 3, analysis.DictionaryEncodedPages)
+		require.Len(t, analysis.RowGroups, 1)
+		require.Equal(t, int64(4), analysis.RowGroups[0].Entries[0].References)
+	})
+
+	t.Run("Unknown column", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/columns/NoSuchColumn/dictionary", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func Test_HandlePageStructure(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	t.Run("Dictionary-encoded data page", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/rowgroups/0/columnchunks/46/pages/1/structure", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var structure model.PageStructure
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &structure))
+		require.Equal(t, "Map.Key_value.Key", structure.Path)
+		require.NotNil(t, structure.RepetitionLevels)
+		require.NotNil(t, structure.DefinitionLevels)
+		require.Equal(t, "RLE_DICTIONARY", structure.Values.Encoding)
+		require.NotEmpty(t, structure.Values.Runs)
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"Page out of range", "/rowgroups/0/columnchunks/1/pages/99/structure", http.StatusNotFound},
+		{"Column out of range", "/rowgroups/0/columnchunks/999/pages/0/structure", http.StatusNotFound},
+		{"Invalid page index", "/rowgroups/0/columnchunks/1/pages/x/structure", http.StatusBadRequest},
+		{"Invalid column index", "/rowgroups/0/columnchunks/x/pages/0/structure", http.StatusBadRequest},
+		{"Invalid row group index", "/rowgroups/x/columnchunks/0/pages/0/structure", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func Test_HandleFileLayout(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	req := httptest.NewRequest("GET", "/layout", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var layout model.FileLayout
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &layout))
+	require.Positive(t, layout.FileSize)
+	require.Equal(t, layout.FileSize, layout.CoveredBytes)
+	require.Empty(t, layout.Issues)
+	require.Equal(t, model.LayoutMagic, layout.Regions[0].Kind)
+	require.Equal(t, model.LayoutMagic, layout.Regions[len(layout.Regions)-1].Kind)
+}
+
+// createSizeStatsTestService writes a file with an optional and a repeated
+// column, which the writer annotates with size statistics, and serves it
+func createSizeStatsTestService(t *testing.T) *ParquetService {
+	t.Helper()
+	type row struct {
+		Name *string  `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Tags []string `parquet:"name=tags, type=LIST, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	}
+
+	path := filepath.Join(t.TempDir(), "sizestats.parquet")
+	fw, err := local.NewLocalFileWriter(path)
+	require.NoError(t, err)
+	pw, err := writer.NewParquetWriter(fw, new(row))
+	require.NoError(t, err)
+	for i := range 10 {
+		var name *string
+		if i%2 == 0 {
+			v := fmt.Sprintf("n%d", i)
+			name = &v
+		}
+		require.NoError(t, pw.Write(row{Name: name, Tags: make([]string, i%3)}))
+	}
+	require.NoError(t, pw.WriteStop())
+	require.NoError(t, fw.Close())
+
+	svc, err := NewParquetService(path, pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = svc.Close() })
+	return svc
+}
+
+func Test_HandleColumnChunkInfo_SizeStatistics(t *testing.T) {
+	svc := createSizeStatsTestService(t)
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	req := httptest.NewRequest("GET", "/rowgroups/0/columnchunks/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var info model.ColumnChunkInfo
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	require.NotNil(t, info.SizeStatistics)
+	require.Len(t, info.SizeStatistics.RepetitionLevelHistogram, 2)
+	require.Len(t, info.SizeStatistics.DefinitionLevelHistogram, 2)
+
+	req = httptest.NewRequest("GET", "/rowgroups/0/columnchunks/0/pages/0", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var page model.PageMetadata
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	require.NotNil(t, page.SizeStatistics)
+	require.Len(t, page.SizeStatistics.DefinitionLevelHistogram, 2)
+}
+
+func Test_HandleColumnGeo(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "geospatial.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	t.Run("Geometry", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/columns/Geometry/geo", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var bounds model.GeospatialBounds
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &bounds))
+		require.Equal(t, "GEOMETRY", bounds.LogicalType)
+		require.Len(t, bounds.RowGroups, 1)
+		require.Equal(t, model.BoundsFromStatistics, bounds.RowGroups[0].Source)
+		require.Equal(t, -3.0, bounds.BoundingBox.Xmin)
+	})
+
+	t.Run("Unknown column", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/columns/NoSuchColumn/geo", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Content as WKT", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/rowgroups/0/columnchunks/0/pages/0/content?geo=wkt", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"POINT (0 0)"`)
+	})
+
+	t.Run("Invalid geo format", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/rowgroups/0/columnchunks/0/pages/0/content?geo=kml", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Column chunk statistics", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/rowgroups/0/columnchunks/0", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var info model.ColumnChunkInfo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+		require.NotNil(t, info.GeospatialStatistics)
+		require.Contains(t, info.GeospatialStatistics.GeometryTypes, "Polygon")
+	})
+}
+
+func Test_HandleColumnGeo_NotGeospatial(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	req := httptest.NewRequest("GET", "/columns/Int32/geo", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// createVariantTestService serves a file with a VARIANT column shredded into
+// an INT64 typed_value, holding a shredded integer, an unshredded string and
+// a missing value, one row per page
+func createVariantTestService(t *testing.T) *ParquetService {
+	t.Helper()
+	type variant struct {
+		Metadata   string  `parquet:"name=metadata, type=BYTE_ARRAY"`
+		Value      *string `parquet:"name=value, type=BYTE_ARRAY, repetitiontype=OPTIONAL"`
+		TypedValue *int64  `parquet:"name=typed_value, type=INT64, repetitiontype=OPTIONAL"`
+	}
+	type row struct {
+		ID  int64   `parquet:"name=id, type=INT64"`
+		Var variant `parquet:"name=var"`
+	}
+
+	metadata := string(types.EncodeVariantMetadata(nil))
+	str := string(types.EncodeVariantString("x"))
+	num := int64(7)
+
+	path := filepath.Join(t.TempDir(), "variant.parquet")
+	fw, err := local.NewLocalFileWriter(path)
+	require.NoError(t, err)
+	pw, err := writer.NewParquetWriter(fw, new(row))
+	require.NoError(t, err)
+	// Annotate the group in the footer only, the writer would otherwise
+	// encode the struct as an unshredded VARIANT
+	group := *pw.Footer.Schema[2]
+	group.LogicalType = &parquet.LogicalType{VARIANT: parquet.NewVariantType()}
+	pw.Footer.Schema[2] = &group
+	require.NoError(t, pw.Write(row{ID: 1, Var: variant{Metadata: metadata, TypedValue: &num}}))
+	require.NoError(t, pw.Write(row{ID: 2, Var: variant{Metadata: metadata, Value: &str}}))
+	require.NoError(t, pw.Write(row{ID: 3, Var: variant{Metadata: metadata}}))
+	require.NoError(t, pw.WriteStop())
+	require.NoError(t, fw.Close())
+
+	svc, err := NewParquetService(path, pio.ReadOption{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = svc.Close() })
+	return svc
+}
+
+func Test_HandleSchemaVariants(t *testing.T) {
+	svc := createVariantTestService(t)
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	req := httptest.NewRequest("GET", "/schema/variants", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var variants []model.VariantColumn
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &variants))
+	require.Equal(t, []model.VariantColumn{{
+		Path:     "Var",
+		Shredded: true,
+		Columns: []model.VariantLeaf{
+			{ColumnIndex: 1, Path: "Var.Metadata", Role: model.VariantRoleMetadata},
+			{ColumnIndex: 2, Path: "Var.Value", Role: model.VariantRoleValue},
+			{ColumnIndex: 3, Path: "Var.Typed_value", Role: model.VariantRoleShredded},
+		},
+	}}, variants)
+
+	// Files without VARIANT columns list none
+	svc = createSizeStatsTestService(t)
+	router = mux.NewRouter()
+	svc.SetupRoutes(router)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/schema/variants", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, "[]", w.Body.String())
+}
+
+func Test_HandlePageContent_Variant(t *testing.T) {
+	svc := createVariantTestService(t)
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	tests := map[string]struct {
+		colIndex int
+		expected []string
+	}{
+		"metadata":    {colIndex: 1, expected: []string{"7", `"x"`, "null"}},
+		"value":       {colIndex: 2, expected: []string{"7", `"x"`, "null"}},
+		"typed_value": {colIndex: 3, expected: []string{"7", "NULL", "NULL"}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var values []string
+			for page := range 3 {
+				req := httptest.NewRequest("GET", fmt.Sprintf("/rowgroups/0/columnchunks/%d/pages/%d/content", tc.colIndex, page), nil)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				require.Equal(t, http.StatusOK, w.Code)
+				var content struct {
+					Values []string `json:"values"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &content))
+				values = append(values, content.Values...)
+			}
+			require.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func Test_HandlePageValue(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	t.Run("JSON value", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/rowgroups/0/columnchunks/10/pages/0/content/1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var detail model.ValueDetail
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+		require.Equal(t, model.ValueKindJSON, detail.Kind)
+		require.Equal(t, "{\n  \"3\": 3\n}", detail.Formatted)
+		require.Equal(t, "7b2233223a337d", detail.Hex)
+		require.Equal(t, "eyIzIjozfQ==", detail.Base64)
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"Value out of range", "/rowgroups/0/columnchunks/10/pages/0/content/9999", http.StatusNotFound},
+		{"Page out of range", "/rowgroups/0/columnchunks/10/pages/99/content/0", http.StatusNotFound},
+		{"Invalid value index", "/rowgroups/0/columnchunks/10/pages/0/content/x", http.StatusBadRequest},
+		{"Invalid page index", "/rowgroups/0/columnchunks/10/pages/x/content/0", http.StatusBadRequest},
+		{"Invalid column index", "/rowgroups/0/columnchunks/x/pages/0/content/0", http.StatusBadRequest},
+		{"Invalid row group index", "/rowgroups/x/columnchunks/10/pages/0/content/0", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func Test_HandleStatistics(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	t.Run("Column chunk max", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/rowgroups/0/columnchunks/17/statistics/max", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var detail model.ValueDetail
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &detail))
+		require.Equal(t, "UTF8-4", detail.Formatted)
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"Unknown statistic", "/rowgroups/0/columnchunks/17/statistics/avg", http.StatusBadRequest},
+		{"Missing statistic", "/rowgroups/0/columnchunks/39/statistics/min", http.StatusNotFound},
+		{"Column out of range", "/rowgroups/0/columnchunks/999/statistics/min", http.StatusNotFound},
+		{"Invalid column index", "/rowgroups/0/columnchunks/x/statistics/min", http.StatusBadRequest},
+		{"Invalid row group index", "/rowgroups/x/columnchunks/0/statistics/min", http.StatusBadRequest},
+		{"Page out of range", "/rowgroups/0/columnchunks/1/pages/99/statistics/min", http.StatusNotFound},
+		{"Page unknown statistic", "/rowgroups/0/columnchunks/1/pages/0/statistics/avg", http.StatusBadRequest},
+		{"Invalid page index", "/rowgroups/0/columnchunks/1/pages/x/statistics/min", http.StatusBadRequest},
+		{"Page invalid column index", "/rowgroups/0/columnchunks/x/pages/0/statistics/min", http.StatusBadRequest},
+		{"Page invalid row group index", "/rowgroups/x/columnchunks/1/pages/0/statistics/min", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func Test_HandlePageContent_Typed(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	req := httptest.NewRequest("GET", "/rowgroups/0/columnchunks/10/pages/0/content?typed=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var content struct {
+		Values []model.TypedValue `json:"values"`
+		Count  int                `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &content))
+	require.Equal(t, len(content.Values), content.Count)
+	require.Equal(t, map[string]any{"2": float64(2)}, content.Values[0].Value)
+	require.Equal(t, "eyIyIjoyfQ==", content.Values[0].Raw)
+
+	req = httptest.NewRequest("GET", "/rowgroups/0/columnchunks/999/pages/0/content?typed=true", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func Test_HandlePageContent_Paginated(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+	const content = "/rowgroups/0/columnchunks/46/pages/2/content"
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w
+	}
+	type response struct {
+		Values []string `json:"values"`
+		Count  int      `json:"count"`
+		Offset *int     `json:"offset"`
+		Total  int      `json:"total"`
+		Next   string   `json:"next"`
+	}
+	decode := func(w *httptest.ResponseRecorder) response {
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var r response
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &r))
+		return r
+	}
+
+	whole := decode(get(content))
+	require.Nil(t, whole.Offset)
+	require.Equal(t, 5, whole.Count)
+
+	t.Run("Following the cursor", func(t *testing.T) {
+		var values []string
+		r := decode(get(content + "?limit=2"))
+		for {
+			require.Equal(t, 5, r.Total)
+			require.Equal(t, len(values), *r.Offset)
+			values = append(values, r.Values...)
+			if r.Next == "" {
+				break
+			}
+			r = decode(get(content + "?cursor=" + r.Next))
+		}
+		require.Equal(t, whole.Values, values)
+	})
+
+	t.Run("Offset and limit", func(t *testing.T) {
+		r := decode(get(content + "?offset=3"))
+		require.Equal(t, whole.Values[3:], r.Values)
+		require.Empty(t, r.Next)
+
+		w := get(content + "?offset=1&limit=2&typed=true")
+		require.Equal(t, http.StatusOK, w.Code)
+		var typed struct {
+			Values []map[string]any `json:"values"`
+			Next   string           `json:"next"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &typed))
+		require.Len(t, typed.Values, 2)
+		require.NotEmpty(t, typed.Next)
+	})
+
+	t.Run("Invalid ranges", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, get(content+"?limit=0").Code)
+		require.Equal(t, http.StatusBadRequest, get(content+"?offset=-1").Code)
+		require.Equal(t, http.StatusBadRequest, get(content+"?cursor=bogus").Code)
+		other := model.PageCursor{Page: 1, Range: model.PageRange{Limit: 2}}.String()
+		require.Equal(t, http.StatusBadRequest, get(content+"?cursor="+other).Code)
+		require.Equal(t, http.StatusNotFound, get(content+"?offset=6").Code)
+	})
+}
+
+func Test_HandlePageContent_Stream(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+	const content = "/rowgroups/0/columnchunks/46/pages/2/content"
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w
+	}
+
+	var whole struct {
+		Values []string `json:"values"`
+	}
+	require.NoError(t, json.Unmarshal(get(content).Body.Bytes(), &whole))
+
+	stream := func(url string) []pageValueEvent {
+		w := get(url)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		var events []pageValueEvent
+		decoder := json.NewDecoder(w.Body)
+		for decoder.More() {
+			var event pageValueEvent
+			require.NoError(t, decoder.Decode(&event))
+			events = append(events, event)
+		}
+		return events
+	}
+
+	events := stream(content + "?stream=true")
+	require.Len(t, events, len(whole.Values))
+	for i, event := range events {
+		require.Equal(t, i, event.Index)
+		require.Equal(t, whole.Values[i], event.Value)
+	}
+
+	events = stream(content + "?stream=true&offset=1&limit=3&typed=true")
+	require.Len(t, events, 3)
+	require.Equal(t, 1, events[0].Index)
+	require.Contains(t, events[0].Value, "Value")
+
+	require.Equal(t, http.StatusNotFound, get(content+"?stream=true&offset=9").Code)
+	require.Equal(t, http.StatusNotFound, get("/rowgroups/0/columnchunks/46/pages/99/content?stream=true").Code)
+}
+
+func Test_pageContentReader_DecodesOnce(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	readRange := svc.pageContentReader(httptest.NewRequest("GET", "/", nil), 0, 1, 1, model.GeoFormatGeoJSON, false)
+	first, total, err := readRange(model.PageRange{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+
+	requests := svc.IOStats().Total.Reads
+	for r, ok := (model.PageRange{Limit: 1}).Next(total); ok; r, ok = r.Next(total) {
+		values, _, err := readRange(r)
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+	}
+	require.Equal(t, requests, svc.IOStats().Total.Reads)
+}
+
+func Test_ColumnPathRoutes(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Every path-addressed endpoint answers like its positional twin
+	suffixes := []string{
+		"", "/statistics/max", "/pages", "/pages/1", "/pages/1/content", "/pages/1/content?typed=true",
+		"/pages/1/content/0", "/pages/1/statistics/min", "/pages/1/structure",
+	}
+	for _, suffix := range suffixes {
+		t.Run("Map.Key_value.Key"+suffix, func(t *testing.T) {
+			byPath := get("/rowgroups/0/columns/Map.Key_value.Key" + suffix)
+			byIndex := get("/rowgroups/0/columnchunks/46" + suffix)
+			require.Equal(t, http.StatusOK, byPath.Code, byPath.Body.String())
+			require.JSONEq(t, byIndex.Body.String(), byPath.Body.String())
+		})
+	}
+
+	t.Run("responses carry the path", func(t *testing.T) {
+		var info model.ColumnChunkInfo
+		require.NoError(t, json.Unmarshal(get("/rowgroups/0/columns/Int32").Body.Bytes(), &info))
+		require.Equal(t, "Int32", info.Path)
+		require.Equal(t, 1, info.Index)
+
+		var pages []model.PageMetadata
+		require.NoError(t, json.Unmarshal(get("/rowgroups/0/columns/Int32/pages").Body.Bytes(), &pages))
+		require.Equal(t, "Int32", pages[0].Path)
+
+		var content struct {
+			Path string `json:"path"`
+		}
+		require.NoError(t, json.Unmarshal(get("/rowgroups/0/columnchunks/1/pages/1/content").Body.Bytes(), &content))
+		require.Equal(t, "Int32", content.Path)
+
+		var detail model.ValueDetail
+		require.NoError(t, json.Unmarshal(get("/rowgroups/0/columns/Int32/pages/1/content/0").Body.Bytes(), &detail))
+		require.Equal(t, "Int32", detail.Path)
+	})
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"Unknown column", "/rowgroups/0/columns/NoSuchColumn", http.StatusNotFound},
+		{"Leaf name only", "/rowgroups/0/columns/Key/pages", http.StatusNotFound},
+		{"Case mismatch", "/rowgroups/0/columns/int32/pages/0/content", http.StatusNotFound},
+		{"Row group out of range", "/rowgroups/99/columns/Int32", http.StatusNotFound},
+		{"Invalid page index", "/rowgroups/0/columns/Int32/pages/x", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.status, get(tt.path).Code)
+		})
+	}
+}
+
+func Test_HandleLeafColumns(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	req := httptest.NewRequest("GET", "/columns", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var columns []model.LeafColumn
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &columns))
+	require.NotEmpty(t, columns)
+	require.Equal(t, "Map.Key_value.Key", columns[46].Path)
+}
+
+func Test_HandleColumnOverview(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	t.Run("Known column", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/columns/Map.Key_value.Key", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var overview model.ColumnOverview
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &overview))
+		require.Equal(t, "Map.Key_value.Key", overview.Path)
+		require.Equal(t, 46, overview.Index)
+		require.Len(t, overview.Chunks, 1)
+		require.Equal(t, overview.Chunks[0].CompressedSize, overview.TotalCompressedSize)
+	})
+
+	t.Run("Unknown column", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/columns/NoSuchColumn", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func Test_HandleHeatmap(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	t.Run("Default metric", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/heatmap", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var heatmap model.Heatmap
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &heatmap))
+		require.Equal(t, model.HeatmapCompressedSize, heatmap.Metric)
+		require.Len(t, heatmap.Cells, 1)
+		require.Len(t, heatmap.Cells[0], len(heatmap.Columns))
+	})
+
+	t.Run("Page count", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/heatmap?metric=pages", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var heatmap model.Heatmap
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &heatmap))
+		require.Equal(t, model.HeatmapPageCount, heatmap.Metric)
+		require.Equal(t, 4.0, heatmap.Cells[0][1].Value)
+	})
+
+	t.Run("Invalid metric", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/heatmap?metric=bogus", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func Test_HandleRowPages(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	t.Run("All columns", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/rows/1-2/pages", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var rowPages model.RowPageMap
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rowPages))
+		require.Equal(t, int64(1), rowPages.FirstRow)
+		require.Equal(t, int64(2), rowPages.LastRow)
+		require.Len(t, rowPages.Columns, 57)
+		require.Positive(t, rowPages.TotalBytes)
+	})
+
+	t.Run("Selected columns", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/rows/3/pages?column=Int32&column=Map.Key_value.Key", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var rowPages model.RowPageMap
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rowPages))
+		require.Len(t, rowPages.Columns, 2)
+		require.Equal(t, "Int32", rowPages.Columns[0].Path)
+		require.Equal(t, model.RowPageSourcePageHeaders, rowPages.Columns[1].Source)
+		require.Len(t, rowPages.Columns[0].Pages, 1)
+	})
+
+	testCases := map[string]struct {
+		url  string
+		code int
+	}{
+		"invalid range":  {url: "/rows/abc/pages", code: http.StatusBadRequest},
+		"out of range":   {url: "/rows/5/pages", code: http.StatusNotFound},
+		"unknown column": {url: "/rows/0/pages?column=NoSuchColumn", code: http.StatusNotFound},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.code, w.Code)
+		})
+	}
+}
+
+func Test_HandleRowLocation(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	t.Run("All columns", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/rows/3/location", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var location model.RowLocation
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &location))
+		require.Equal(t, int64(3), location.Row)
+		require.Equal(t, int64(3), location.RowIndex)
+		require.Len(t, location.Columns, 57)
+		require.Empty(t, location.Record)
+	})
+
+	t.Run("Selected column with record", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/rows/2/location?column=Map.Key_value.Key&record=true", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var location model.RowLocation
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &location))
+		require.Len(t, location.Columns, 1)
+		require.Equal(t, 2, location.Columns[0].NumValues)
+		require.Contains(t, location.Record, `"ByteArray": "ByteArray-2"`)
+	})
+
+	testCases := map[string]struct {
+		url  string
+		code int
+	}{
+		"invalid row":    {url: "/rows/1-2/location", code: http.StatusBadRequest},
+		"out of range":   {url: "/rows/5/location", code: http.StatusNotFound},
+		"unknown column": {url: "/rows/0/location?column=NoSuchColumn", code: http.StatusNotFound},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.code, w.Code)
+		})
+	}
+}
+
+func Test_HandleSample(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	t.Run("Seeded sample is reproducible", func(t *testing.T) {
+		var samples [2]model.Sample
+		for i := range samples {
+			req := httptest.NewRequest("GET", "/sample?n=2&seed=5&column=Int32&column=ByteArray&binary=utf8", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &samples[i]))
+		}
+		require.Equal(t, samples[0], samples[1])
+		require.Equal(t, uint64(5), samples[0].Seed)
+		require.Equal(t, []string{"Int32", "ByteArray"}, samples[0].Columns)
+		require.Len(t, samples[0].Rows, 2)
+		row := samples[0].Rows[0]
+		require.Equal(t, fmt.Sprintf("ByteArray-%d", row.Row), row.Values[1])
+	})
+
+	t.Run("Stratified", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/sample?mode=stratified&n=3", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var sample model.Sample
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &sample))
+		require.Equal(t, model.SampleStratified, sample.Mode)
+		require.Len(t, sample.Rows, 3)
+		require.Len(t, sample.Columns, 57)
+	})
+
+	testCases := map[string]struct {
+		url  string
+		code int
+	}{
+		"invalid size":   {url: "/sample?n=0", code: http.StatusBadRequest},
+		"invalid mode":   {url: "/sample?mode=bogus", code: http.StatusBadRequest},
+		"invalid seed":   {url: "/sample?seed=x", code: http.StatusBadRequest},
+		"unknown column": {url: "/sample?column=NoSuchColumn", code: http.StatusNotFound},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tc.code, w.Code)
+		})
+	}
+}
+
+func Test_ParquetService_Sample(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	sample, err := svc.Sample(context.Background(), model.SampleOptions{N: 1, Seed: 1}, []string{"Int32"})
+	require.NoError(t, err)
+	require.Len(t, sample.Rows, 1)
+	require.Equal(t, []string{"Int32"}, sample.Columns)
+
+	_, err = svc.Sample(context.Background(), model.SampleOptions{N: 1}, []string{"NoSuchColumn"})
+	require.ErrorIs(t, err, model.ErrInvalidColumnPath)
+}
+
+// Test_ParquetService_ConcurrentRequests serves the same requests from many
+// goroutines at once, run with -race to check requests never share a file
+// handle
+func Test_ParquetService_ConcurrentRequests(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	urls := []string{
+		"/rowgroups/0/columnchunks/1/pages",
+		"/rowgroups/0/columnchunks/1/pages/1/content",
+		"/rowgroups/0/columns/Map.Key_value.Key/pages/1/content",
+		"/rowgroups/0/columnchunks/1/pages/1/structure",
+		"/columns/Int32/dictionary",
+		"/layout",
+		"/rows/2/location",
+		"/sample?n=2&seed=5&column=Int32&column=ByteArray",
+	}
+	get := func(url string) (int, string) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w.Code, w.Body.String()
+	}
+
+	want := make([]string, len(urls))
+	for i, url := range urls {
+		code, body := get(url)
+		require.Equal(t, http.StatusOK, code, url)
+		want[i] = body
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 3 {
+				for i, url := range urls {
+					code, body := get(url)
+					if assert.Equal(t, http.StatusOK, code, url) {
+						assert.Equal(t, want[i], body, url)
+					}
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func Test_HandleCacheStats(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w
+	}
+
+	for range 2 {
+		require.Equal(t, http.StatusOK, get("/rowgroups/0/columnchunks/1/pages/1/content").Code)
+	}
+
+	w := get("/cache")
+	require.Equal(t, http.StatusOK, w.Code)
+	var stats model.CacheStats
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	require.True(t, stats.Enabled)
+	require.Equal(t, int64(model.DefaultCacheSize), stats.MaxBytes)
+	require.Positive(t, stats.Hits)
+	require.Positive(t, stats.Misses)
+	require.Positive(t, stats.Bytes)
+
+	svc.SetCacheOptions(model.CacheOptions{})
+	w = get("/cache")
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	require.Equal(t, model.CacheStats{}, stats)
+}
+
+func Test_ParquetService_FileChanged(t *testing.T) {
+	original := getTestParquetPathAPI("all-types.parquet")
+	replacement := getTestParquetPathAPI("row-group.parquet")
+	if original == "" || replacement == "" {
+		t.Skip("Test files not found - run 'make test' to download test files")
+	}
+	copyFile := func(from, to string) {
+		data, err := os.ReadFile(from)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(to, data, 0o644))
+	}
+	path := filepath.Join(t.TempDir(), "changing.parquet")
+	copyFile(original, path)
+
+	svc, err := NewParquetService(path, pio.ReadOption{})
+	require.NoError(t, err)
+	defer func() {
+		_ = svc.Close()
+	}()
+	svc.SetCacheOptions(model.CacheOptions{MaxBytes: model.DefaultCacheSize, CheckInterval: time.Nanosecond})
+	require.NoError(t, svc.SetDisplayOptions(model.DisplayOptions{Binary: model.BinaryHex}))
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+	info := func() model.FileInfo {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/info", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var info model.FileInfo
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+		return info
+	}
+	require.Equal(t, 1, info().NumRowGroups)
+
+	copyFile(replacement, path)
+	require.Equal(t, 2, info().NumRowGroups)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/rowgroups/1/columnchunks/0/pages/0/content", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, int64(1), svc.CacheStats().Invalidations)
+	require.Equal(t, model.BinaryHex, svc.defaultReader().DisplayOptions().Binary)
+}
+
+func Test_HandleIOStats(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w
+	}
+
+	require.Equal(t, http.StatusOK, get("/rowgroups/0/columnchunks/1/pages").Code)
+	w := get("/debug/io")
+	require.Equal(t, http.StatusOK, w.Code)
+	var stats model.IOStats
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	require.NotEmpty(t, stats.Operations)
+	require.Positive(t, stats.Total.Reads)
+	require.Positive(t, stats.Total.Bytes)
+	require.Zero(t, stats.Prefetch.MaxBlock)
+
+	opts := model.PrefetchOptions{MinBlock: 16 << 10, MaxBlock: 1 << 20}
+	svc.SetPrefetchOptions(opts)
+	require.Equal(t, http.StatusOK, get("/rowgroups/0/columnchunks/2/pages").Code)
+	w = get("/debug/io")
+	var prefetched model.IOStats
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &prefetched))
+	require.Equal(t, opts, prefetched.Prefetch)
+	require.Greater(t, prefetched.Total.Reads, stats.Total.Reads)
+	require.Positive(t, prefetched.Total.Prefetched)
+}
+
+func Test_ParquetService_Close_WithRealFile(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w
+	}
+
+	require.Equal(t, http.StatusOK, get("/rowgroups/0/columnchunks/1/pages/1/content").Code)
+	w := get("/handles")
+	require.Equal(t, http.StatusOK, w.Code)
+	var stats model.HandleStats
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	require.Equal(t, model.DefaultReaderPoolSize, stats.PoolSize)
+	require.Positive(t, stats.Readers)
+	require.Positive(t, stats.FileHandles)
+	require.Zero(t, stats.ColumnReaders)
+	require.Equal(t, stats.Opened-int64(stats.Readers), stats.Closed)
+
+	svc.SetReaderPoolSize(2)
+	require.Equal(t, 2, svc.HandleStats().PoolSize)
+
+	require.NoError(t, svc.Close())
+	stats = svc.HandleStats()
+	require.Zero(t, stats.Readers)
+	require.Zero(t, stats.FileHandles)
+	require.Equal(t, stats.Opened, stats.Closed)
+
+	w = get("/layout")
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Contains(t, w.Body.String(), model.ErrReaderClosed.Error())
+	require.NoError(t, svc.Close())
+}
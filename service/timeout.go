@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// SetRequestTimeout limits how long a request may read the file, 0 lets
+// requests run until the client goes away. A request over the limit stops
+// reading and fails with 504.
+func (s *ParquetService) SetRequestTimeout(timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requestTimeout = timeout
+}
+
+// RequestTimeout returns how long a request may read the file, 0 for no limit
+func (s *ParquetService) RequestTimeout() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.requestTimeout
+}
+
+// timeoutKey marks the context of a request the timeout is applied to
+type timeoutKey struct{}
+
+// withRequestTimeout gives each request a deadline when a timeout is set, and
+// a response writer that reports errors past the deadline as 504. It goes
+// before displayMiddleware so the reader of the request sees the deadline.
+func (s *ParquetService) withRequestTimeout(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timeout := s.RequestTimeout()
+		// Routes of the API and the web UI share a router, apply once
+		if r.Context().Value(timeoutKey{}) != nil || timeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.WithValue(r.Context(), timeoutKey{}, true), timeout)
+		defer cancel()
+		next.ServeHTTP(&timeoutWriter{ResponseWriter: w, ctx: ctx}, r.WithContext(ctx))
+	})
+}
+
+// timeoutWriter turns the error status of a request that ran out of time
+// into 504, whatever error the interrupted read surfaced as. Some handlers
+// report every read error as 404, bad requests stay 400.
+type timeoutWriter struct {
+	http.ResponseWriter
+	ctx context.Context
+}
+
+// WriteHeader writes the status, 504 for errors past the deadline
+func (w *timeoutWriter) WriteHeader(status int) {
+	failed := status == http.StatusNotFound || status >= http.StatusInternalServerError
+	if failed && errors.Is(w.ctx.Err(), context.DeadlineExceeded) {
+		status = http.StatusGatewayTimeout
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Flush sends buffered data to the client, for streamed responses
+func (w *timeoutWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SetRequestTimeout(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	t.Cleanup(func() { _ = svc.Close() })
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		return w
+	}
+
+	require.Zero(t, svc.RequestTimeout())
+	require.Equal(t, http.StatusOK, get("/layout").Code)
+
+	svc.SetRequestTimeout(time.Nanosecond)
+	require.Equal(t, time.Nanosecond, svc.RequestTimeout())
+	// The footer is in memory, nothing to read from the file
+	require.Equal(t, http.StatusOK, get("/info").Code)
+	w := get("/layout")
+	require.Equal(t, http.StatusGatewayTimeout, w.Code)
+	require.Contains(t, w.Body.String(), context.DeadlineExceeded.Error())
+	require.Equal(t, http.StatusGatewayTimeout, get("/rowgroups/0/columnchunks/1/pages/1/content").Code)
+	require.Equal(t, http.StatusBadRequest, get("/info?decimal=bogus").Code)
+
+	svc.SetRequestTimeout(time.Minute)
+	require.Equal(t, http.StatusOK, get("/rowgroups/0/columnchunks/1/pages/1/content").Code)
+	require.Equal(t, http.StatusOK, get("/columns/Int32/profile?stream=true").Code)
+}
+
+func Test_RequestCancelled(t *testing.T) {
+	svc := createTestServiceWithRealFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	t.Cleanup(func() { _ = svc.Close() })
+
+	router := mux.NewRouter()
+	svc.SetupRoutes(router)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/layout", nil).WithContext(ctx))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Contains(t, w.Body.String(), context.Canceled.Error())
+	require.Zero(t, svc.CacheStats().Entries)
+}
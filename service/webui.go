@@ -2,13 +2,17 @@ package service
 
 import (
 	"embed"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
+	"math"
 	"net/http"
+	"net/url"
 	"os/exec"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -55,18 +59,41 @@ func init() {
 
 // SetupWebUIRoutes configures all web UI routes
 func (s *ParquetService) SetupWebUIRoutes(r *mux.Router) {
+	r.Use(s.withRequestTimeout)
+	r.Use(s.displayMiddleware)
+
 	// Main UI routes
 	r.HandleFunc("/", s.handleIndexPage).Methods("GET")
 	r.HandleFunc("/ui/main", s.handleMainView).Methods("GET")
+	r.HandleFunc("/ui/layout", s.handleFileLayoutView).Methods("GET")
+	r.HandleFunc("/ui/heatmap", s.handleHeatmapView).Methods("GET")
+	r.HandleFunc("/ui/rows", s.handleRowPagesView).Methods("GET")
+	r.HandleFunc("/ui/rows/location", s.handleRowLocationView).Methods("GET")
+	r.HandleFunc("/ui/sample", s.handleSampleView).Methods("GET")
 	r.HandleFunc("/ui/schema", s.handleSchemaView).Methods("GET")
 	r.HandleFunc("/ui/schema/go", s.handleSchemaGoView).Methods("GET")
 	r.HandleFunc("/ui/schema/json", s.handleSchemaJSONView).Methods("GET")
 	r.HandleFunc("/ui/schema/csv", s.handleSchemaCSVView).Methods("GET")
 	r.HandleFunc("/ui/schema/raw", s.handleSchemaRawView).Methods("GET")
+	r.HandleFunc("/ui/schema/variants", s.handleSchemaVariantsView).Methods("GET")
 	r.HandleFunc("/ui/rowgroups", s.handleRowGroupsView).Methods("GET")
 	r.HandleFunc("/ui/rowgroups/{rgIndex}/columns", s.handleColumnsView).Methods("GET")
-	r.HandleFunc("/ui/rowgroups/{rgIndex}/columns/{colIndex}/pages", s.handlePagesView).Methods("GET")
-	r.HandleFunc("/ui/rowgroups/{rgIndex}/columns/{colIndex}/pages/{pageIndex}/content", s.handlePageContentView).Methods("GET")
+	r.HandleFunc("/ui/rowgroups/{rgIndex}/columns/{path}/pages", s.handlePagesView).Methods("GET")
+	r.HandleFunc("/ui/rowgroups/{rgIndex}/columns/{path}/pages/{pageIndex}/content", s.handlePageContentView).Methods("GET")
+	r.HandleFunc("/ui/rowgroups/{rgIndex}/columns/{path}/pages/{pageIndex}/values", s.handlePageValuesView).Methods("GET")
+	r.HandleFunc("/ui/rowgroups/{rgIndex}/columns/{path}/pages/{pageIndex}/structure", s.handlePageStructureView).Methods("GET")
+	r.HandleFunc("/ui/rowgroups/{rgIndex}/columns/{path}/pages/{pageIndex}/content/{valueIndex}", s.handlePageValueView).Methods("GET")
+	r.HandleFunc("/ui/rowgroups/{rgIndex}/columns/{path}/pages/{pageIndex}/statistics/{stat}", s.handlePageStatisticView).Methods("GET")
+	r.HandleFunc("/ui/rowgroups/{rgIndex}/columns/{path}/statistics/{stat}", s.handleColumnChunkStatisticView).Methods("GET")
+	r.HandleFunc("/ui/columns", s.handleLeafColumnsView).Methods("GET")
+	r.HandleFunc("/ui/columns/{path}", s.handleColumnOverviewView).Methods("GET")
+	r.HandleFunc("/ui/columns/{path}/profile", s.handleColumnProfileView).Methods("GET")
+	r.HandleFunc("/ui/columns/{path}/profile/result", s.handleColumnProfileResultView).Methods("GET")
+	r.HandleFunc("/ui/columns/{path}/dictionary", s.handleColumnDictionaryView).Methods("GET")
+	r.HandleFunc("/ui/columns/{path}/geo", s.handleColumnGeoView).Methods("GET")
+	r.HandleFunc("/ui/settings", s.handleSettingsView).Methods("GET")
+	r.HandleFunc("/ui/io", s.handleIOFooter).Methods("GET")
+	r.HandleFunc("/ui/settings", s.handleSettingsSave).Methods("POST")
 
 	// Catch-all for static files and other resources (favicon, service worker, etc.)
 	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -89,12 +116,48 @@ func (s *ParquetService) handleIndexPage(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// ioFooterData is the summary of the reads of the file at the bottom of
+// every page
+type ioFooterData struct {
+	Summary  string
+	Detail   string // a line per operation
+	Prefetch string // largest read-ahead, "" when prefetching is off
+}
+
+// handleIOFooter returns the summary of the reads of the file so far, which
+// the footer reloads every few seconds and after each view
+func (s *ParquetService) handleIOFooter(w http.ResponseWriter, r *http.Request) {
+	stats := s.IOStats()
+	data := ioFooterData{Summary: stats.Total.Summary()}
+	lines := make([]string, 0, len(stats.Operations))
+	for _, op := range stats.Operations {
+		lines = append(lines, fmt.Sprintf("%s: %s, longest %s", op.Operation, op.Summary(), model.FormatLatency(op.MaxLatency)))
+	}
+	data.Detail = strings.Join(lines, "\n")
+	if stats.Prefetch.MaxBlock > 0 {
+		data.Prefetch = model.FormatBytes(int64(stats.Prefetch.MaxBlock))
+	}
+	if err := renderPartial(w, r, "io_footer", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 // handleMainView serves the main view with file info and row groups
 func (s *ParquetService) handleMainView(w http.ResponseWriter, r *http.Request) {
-	info := s.reader.GetFileInfo()
+	info := s.readerFor(r).GetFileInfo()
 
-	// Get row groups
-	rowGroups := s.reader.GetAllRowGroupsInfo()
+	// Get the window of row groups to show
+	opts, err := parseListOptions(r, model.RowGroupSortKeys)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	list, err := s.readerFor(r).ListRowGroups(opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rowGroups := list.RowGroups
 
 	// Format the row groups for display
 	type FormattedRowGroup struct {
@@ -131,6 +194,7 @@ func (s *ParquetService) handleMainView(w http.ResponseWriter, r *http.Request)
 		CreatedBy             string
 		Encryption            string
 		RowGroups             []FormattedRowGroup
+		List                  listView
 	}{
 		FileName:              s.uri,
 		Version:               info.Version,
@@ -143,14 +207,148 @@ func (s *ParquetService) handleMainView(w http.ResponseWriter, r *http.Request)
 		CreatedBy:             info.CreatedBy,
 		Encryption:            info.Encryption,
 		RowGroups:             formatted,
+		List:                  newListView("/ui/main", opts, list.Total, len(rowGroups), "Filter by index"),
 	}
 
-	err := renderPartial(w, r, "main", data)
+	err = renderPartial(w, r, "main", data)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
+// listView is the window of a listing shown in a web UI table, with the
+// links to sort, filter and page through the listing
+type listView struct {
+	URL         string // path of the view
+	Opts        model.ListOptions
+	Total       int // items matching the filter
+	Shown       int // items in the window
+	Placeholder string
+}
+
+// newListView describes the window of a listing served at path
+func newListView(path string, opts model.ListOptions, total, shown int, placeholder string) listView {
+	return listView{URL: path, Opts: opts, Total: total, Shown: shown, Placeholder: placeholder}
+}
+
+// SortParam returns the sort query parameter of the listing, "" for index
+// order
+func (v listView) SortParam() string {
+	key := v.Opts.Sort
+	if key == "" {
+		if !v.Opts.Desc {
+			return ""
+		}
+		key = "index"
+	}
+	if v.Opts.Desc {
+		return "-" + key
+	}
+	return key
+}
+
+// LimitParam returns the limit query parameter of the listing, 0 for the
+// default
+func (v listView) LimitParam() int {
+	if v.Opts.Limit == model.DefaultListLimit {
+		return 0
+	}
+	return v.Opts.Limit
+}
+
+// link returns the URL of the listing from offset, sorted by sort
+func (v listView) link(offset int, sort string) string {
+	query := url.Values{}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+	if limit := v.LimitParam(); limit != 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if sort != "" {
+		query.Set("sort", sort)
+	}
+	if v.Opts.Filter != "" {
+		query.Set("filter", v.Opts.Filter)
+	}
+	if len(query) == 0 {
+		return v.URL
+	}
+	return v.URL + "?" + query.Encode()
+}
+
+// SortURL returns the URL of the listing sorted by key from the start,
+// reversing the order when it is already sorted by key
+func (v listView) SortURL(key string) string {
+	current := v.Opts.Sort
+	if current == "" {
+		current = "index"
+	}
+	sort := key
+	if current == key && !v.Opts.Desc {
+		sort = "-" + key
+	}
+	if sort == "index" {
+		sort = ""
+	}
+	return v.link(0, sort)
+}
+
+// SortMark returns the arrow shown next to the column the listing is sorted
+// by
+func (v listView) SortMark(key string) string {
+	current := v.Opts.Sort
+	if current == "" {
+		current = "index"
+	}
+	switch {
+	case current != key:
+		return ""
+	case v.Opts.Desc:
+		return " ▼"
+	default:
+		return " ▲"
+	}
+}
+
+// sortHeader is a table header sorting the listing by its column
+type sortHeader struct {
+	URL   string
+	Label string
+	Mark  string
+}
+
+// SortHeader returns the header of the column sorting the listing by key
+func (v listView) SortHeader(key, label string) sortHeader {
+	return sortHeader{URL: v.SortURL(key), Label: label, Mark: v.SortMark(key)}
+}
+
+// First returns the 1-based position of the first item shown
+func (v listView) First() int {
+	return v.Opts.Offset + 1
+}
+
+// Last returns the 1-based position of the last item shown
+func (v listView) Last() int {
+	return v.Opts.Offset + v.Shown
+}
+
+// PrevURL returns the URL of the previous window, "" on the first one
+func (v listView) PrevURL() string {
+	if v.Opts.Offset == 0 || v.Opts.Limit == 0 {
+		return ""
+	}
+	return v.link(max(0, v.Opts.Offset-v.Opts.Limit), v.SortParam())
+}
+
+// NextURL returns the URL of the next window, "" on the last one
+func (v listView) NextURL() string {
+	if v.Opts.Limit == 0 || v.Opts.Offset+v.Opts.Limit >= v.Total {
+		return ""
+	}
+	return v.link(v.Opts.Offset+v.Opts.Limit, v.SortParam())
+}
+
 // handleSchemaView serves the schema viewer page
 func (s *ParquetService) handleSchemaView(w http.ResponseWriter, r *http.Request) {
 	err := renderPartial(w, r, "schema", nil)
@@ -161,7 +359,7 @@ func (s *ParquetService) handleSchemaView(w http.ResponseWriter, r *http.Request
 
 // handleSchemaGoView returns schema in Go format for HTMX
 func (s *ParquetService) handleSchemaGoView(w http.ResponseWriter, r *http.Request) {
-	schemaRoot, err := pschema.NewSchemaTree(s.parquetReader, pschema.SchemaOption{FailOnInt96: false})
+	schemaRoot, err := pschema.NewSchemaTree(s.readerFor(r).Reader, pschema.SchemaOption{FailOnInt96: false})
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to generate schema: %v", err), http.StatusInternalServerError)
 		return
@@ -184,7 +382,7 @@ func (s *ParquetService) handleSchemaGoView(w http.ResponseWriter, r *http.Reque
 
 // handleSchemaJSONView returns schema in JSON format for HTMX
 func (s *ParquetService) handleSchemaJSONView(w http.ResponseWriter, r *http.Request) {
-	schemaRoot, err := pschema.NewSchemaTree(s.parquetReader, pschema.SchemaOption{FailOnInt96: false})
+	schemaRoot, err := pschema.NewSchemaTree(s.readerFor(r).Reader, pschema.SchemaOption{FailOnInt96: false})
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to generate schema: %v", err), http.StatusInternalServerError)
 		return
@@ -198,7 +396,7 @@ func (s *ParquetService) handleSchemaJSONView(w http.ResponseWriter, r *http.Req
 
 // handleSchemaCSVView returns schema in CSV format for HTMX
 func (s *ParquetService) handleSchemaCSVView(w http.ResponseWriter, r *http.Request) {
-	schemaRoot, err := pschema.NewSchemaTree(s.parquetReader, pschema.SchemaOption{FailOnInt96: false})
+	schemaRoot, err := pschema.NewSchemaTree(s.readerFor(r).Reader, pschema.SchemaOption{FailOnInt96: false})
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to generate schema: %v", err), http.StatusInternalServerError)
 		return
@@ -216,7 +414,7 @@ func (s *ParquetService) handleSchemaCSVView(w http.ResponseWriter, r *http.Requ
 
 // handleSchemaRawView returns raw schema for HTMX (compact JSON)
 func (s *ParquetService) handleSchemaRawView(w http.ResponseWriter, r *http.Request) {
-	schemaRoot, err := pschema.NewSchemaTree(s.parquetReader, pschema.SchemaOption{FailOnInt96: false})
+	schemaRoot, err := pschema.NewSchemaTree(s.readerFor(r).Reader, pschema.SchemaOption{FailOnInt96: false})
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to generate schema: %v", err), http.StatusInternalServerError)
 		return
@@ -235,10 +433,51 @@ func (s *ParquetService) handleSchemaRawView(w http.ResponseWriter, r *http.Requ
 	_, _ = w.Write(rawJSON)
 }
 
+// handleSchemaVariantsView returns the VARIANT columns as text for HTMX,
+// marking the role of each leaf column
+func (s *ParquetService) handleSchemaVariantsView(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(formatVariantSchema(s.readerFor(r).GetVariantColumns())))
+}
+
+// formatVariantSchema renders VARIANT columns one leaf column per line,
+// prefixed with its role
+func formatVariantSchema(variants []model.VariantColumn) string {
+	if len(variants) == 0 {
+		return "No VARIANT columns\n"
+	}
+
+	var text strings.Builder
+	for i, variant := range variants {
+		if i > 0 {
+			text.WriteString("\n")
+		}
+		kind := "unshredded"
+		if variant.Shredded {
+			kind = "shredded"
+		}
+		_, _ = fmt.Fprintf(&text, "%s (VARIANT, %s)\n", variant.Path, kind)
+		for _, col := range variant.Columns {
+			_, _ = fmt.Fprintf(&text, "  %-10s #%-4d %s\n", "["+col.Role+"]", col.ColumnIndex, col.Path)
+		}
+	}
+	return text.String()
+}
+
 // handleRowGroupsView serves the row groups list view
 func (s *ParquetService) handleRowGroupsView(w http.ResponseWriter, r *http.Request) {
-	rowGroups := s.reader.GetAllRowGroupsInfo()
-	fileInfo := s.reader.GetFileInfo()
+	opts, err := parseListOptions(r, model.RowGroupSortKeys)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	list, err := s.readerFor(r).ListRowGroups(opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rowGroups := list.RowGroups
+	fileInfo := s.readerFor(r).GetFileInfo()
 
 	// Format the row groups for display
 	type FormattedRowGroup struct {
@@ -251,15 +490,8 @@ func (s *ParquetService) handleRowGroupsView(w http.ResponseWriter, r *http.Requ
 		FileOffset       string
 	}
 
-	// Calculate totals
-	var totalRows int64
-	var totalCompressed, totalUncompressed int64
 	formatted := make([]FormattedRowGroup, len(rowGroups))
 	for i, rg := range rowGroups {
-		totalRows += rg.NumRows
-		totalCompressed += rg.CompressedSize
-		totalUncompressed += rg.UncompressedSize
-
 		formatted[i] = FormattedRowGroup{
 			Index:            rg.Index,
 			NumRows:          rg.NumRows,
@@ -279,17 +511,20 @@ func (s *ParquetService) handleRowGroupsView(w http.ResponseWriter, r *http.Requ
 		TotalCompressed   string
 		TotalUncompressed string
 		OverallRatio      string
+		List              listView
 	}{
+		// The totals cover the whole file, not just the row groups shown
 		RowGroups:         formatted,
-		TotalRowGroups:    len(rowGroups),
-		TotalRows:         totalRows,
+		TotalRowGroups:    fileInfo.NumRowGroups,
+		TotalRows:         fileInfo.NumRows,
 		TotalColumns:      fileInfo.NumLeafColumns,
-		TotalCompressed:   model.FormatBytes(totalCompressed),
-		TotalUncompressed: model.FormatBytes(totalUncompressed),
-		OverallRatio:      formatRatio(float64(totalUncompressed) / float64(totalCompressed)),
+		TotalCompressed:   model.FormatBytes(fileInfo.TotalCompressedSize),
+		TotalUncompressed: model.FormatBytes(fileInfo.TotalUncompressedSize),
+		OverallRatio:      formatRatio(fileInfo.CompressionRatio),
+		List:              newListView("/ui/rowgroups", opts, list.Total, len(rowGroups), "Filter by index"),
 	}
 
-	err := renderPartial(w, r, "rowgroups", data)
+	err = renderPartial(w, r, "rowgroups", data)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
@@ -304,14 +539,20 @@ func (s *ParquetService) handleColumnsView(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	columns, err := s.reader.GetAllColumnChunksInfo(rgIndex)
+	opts, err := parseListOptions(r, model.ColumnChunkSortKeys)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	list, err := s.readerFor(r).ListColumnChunks(rgIndex, opts)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
+	columns := list.ColumnChunks
 
 	// Get row group info for summary
-	rowGroupInfo, err := s.reader.GetRowGroupInfo(rgIndex)
+	rowGroupInfo, err := s.readerFor(r).GetRowGroupInfo(rgIndex)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
@@ -331,22 +572,16 @@ func (s *ParquetService) handleColumnsView(w http.ResponseWriter, r *http.Reques
 		UncompressedSize string
 		MinValue         string
 		MaxValue         string
+		SizeStats        *sizeStatsView
+		Geo              *geoStatsView
+		Variant          *model.VariantMember
 	}
 
-	// Calculate totals
-	var totalValues int64
-	var totalNulls int64
-	var totalCompressed, totalUncompressed int64
 	formatted := make([]FormattedColumn, len(columns))
 	for i, col := range columns {
-		totalValues += col.NumValues
-		totalCompressed += col.CompressedSize
-		totalUncompressed += col.UncompressedSize
-
 		nullCountStr := "0"
 		if col.NullCount != nil {
 			nullCountStr = fmt.Sprintf("%d", *col.NullCount)
-			totalNulls += *col.NullCount
 		}
 
 		minValue := col.MinValue
@@ -371,6 +606,9 @@ func (s *ParquetService) handleColumnsView(w http.ResponseWriter, r *http.Reques
 			UncompressedSize: model.FormatBytes(col.UncompressedSize),
 			MinValue:         minValue,
 			MaxValue:         maxValue,
+			SizeStats:        buildSizeStatsView(col.SizeStatistics),
+			Geo:              buildGeoStatsView(col.GeospatialStatistics),
+			Variant:          col.Variant,
 		}
 	}
 
@@ -384,16 +622,20 @@ func (s *ParquetService) handleColumnsView(w http.ResponseWriter, r *http.Reques
 		TotalCompressed   string
 		TotalUncompressed string
 		CompressionRatio  string
+		List              listView
 	}{
+		// The totals cover all the column chunks matching the filter
 		RowGroupIndex:     rgIndex,
 		Columns:           formatted,
 		NumRows:           rowGroupInfo.NumRows,
-		TotalColumns:      len(columns),
-		TotalValues:       totalValues,
-		TotalNulls:        totalNulls,
-		TotalCompressed:   model.FormatBytes(totalCompressed),
-		TotalUncompressed: model.FormatBytes(totalUncompressed),
-		CompressionRatio:  formatRatio(float64(totalUncompressed) / float64(totalCompressed)),
+		TotalColumns:      list.Total,
+		TotalValues:       list.NumValues,
+		TotalNulls:        list.NullCount,
+		TotalCompressed:   model.FormatBytes(list.CompressedSize),
+		TotalUncompressed: model.FormatBytes(list.UncompressedSize),
+		CompressionRatio:  formatRatio(float64(list.UncompressedSize) / float64(list.CompressedSize)),
+		List: newListView(fmt.Sprintf("/ui/rowgroups/%d/columns", rgIndex), opts, list.Total, len(columns),
+			"Filter by path, type or codec"),
 	}
 
 	err = renderPartial(w, r, "columns", data)
@@ -402,6 +644,356 @@ func (s *ParquetService) handleColumnsView(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// handleLeafColumnsView lists the leaf columns, each linking to its chunks
+// across all row groups
+func (s *ParquetService) handleLeafColumnsView(w http.ResponseWriter, r *http.Request) {
+	data := struct {
+		Columns      []model.LeafColumn
+		NumRowGroups int
+	}{
+		Columns:      s.readerFor(r).GetLeafColumns(),
+		NumRowGroups: s.readerFor(r).GetFileInfo().NumRowGroups,
+	}
+
+	err := renderPartial(w, r, "leaf_columns", data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// rowPageView is one page of the row lookup, linked to its content
+type rowPageView struct {
+	RowGroup      int
+	PageIndex     int
+	FirstRowIndex int64
+	FirstRow      int64
+	NumRows       int64
+	Offset        int64
+	Size          string
+	Link          string
+}
+
+// rowColumnView is one column of the row lookup with the bytes to read
+type rowColumnView struct {
+	Path            string
+	Source          string
+	Pages           []rowPageView
+	DictionaryBytes string
+	Bytes           string
+}
+
+// handleRowPagesView looks up a row or row range given as rows=N or
+// rows=first-last and shows the pages holding it in every column, or in the
+// columns picked with column=path
+func (s *ParquetService) handleRowPagesView(w http.ResponseWriter, r *http.Request) {
+	type columnOption struct {
+		Path     string
+		Selected bool
+	}
+
+	query := r.URL.Query()
+	selected := query["column"]
+	leafColumns := s.readerFor(r).GetLeafColumns()
+	options := make([]columnOption, len(leafColumns))
+	for i, column := range leafColumns {
+		options[i] = columnOption{Path: column.Path, Selected: slices.Contains(selected, column.Path)}
+	}
+
+	data := struct {
+		Rows       string
+		NumRows    int64
+		Options    []columnOption
+		Error      string
+		Found      bool
+		RowPages   model.RowPageMap
+		Columns    []rowColumnView
+		TotalBytes string
+	}{
+		Rows:    query.Get("rows"),
+		NumRows: s.readerFor(r).GetFileInfo().NumRows,
+		Options: options,
+	}
+
+	if data.Rows != "" {
+		rowPages, err := s.lookupRowPages(r, data.Rows)
+		if err != nil {
+			data.Error = err.Error()
+		} else {
+			data.Found = true
+			data.RowPages = rowPages
+			data.Columns = buildRowColumns(rowPages)
+			data.TotalBytes = model.FormatBytes(rowPages.TotalBytes)
+		}
+	}
+
+	err := renderPartial(w, r, "row_pages", data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// rowLocationColumnView is one column of a located row, linked to its page
+// with the row's values highlighted
+type rowLocationColumnView struct {
+	model.RowColumnLocation
+	Link string
+}
+
+// handleRowLocationView goes to the row given as row=N: its row group and,
+// in every column, the page and values holding it. With record=true the
+// assembled row is shown as well.
+func (s *ParquetService) handleRowLocationView(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	data := struct {
+		Row        string
+		NumRows    int64
+		WithRecord bool
+		Error      string
+		Found      bool
+		Location   model.RowLocation
+		Columns    []rowLocationColumnView
+	}{
+		Row:        query.Get("row"),
+		NumRows:    s.readerFor(r).GetFileInfo().NumRows,
+		WithRecord: query.Get("record") == "true",
+	}
+
+	if data.Row != "" {
+		location, err := s.lookupRowLocation(r, data.Row, data.WithRecord)
+		if err != nil {
+			data.Error = err.Error()
+		} else {
+			data.Found = true
+			data.Location = location
+			data.Columns = make([]rowLocationColumnView, len(location.Columns))
+			for i, column := range location.Columns {
+				data.Columns[i] = rowLocationColumnView{
+					RowColumnLocation: column,
+					Link: fmt.Sprintf("/ui/rowgroups/%d/columns/%s/pages/%d/content?highlight=%d&highlight_count=%d",
+						location.RowGroup, url.PathEscape(column.Path), column.PageIndex, column.ValueIndex, column.NumValues),
+				}
+			}
+		}
+	}
+
+	err := renderPartial(w, r, "row_location", data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleSampleView draws a sample of rows once the form is submitted with
+// n=N, mode=random|stratified, an optional seed and the columns picked with
+// column=path, and shows it as a grid of rows × columns
+func (s *ParquetService) handleSampleView(w http.ResponseWriter, r *http.Request) {
+	type columnOption struct {
+		Path     string
+		Selected bool
+	}
+
+	query := r.URL.Query()
+	selected := query["column"]
+	leafColumns := s.readerFor(r).GetLeafColumns()
+	options := make([]columnOption, len(leafColumns))
+	for i, column := range leafColumns {
+		options[i] = columnOption{Path: column.Path, Selected: slices.Contains(selected, column.Path)}
+	}
+
+	data := struct {
+		N         string
+		Mode      string
+		Modes     []model.SampleMode
+		Seed      string
+		NumRows   int64
+		Options   []columnOption
+		Error     string
+		Found     bool
+		Sample    model.Sample
+		BytesRead string
+		Permalink string // draws the same sample again
+	}{
+		N:       query.Get("n"),
+		Mode:    query.Get("mode"),
+		Modes:   model.SampleModes,
+		Seed:    query.Get("seed"),
+		NumRows: s.readerFor(r).GetFileInfo().NumRows,
+		Options: options,
+	}
+
+	if query.Has("n") {
+		sample, err := s.lookupSample(r)
+		if err != nil {
+			data.Error = err.Error()
+		} else {
+			data.Found = true
+			data.Sample = sample
+			data.BytesRead = model.FormatBytes(sample.BytesRead)
+			permalink := r.URL.Query()
+			permalink.Set("seed", strconv.FormatUint(sample.Seed, 10))
+			data.Permalink = "/ui/sample?" + permalink.Encode()
+		}
+	}
+	if data.N == "" {
+		data.N = strconv.Itoa(model.DefaultSampleSize)
+	}
+
+	err := renderPartial(w, r, "sample", data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// lookupSample parses the sample options of a request and draws the sample
+func (s *ParquetService) lookupSample(r *http.Request) (model.Sample, error) {
+	opts, err := parseSampleOptions(r.URL.Query())
+	if err != nil {
+		return model.Sample{}, err
+	}
+	if opts.Columns, err = s.requestColumnIndexes(r); err != nil {
+		return model.Sample{}, err
+	}
+	return s.readerFor(r).SampleRows(r.Context(), opts)
+}
+
+// lookupRowLocation parses a row number and locates it in every column,
+// assembling the whole row when asked to
+func (s *ParquetService) lookupRowLocation(r *http.Request, row string, withRecord bool) (model.RowLocation, error) {
+	rowNumber, err := model.ParseRowNumber(row)
+	if err != nil {
+		return model.RowLocation{}, err
+	}
+	location, err := s.readerFor(r).GetRowLocation(r.Context(), rowNumber, nil)
+	if err != nil {
+		return model.RowLocation{}, err
+	}
+	if withRecord {
+		location.Record, err = s.readerFor(r).GetRecord(rowNumber)
+	}
+	return location, err
+}
+
+// lookupRowPages parses a row range and finds its pages in the requested columns
+func (s *ParquetService) lookupRowPages(r *http.Request, rows string) (model.RowPageMap, error) {
+	firstRow, lastRow, err := model.ParseRowRange(rows)
+	if err != nil {
+		return model.RowPageMap{}, err
+	}
+	colIndexes, err := s.requestColumnIndexes(r)
+	if err != nil {
+		return model.RowPageMap{}, err
+	}
+	return s.readerFor(r).GetRowPages(r.Context(), firstRow, lastRow, colIndexes)
+}
+
+// buildRowColumns describes the columns of a row lookup for display
+func buildRowColumns(rowPages model.RowPageMap) []rowColumnView {
+	columns := make([]rowColumnView, len(rowPages.Columns))
+	for i, column := range rowPages.Columns {
+		columns[i] = rowColumnView{
+			Path:            column.Path,
+			Source:          column.Source,
+			Pages:           make([]rowPageView, len(column.Pages)),
+			DictionaryBytes: model.FormatBytes(column.DictionaryBytes),
+			Bytes:           model.FormatBytes(column.Bytes),
+		}
+		for j, page := range column.Pages {
+			columns[i].Pages[j] = rowPageView{
+				RowGroup:      page.RowGroup,
+				PageIndex:     page.PageIndex,
+				FirstRowIndex: page.FirstRowIndex,
+				FirstRow:      page.FirstRow,
+				NumRows:       page.NumRows,
+				Offset:        page.Offset,
+				Size:          model.FormatBytes(page.Size),
+				Link: fmt.Sprintf("/ui/rowgroups/%d/columns/%s/pages/%d/content",
+					page.RowGroup, url.PathEscape(column.Path), page.PageIndex),
+			}
+		}
+	}
+	return columns
+}
+
+// handleColumnOverviewView shows the chunks of one column in every row group
+// side by side, with file-wide totals
+func (s *ParquetService) handleColumnOverviewView(w http.ResponseWriter, r *http.Request) {
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(mux.Vars(r)["path"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	overview, err := s.readerFor(r).GetColumnOverview(colIndex)
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	type chunkRow struct {
+		model.ColumnChunkInfo
+		Encodings        string
+		NullCount        string
+		CompressedSize   string
+		UncompressedSize string
+		CompressionRatio string
+		MinValue         string
+		MaxValue         string
+	}
+
+	rows := make([]chunkRow, len(overview.Chunks))
+	for i, chunk := range overview.Chunks {
+		row := chunkRow{
+			ColumnChunkInfo:  chunk,
+			Encodings:        strings.Join(chunk.Encodings, ", "),
+			NullCount:        "-",
+			CompressedSize:   model.FormatBytes(chunk.CompressedSize),
+			UncompressedSize: model.FormatBytes(chunk.UncompressedSize),
+			CompressionRatio: formatRatio(chunk.CompressionRatio),
+			MinValue:         chunk.MinValue,
+			MaxValue:         chunk.MaxValue,
+		}
+		if row.MinValue == "" {
+			row.MinValue = "-"
+		}
+		if row.MaxValue == "" {
+			row.MaxValue = "-"
+		}
+		if chunk.NullCount != nil {
+			row.NullCount = fmt.Sprintf("%d", *chunk.NullCount)
+		}
+		rows[i] = row
+	}
+
+	nullCount := "-"
+	if overview.NullCount != nil {
+		nullCount = fmt.Sprintf("%d", *overview.NullCount)
+	}
+
+	data := struct {
+		Overview          model.ColumnOverview
+		Rows              []chunkRow
+		NullCount         string
+		TotalCompressed   string
+		TotalUncompressed string
+		CompressionRatio  string
+		Codecs            string
+		Encodings         string
+	}{
+		Overview:          overview,
+		Rows:              rows,
+		NullCount:         nullCount,
+		TotalCompressed:   model.FormatBytes(overview.TotalCompressedSize),
+		TotalUncompressed: model.FormatBytes(overview.TotalUncompressedSize),
+		CompressionRatio:  formatRatio(overview.CompressionRatio),
+		Codecs:            strings.Join(overview.Codecs, ", "),
+		Encodings:         strings.Join(overview.Encodings, ", "),
+	}
+
+	err = renderPartial(w, r, "column_overview", data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 // handlePagesView serves the pages view for a column
 func (s *ParquetService) handlePagesView(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -411,26 +1003,29 @@ func (s *ParquetService) handlePagesView(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	colIndex, err := strconv.Atoi(vars["colIndex"])
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(vars["path"])
 	if err != nil {
-		http.Error(w, "Invalid column index", http.StatusBadRequest)
+		renderPagesError(w, r, err)
 		return
 	}
 
-	pages, err := s.reader.GetPageMetadataList(rgIndex, colIndex)
+	pages, err := s.readerFor(r).GetPageMetadataList(rgIndex, colIndex)
 	if err != nil {
 		renderPagesError(w, r, err)
 		return
 	}
 
 	// Get column info for breadcrumb and summary
-	colInfo, err := s.reader.GetColumnChunkInfo(rgIndex, colIndex)
+	colInfo, err := s.readerFor(r).GetColumnChunkInfo(rgIndex, colIndex)
 	var columnPath, physicalType, logicalType, convertedType, codec string
 	var columnNumValues int64
 	var columnNullCount string
 	var columnCompressedSize, columnUncompressedSize string
 	var columnCompressionRatio string
 	var columnMinValue, columnMaxValue string
+	var columnSizeStats *sizeStatsView
+	var columnGeo *geoStatsView
+	var columnVariant *model.VariantMember
 	if err == nil {
 		columnPath = colInfo.Name
 		physicalType = colInfo.PhysicalType
@@ -454,6 +1049,9 @@ func (s *ParquetService) handlePagesView(w http.ResponseWriter, r *http.Request)
 		if columnMaxValue == "" {
 			columnMaxValue = "-"
 		}
+		columnSizeStats = buildSizeStatsView(colInfo.SizeStatistics)
+		columnGeo = buildGeoStatsView(colInfo.GeospatialStatistics)
+		columnVariant = colInfo.Variant
 	}
 
 	// Format pages for display
@@ -467,6 +1065,7 @@ func (s *ParquetService) handlePagesView(w http.ResponseWriter, r *http.Request)
 		Encoding         string
 		MinValue         string
 		MaxValue         string
+		SizeStats        *sizeStatsView
 	}
 
 	// Calculate totals
@@ -497,6 +1096,7 @@ func (s *ParquetService) handlePagesView(w http.ResponseWriter, r *http.Request)
 			Encoding:         page.Encoding,
 			MinValue:         minValue,
 			MaxValue:         maxValue,
+			SizeStats:        buildSizeStatsView(page.SizeStatistics),
 		}
 	}
 
@@ -515,6 +1115,9 @@ func (s *ParquetService) handlePagesView(w http.ResponseWriter, r *http.Request)
 		ColumnCompressionRatio string
 		ColumnMinValue         string
 		ColumnMaxValue         string
+		SizeStats              *sizeStatsView
+		Geo                    *geoStatsView
+		Variant                *model.VariantMember
 		Pages                  []FormattedPage
 		TotalPages             int
 		TotalValues            int32
@@ -536,6 +1139,9 @@ func (s *ParquetService) handlePagesView(w http.ResponseWriter, r *http.Request)
 		ColumnCompressionRatio: columnCompressionRatio,
 		ColumnMinValue:         columnMinValue,
 		ColumnMaxValue:         columnMaxValue,
+		SizeStats:              columnSizeStats,
+		Geo:                    columnGeo,
+		Variant:                columnVariant,
 		Pages:                  formatted,
 		TotalPages:             len(pages),
 		TotalValues:            totalValues,
@@ -559,20 +1165,20 @@ func (s *ParquetService) handlePageContentView(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	colIndex, err := strconv.Atoi(vars["colIndex"])
+	pageIndex, err := strconv.Atoi(vars["pageIndex"])
 	if err != nil {
-		http.Error(w, "Invalid column index", http.StatusBadRequest)
+		http.Error(w, "Invalid page index", http.StatusBadRequest)
 		return
 	}
 
-	pageIndex, err := strconv.Atoi(vars["pageIndex"])
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(vars["path"])
 	if err != nil {
-		http.Error(w, "Invalid page index", http.StatusBadRequest)
+		renderPagesError(w, r, err)
 		return
 	}
 
 	// Get page metadata
-	pages, err := s.reader.GetPageMetadataList(rgIndex, colIndex)
+	pages, err := s.readerFor(r).GetPageMetadataList(rgIndex, colIndex)
 	if err != nil {
 		renderPagesError(w, r, err)
 		return
@@ -585,16 +1191,47 @@ func (s *ParquetService) handlePageContentView(w http.ResponseWriter, r *http.Re
 
 	pageMetadata := pages[pageIndex]
 
-	values, err := s.reader.GetPageContentFormatted(rgIndex, colIndex, pageIndex)
+	geoFormat, err := model.ParseGeoFormat(r.URL.Query().Get("geo"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The values of a located row are highlighted with highlight=valueIndex
+	// and, for repeated columns, highlight_count=numValues
+	highlightStart, highlightEnd := -1, -1
+	if value, err := strconv.Atoi(r.URL.Query().Get("highlight")); err == nil && value >= 0 {
+		count, err := strconv.Atoi(r.URL.Query().Get("highlight_count"))
+		if err != nil || count < 1 {
+			count = 1
+		}
+		highlightStart, highlightEnd = value, value+count
+	}
+
+	// The first batch shows the highlighted values, the rest are loaded as
+	// the list is scrolled
+	pageRange := model.PageRange{Limit: max(pageValuesBatch, highlightEnd)}
+	values, total, err := s.readerFor(r).GetPageContentFormattedRange(rgIndex, colIndex, pageIndex, geoFormat, pageRange)
 	if err != nil {
 		renderPagesError(w, r, err)
 		return
 	}
+	fragment := newPageValuesFragment(rgIndex, vars["path"], pageIndex, geoFormat, pageRange, values, total)
+	fragment.HighlightStart, fragment.HighlightEnd = highlightStart, highlightEnd
+
+	// Only GEOMETRY and GEOGRAPHY columns offer a choice of value format
+	isGeospatial := false
+	if colInfo, err := s.readerFor(r).GetColumnChunkInfo(rgIndex, colIndex); err == nil {
+		isGeospatial = colInfo.LogicalType == "GEOMETRY" || colInfo.LogicalType == "GEOGRAPHY"
+	}
 
 	data := struct {
 		RowGroupIndex    int
 		ColumnIndex      int
+		ColumnPath       string
 		PageIndex        int
+		IsGeospatial     bool
+		GeoFormat        model.GeoFormat
 		PageType         string
 		Offset           string
 		CompressedSize   string
@@ -603,12 +1240,16 @@ func (s *ParquetService) handlePageContentView(w http.ResponseWriter, r *http.Re
 		NumValues        int32
 		NullCount        string
 		Encoding         string
-		Values           []string
+		Values           pageValuesFragment
 		Count            int
+		HighlightStart   int
 	}{
 		RowGroupIndex:    rgIndex,
 		ColumnIndex:      colIndex,
+		ColumnPath:       vars["path"],
 		PageIndex:        pageIndex,
+		IsGeospatial:     isGeospatial,
+		GeoFormat:        geoFormat,
 		PageType:         pageMetadata.PageType,
 		Offset:           fmt.Sprintf("0x%X", pageMetadata.Offset),
 		CompressedSize:   model.FormatBytes(int64(pageMetadata.CompressedSize)),
@@ -617,8 +1258,9 @@ func (s *ParquetService) handlePageContentView(w http.ResponseWriter, r *http.Re
 		NumValues:        pageMetadata.NumValues,
 		NullCount:        formatNullCount(pageMetadata.NullCount),
 		Encoding:         pageMetadata.Encoding,
-		Values:           values,
-		Count:            len(values),
+		Values:           fragment,
+		Count:            total,
+		HighlightStart:   highlightStart,
 	}
 
 	err = renderPartial(w, r, "page_content", data)
@@ -627,6 +1269,1149 @@ func (s *ParquetService) handlePageContentView(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// pageValuesBatch is the number of page values shown at once, more are
+// loaded as the list of values is scrolled
+const pageValuesBatch = 500
+
+// pageValue is a value of a page with its index in the page
+type pageValue struct {
+	Index int
+	Value string
+}
+
+// pageValuesFragment is a batch of the values of a page, followed by a
+// placeholder loading the next batch when scrolled into view
+type pageValuesFragment struct {
+	RowGroupIndex  int
+	ColumnPath     string
+	PageIndex      int
+	GeoFormat      model.GeoFormat
+	Values         []pageValue
+	Total          int
+	NextOffset     int // first value of the next batch, 0 at the end of the page
+	HighlightStart int
+	HighlightEnd   int
+}
+
+// newPageValuesFragment pairs the values read for pageRange with their
+// index and points at the next batch
+func newPageValuesFragment(rgIndex int, path string, pageIndex int, geoFormat model.GeoFormat, pageRange model.PageRange, values []string, total int) pageValuesFragment {
+	fragment := pageValuesFragment{
+		RowGroupIndex:  rgIndex,
+		ColumnPath:     path,
+		PageIndex:      pageIndex,
+		GeoFormat:      geoFormat,
+		Values:         make([]pageValue, len(values)),
+		Total:          total,
+		HighlightStart: -1,
+		HighlightEnd:   -1,
+	}
+	for i, value := range values {
+		fragment.Values[i] = pageValue{Index: pageRange.Offset + i, Value: value}
+	}
+	if next := pageRange.Offset + len(values); next < total {
+		fragment.NextOffset = next
+	}
+	return fragment
+}
+
+// handlePageValuesView serves the batch of page values from ?offset=, for
+// the page content view to load as it is scrolled
+func (s *ParquetService) handlePageValuesView(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	rgIndex, err := strconv.Atoi(vars["rgIndex"])
+	if err != nil {
+		http.Error(w, "Invalid row group index", http.StatusBadRequest)
+		return
+	}
+
+	pageIndex, err := strconv.Atoi(vars["pageIndex"])
+	if err != nil {
+		http.Error(w, "Invalid page index", http.StatusBadRequest)
+		return
+	}
+
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(vars["path"])
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	geoFormat, err := model.ParseGeoFormat(r.URL.Query().Get("geo"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pageRange, err := model.ParsePageRange(r.URL.Query().Get("offset"), strconv.Itoa(pageValuesBatch))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	values, total, err := s.readerFor(r).GetPageContentFormattedRange(rgIndex, colIndex, pageIndex, geoFormat, pageRange)
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	fragment := newPageValuesFragment(rgIndex, vars["path"], pageIndex, geoFormat, pageRange, values, total)
+	if err := renderPartial(w, r, "page_values", fragment); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// maxStructureRowsShown caps each table of the page structure view
+const maxStructureRowsShown = 200
+
+// structureTable is one table of the page structure view, capped to
+// maxStructureRowsShown rows
+type structureTable struct {
+	Title   string
+	Headers []string
+	Rows    [][]string
+	Total   int
+}
+
+// structureSection is one encoded section of a page with its tables
+type structureSection struct {
+	Title   string
+	Section model.EncodedSection
+	Tables  []structureTable
+}
+
+// handlePageStructureView serves the encoding structure of a page
+func (s *ParquetService) handlePageStructureView(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	rgIndex, err := strconv.Atoi(vars["rgIndex"])
+	if err != nil {
+		http.Error(w, "Invalid row group index", http.StatusBadRequest)
+		return
+	}
+
+	pageIndex, err := strconv.Atoi(vars["pageIndex"])
+	if err != nil {
+		http.Error(w, "Invalid page index", http.StatusBadRequest)
+		return
+	}
+
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(vars["path"])
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	structure, err := s.readerFor(r).GetPageStructure(rgIndex, colIndex, pageIndex)
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	var sections []structureSection
+	if structure.RepetitionLevels != nil {
+		sections = append(sections, buildStructureSection("Repetition Levels", *structure.RepetitionLevels))
+	}
+	if structure.DefinitionLevels != nil {
+		sections = append(sections, buildStructureSection("Definition Levels", *structure.DefinitionLevels))
+	}
+	sections = append(sections, buildStructureSection("Values", structure.Values))
+
+	data := struct {
+		Structure        model.PageStructure
+		UncompressedSize string
+		Sections         []structureSection
+	}{
+		Structure:        structure,
+		UncompressedSize: model.FormatBytes(int64(structure.UncompressedSize)),
+		Sections:         sections,
+	}
+
+	err = renderPartial(w, r, "page_structure", data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// maxHexDumpBytes caps the bytes shown in the hex dump of a value popup, the
+// copy button still copies all of them
+const maxHexDumpBytes = 64 * 1024
+
+// valueDetailData is the data model for the "value_detail" template partial
+type valueDetailData struct {
+	Title   string
+	Detail  model.ValueDetail
+	HexDump string
+	Hidden  int // bytes left out of the hex dump
+}
+
+// handlePageValueView shows one value of a page in full in a popup
+func (s *ParquetService) handlePageValueView(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	rgIndex, err := strconv.Atoi(vars["rgIndex"])
+	if err != nil {
+		http.Error(w, "Invalid row group index", http.StatusBadRequest)
+		return
+	}
+
+	pageIndex, err := strconv.Atoi(vars["pageIndex"])
+	if err != nil {
+		http.Error(w, "Invalid page index", http.StatusBadRequest)
+		return
+	}
+
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(vars["path"])
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	valueIndex, err := strconv.Atoi(vars["valueIndex"])
+	if err != nil {
+		http.Error(w, "Invalid value index", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := s.readerFor(r).GetPageValueDetail(rgIndex, colIndex, pageIndex, valueIndex)
+	title := fmt.Sprintf("Value %d - Row Group %d, Column %d, Page %d", valueIndex, rgIndex, colIndex, pageIndex)
+	renderValueDetail(w, r, title, detail, err)
+}
+
+// handlePageStatisticView shows the min or max statistic of a page in full in a popup
+func (s *ParquetService) handlePageStatisticView(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	rgIndex, err := strconv.Atoi(vars["rgIndex"])
+	if err != nil {
+		http.Error(w, "Invalid row group index", http.StatusBadRequest)
+		return
+	}
+
+	pageIndex, err := strconv.Atoi(vars["pageIndex"])
+	if err != nil {
+		http.Error(w, "Invalid page index", http.StatusBadRequest)
+		return
+	}
+
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(vars["path"])
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	stat := vars["stat"]
+	detail, err := s.readerFor(r).GetPageStatistic(rgIndex, colIndex, pageIndex, stat)
+	title := fmt.Sprintf("Page %s - Row Group %d, Column %d, Page %d", statisticTitle(stat), rgIndex, colIndex, pageIndex)
+	renderValueDetail(w, r, title, detail, err)
+}
+
+// handleColumnChunkStatisticView shows the min or max statistic of a column chunk in full in a popup
+func (s *ParquetService) handleColumnChunkStatisticView(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	rgIndex, err := strconv.Atoi(vars["rgIndex"])
+	if err != nil {
+		http.Error(w, "Invalid row group index", http.StatusBadRequest)
+		return
+	}
+
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(vars["path"])
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	stat := vars["stat"]
+	detail, err := s.readerFor(r).GetColumnChunkStatistic(rgIndex, colIndex, stat)
+	title := fmt.Sprintf("Column Chunk %s - Row Group %d, Column %d", statisticTitle(stat), rgIndex, colIndex)
+	renderValueDetail(w, r, title, detail, err)
+}
+
+// statisticTitle capitalizes a statistic name for popup titles
+func statisticTitle(stat string) string {
+	switch stat {
+	case model.StatisticMin:
+		return "Min"
+	case model.StatisticMax:
+		return "Max"
+	}
+	return stat
+}
+
+// renderValueDetail renders a value detail popup, or the error popup when the
+// value could not be read
+func renderValueDetail(w http.ResponseWriter, r *http.Request, title string, detail model.ValueDetail, err error) {
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	data := valueDetailData{Title: title, Detail: detail}
+	if raw, err := hex.DecodeString(detail.Hex); err == nil {
+		data.Hidden = max(len(raw)-maxHexDumpBytes, 0)
+		data.HexDump = hex.Dump(raw[:len(raw)-data.Hidden])
+	}
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Push-Url", "false")
+	}
+
+	if err := renderPartial(w, r, "value_detail", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// buildStructureSection lays out the tables describing one encoded section
+func buildStructureSection(title string, section model.EncodedSection) structureSection {
+	result := structureSection{Title: title, Section: section}
+
+	if len(section.Runs) > 0 {
+		rows := make([][]string, 0, min(len(section.Runs), maxStructureRowsShown))
+		for i, run := range section.Runs[:min(len(section.Runs), maxStructureRowsShown)] {
+			value := "-"
+			if run.Type == "RLE" {
+				value = strconv.FormatUint(uint64(run.Value), 10)
+			}
+			rows = append(rows, []string{
+				strconv.Itoa(i), run.Type, strconv.Itoa(run.Offset), strconv.Itoa(run.ByteLength), strconv.Itoa(run.Count), value,
+			})
+		}
+		result.Tables = append(result.Tables, structureTable{
+			Title:   fmt.Sprintf("RLE/bit-packed hybrid runs (bit width %d)", section.BitWidth),
+			Headers: []string{"Run", "Type", "Offset", "Bytes", "Values", "RLE Value"},
+			Rows:    rows,
+			Total:   len(section.Runs),
+		})
+	}
+
+	if section.DeltaBinary != nil {
+		result.Tables = append(result.Tables, deltaStreamTable("Deltas", *section.DeltaBinary))
+	}
+
+	if layout := section.DeltaLength; layout != nil {
+		result.Tables = append(result.Tables,
+			deltaStreamTable("Lengths", layout.LengthStream),
+			lengthsTable(fmt.Sprintf("Values (%d data bytes at offset %d)", layout.DataByteLength, layout.DataOffset),
+				[]string{"Value", "Length"}, layout.Lengths))
+	}
+
+	if layout := section.DeltaByteArray; layout != nil {
+		result.Tables = append(result.Tables,
+			deltaStreamTable("Prefix lengths", layout.PrefixStream),
+			deltaStreamTable("Suffix lengths", layout.SuffixStream),
+			lengthsTable(fmt.Sprintf("Values (%d suffix bytes at offset %d)", layout.DataByteLength, layout.DataOffset),
+				[]string{"Value", "Prefix Length", "Suffix Length"}, layout.PrefixLengths, layout.SuffixLengths))
+	}
+
+	if layout := section.ByteStreamSplit; layout != nil {
+		rows := make([][]string, len(layout.Streams))
+		for i, stream := range layout.Streams {
+			rows[i] = []string{
+				strconv.Itoa(stream.Index), strconv.Itoa(stream.Offset), strconv.Itoa(stream.ByteLength), strconv.Itoa(stream.DistinctBytes),
+			}
+		}
+		result.Tables = append(result.Tables, structureTable{
+			Title:   fmt.Sprintf("Byte streams (%d values of %d bytes)", layout.NumValues, layout.TypeSize),
+			Headers: []string{"Stream", "Offset", "Bytes", "Distinct Bytes"},
+			Rows:    rows,
+			Total:   len(rows),
+		})
+	}
+
+	return result
+}
+
+// deltaStreamTable lists the miniblocks of a DELTA_BINARY_PACKED stream
+func deltaStreamTable(title string, stream model.DeltaBinaryPacked) structureTable {
+	table := structureTable{
+		Title: fmt.Sprintf("%s: DELTA_BINARY_PACKED at offset %d, %d bytes, block size %d, %d miniblocks per block, %d values, first value %d",
+			title, stream.Offset, stream.ByteLength, stream.BlockSize, stream.MiniblocksPerBlock, stream.TotalValues, stream.FirstValue),
+		Headers: []string{"Block", "Min Delta", "Miniblock", "Offset", "Bit Width", "Bytes", "Values"},
+	}
+	for b, block := range stream.Blocks {
+		for m, mb := range block.Miniblocks {
+			table.Total++
+			if len(table.Rows) < maxStructureRowsShown {
+				table.Rows = append(table.Rows, []string{
+					strconv.Itoa(b), strconv.FormatInt(block.MinDelta, 10), strconv.Itoa(m), strconv.Itoa(mb.Offset),
+					strconv.Itoa(mb.BitWidth), strconv.Itoa(mb.ByteLength), strconv.Itoa(mb.Count),
+				})
+			}
+		}
+	}
+	return table
+}
+
+// lengthsTable lists per-value lengths, one column per lengths slice
+func lengthsTable(title string, headers []string, columns ...[]int64) structureTable {
+	table := structureTable{Title: title, Headers: headers}
+	if len(columns) == 0 {
+		return table
+	}
+	table.Total = len(columns[0])
+	for i := 0; i < min(table.Total, maxStructureRowsShown); i++ {
+		row := []string{strconv.Itoa(i)}
+		for _, column := range columns {
+			if i < len(column) {
+				row = append(row, strconv.FormatInt(column[i], 10))
+			} else {
+				row = append(row, "-")
+			}
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// handleColumnProfileView serves the profile page; the profile itself is loaded
+// by a follow-up request so the user sees progress and can cancel it
+func (s *ParquetService) handleColumnProfileView(w http.ResponseWriter, r *http.Request) {
+	path := mux.Vars(r)["path"]
+	if _, err := s.readerFor(r).ColumnIndexByPath(path); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	info := s.readerFor(r).GetFileInfo()
+	data := struct {
+		Path         string
+		NumRows      int64
+		NumRowGroups int
+	}{
+		Path:         path,
+		NumRows:      info.NumRows,
+		NumRowGroups: info.NumRowGroups,
+	}
+
+	err := renderPartial(w, r, "profile", data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// profileBar is one bar of a horizontal bar chart
+type profileBar struct {
+	Label   string
+	Count   int64
+	Percent string
+}
+
+// profileViewEvent is one line of the streamed profile result: a progress
+// event, then the rendered profile or an error
+type profileViewEvent struct {
+	Progress *model.ProfileProgress `json:"progress,omitempty"`
+	HTML     string                 `json:"html,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// handleColumnProfileResultView computes the column profile and renders it as
+// charts. With stream=true the response is NDJSON: progress events while the
+// row groups are read, then the rendered profile, which the profile page
+// shows as it goes.
+func (s *ParquetService) handleColumnProfileResultView(w http.ResponseWriter, r *http.Request) {
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(mux.Vars(r)["path"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if r.URL.Query().Get("stream") != "true" {
+		profile, err := s.readerFor(r).ProfileColumn(r.Context(), colIndex, model.ProfileOptions{})
+		if err != nil {
+			renderPagesError(w, r, err)
+			return
+		}
+		err = renderPartial(w, r, "profile_result", buildProfileResult(profile))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	writeEvent := func(event profileViewEvent) {
+		_ = encoder.Encode(event)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	profile, err := s.readerFor(r).ProfileColumn(r.Context(), colIndex, model.ProfileOptions{
+		Progress: func(p model.ProfileProgress) { writeEvent(profileViewEvent{Progress: &p}) },
+	})
+	if err != nil {
+		writeEvent(profileViewEvent{Error: fmt.Sprintf("Failed to profile column: %v", err)})
+		return
+	}
+	var buf strings.Builder
+	if err := templates.ExecuteTemplate(&buf, "profile_result", buildProfileResult(profile)); err != nil {
+		writeEvent(profileViewEvent{Error: err.Error()})
+		return
+	}
+	writeEvent(profileViewEvent{HTML: buf.String()})
+}
+
+// profileResult is the display form of a column profile
+type profileResult struct {
+	Profile     model.ColumnProfile
+	NullPercent string
+	TopValues   []profileBar
+	Histogram   []profileBar
+	Quantiles   []formattedQuantile
+	Lengths     []profileBar
+	Gaps        []profileBar
+}
+
+// formattedQuantile is one quantile of a column profile ready for display
+type formattedQuantile struct {
+	Label string
+	Value string
+}
+
+// buildProfileResult formats a column profile for the profile_result template
+func buildProfileResult(profile model.ColumnProfile) profileResult {
+	topValues := make([]profileBar, len(profile.TopValues))
+	for i, v := range profile.TopValues {
+		topValues[i] = profileBar{Label: v.Value, Count: v.Count}
+	}
+	histogram := make([]profileBar, len(profile.Histogram))
+	for i, b := range profile.Histogram {
+		histogram[i] = profileBar{Label: fmt.Sprintf("%g – %g", b.Lower, b.Upper), Count: b.Count}
+	}
+	quantiles := make([]formattedQuantile, len(profile.Quantiles))
+	for i, q := range profile.Quantiles {
+		quantiles[i] = formattedQuantile{Label: fmt.Sprintf("p%g", q.Quantile*100), Value: fmt.Sprintf("%g", q.Value)}
+	}
+	var lengths []profileBar
+	if profile.StringLengths != nil {
+		for _, b := range profile.StringLengths.Buckets {
+			lengths = append(lengths, profileBar{Label: model.FormatLengthBucket(b), Count: b.Count})
+		}
+	}
+	var gaps []profileBar
+	if profile.Timestamps != nil {
+		for _, g := range profile.Timestamps.Gaps {
+			gaps = append(gaps, profileBar{Label: g.Label, Count: g.Count})
+		}
+	}
+
+	nullPercent := "0.0%"
+	if profile.TotalValues > 0 {
+		nullPercent = fmt.Sprintf("%.1f%%", profile.NullRatio*100)
+	}
+
+	return profileResult{
+		Profile:     profile,
+		NullPercent: nullPercent,
+		TopValues:   scaleProfileBars(topValues),
+		Histogram:   scaleProfileBars(histogram),
+		Quantiles:   quantiles,
+		Lengths:     scaleProfileBars(lengths),
+		Gaps:        scaleProfileBars(gaps),
+	}
+}
+
+// scaleProfileBars sets each bar's width relative to the largest count
+func scaleProfileBars(bars []profileBar) []profileBar {
+	var maxCount int64
+	for _, b := range bars {
+		maxCount = max(maxCount, b.Count)
+	}
+	for i := range bars {
+		if maxCount > 0 {
+			bars[i].Percent = fmt.Sprintf("%.1f", float64(bars[i].Count)*100/float64(maxCount))
+		} else {
+			bars[i].Percent = "0"
+		}
+	}
+	return bars
+}
+
+// sizeStatsView is the display form of a column chunk's or page's size statistics
+type sizeStatsView struct {
+	UnencodedBytes string
+	Repetition     []profileBar
+	Definition     []profileBar
+}
+
+// buildSizeStatsView formats size statistics for display, nil when none are recorded
+func buildSizeStatsView(stats *model.SizeStatistics) *sizeStatsView {
+	if stats == nil {
+		return nil
+	}
+	view := &sizeStatsView{
+		Repetition: levelHistogramBars(stats.RepetitionLevelHistogram),
+		Definition: levelHistogramBars(stats.DefinitionLevelHistogram),
+	}
+	if stats.UnencodedByteArrayDataBytes != nil {
+		view.UnencodedBytes = model.FormatBytes(*stats.UnencodedByteArrayDataBytes)
+	}
+	return view
+}
+
+// levelHistogramBars turns a level histogram into one bar per level
+func levelHistogramBars(histogram []int64) []profileBar {
+	if len(histogram) == 0 {
+		return nil
+	}
+	bars := make([]profileBar, len(histogram))
+	for level, count := range histogram {
+		bars[level] = profileBar{Label: strconv.Itoa(level), Count: count}
+	}
+	return scaleProfileBars(bars)
+}
+
+// maxDictionaryValuesShown caps the dictionary values chart in the web UI
+const maxDictionaryValuesShown = 100
+
+// handleColumnDictionaryView renders the dictionary analysis of a column
+func (s *ParquetService) handleColumnDictionaryView(w http.ResponseWriter, r *http.Request) {
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(mux.Vars(r)["path"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	analysis, err := s.readerFor(r).AnalyzeDictionary(r.Context(), colIndex)
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	type rowGroupRow struct {
+		model.RowGroupDictionary
+		CompressedSize   string
+		UncompressedSize string
+		EncodedPercent   string
+	}
+	type valueBar struct {
+		profileBar
+		RowGroups int
+	}
+
+	rows := make([]rowGroupRow, len(analysis.RowGroups))
+	for i, rg := range analysis.RowGroups {
+		rows[i] = rowGroupRow{
+			RowGroupDictionary: rg,
+			CompressedSize:     model.FormatBytes(int64(rg.CompressedSize)),
+			UncompressedSize:   model.FormatBytes(int64(rg.UncompressedSize)),
+			EncodedPercent:     formatPercent(rg.DictionaryEncodedPages, rg.DataPages),
+		}
+	}
+
+	shown := analysis.Values[:min(len(analysis.Values), maxDictionaryValuesShown)]
+	bars := make([]profileBar, len(shown))
+	for i, v := range shown {
+		bars[i] = profileBar{Label: v.Value, Count: v.References}
+	}
+	bars = scaleProfileBars(bars)
+	values := make([]valueBar, len(shown))
+	for i, v := range shown {
+		values[i] = valueBar{profileBar: bars[i], RowGroups: v.RowGroups}
+	}
+
+	data := struct {
+		Analysis         model.DictionaryAnalysis
+		EncodedPercent   string
+		CompressedSize   string
+		UncompressedSize string
+		RowGroups        []rowGroupRow
+		Values           []valueBar
+	}{
+		Analysis:         analysis,
+		EncodedPercent:   formatPercent(analysis.DictionaryEncodedPages, analysis.DataPages),
+		CompressedSize:   model.FormatBytes(analysis.TotalCompressedSize),
+		UncompressedSize: model.FormatBytes(analysis.TotalUncompressedSize),
+		RowGroups:        rows,
+		Values:           values,
+	}
+
+	err = renderPartial(w, r, "dictionary", data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Size of the SVG bounding box plot of the geospatial view, in pixels
+const (
+	geoMapWidth  = 480
+	geoMapHeight = 320
+	geoMapMargin = 40
+)
+
+// geoStatsView is the display form of a column chunk's geospatial statistics
+type geoStatsView struct {
+	Min           string
+	Max           string
+	BoundingBox   string
+	GeometryTypes string
+}
+
+// geoMapRect is a row group bounding box positioned on the SVG plot
+type geoMapRect struct {
+	RowGroup int
+	X        float64
+	Y        float64
+	Width    float64
+	Height   float64
+	Color    string
+	Title    string
+}
+
+// geoMapView is the SVG plot of the row group bounding boxes of a column
+type geoMapView struct {
+	Width  int
+	Height int
+	Frame  geoMapRect
+	Rects  []geoMapRect
+	XMin   string
+	XMax   string
+	YMin   string
+	YMax   string
+}
+
+// handleColumnGeoView serves the bounding box plot of a geospatial column
+func (s *ParquetService) handleColumnGeoView(w http.ResponseWriter, r *http.Request) {
+	colIndex, err := s.readerFor(r).ColumnIndexByPath(mux.Vars(r)["path"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	bounds, err := s.readerFor(r).GetGeospatialBounds(r.Context(), colIndex)
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	type rowGroupRow struct {
+		model.RowGroupBounds
+		Extent        string
+		GeometryTypes string
+		Color         string
+	}
+
+	rows := make([]rowGroupRow, len(bounds.RowGroups))
+	for i, rg := range bounds.RowGroups {
+		rows[i] = rowGroupRow{
+			RowGroupBounds: rg,
+			Extent:         formatBoundingBox(rg.BoundingBox),
+			GeometryTypes:  strings.Join(rg.GeometryTypes, ", "),
+			Color:          geoMapColor(rg.RowGroup),
+		}
+	}
+
+	data := struct {
+		Bounds    model.GeospatialBounds
+		Extent    string
+		Map       *geoMapView
+		RowGroups []rowGroupRow
+	}{
+		Bounds:    bounds,
+		Extent:    formatBoundingBox(bounds.BoundingBox),
+		Map:       buildGeoMap(bounds),
+		RowGroups: rows,
+	}
+
+	err = renderPartial(w, r, "geo", data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// buildGeoStatsView formats geospatial statistics, nil when there are none
+func buildGeoStatsView(stats *model.GeospatialStatistics) *geoStatsView {
+	if stats == nil {
+		return nil
+	}
+	view := &geoStatsView{
+		Min:           "-",
+		Max:           "-",
+		BoundingBox:   formatBoundingBox(stats.BoundingBox),
+		GeometryTypes: strings.Join(stats.GeometryTypes, ", "),
+	}
+	if bbox := stats.BoundingBox; bbox != nil {
+		view.Min = fmt.Sprintf("(%s, %s)", formatGeoCoord(bbox.Xmin), formatGeoCoord(bbox.Ymin))
+		view.Max = fmt.Sprintf("(%s, %s)", formatGeoCoord(bbox.Xmax), formatGeoCoord(bbox.Ymax))
+	}
+	return view
+}
+
+// formatBoundingBox formats a bounding box as coordinate ranges
+func formatBoundingBox(bbox *model.BoundingBox) string {
+	if bbox == nil {
+		return "-"
+	}
+	text := fmt.Sprintf("X [%s, %s] Y [%s, %s]",
+		formatGeoCoord(bbox.Xmin), formatGeoCoord(bbox.Xmax), formatGeoCoord(bbox.Ymin), formatGeoCoord(bbox.Ymax))
+	if bbox.Zmin != nil && bbox.Zmax != nil {
+		text += fmt.Sprintf(" Z [%s, %s]", formatGeoCoord(*bbox.Zmin), formatGeoCoord(*bbox.Zmax))
+	}
+	if bbox.Mmin != nil && bbox.Mmax != nil {
+		text += fmt.Sprintf(" M [%s, %s]", formatGeoCoord(*bbox.Mmin), formatGeoCoord(*bbox.Mmax))
+	}
+	return text
+}
+
+// formatGeoCoord formats a coordinate with at most 6 decimals
+func formatGeoCoord(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1e6)/1e6, 'f', -1, 64)
+}
+
+// geoMapColors are cycled through to tell row groups apart on the plot
+var geoMapColors = []string{"#667eea", "#fd7e14", "#20c997", "#dc3545", "#6f42c1", "#ffc107", "#17a2b8", "#e83e8c", "#28a745", "#343a40"}
+
+// geoMapColor picks the plot color of a row group
+func geoMapColor(rowGroup int) string {
+	return geoMapColors[rowGroup%len(geoMapColors)]
+}
+
+// buildGeoMap scales the row group bounding boxes onto the SVG plot, keeping
+// the aspect ratio of the coordinates. It returns nil without any extent.
+func buildGeoMap(bounds model.GeospatialBounds) *geoMapView {
+	extent := bounds.BoundingBox
+	if extent == nil {
+		return nil
+	}
+
+	// Pad degenerate extents, such as a single point, so they can be scaled
+	xmin, xmax, ymin, ymax := extent.Xmin, extent.Xmax, extent.Ymin, extent.Ymax
+	if xmax-xmin == 0 {
+		xmin, xmax = xmin-0.5, xmax+0.5
+	}
+	if ymax-ymin == 0 {
+		ymin, ymax = ymin-0.5, ymax+0.5
+	}
+	scale := math.Min(float64(geoMapWidth-2*geoMapMargin)/(xmax-xmin), float64(geoMapHeight-2*geoMapMargin)/(ymax-ymin))
+
+	// SVG y grows downwards, so ymax maps to the top of the plot
+	project := func(bbox *model.BoundingBox) geoMapRect {
+		return geoMapRect{
+			X:      float64(geoMapMargin) + (bbox.Xmin-xmin)*scale,
+			Y:      float64(geoMapMargin) + (ymax-bbox.Ymax)*scale,
+			Width:  math.Max((bbox.Xmax-bbox.Xmin)*scale, 2),
+			Height: math.Max((bbox.Ymax-bbox.Ymin)*scale, 2),
+		}
+	}
+
+	view := &geoMapView{
+		Width:  geoMapWidth,
+		Height: geoMapHeight,
+		Frame:  project(&model.BoundingBox{Xmin: xmin, Xmax: xmax, Ymin: ymin, Ymax: ymax}),
+		XMin:   formatGeoCoord(extent.Xmin),
+		XMax:   formatGeoCoord(extent.Xmax),
+		YMin:   formatGeoCoord(extent.Ymin),
+		YMax:   formatGeoCoord(extent.Ymax),
+	}
+	for _, rg := range bounds.RowGroups {
+		if rg.BoundingBox == nil {
+			continue
+		}
+		rect := project(rg.BoundingBox)
+		rect.RowGroup = rg.RowGroup
+		rect.Color = geoMapColor(rg.RowGroup)
+		rect.Title = fmt.Sprintf("Row group %d: %s", rg.RowGroup, formatBoundingBox(rg.BoundingBox))
+		view.Rects = append(view.Rects, rect)
+	}
+	return view
+}
+
+// layoutZoomLevels are the widths, as multiples of the view, the layout bar can be drawn at
+var layoutZoomLevels = []int{1, 2, 4, 8, 16, 32, 64}
+
+// maxLayoutRowsShown caps the region table of the layout view
+const maxLayoutRowsShown = 500
+
+// layoutSegment is a layout region positioned on the bar, in percent of the file
+type layoutSegment struct {
+	model.LayoutRegion
+	Class    string
+	Left     float64
+	Width    float64
+	End      int64
+	Size     string
+	Location string
+	Link     string
+}
+
+// layoutIssueMark is a layout issue positioned on the bar
+type layoutIssueMark struct {
+	model.LayoutIssue
+	Left  float64
+	Width float64
+}
+
+// handleFileLayoutView renders the byte-level layout of the file as a zoomable bar
+func (s *ParquetService) handleFileLayoutView(w http.ResponseWriter, r *http.Request) {
+	zoom := 1
+	if value, err := strconv.Atoi(r.URL.Query().Get("zoom")); err == nil && slices.Contains(layoutZoomLevels, value) {
+		zoom = value
+	}
+
+	layout, err := s.readerFor(r).GetFileLayout()
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	percent := func(offset int64) float64 {
+		if layout.FileSize == 0 {
+			return 0
+		}
+		return float64(offset) * 100 / float64(layout.FileSize)
+	}
+
+	segments := make([]layoutSegment, len(layout.Regions))
+	for i, region := range layout.Regions {
+		segments[i] = buildLayoutSegment(region)
+		segments[i].Left = percent(region.Offset)
+		segments[i].Width = percent(region.Length)
+	}
+	marks := make([]layoutIssueMark, len(layout.Issues))
+	for i, issue := range layout.Issues {
+		marks[i] = layoutIssueMark{LayoutIssue: issue, Left: percent(issue.Offset), Width: percent(issue.Length)}
+	}
+
+	data := struct {
+		Layout       model.FileLayout
+		FileSize     string
+		CoveredBytes string
+		GapBytes     string
+		OverlapBytes string
+		Zoom         int
+		BarWidth     int
+		ZoomLevels   []int
+		Segments     []layoutSegment
+		Rows         []layoutSegment
+		Issues       []layoutIssueMark
+	}{
+		Layout:       layout,
+		FileSize:     model.FormatBytes(layout.FileSize),
+		CoveredBytes: model.FormatBytes(layout.CoveredBytes),
+		GapBytes:     model.FormatBytes(layout.GapBytes),
+		OverlapBytes: model.FormatBytes(layout.OverlapBytes),
+		Zoom:         zoom,
+		BarWidth:     zoom * 100,
+		ZoomLevels:   layoutZoomLevels,
+		Segments:     segments,
+		Rows:         segments[:min(len(segments), maxLayoutRowsShown)],
+		Issues:       marks,
+	}
+
+	err = renderPartial(w, r, "layout", data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// buildLayoutSegment describes a layout region for display, linking pages to
+// their content and chunk-level structures to the page list
+func buildLayoutSegment(region model.LayoutRegion) layoutSegment {
+	segment := layoutSegment{
+		LayoutRegion: region,
+		Class:        layoutKindClass(region.Kind),
+		End:          region.Offset + region.Length,
+		Size:         model.FormatBytes(region.Length),
+		Location:     "-",
+	}
+
+	switch {
+	case region.Page >= 0:
+		segment.Location = fmt.Sprintf("RG %d / %s / page %d", region.RowGroup, region.Path, region.Page)
+		segment.Link = fmt.Sprintf("/ui/rowgroups/%d/columns/%s/pages/%d/content", region.RowGroup, url.PathEscape(region.Path), region.Page)
+	case region.RowGroup >= 0:
+		segment.Location = fmt.Sprintf("RG %d / %s", region.RowGroup, region.Path)
+		segment.Link = fmt.Sprintf("/ui/rowgroups/%d/columns/%s/pages", region.RowGroup, url.PathEscape(region.Path))
+	}
+	return segment
+}
+
+// layoutKindClass maps a layout region kind to the CSS class that colors it
+func layoutKindClass(kind string) string {
+	switch kind {
+	case model.LayoutMagic, model.LayoutFooter, model.LayoutFooterLength:
+		return "lk-file"
+	case model.LayoutPageHeader:
+		return "lk-header"
+	case "DICTIONARY_PAGE":
+		return "lk-dictionary"
+	case "DATA_PAGE", "DATA_PAGE_V2", model.LayoutColumnChunk:
+		return "lk-data"
+	case model.LayoutColumnIndex, model.LayoutOffsetIndex:
+		return "lk-index"
+	case model.LayoutBloomFilter:
+		return "lk-bloom"
+	case model.LayoutGap:
+		return "lk-gap"
+	default:
+		return "lk-other"
+	}
+}
+
+// heatmapCellView is a heatmap cell linked to the pages of its column chunk
+type heatmapCellView struct {
+	model.HeatmapCell
+	Class string
+	Title string
+	Link  string
+}
+
+// heatmapRowView is the cells of one row group of the heatmap
+type heatmapRowView struct {
+	RowGroup int
+	Cells    []heatmapCellView
+}
+
+// handleHeatmapView renders a metric of every column chunk as a row group ×
+// column grid, each cell linking to the chunk's pages
+func (s *ParquetService) handleHeatmapView(w http.ResponseWriter, r *http.Request) {
+	metric, err := model.ParseHeatmapMetric(r.URL.Query().Get("metric"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	heatmap, err := s.readerFor(r).GetHeatmap(r.Context(), metric)
+	if err != nil {
+		renderPagesError(w, r, err)
+		return
+	}
+
+	rows := make([]heatmapRowView, len(heatmap.Cells))
+	for rgIndex, cells := range heatmap.Cells {
+		rows[rgIndex] = heatmapRowView{RowGroup: rgIndex, Cells: make([]heatmapCellView, len(cells))}
+		for colIndex, cell := range cells {
+			rows[rgIndex].Cells[colIndex] = buildHeatmapCell(cell, rgIndex, heatmap.Columns[colIndex])
+		}
+	}
+
+	// Columns are too narrow for their paths, every tenth one is numbered
+	// and the path shows on hover
+	type columnHeader struct {
+		Path  string
+		Label string
+	}
+	columns := make([]columnHeader, len(heatmap.Columns))
+	for i, path := range heatmap.Columns {
+		columns[i].Path = path
+		if i%10 == 0 {
+			columns[i].Label = strconv.Itoa(i)
+		}
+	}
+
+	levels := make([]string, model.HeatmapLevels)
+	for i := range levels {
+		levels[i] = heatmapLevelClass(i)
+	}
+
+	data := struct {
+		Heatmap model.Heatmap
+		Metrics []model.HeatmapMetric
+		Columns []columnHeader
+		Rows    []heatmapRowView
+		Levels  []string
+	}{
+		Heatmap: heatmap,
+		Metrics: model.HeatmapMetrics,
+		Columns: columns,
+		Rows:    rows,
+		Levels:  levels,
+	}
+
+	err = renderPartial(w, r, "heatmap", data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// buildHeatmapCell describes a heatmap cell for display
+func buildHeatmapCell(cell model.HeatmapCell, rgIndex int, path string) heatmapCellView {
+	view := heatmapCellView{
+		HeatmapCell: cell,
+		Class:       "heat-none",
+		Title:       fmt.Sprintf("RG %d / %s: %s", rgIndex, path, cell.Label),
+		Link:        fmt.Sprintf("/ui/rowgroups/%d/columns/%s/pages", rgIndex, url.PathEscape(path)),
+	}
+	if cell.Valid {
+		view.Class = heatmapLevelClass(cell.Level)
+	}
+	return view
+}
+
+// heatmapLevelClass is the CSS class that colors a heatmap level
+func heatmapLevelClass(level int) string {
+	return fmt.Sprintf("heat-%d", level)
+}
+
+// formatPercent formats part/total as a percentage, "0%" when total is zero
+func formatPercent(part, total int) string {
+	if total == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%.0f%%", float64(part)*100/float64(total))
+}
+
+// displaySettingsData is the data for the display settings template
+type displaySettingsData struct {
+	Saved    model.DisplayOptions // the settings in the browser cookie
+	Defaults model.DisplayOptions // the server settings, used for anything not saved
+	Error    string
+}
+
+// handleSettingsView renders the display settings form
+func (s *ParquetService) handleSettingsView(w http.ResponseWriter, r *http.Request) {
+	s.renderSettings(w, r, displaySettingsData{Saved: savedDisplayOptions(r)})
+}
+
+// handleSettingsSave keeps the display settings in a cookie and reloads the
+// page so every view picks them up
+func (s *ParquetService) handleSettingsSave(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.renderSettings(w, r, displaySettingsData{Error: err.Error()})
+		return
+	}
+
+	cookie := &http.Cookie{
+		Name:     displayCookieName,
+		Path:     "/",
+		MaxAge:   365 * 24 * 60 * 60,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if r.PostForm.Get("reset") == "true" {
+		cookie.MaxAge = -1
+	} else {
+		opts, err := parseDisplayOptions(r.PostForm)
+		if err != nil {
+			s.renderSettings(w, r, displaySettingsData{Saved: opts, Error: err.Error()})
+			return
+		}
+		cookie.Value = encodeDisplayOptions(opts).Encode()
+	}
+	http.SetCookie(w, cookie)
+
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Refresh", "true")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// renderSettings renders the display settings form as a modal
+func (s *ParquetService) renderSettings(w http.ResponseWriter, r *http.Request, data displaySettingsData) {
+	data.Defaults = s.defaultReader().DisplayOptions()
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Push-Url", "false")
+	}
+	if data.Error != "" && r.Header.Get("HX-Request") != "true" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if err := renderPartial(w, r, "settings", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 // CreateWebUIRouter creates a router configured for the web UI
 func CreateWebUIRouter(s *ParquetService) *mux.Router {
 	r := mux.NewRouter()
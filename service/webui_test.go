@@ -1,12 +1,15 @@
 package service
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -118,13 +121,13 @@ func Test_HandleRowGroupsView_InvalidIndices(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name:           "Invalid column index",
+			name:           "Unknown column",
 			path:           "/ui/rowgroups/0/columns/xyz/pages",
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusNotFound,
 		},
 		{
 			name:           "Invalid page index",
-			path:           "/ui/rowgroups/0/columns/0/pages/invalid/content",
+			path:           "/ui/rowgroups/0/columns/Bool/pages/invalid/content",
 			expectedStatus: http.StatusBadRequest,
 		},
 	}
@@ -888,7 +891,7 @@ func Test_HandleColumnsView_WithRealFile(t *testing.T) {
 	require.Contains(t, body, "Total Columns")
 	require.Contains(t, body, "Total Values")
 	require.Contains(t, body, "Total Size")
-	require.Contains(t, body, "/ui/rowgroups/0/columns/0/pages")
+	require.Contains(t, body, "/ui/rowgroups/0/columns/Bool/pages")
 	// Verify Min and Max columns are present
 	require.Contains(t, body, "<th>Min</th>")
 	require.Contains(t, body, "<th>Max</th>")
@@ -906,7 +909,7 @@ func Test_HandlePagesView_WithRealFile(t *testing.T) {
 	router := mux.NewRouter()
 	svc.SetupWebUIRoutes(router)
 
-	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/0/pages", nil)
+	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/Bool/pages", nil)
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
@@ -976,7 +979,7 @@ func Test_HandlePageContentView_WithRealFile(t *testing.T) {
 	router := mux.NewRouter()
 	svc.SetupWebUIRoutes(router)
 
-	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/0/pages/0/content", nil)
+	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/Bool/pages/0/content", nil)
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
@@ -1012,22 +1015,22 @@ func Test_HandlePageContentView_InvalidIndices(t *testing.T) {
 	}{
 		{
 			name:           "Invalid row group index",
-			path:           "/ui/rowgroups/invalid/columns/0/pages/0/content",
+			path:           "/ui/rowgroups/invalid/columns/Bool/pages/0/content",
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name:           "Invalid column index",
+			name:           "Unknown column",
 			path:           "/ui/rowgroups/0/columns/invalid/pages/0/content",
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusNotFound,
 		},
 		{
 			name:           "Invalid page index",
-			path:           "/ui/rowgroups/0/columns/0/pages/invalid/content",
+			path:           "/ui/rowgroups/0/columns/Bool/pages/invalid/content",
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
 			name:           "Out of range row group direct request",
-			path:           "/ui/rowgroups/999/columns/0/pages/0/content",
+			path:           "/ui/rowgroups/999/columns/Bool/pages/0/content",
 			expectedStatus: http.StatusNotFound,
 		},
 		{
@@ -1037,12 +1040,12 @@ func Test_HandlePageContentView_InvalidIndices(t *testing.T) {
 		},
 		{
 			name:           "Out of range page direct request",
-			path:           "/ui/rowgroups/0/columns/0/pages/999/content",
+			path:           "/ui/rowgroups/0/columns/Bool/pages/999/content",
 			expectedStatus: http.StatusNotFound,
 		},
 		{
 			name:           "Out of range row group HTMX request",
-			path:           "/ui/rowgroups/999/columns/0/pages/0/content",
+			path:           "/ui/rowgroups/999/columns/Bool/pages/0/content",
 			htmx:           true,
 			expectedStatus: http.StatusOK,
 		},
@@ -1054,7 +1057,7 @@ func Test_HandlePageContentView_InvalidIndices(t *testing.T) {
 		},
 		{
 			name:           "Out of range page HTMX request",
-			path:           "/ui/rowgroups/0/columns/0/pages/999/content",
+			path:           "/ui/rowgroups/0/columns/Bool/pages/999/content",
 			htmx:           true,
 			expectedStatus: http.StatusOK,
 		},
@@ -1117,17 +1120,17 @@ func Test_HandlePagesView_InvalidIndices(t *testing.T) {
 	}{
 		{
 			name:           "Invalid row group index",
-			path:           "/ui/rowgroups/abc/columns/0/pages",
+			path:           "/ui/rowgroups/abc/columns/Bool/pages",
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name:           "Invalid column index",
+			name:           "Unknown column",
 			path:           "/ui/rowgroups/0/columns/xyz/pages",
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusNotFound,
 		},
 		{
 			name:           "Out of range row group direct request",
-			path:           "/ui/rowgroups/999/columns/0/pages",
+			path:           "/ui/rowgroups/999/columns/Bool/pages",
 			expectedStatus: http.StatusNotFound,
 		},
 		{
@@ -1137,7 +1140,7 @@ func Test_HandlePagesView_InvalidIndices(t *testing.T) {
 		},
 		{
 			name:           "Out of range row group HTMX request",
-			path:           "/ui/rowgroups/999/columns/0/pages",
+			path:           "/ui/rowgroups/999/columns/Bool/pages",
 			htmx:           true,
 			expectedStatus: http.StatusOK,
 		},
@@ -1178,7 +1181,7 @@ func Test_HandlePagesView_WithoutColumnInfo(t *testing.T) {
 	svc.SetupWebUIRoutes(router)
 
 	// Request pages for a valid row group and column
-	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/0/pages", nil)
+	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/Bool/pages", nil)
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
@@ -1309,7 +1312,7 @@ func Test_HandlePagesView_CompressionRatio(t *testing.T) {
 	router := mux.NewRouter()
 	svc.SetupWebUIRoutes(router)
 
-	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/0/pages", nil)
+	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/Bool/pages", nil)
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
@@ -1390,10 +1393,10 @@ func Test_HandlePageContentView_MultipleColumns(t *testing.T) {
 	svc.SetupWebUIRoutes(router)
 
 	// Test multiple columns if they exist
-	for colIndex := 0; colIndex < 3; colIndex++ {
-		t.Run(fmt.Sprintf("column_%d", colIndex), func(t *testing.T) {
+	for _, column := range []string{"Bool", "Int32", "Int64"} {
+		t.Run(column, func(t *testing.T) {
 			req := httptest.NewRequest("GET",
-				fmt.Sprintf("/ui/rowgroups/0/columns/%d/pages/0/content", colIndex), nil)
+				fmt.Sprintf("/ui/rowgroups/0/columns/%s/pages/0/content", column), nil)
 			w := httptest.NewRecorder()
 
 			router.ServeHTTP(w, req)
@@ -1447,7 +1450,7 @@ func Test_HandlePagesView_ConvertedType(t *testing.T) {
 	router := mux.NewRouter()
 	svc.SetupWebUIRoutes(router)
 
-	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/0/pages", nil)
+	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/Bool/pages", nil)
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
@@ -1662,7 +1665,7 @@ func Test_HandlePageContentView_DifferentPageIndices(t *testing.T) {
 	svc.SetupWebUIRoutes(router)
 
 	// Test page 0
-	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/0/pages/0/content", nil)
+	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/Bool/pages/0/content", nil)
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
@@ -1688,9 +1691,9 @@ func Test_HandlePagesView_WithConverterType(t *testing.T) {
 	svc.SetupWebUIRoutes(router)
 
 	// Try different columns to cover converter type paths
-	for colIdx := 0; colIdx < 5; colIdx++ {
+	for _, column := range []string{"Bool", "Int32", "Int64", "Int96", "Float"} {
 		req := httptest.NewRequest("GET",
-			fmt.Sprintf("/ui/rowgroups/0/columns/%d/pages", colIdx), nil)
+			fmt.Sprintf("/ui/rowgroups/0/columns/%s/pages", column), nil)
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
@@ -1794,8 +1797,8 @@ func Test_WebUI_MaximizeCoverage(t *testing.T) {
 		"/ui/schema/raw",
 		"/ui/rowgroups",
 		"/ui/rowgroups/0/columns",
-		"/ui/rowgroups/0/columns/0/pages",
-		"/ui/rowgroups/0/columns/0/pages/0/content",
+		"/ui/rowgroups/0/columns/Bool/pages",
+		"/ui/rowgroups/0/columns/Bool/pages/0/content",
 	}
 
 	for _, endpoint := range endpoints {
@@ -1873,7 +1876,7 @@ func Test_HandlePagesView_AllCodePaths(t *testing.T) {
 	router := mux.NewRouter()
 	svc.SetupWebUIRoutes(router)
 
-	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/0/pages", nil)
+	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/Bool/pages", nil)
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
@@ -1897,7 +1900,7 @@ func Test_HandlePageContentView_AllCodePaths(t *testing.T) {
 	router := mux.NewRouter()
 	svc.SetupWebUIRoutes(router)
 
-	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/0/pages/0/content", nil)
+	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/Bool/pages/0/content", nil)
 	w := httptest.NewRecorder()
 
 	router.ServeHTTP(w, req)
@@ -2295,3 +2298,1107 @@ func Test_formatNullCount(t *testing.T) {
 		require.Equal(t, "0", result)
 	})
 }
+
+func Test_HandleColumnProfileView_WithRealFile(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		contains []string
+	}{
+		{"Profile page", "/ui/columns/Int32/profile", http.StatusOK, []string{"Profiling Int32", "/ui/columns/Int32/profile/result?stream=true", "profile-progress", "Cancel"}},
+		{"Numeric result", "/ui/columns/Int32/profile/result", http.StatusOK, []string{"Profile - Int32", "Histogram", "Quantiles", "p50", "pb-bar-fill"}},
+		{"String result", "/ui/columns/Utf8/profile/result", http.StatusOK, []string{"Top Values", "Length Distribution", "UTF8-0"}},
+		{"Timestamp result", "/ui/columns/TimestampMillis/profile/result", http.StatusOK, []string{"Timestamp Range", "Gaps Between Consecutive Values"}},
+		{"Unknown page", "/ui/columns/NoSuchColumn/profile", http.StatusNotFound, nil},
+		{"Unknown result", "/ui/columns/NoSuchColumn/profile/result", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func Test_HandleColumnProfileResultView_Stream(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	req := httptest.NewRequest("GET", "/ui/columns/Utf8/profile/result?stream=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.GreaterOrEqual(t, len(lines), 2)
+
+	var first, last profileViewEvent
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NotNil(t, first.Progress)
+	require.Positive(t, first.Progress.RowsRead)
+	require.Equal(t, int64(5), first.Progress.TotalRows)
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &last))
+	require.Empty(t, last.Error)
+	require.Contains(t, last.HTML, "Profile - Utf8")
+	require.Contains(t, last.HTML, "Length Distribution")
+}
+
+func Test_HandleColumnsView_AnalysisLinks(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "/ui/columns/Map.Key_value.Key/profile")
+	require.Contains(t, w.Body.String(), "/ui/columns/Map.Key_value.Key/dictionary")
+}
+
+func Test_scaleProfileBars(t *testing.T) {
+	bars := scaleProfileBars([]profileBar{{Label: "a", Count: 2}, {Label: "b", Count: 4}, {Label: "c"}})
+	require.Equal(t, "50.0", bars[0].Percent)
+	require.Equal(t, "100.0", bars[1].Percent)
+	require.Equal(t, "0.0", bars[2].Percent)
+
+	empty := scaleProfileBars([]profileBar{{Label: "a"}})
+	require.Equal(t, "0", empty[0].Percent)
+}
+
+func Test_HandleColumnDictionaryView_WithRealFile(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		contains []string
+	}{
+		{"Dictionary encoded", "/ui/columns/Map.Key_value.Key/dictionary", http.StatusOK, []string{
+			"Dictionary - Map.Key_value.Key", "3 of 3 (100%)", "Composite-0", "/ui/rowgroups/0/columns/Map.Key_value.Key/pages", "pb-bar-fill",
+		}},
+		{"Plain fallback", "/ui/columns/Int96/dictionary", http.StatusOK, []string{"0 of 3 (0%)", "PLAIN"}},
+		{"Unknown column", "/ui/columns/NoSuchColumn/dictionary", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func Test_formatPercent(t *testing.T) {
+	require.Equal(t, "0%", formatPercent(0, 0))
+	require.Equal(t, "50%", formatPercent(1, 2))
+	require.Equal(t, "100%", formatPercent(3, 3))
+}
+
+func Test_HandlePageStructureView_WithRealFile(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		contains []string
+	}{
+		{"Repeated column", "/ui/rowgroups/0/columns/Map.Key_value.Key/pages/1/structure", http.StatusOK, []string{
+			"Page Structure - Row Group 0, Column 46, Page 1", "Repetition Levels", "Definition Levels",
+			"RLE/bit-packed hybrid runs (bit width 1)", "RLE_DICTIONARY", "/ui/rowgroups/0/columns/Map.Key_value.Key/pages/1/content",
+		}},
+		{"Dictionary page", "/ui/rowgroups/0/columns/Int32/pages/0/structure", http.StatusOK, []string{"DICTIONARY_PAGE", "PLAIN"}},
+		{"Page out of range", "/ui/rowgroups/0/columns/Int32/pages/99/structure", http.StatusOK, []string{"Cannot read this column"}},
+		{"Invalid page index", "/ui/rowgroups/0/columns/Int32/pages/x/structure", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func Test_buildStructureSection(t *testing.T) {
+	runs := make([]model.HybridRun, maxStructureRowsShown+1)
+	for i := range runs {
+		runs[i] = model.HybridRun{Type: "BIT_PACKED", Count: 8}
+	}
+	runs[0] = model.HybridRun{Type: "RLE", Count: 3, Value: 7}
+
+	section := buildStructureSection("Values", model.EncodedSection{Encoding: "RLE", BitWidth: 1, Runs: runs})
+	require.Len(t, section.Tables, 1)
+	require.Len(t, section.Tables[0].Rows, maxStructureRowsShown)
+	require.Equal(t, len(runs), section.Tables[0].Total)
+	require.Equal(t, "7", section.Tables[0].Rows[0][5])
+	require.Equal(t, "-", section.Tables[0].Rows[1][5])
+
+	section = buildStructureSection("Values", model.EncodedSection{
+		Encoding: "DELTA_BYTE_ARRAY",
+		DeltaByteArray: &model.DeltaByteArray{
+			PrefixStream:  model.DeltaBinaryPacked{Blocks: []model.DeltaBlock{{MinDelta: -1, Miniblocks: []model.DeltaMiniblock{{BitWidth: 2, Count: 4}}}}},
+			PrefixLengths: []int64{0, 3},
+			SuffixLengths: []int64{5},
+		},
+	})
+	require.Len(t, section.Tables, 3)
+	require.Equal(t, []string{"0", "-1", "0", "0", "2", "0", "4"}, section.Tables[0].Rows[0])
+	require.Equal(t, []string{"1", "3", "-"}, section.Tables[2].Rows[1])
+
+	section = buildStructureSection("Values", model.EncodedSection{
+		ByteStreamSplit: &model.ByteStreamSplit{TypeSize: 4, NumValues: 2, Streams: make([]model.ByteStream, 4)},
+	})
+	require.Len(t, section.Tables, 1)
+	require.Contains(t, section.Tables[0].Title, "2 values of 4 bytes")
+}
+
+func Test_HandleFileLayoutView_WithRealFile(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name     string
+		path     string
+		contains []string
+	}{
+		{"Default zoom", "/ui/layout", []string{
+			"File Layout", "width: 100%;", "lk-dictionary", "/ui/rowgroups/0/columns/Bool/pages/0/content", "RG 0 / Bool / page 0",
+		}},
+		{"Zoomed", "/ui/layout?zoom=8", []string{"width: 800%;", `class="active">8x`}},
+		{"Unsupported zoom", "/ui/layout?zoom=3", []string{"width: 100%;"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func Test_buildLayoutSegment(t *testing.T) {
+	segment := buildLayoutSegment(model.LayoutRegion{Kind: "DATA_PAGE", Offset: 10, Length: 5, RowGroup: 1, Column: 2, Page: 3, Path: "a.b"})
+	require.Equal(t, "lk-data", segment.Class)
+	require.Equal(t, int64(15), segment.End)
+	require.Equal(t, "RG 1 / a.b / page 3", segment.Location)
+	require.Equal(t, "/ui/rowgroups/1/columns/a.b/pages/3/content", segment.Link)
+
+	segment = buildLayoutSegment(model.LayoutRegion{Kind: model.LayoutColumnIndex, RowGroup: 0, Column: 4, Page: -1, Path: "c"})
+	require.Equal(t, "lk-index", segment.Class)
+	require.Equal(t, "/ui/rowgroups/0/columns/c/pages", segment.Link)
+
+	segment = buildLayoutSegment(model.LayoutRegion{Kind: model.LayoutGap, RowGroup: -1, Column: -1, Page: -1})
+	require.Equal(t, "lk-gap", segment.Class)
+	require.Equal(t, "-", segment.Location)
+	require.Empty(t, segment.Link)
+}
+
+func Test_layoutKindClass(t *testing.T) {
+	require.Equal(t, "lk-file", layoutKindClass(model.LayoutFooter))
+	require.Equal(t, "lk-header", layoutKindClass(model.LayoutPageHeader))
+	require.Equal(t, "lk-dictionary", layoutKindClass("DICTIONARY_PAGE"))
+	require.Equal(t, "lk-bloom", layoutKindClass(model.LayoutBloomFilter))
+	require.Equal(t, "lk-other", layoutKindClass("INDEX_PAGE"))
+}
+
+func Test_SizeStatisticsViews(t *testing.T) {
+	svc := createSizeStatsTestService(t)
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name     string
+		path     string
+		contains []string
+	}{
+		{"Column chunks", "/ui/rowgroups/0/columns", []string{"Size Stats", "lh-mini-bar", `title="level 1: 5"`}},
+		{"Pages", "/ui/rowgroups/0/columns/Tags.List.Element/pages", []string{
+			"Repetition Level Histogram", "Definition Level Histogram", "Unencoded Byte Array Data", "pb-bar-fill", "lh-mini-bar",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func Test_buildSizeStatsView(t *testing.T) {
+	require.Nil(t, buildSizeStatsView(nil))
+
+	bytes := int64(2048)
+	view := buildSizeStatsView(&model.SizeStatistics{
+		UnencodedByteArrayDataBytes: &bytes,
+		DefinitionLevelHistogram:    []int64{1, 4},
+	})
+	require.Equal(t, "2.0 KB", view.UnencodedBytes)
+	require.Nil(t, view.Repetition)
+	require.Equal(t, []profileBar{{Label: "0", Count: 1, Percent: "25.0"}, {Label: "1", Count: 4, Percent: "100.0"}}, view.Definition)
+}
+
+func Test_HandleColumnGeoView(t *testing.T) {
+	svc := createTestServiceWithFile(t, "geospatial.parquet")
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name       string
+		path       string
+		statusCode int
+		contains   []string
+	}{
+		{"Geometry map", "/ui/columns/Geometry/geo", http.StatusOK, []string{
+			"Geospatial Bounds - Geometry", "OGC:CRS84", "<svg", "X [-3, 16] Y [-8, 11]", "statistics", "Row group 0:",
+		}},
+		{"Geography map", "/ui/columns/Geography/geo", http.StatusOK, []string{"GEOGRAPHY", "values", "X [0, 10.5] Y [0, 10.5]"}},
+		{"Column chunks", "/ui/rowgroups/0/columns", http.StatusOK, []string{"(-3, -8)", "(16, 11)", "/ui/columns/Geometry/geo"}},
+		{"Pages", "/ui/rowgroups/0/columns/Geometry/pages", http.StatusOK, []string{"Bounding Box", "Geometry Types", "MultiPolygon"}},
+		{"Content as GeoJSON", "/ui/rowgroups/0/columns/Geometry/pages/0/content", http.StatusOK, []string{"<strong>GeoJSON</strong>", "?geo=wkt", "&#34;type&#34;:&#34;Point&#34;"}},
+		{"Content as WKT", "/ui/rowgroups/0/columns/Geometry/pages/0/content?geo=wkt", http.StatusOK, []string{"<strong>WKT</strong>", "LINESTRING (0 0, 1 1, 2 -1)"}},
+		{"Invalid format", "/ui/rowgroups/0/columns/Geometry/pages/0/content?geo=kml", http.StatusBadRequest, nil},
+		{"Unknown column", "/ui/columns/NoSuchColumn/geo", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.statusCode, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+			require.NotContains(t, w.Body.String(), "ZgotmplZ")
+		})
+	}
+
+	// Other columns offer no format choice
+	plain := createTestServiceWithFile(t, "all-types.parquet")
+	plainRouter := mux.NewRouter()
+	plain.SetupWebUIRoutes(plainRouter)
+	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/Geometry/pages/0/content", nil)
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+	plainRouter.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "?geo=wkt")
+}
+
+func Test_buildGeoStatsView(t *testing.T) {
+	require.Nil(t, buildGeoStatsView(nil))
+
+	view := buildGeoStatsView(&model.GeospatialStatistics{GeometryTypes: []string{"Point"}})
+	require.Equal(t, &geoStatsView{Min: "-", Max: "-", BoundingBox: "-", GeometryTypes: "Point"}, view)
+
+	view = buildGeoStatsView(&model.GeospatialStatistics{
+		BoundingBox:   &model.BoundingBox{Xmin: 1.23456789, Xmax: 2, Ymin: -3, Ymax: 4},
+		GeometryTypes: []string{"Point", "Polygon"},
+	})
+	require.Equal(t, "(1.234568, -3)", view.Min)
+	require.Equal(t, "(2, 4)", view.Max)
+	require.Equal(t, "Point, Polygon", view.GeometryTypes)
+}
+
+func Test_formatBoundingBox(t *testing.T) {
+	zmin, zmax, mmin, mmax := 0.0, 10.0, 1.0, 2.0
+	require.Equal(t, "-", formatBoundingBox(nil))
+	require.Equal(t, "X [1, 2] Y [3, 4]", formatBoundingBox(&model.BoundingBox{Xmin: 1, Xmax: 2, Ymin: 3, Ymax: 4}))
+	require.Equal(t, "X [1, 2] Y [3, 4] Z [0, 10] M [1, 2]", formatBoundingBox(&model.BoundingBox{
+		Xmin: 1, Xmax: 2, Ymin: 3, Ymax: 4, Zmin: &zmin, Zmax: &zmax, Mmin: &mmin, Mmax: &mmax,
+	}))
+}
+
+func Test_buildGeoMap(t *testing.T) {
+	require.Nil(t, buildGeoMap(model.GeospatialBounds{}))
+
+	view := buildGeoMap(model.GeospatialBounds{
+		BoundingBox: &model.BoundingBox{Xmin: 0, Xmax: 100, Ymin: 0, Ymax: 50},
+		RowGroups: []model.RowGroupBounds{
+			{RowGroup: 0, BoundingBox: &model.BoundingBox{Xmin: 0, Xmax: 50, Ymin: 0, Ymax: 50}},
+			{RowGroup: 1},
+			{RowGroup: 2, BoundingBox: &model.BoundingBox{Xmin: 100, Xmax: 100, Ymin: 50, Ymax: 50}},
+		},
+	})
+	require.Equal(t, geoMapWidth, view.Width)
+	// Width limits the scale: 400px for 100 units
+	require.Equal(t, geoMapRect{X: 40, Y: 40, Width: 400, Height: 200}, view.Frame)
+	require.Len(t, view.Rects, 2)
+	require.Equal(t, 0, view.Rects[0].RowGroup)
+	require.Equal(t, 200.0, view.Rects[0].Width)
+	// A point is drawn at its position with a minimum size, y pointing down
+	require.Equal(t, 2, view.Rects[1].RowGroup)
+	require.Equal(t, 440.0, view.Rects[1].X)
+	require.Equal(t, 40.0, view.Rects[1].Y)
+	require.Equal(t, 2.0, view.Rects[1].Width)
+	require.Equal(t, geoMapColors[2], view.Rects[1].Color)
+
+	// A single point extent is padded rather than divided by zero
+	view = buildGeoMap(model.GeospatialBounds{BoundingBox: &model.BoundingBox{Xmin: 1, Xmax: 1, Ymin: 1, Ymax: 1}})
+	require.False(t, math.IsInf(view.Frame.Width, 0) || math.IsNaN(view.Frame.Width))
+}
+
+func Test_VariantViews(t *testing.T) {
+	svc := createVariantTestService(t)
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name     string
+		path     string
+		contains []string
+	}{
+		{"Schema", "/ui/schema", []string{"/ui/schema/variants", "Variants"}},
+		{"Variants", "/ui/schema/variants", []string{"Var (VARIANT, shredded)", "[shredded] #3    Var.Typed_value"}},
+		{"Column chunks", "/ui/rowgroups/0/columns", []string{`title="VARIANT Var">metadata</span>`, `title="VARIANT Var">shredded</span>`}},
+		{"Pages", "/ui/rowgroups/0/columns/Var.Value/pages", []string{"<strong>Variant</strong>", "Var <span class=\"badge badge-info\">value</span> (shredded)"}},
+		{"Content", "/ui/rowgroups/0/columns/Var.Value/pages/1/content", []string{"&#34;x&#34;"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func Test_formatVariantSchema(t *testing.T) {
+	require.Equal(t, "No VARIANT columns\n", formatVariantSchema(nil))
+
+	text := formatVariantSchema([]model.VariantColumn{
+		{Path: "A", Columns: []model.VariantLeaf{
+			{ColumnIndex: 0, Path: "A.metadata", Role: model.VariantRoleMetadata},
+			{ColumnIndex: 1, Path: "A.value", Role: model.VariantRoleValue},
+		}},
+		{Path: "B", Shredded: true, Columns: []model.VariantLeaf{
+			{ColumnIndex: 12, Path: "B.typed_value", Role: model.VariantRoleShredded},
+		}},
+	})
+	require.Equal(t, "A (VARIANT, unshredded)\n"+
+		"  [metadata] #0    A.metadata\n"+
+		"  [value]    #1    A.value\n"+
+		"\n"+
+		"B (VARIANT, shredded)\n"+
+		"  [shredded] #12   B.typed_value\n", text)
+}
+
+func Test_ValueDetailViews(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		contains []string
+	}{
+		{"JSON value", "/ui/rowgroups/0/columns/Json/pages/0/content/1", http.StatusOK, []string{
+			"Value 1 - Row Group 0, Column 10, Page 0", "pb-value-modal", "json", "7 bytes",
+			"{\n  &#34;3&#34;: 3\n}", `data-copy="7b2233223a337d"`, "eyIzIjozfQ==", "00000000  7b 22 33 22 3a 33 7d",
+		}},
+		{"Column chunk statistic", "/ui/rowgroups/0/columns/Utf8/statistics/max", http.StatusOK, []string{
+			"Column Chunk Max - Row Group 0, Column 17", "UTF8-4",
+		}},
+		{"Page statistic", "/ui/rowgroups/0/columns/Int32/pages/1/statistics/min", http.StatusOK, []string{
+			"Page Min - Row Group 0, Column 1, Page 1",
+		}},
+		{"Value out of range", "/ui/rowgroups/0/columns/Json/pages/0/content/9999", http.StatusOK, []string{"pb-error-modal"}},
+		{"Missing statistic", "/ui/rowgroups/0/columns/Interval/statistics/min", http.StatusOK, []string{"pb-error-modal"}},
+		{"Invalid value index", "/ui/rowgroups/0/columns/Json/pages/0/content/x", http.StatusBadRequest, nil},
+		{"Invalid page index", "/ui/rowgroups/0/columns/Json/pages/x/content/0", http.StatusBadRequest, nil},
+		{"Invalid statistic page index", "/ui/rowgroups/0/columns/Int32/pages/x/statistics/min", http.StatusBadRequest, nil},
+		{"Unknown statistic column", "/ui/rowgroups/0/columns/x/statistics/min", http.StatusOK, []string{"pb-error-modal"}},
+		{"Invalid statistic row group index", "/ui/rowgroups/x/columns/Int32/statistics/min", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+
+	t.Run("Links to the popups", func(t *testing.T) {
+		for path, link := range map[string]string{
+			"/ui/rowgroups/0/columns/Json/pages/0/content": `hx-get="/ui/rowgroups/0/columns/Json/pages/0/content/1"`,
+			"/ui/rowgroups/0/columns/Utf8/pages":           `hx-get="/ui/rowgroups/0/columns/Utf8/statistics/max"`,
+			"/ui/rowgroups/0/columns":                      `hx-get="/ui/rowgroups/0/columns/Utf8/statistics/min"`,
+		} {
+			req := httptest.NewRequest("GET", path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Contains(t, w.Body.String(), link, path)
+		}
+	})
+}
+
+func Test_renderValueDetail_HexDumpCap(t *testing.T) {
+	raw := make([]byte, maxHexDumpBytes+10)
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+
+	renderValueDetail(w, req, "Big", model.ValueDetail{Kind: model.ValueKindBinary, Hex: hex.EncodeToString(raw)}, nil)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "... 10 more bytes")
+	require.Equal(t, "false", w.Header().Get("HX-Push-Url"))
+}
+
+func Test_statisticTitle(t *testing.T) {
+	require.Equal(t, "Min", statisticTitle(model.StatisticMin))
+	require.Equal(t, "Max", statisticTitle(model.StatisticMax))
+	require.Equal(t, "avg", statisticTitle("avg"))
+}
+
+func Test_SettingsViews(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+	require.NoError(t, svc.SetDisplayOptions(model.DisplayOptions{Binary: model.BinaryHex}))
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	post := func(form url.Values, htmx bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/ui/settings", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Form", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/ui/settings", nil)
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(&http.Cookie{Name: displayCookieName, Value: "temporal=epoch&float_precision=4"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		require.Contains(t, body, "pb-settings-modal")
+		require.Contains(t, body, `<option value="epoch" selected>`)
+		require.Contains(t, body, "Server default (hex)")
+		require.Contains(t, body, `value="4"`)
+	})
+
+	t.Run("Save", func(t *testing.T) {
+		w := post(url.Values{"timezone": {"Asia/Tokyo"}, "temporal": {"epoch"}, "binary": {""}}, true)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, "true", w.Header().Get("HX-Refresh"))
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		require.Equal(t, displayCookieName, cookies[0].Name)
+		require.Equal(t, "temporal=epoch&timezone=Asia%2FTokyo", cookies[0].Value)
+		require.True(t, cookies[0].HttpOnly)
+	})
+
+	t.Run("Save without HTMX", func(t *testing.T) {
+		w := post(url.Values{"decimal": {"unscaled"}}, false)
+		require.Equal(t, http.StatusSeeOther, w.Code)
+		require.Equal(t, "/", w.Header().Get("Location"))
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		w := post(url.Values{"reset": {"true"}, "temporal": {"epoch"}}, true)
+		require.Equal(t, http.StatusNoContent, w.Code)
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		require.Empty(t, cookies[0].Value)
+		require.Negative(t, cookies[0].MaxAge)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		w := post(url.Values{"timezone": {"Nowhere/Nothing"}}, true)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Empty(t, w.Result().Cookies())
+		require.Contains(t, w.Body.String(), "invalid display option")
+		require.Contains(t, w.Body.String(), `value="Nowhere/Nothing"`)
+
+		w = post(url.Values{"float_precision": {"99"}}, false)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Applied to views", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/TimestampMillis/pages/0/content", nil)
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(&http.Cookie{Name: displayCookieName, Value: "temporal=epoch"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), "1640995200004")
+	})
+}
+
+func Test_HandleLeafColumnsView_WithRealFile(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		contains []string
+	}{
+		{"Leaf columns", "/ui/columns", http.StatusOK, []string{
+			"Columns (", "across all 1 row groups", `hx-get="/ui/columns/Map.Key_value.Key"`,
+		}},
+		{"Column overview", "/ui/columns/Map.Key_value.Key", http.StatusOK, []string{
+			"Column 46: Map.Key_value.Key", "Total Values", "/ui/rowgroups/0/columns/Map.Key_value.Key/pages",
+			"/ui/columns/Map.Key_value.Key/profile", "RLE_DICTIONARY",
+		}},
+		{"Unknown column", "/ui/columns/NoSuchColumn", http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func Test_HandleHeatmapView_WithRealFile(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name     string
+		path     string
+		status   int
+		contains []string
+	}{
+		{"Default metric", "/ui/heatmap", http.StatusOK, []string{
+			"Heatmap - Compressed Size", "(log scale)", `title="RG 0 / Map.Key_value.Key: `,
+			`hx-get="/ui/rowgroups/0/columns/Map.Key_value.Key/pages"`, `href="/ui/heatmap?metric=dictionary"`,
+		}},
+		{"Flag metric", "/ui/heatmap?metric=dictionary", http.StatusOK, []string{
+			"Heatmap - Has Dictionary", `title="RG 0 / Int32: yes"`, "heat-3",
+		}},
+		{"Invalid metric", "/ui/heatmap?metric=bogus", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func Test_buildHeatmapCell(t *testing.T) {
+	cell := buildHeatmapCell(model.HeatmapCell{Valid: true, Level: 2, Label: "1 KB"}, 3, "a b")
+	require.Equal(t, "heat-2", cell.Class)
+	require.Equal(t, "RG 3 / a b: 1 KB", cell.Title)
+	require.Equal(t, "/ui/rowgroups/3/columns/a%20b/pages", cell.Link)
+
+	cell = buildHeatmapCell(model.HeatmapCell{Label: "-"}, 0, "x")
+	require.Equal(t, "heat-none", cell.Class)
+}
+
+func Test_HandleRowPagesView_WithRealFile(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name        string
+		path        string
+		contains    []string
+		notContains []string
+	}{
+		{"Form only", "/ui/rows", []string{"Find Rows", `name="rows"`, `<option value="Map.Key_value.Key">`}, []string{"Pages by Column"}},
+		{"All columns", "/ui/rows?rows=1-2", []string{
+			"Rows 1 - 2", "Pages by Column", "Bytes To Read", "PAGE_HEADERS",
+			`hx-get="/ui/rowgroups/0/columns/Int32/pages/1/content"`,
+		}, nil},
+		{"Selected column", "/ui/rows?rows=3&column=Int32", []string{
+			"Rows 3</h2>", `<option value="Int32" selected>`, "Int32 total",
+		}, []string{"Map.Key_value.Key total"}},
+		{"Invalid range", "/ui/rows?rows=abc", []string{`class="error"`, "invalid row index"}, []string{"Pages by Column"}},
+		{"Out of range", "/ui/rows?rows=5", []string{`class="error"`, "out of range"}, nil},
+		{"Unknown column", "/ui/rows?rows=0&column=NoSuchColumn", []string{`class="error"`}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+			for _, s := range tt.notContains {
+				require.NotContains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func Test_HandleRowLocationView_WithRealFile(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name        string
+		path        string
+		contains    []string
+		notContains []string
+	}{
+		{"Form only", "/ui/rows/location", []string{"Go to Row", `name="row"`, "the file has 5"}, []string{"Row in Row Group"}},
+		{"Row", "/ui/rows/location?row=2", []string{
+			"Row 2</h2>", "Row in Row Group",
+			`hx-get="/ui/rowgroups/0/columns/Int32/pages/2/content?highlight=0&amp;highlight_count=1"`,
+			`hx-get="/ui/rowgroups/0/columns/Map.Key_value.Key/pages/2/content?highlight=0&amp;highlight_count=2"`,
+		}, []string{"Assembled Record"}},
+		{"With record", "/ui/rows/location?row=2&record=true", []string{
+			"Assembled Record", "ByteArray-2", `name="record" value="true" checked`,
+		}, nil},
+		{"Invalid row", "/ui/rows/location?row=abc", []string{`class="error"`, "invalid row index"}, []string{"Row in Row Group"}},
+		{"Out of range", "/ui/rows/location?row=5", []string{`class="error"`, "out of range"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+			for _, s := range tt.notContains {
+				require.NotContains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func Test_HandlePageContentView_Highlight(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	req := httptest.NewRequest("GET", "/ui/rowgroups/0/columns/Map.Key_value.Key/pages/1/content?highlight=0&highlight_count=2", nil)
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	require.Equal(t, 2, strings.Count(body, "value-item value-highlight"))
+	require.Equal(t, 1, strings.Count(body, `id="highlighted-value"`))
+	require.Contains(t, body, "scrollIntoView")
+
+	req = httptest.NewRequest("GET", "/ui/rowgroups/0/columns/Map.Key_value.Key/pages/1/content", nil)
+	req.Header.Set("HX-Request", "true")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "value-highlight")
+	require.NotContains(t, w.Body.String(), "scrollIntoView")
+}
+
+func Test_HandlePageValuesView(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+	get := func(url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", url, nil)
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/ui/rowgroups/0/columns/Map.Key_value.Key/pages/2/values?offset=3")
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	require.Equal(t, 2, strings.Count(body, `class="value-item"`))
+	require.Contains(t, body, `/pages/2/content/3"`)
+	require.Contains(t, body, `/pages/2/content/4"`)
+	require.NotContains(t, body, "value-more")
+
+	require.Equal(t, http.StatusBadRequest, get("/ui/rowgroups/0/columns/Map.Key_value.Key/pages/2/values?offset=-1").Code)
+	require.Equal(t, http.StatusBadRequest, get("/ui/rowgroups/0/columns/Map.Key_value.Key/pages/2/values?geo=bogus").Code)
+	w = get("/ui/rowgroups/0/columns/Map.Key_value.Key/pages/2/values?offset=9")
+	require.Contains(t, w.Body.String(), `class="error`)
+}
+
+func Test_newPageValuesFragment(t *testing.T) {
+	values := make([]string, pageValuesBatch)
+	fragment := newPageValuesFragment(0, "a.b", 1, model.GeoFormatWKT, model.PageRange{Offset: 10, Limit: pageValuesBatch}, values, 2000)
+	require.Equal(t, 10, fragment.Values[0].Index)
+	require.Equal(t, 10+pageValuesBatch, fragment.NextOffset)
+	require.Equal(t, -1, fragment.HighlightStart)
+
+	var html strings.Builder
+	require.NoError(t, templates.ExecuteTemplate(&html, "page_values", fragment))
+	require.Contains(t, html.String(), fmt.Sprintf(`/ui/rowgroups/0/columns/a.b/pages/1/values?offset=%d&geo=wkt"`, 10+pageValuesBatch))
+	require.Contains(t, html.String(), `hx-trigger="intersect once"`)
+	require.Contains(t, html.String(), fmt.Sprintf("%d of 2000 shown", 10+pageValuesBatch))
+
+	fragment = newPageValuesFragment(0, "a.b", 1, "", model.PageRange{Offset: 1990, Limit: pageValuesBatch}, values[:10], 2000)
+	require.Zero(t, fragment.NextOffset)
+}
+
+func Test_listView(t *testing.T) {
+	v := newListView("/ui/rowgroups", model.ListOptions{Offset: 100, Limit: model.DefaultListLimit}, 250, 100, "")
+	require.Equal(t, 101, v.First())
+	require.Equal(t, 200, v.Last())
+	require.Equal(t, "/ui/rowgroups", v.PrevURL())
+	require.Equal(t, "/ui/rowgroups?offset=200", v.NextURL())
+	require.Equal(t, "/ui/rowgroups?sort=-index", v.SortURL("index"))
+	require.Equal(t, " ▲", v.SortMark("index"))
+	require.Equal(t, "/ui/rowgroups?sort=rows", v.SortURL("rows"))
+	require.Empty(t, v.SortMark("rows"))
+
+	v = newListView("/ui/main", model.ListOptions{Offset: 10, Limit: 10, Sort: "rows", Filter: "1"}, 15, 5, "")
+	require.Equal(t, "/ui/main?filter=1&limit=10&sort=rows", v.PrevURL())
+	require.Empty(t, v.NextURL())
+	require.Equal(t, "/ui/main?filter=1&limit=10&sort=-rows", v.SortURL("rows"))
+	require.Equal(t, "/ui/main?filter=1&limit=10", v.SortURL("index"))
+	require.Equal(t, 10, v.LimitParam())
+
+	v = newListView("/ui/main", model.ListOptions{Limit: model.DefaultListLimit, Desc: true}, 15, 15, "")
+	require.Equal(t, "-index", v.SortParam())
+	require.Equal(t, " ▼", v.SortMark("index"))
+	require.Equal(t, "/ui/main", v.SortURL("index"))
+	require.Empty(t, v.PrevURL())
+}
+
+func Test_ListViews_Window(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+	get := func(url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", url, nil)
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/ui/rowgroups/0/columns?limit=5&sort=-size")
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	require.Contains(t, body, "1-5 of 57")
+	require.Contains(t, body, "Size ▼")
+	require.Contains(t, body, `hx-get="/ui/rowgroups/0/columns?limit=5&amp;offset=5&amp;sort=-size"`)
+	require.Equal(t, 10, strings.Count(body, `/pages"`), "5 rows, each linking to its pages twice")
+
+	w = get("/ui/rowgroups/0/columns?filter=Map.Key_value.Key")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `value="Map.Key_value.Key"`)
+	require.NotContains(t, w.Body.String(), ">Int32<")
+
+	for _, path := range []string{"/ui/main", "/ui/rowgroups"} {
+		w = get(path + "?sort=-rows&filter=0")
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), "1-1 of 1")
+		require.Contains(t, w.Body.String(), "Rows ▼")
+
+		w = get(path + "?filter=9")
+		require.Contains(t, w.Body.String(), "None of 0")
+		require.Equal(t, http.StatusBadRequest, get(path+"?sort=bogus").Code)
+	}
+	require.Equal(t, http.StatusBadRequest, get("/ui/rowgroups/0/columns?limit=-1").Code)
+}
+
+func Test_HandleSampleView_WithRealFile(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+
+	tests := []struct {
+		name        string
+		path        string
+		contains    []string
+		notContains []string
+	}{
+		{"Form only", "/ui/sample", []string{"Sample Rows", `name="n" value="10"`, `<option value="stratified"`}, []string{"Pages Read"}},
+		{"Seeded", "/ui/sample?n=5&mode=stratified&seed=3&column=Int32", []string{
+			"5 Rows</h2>", "Pages Read", `<th title="Int32">Int32</th>`,
+			`hx-get="/ui/rows/location?row=4"`, `<option value="stratified" selected>`,
+			`hx-get="/ui/sample?column=Int32&amp;mode=stratified&amp;n=5&amp;seed=3"`,
+		}, []string{`<th title="ByteArray">`}},
+		{"Random seed", "/ui/sample?n=1&seed=", []string{"1 Rows</h2>", "/ui/sample?n=1&amp;seed="}, nil},
+		{"Invalid size", "/ui/sample?n=0", []string{`class="error"`, "invalid sample size"}, []string{"Pages Read"}},
+		{"Unknown column", "/ui/sample?n=1&column=NoSuchColumn", []string{`class="error"`, "invalid column path"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			for _, s := range tt.contains {
+				require.Contains(t, w.Body.String(), s)
+			}
+			for _, s := range tt.notContains {
+				require.NotContains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func Test_HandleIOFooter(t *testing.T) {
+	svc := createTestServiceWithFile(t, "all-types.parquet")
+	if svc == nil {
+		return
+	}
+	defer func() {
+		_ = svc.Close()
+	}()
+
+	router := mux.NewRouter()
+	svc.SetupWebUIRoutes(router)
+	get := func(url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", url, nil)
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/")
+	require.Contains(t, w.Body.String(), `hx-get="/ui/io"`)
+
+	require.Equal(t, http.StatusOK, get("/ui/rowgroups/0/columns/Int32/pages").Code)
+	w = get("/ui/io")
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	require.Contains(t, body, "File I/O: ")
+	require.Contains(t, body, "page_headers: ")
+	require.NotContains(t, body, "read-ahead")
+
+	svc.SetPrefetchOptions(model.PrefetchOptions{MinBlock: 64 << 10, MaxBlock: 1 << 20})
+	w = get("/ui/io")
+	require.Contains(t, w.Body.String(), "read-ahead 1.0 MB")
+}